@@ -37,7 +37,7 @@ func main() {
 	database.RunMigrations()
 
 	// Setup router
-	router := server.SetupRouter()
+	router := server.SetupRouter(cfg)
 
 	// Start server
 	port := cfg.AppPort
@@ -0,0 +1,19 @@
+package admin
+
+import (
+	"crypto/subtle"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Header carries the administrator token
+const Header = "X-Admin-Token"
+
+// Is reports whether the request carries the administrator token. An empty token disables
+// administrator access.
+func Is(ctx *gin.Context, token string) bool {
+	if token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(ctx.GetHeader(Header)), []byte(token)) == 1
+}
@@ -15,6 +15,8 @@ type Config struct {
 	DBPassword string
 	DBName     string
 	DBSSLMode  string
+	AdminToken string // required in X-Admin-Token for hard deletes; empty disables them
+	NightlyAt  string // local time of day, HH:MM, when nightly jobs run
 }
 
 func LoadConfig() *Config {
@@ -31,6 +33,8 @@ func LoadConfig() *Config {
 		DBPassword: getEnv("DB_PASSWORD", ""),
 		DBName:     getEnv("DB_NAME", "school_db"),
 		DBSSLMode:  getEnv("DB_SSLMODE", "disable"),
+		AdminToken: getEnv("ADMIN_TOKEN", ""),
+		NightlyAt:  getEnv("NIGHTLY_AT", "02:00"),
 	}
 	return cfg
 }
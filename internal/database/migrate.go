@@ -3,16 +3,32 @@ package database
 import (
 	"log"
 
+	"school_management/internal/modules/admission"
 	"school_management/internal/modules/attendance"
 	"school_management/internal/modules/course"
 	"school_management/internal/modules/department"
 	"school_management/internal/modules/exam"
+	"school_management/internal/modules/exam_seating"
 	"school_management/internal/modules/grade"
+	"school_management/internal/modules/grade_curve"
+	"school_management/internal/modules/guardian"
+	"school_management/internal/modules/homeroom"
 	"school_management/internal/modules/homework"
+	"school_management/internal/modules/online_exam"
+	"school_management/internal/modules/regrade"
+	"school_management/internal/modules/report_card"
+	"school_management/internal/modules/risk"
+	"school_management/internal/modules/rollover"
+	"school_management/internal/modules/rubric"
+	"school_management/internal/modules/similarity"
+	"school_management/internal/modules/standing"
 	"school_management/internal/modules/student"
 	"school_management/internal/modules/student_courses"
 	"school_management/internal/modules/students_homework"
+	"school_management/internal/modules/substitute"
 	"school_management/internal/modules/teacher"
+	"school_management/internal/modules/trash"
+	"school_management/internal/modules/workload"
 )
 
 // RunMigrations runs all database migrations using GORM AutoMigrate
@@ -23,21 +39,101 @@ func RunMigrations() error {
 	err := DB.AutoMigrate(
 		// Core entities (no dependencies)
 		&department.Department{},
+		&rubric.Rubric{},
+		&rubric.RubricCriterion{}, // depends on Rubric
+		&rubric.RubricLevel{},     // depends on RubricCriterion
 
 		// Entities with single dependencies
-		&teacher.Teacher{}, // depends on Department
-		&student.Student{}, // no dependencies
-		&course.Course{},   // depends on Department and Teacher
+		&teacher.Teacher{},             // depends on Department
+		&student.Student{},             // no dependencies
+		&student.StudentStatusChange{}, // depends on Student
+		&course.Course{},               // depends on Department and Teacher
 
 		// Academic operations (depend on core entities)
 		&attendance.Attendance{}, // depends on Student and Course
-		&homework.Homework{},     // depends on Course
+		&homework.Homework{},     // depends on Course and Rubric
 		&exam.Exam{},             // depends on Course
 		&grade.Grade{},           // depends on Student and Exam
 
 		// Junction tables (depend on multiple entities)
 		&student_courses.StudentCourse{},     // depends on Student and Course
 		&students_homework.StudentHomework{}, // depends on Student and Homework
+
+		// Submission history (depends on submissions)
+		&students_homework.SubmissionVersion{},    // depends on StudentHomework
+		&students_homework.SubmissionAttachment{}, // depends on SubmissionVersion
+		&students_homework.CriterionScore{},       // depends on StudentHomework and RubricLevel
+
+		// Reports (depend on homework and submissions)
+		&similarity.SimilarityReport{}, // depends on Homework
+		&similarity.SimilarityMatch{},  // depends on SimilarityReport
+
+		// Online exams (depend on courses, exams and students)
+		&online_exam.Question{},          // depends on Course
+		&online_exam.QuestionOption{},    // depends on Question
+		&online_exam.ExamPaper{},         // depends on Exam
+		&online_exam.ExamPaperQuestion{}, // depends on ExamPaper and Question
+		&online_exam.ExamAttempt{},       // depends on Exam and Student
+		&online_exam.AttemptAnswer{},     // depends on ExamAttempt and Question
+
+		// Exam seating
+		&exam_seating.ExamRoom{},       // no dependencies
+		&exam_seating.SeatAssignment{}, // depends on Exam, Student and ExamRoom
+
+		// Regrade requests (depend on grades and submissions)
+		&regrade.RegradeRequest{},    // depends on Student, Teacher and Department
+		&regrade.RegradeAttachment{}, // depends on RegradeRequest
+		&regrade.RegradeEvent{},      // depends on RegradeRequest
+
+		// Grade curves
+		&grade_curve.GradeCurve{}, // depends on Exam
+		&grade_curve.CurveEntry{}, // depends on GradeCurve and Grade
+
+		// Academic standing (derived from published grades)
+		&standing.StandingPolicy{},     // no dependencies
+		&standing.StandingSync{},       // no dependencies
+		&standing.AcademicStanding{},   // depends on Student
+		&standing.DepartmentStanding{}, // depends on Student and Department
+
+		// Early warning
+		&risk.RiskScore{}, // depends on Student
+		&risk.RiskAlert{}, // depends on Student and Teacher
+
+		// Report cards
+		&report_card.ReportCardTemplate{}, // no dependencies
+		&report_card.ReportCardComment{},  // depends on Student, Course and Teacher
+		&report_card.ReportCardJob{},      // depends on Course
+
+		// Guardians
+		&guardian.Guardian{},        // no dependencies
+		&guardian.GuardianStudent{}, // depends on Guardian and Student
+
+		// Grade levels and homerooms
+		&homeroom.GradeLevel{}, // no dependencies
+		&homeroom.Homeroom{},   // depends on GradeLevel and Teacher
+		&homeroom.Membership{}, // depends on Student and Homeroom
+
+		// Year-end rollover
+		&rollover.PromotionPolicy{}, // no dependencies
+		&rollover.Rollover{},        // no dependencies
+
+		// Admissions
+		&admission.Application{},         // depends on GradeLevel
+		&admission.ApplicationCourse{},   // depends on Application and Course
+		&admission.ApplicationDocument{}, // depends on Application
+		&admission.ApplicationReview{},   // depends on Application and Teacher
+		&admission.Capacity{},            // depends on GradeLevel
+
+		// Teaching load
+		&workload.LoadPolicy{}, // no dependencies
+
+		// Timetable, teacher leave and substitutes
+		&course.ClassPeriod{},      // depends on Course
+		&substitute.LeaveRequest{}, // depends on Teacher
+		&substitute.Coverage{},     // depends on LeaveRequest, Course and Teacher
+
+		// Trash
+		&trash.TrashPolicy{}, // no dependencies
 	)
 
 	if err != nil {
@@ -45,6 +141,28 @@ func RunMigrations() error {
 		return err
 	}
 
+	// Replaced unique indexes: course codes used to be unique on their own and are now unique
+	// per academic year, and emails and codes used to stay taken by deleted rows, which now
+	// only count while they are not deleted
+	replaced := []struct {
+		model interface{}
+		name  string
+	}{
+		{&course.Course{}, "idx_courses_code"},
+		{&course.Course{}, "idx_course_code_year"},
+		{&student.Student{}, "idx_students_email"},
+		{&teacher.Teacher{}, "idx_teachers_email"},
+	}
+	for _, idx := range replaced {
+		if !DB.Migrator().HasIndex(idx.model, idx.name) {
+			continue
+		}
+		if err := DB.Migrator().DropIndex(idx.model, idx.name); err != nil {
+			log.Printf("❌ Migration failed: %v", err)
+			return err
+		}
+	}
+
 	log.Println("✅ Database migrations completed successfully!")
 	return nil
 }
@@ -0,0 +1,460 @@
+package deletion
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Policy decides what happens to the records that still reference one being deleted
+type Policy string
+
+const (
+	Restrict Policy = "restrict" // refuse while anything references it
+	Reassign Policy = "reassign" // point the references at another record first
+	Cascade  Policy = "cascade"  // soft-delete the referencing records too
+)
+
+// Action is what a delete does, or would do, to a set of referencing records
+type Action string
+
+const (
+	Blocks     Action = "blocks"     // restrict: the delete is refused while these exist
+	Reassigned Action = "reassigned" // moved to the reassign target
+	Deleted    Action = "deleted"    // soft-deleted along with the record
+	Cleared    Action = "cleared"    // an optional reference, set to NULL
+	Kept       Action = "kept"       // an audit reference, left pointing at the deleted record
+	Conflicts  Action = "conflicts"  // reassign: would duplicate a row the target already has
+	Restored   Action = "restored"   // brought back with the record it was deleted with
+	Purged     Action = "purged"     // removed for good along with the record
+)
+
+// ErrReferenced is returned when a restricted delete finds records that still reference the target
+var ErrReferenced = errors.New("record is still referenced")
+
+// ErrConflict is returned when reassigning would give the target a second row where a unique
+// index allows only one, such as a student enrolled twice in the same course
+var ErrConflict = errors.New("reassign would create duplicates")
+
+// Options are the delete settings a client chose
+type Options struct {
+	Policy     Policy
+	ReassignTo uint // required with Reassign
+	DryRun     bool // report what would happen without changing anything
+}
+
+// ParseOptions reads ?policy=restrict|reassign|cascade (default restrict), ?reassign_to and ?dry_run
+func ParseOptions(ctx *gin.Context) (Options, error) {
+	opts := Options{Policy: Restrict}
+	switch p := Policy(ctx.Query("policy")); p {
+	case "":
+	case Restrict, Reassign, Cascade:
+		opts.Policy = p
+	default:
+		return opts, fmt.Errorf("unsupported policy %q (use restrict, reassign or cascade)", p)
+	}
+
+	if raw := ctx.Query("reassign_to"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			return opts, fmt.Errorf("invalid reassign_to ID")
+		}
+		opts.ReassignTo = uint(id)
+	}
+	if opts.Policy == Reassign && opts.ReassignTo == 0 {
+		return opts, fmt.Errorf("reassign_to is required with the reassign policy")
+	}
+
+	if raw := ctx.Query("dry_run"); raw != "" {
+		dryRun, err := strconv.ParseBool(raw)
+		if err != nil {
+			return opts, fmt.Errorf("invalid dry_run value")
+		}
+		opts.DryRun = dryRun
+	}
+	return opts, nil
+}
+
+// Reference is a column whose rows point at the record being deleted
+type Reference struct {
+	Table    string
+	Column   string
+	Label    string      // what the rows are, e.g. "enrollments"
+	Nullable bool        // optional; cleared instead of blocking or being deleted
+	Audit    bool        // records who did something; left as it is so history is not rewritten
+	Unique   []string    // the other columns of a unique index on Column, checked on reassign
+	Children []Reference // rows pointing at these rows, deleted with them on cascade
+}
+
+// Dependent is how many records of one kind reference the target and what happens to them
+type Dependent struct {
+	Label  string `json:"label"`
+	Count  int    `json:"count"`
+	Action Action `json:"action"`
+}
+
+// Report describes a delete: what references the records and what was, or would be, done
+type Report struct {
+	IDs        []uint      `json:"ids"` // the record, plus sub-records deleted with it
+	Policy     Policy      `json:"policy"`
+	ReassignTo *uint       `json:"reassign_to,omitempty"`
+	DryRun     bool        `json:"dry_run"`
+	Deleted    bool        `json:"deleted"`
+	Dependents []Dependent `json:"dependents"`
+}
+
+// step is one update a plan makes to a set of referencing rows
+type step struct {
+	ref    Reference
+	ids    []uint
+	action Action
+}
+
+// Plan is what a delete will do to the records that reference its target
+type Plan struct {
+	refs       []Reference
+	ids        []uint
+	opts       Options
+	steps      []step
+	dependents []Dependent
+	seen       map[string]map[uint]bool // rows already in a step, by table
+}
+
+// Inspect works out what deleting the records does to everything that references them.
+// A row reached by more than one path is counted and changed once.
+func Inspect(db *gorm.DB, refs []Reference, ids []uint, opts Options) (*Plan, error) {
+	p := &Plan{refs: refs, ids: ids, opts: opts, seen: make(map[string]map[uint]bool)}
+	if err := p.walk(db, refs, ids); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Plan) walk(db *gorm.DB, refs []Reference, parentIDs []uint) error {
+	for _, ref := range refs {
+		var found []uint
+		if err := db.Table(ref.Table).
+			Where(ref.Column+" IN ? AND deleted_at IS NULL", parentIDs).
+			Pluck("id", &found).Error; err != nil {
+			return fmt.Errorf("failed to check %s: %w", ref.Label, err)
+		}
+
+		action := p.action(ref)
+		key := ref.Table
+		if action != Deleted && action != Blocks {
+			key += "." + ref.Column
+		}
+		ids := p.unseen(key, found)
+		if len(ids) == 0 {
+			continue
+		}
+
+		p.steps = append(p.steps, step{ref: ref, ids: ids, action: action})
+		p.count(ref.Label, action, len(ids))
+		if action == Reassigned && len(ref.Unique) > 0 {
+			n, err := p.clashes(db, ref, ids)
+			if err != nil {
+				return err
+			}
+			if n > 0 {
+				p.count(ref.Label, Conflicts, n)
+			}
+		}
+		if action == Deleted && len(ref.Children) > 0 {
+			if err := p.walk(db, ref.Children, ids); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *Plan) action(ref Reference) Action {
+	switch {
+	case ref.Audit:
+		return Kept
+	case p.opts.Policy == Reassign:
+		return Reassigned
+	case ref.Nullable:
+		return Cleared
+	case p.opts.Policy == Cascade:
+		return Deleted
+	default:
+		return Blocks
+	}
+}
+
+// unseen returns the rows not reached before under key and marks them as reached
+func (p *Plan) unseen(key string, found []uint) []uint {
+	if p.seen[key] == nil {
+		p.seen[key] = make(map[uint]bool)
+	}
+	var ids []uint
+	for _, id := range found {
+		if !p.seen[key][id] {
+			p.seen[key][id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// clashes counts the rows that cannot move to the reassign target because the target, or
+// another moving row, already has a row with the same unique values. The indexes cover
+// soft-deleted rows too, so those count.
+func (p *Plan) clashes(db *gorm.DB, ref Reference, ids []uint) (int, error) {
+	same := make([]string, len(ref.Unique))
+	for i, column := range ref.Unique {
+		same[i] = "o." + column + " = r." + column
+	}
+	match := strings.Join(same, " AND ")
+
+	var n int64
+	if err := db.Table(ref.Table+" AS r").
+		Where("r.id IN ?", ids).
+		Where("(EXISTS (SELECT 1 FROM "+ref.Table+" AS o WHERE o."+ref.Column+" = ? AND "+match+")"+
+			" OR EXISTS (SELECT 1 FROM "+ref.Table+" AS o WHERE o.id IN ? AND o.id < r.id AND "+match+"))",
+			p.opts.ReassignTo, ids).
+		Count(&n).Error; err != nil {
+		return 0, fmt.Errorf("failed to check %s: %w", ref.Label, err)
+	}
+	return int(n), nil
+}
+
+func (p *Plan) count(label string, action Action, n int) {
+	for i := range p.dependents {
+		if p.dependents[i].Label == label && p.dependents[i].Action == action {
+			p.dependents[i].Count += n
+			return
+		}
+	}
+	p.dependents = append(p.dependents, Dependent{Label: label, Count: n, Action: action})
+}
+
+// Blocked reports whether a restricted delete, or a reassign with conflicts, has to be refused
+func (p *Plan) Blocked() bool {
+	for _, d := range p.dependents {
+		if d.Action == Blocks || d.Action == Conflicts {
+			return true
+		}
+	}
+	return false
+}
+
+// Err explains why a delete is refused, or returns nil when it is not
+func (p *Plan) Err() error {
+	var blocking, clashing []string
+	for _, d := range p.dependents {
+		switch d.Action {
+		case Blocks:
+			blocking = append(blocking, fmt.Sprintf("%d %s", d.Count, d.Label))
+		case Conflicts:
+			clashing = append(clashing, fmt.Sprintf("%d %s", d.Count, d.Label))
+		}
+	}
+	if len(clashing) > 0 {
+		return fmt.Errorf("%w: %s would duplicate ones the reassign target already has; resolve them first or use the cascade policy",
+			ErrConflict, strings.Join(clashing, ", "))
+	}
+	if len(blocking) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w by %s; use the reassign or cascade policy", ErrReferenced, strings.Join(blocking, ", "))
+}
+
+// Report describes the plan; deleted says whether it has been applied
+func (p *Plan) Report(deleted bool) *Report {
+	r := &Report{
+		IDs:        p.ids,
+		Policy:     p.opts.Policy,
+		DryRun:     p.opts.DryRun,
+		Deleted:    deleted,
+		Dependents: p.result(),
+	}
+	if p.opts.Policy == Reassign {
+		r.ReassignTo = &p.opts.ReassignTo
+	}
+	return r
+}
+
+// Apply makes the plan's changes to the referencing rows. Run it in the same transaction
+// that deletes the records themselves, after locking them: it inspects the references again
+// there, so rows added since the plan was made are counted, and the plan then reports them.
+func (p *Plan) Apply(tx *gorm.DB, now time.Time) error {
+	fresh, err := Inspect(tx, p.refs, p.ids, p.opts)
+	if err != nil {
+		return err
+	}
+	p.steps, p.dependents, p.seen = fresh.steps, fresh.dependents, fresh.seen
+
+	if err := p.Err(); err != nil {
+		return err
+	}
+	for _, s := range p.steps {
+		query := tx.Table(s.ref.Table).Where("id IN ?", s.ids)
+		var err error
+		switch s.action {
+		case Reassigned:
+			err = query.Update(s.ref.Column, p.opts.ReassignTo).Error
+		case Cleared:
+			err = query.Update(s.ref.Column, nil).Error
+		case Deleted:
+			err = query.Update("deleted_at", now).Error
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update %s: %w", s.ref.Label, err)
+		}
+	}
+	return nil
+}
+
+// Lock holds the records' rows until the transaction ends, so rows that reference them through
+// a foreign key cannot be added while a delete is applied
+func Lock(tx *gorm.DB, table string, ids []uint) error {
+	var locked []uint
+	if err := tx.Table(table).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", ids).Pluck("id", &locked).Error; err != nil {
+		return fmt.Errorf("failed to lock %s: %w", table, err)
+	}
+	return nil
+}
+
+// Restore undeletes the rows that were cascade-deleted with the records, found by the
+// deleted_at they share. References that were cleared stay cleared, and audit references
+// were never changed.
+func Restore(tx *gorm.DB, refs []Reference, ids []uint, deletedAt time.Time) ([]Dependent, error) {
+	p := &Plan{}
+	if err := p.restore(tx, refs, ids, deletedAt); err != nil {
+		return nil, err
+	}
+	return p.result(), nil
+}
+
+func (p *Plan) restore(tx *gorm.DB, refs []Reference, parentIDs []uint, deletedAt time.Time) error {
+	for _, ref := range refs {
+		if ref.Nullable || ref.Audit {
+			continue
+		}
+		var found []uint
+		if err := tx.Table(ref.Table).
+			Where(ref.Column+" IN ? AND deleted_at = ?", parentIDs, deletedAt).
+			Pluck("id", &found).Error; err != nil {
+			return fmt.Errorf("failed to check %s: %w", ref.Label, err)
+		}
+		if len(found) == 0 {
+			continue
+		}
+		if err := tx.Table(ref.Table).Where("id IN ?", found).Update("deleted_at", nil).Error; err != nil {
+			return fmt.Errorf("failed to restore %s: %w", ref.Label, err)
+		}
+		p.count(ref.Label, Restored, len(found))
+		if err := p.restore(tx, ref.Children, found, deletedAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Active counts the rows that are not deleted and still depend on the records, directly or
+// through deleted rows that reference them. Optional references do not count unless they are
+// audit ones. Purge must not run while there are any.
+func Active(db *gorm.DB, refs []Reference, ids []uint) ([]Dependent, error) {
+	p := &Plan{seen: make(map[string]map[uint]bool)}
+	if err := p.active(db, refs, ids); err != nil {
+		return nil, err
+	}
+	return p.result(), nil
+}
+
+func (p *Plan) active(db *gorm.DB, refs []Reference, parentIDs []uint) error {
+	for _, ref := range refs {
+		if ref.Nullable && !ref.Audit {
+			continue
+		}
+		var count int64
+		if err := db.Table(ref.Table).
+			Where(ref.Column+" IN ? AND deleted_at IS NULL", parentIDs).
+			Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to check %s: %w", ref.Label, err)
+		}
+		if count > 0 {
+			p.count(ref.Label, Blocks, int(count))
+		}
+		if len(ref.Children) == 0 {
+			continue
+		}
+
+		var found []uint
+		if err := db.Table(ref.Table).
+			Where(ref.Column+" IN ? AND deleted_at IS NOT NULL", parentIDs).
+			Pluck("id", &found).Error; err != nil {
+			return fmt.Errorf("failed to check %s: %w", ref.Label, err)
+		}
+		if ids := p.unseen(ref.Table, found); len(ids) > 0 {
+			if err := p.active(db, ref.Children, ids); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Purge permanently deletes the deleted rows that reference the records, children first so no
+// foreign key is left dangling, and clears optional references. Check the records with Active
+// first: rows that are not deleted are left alone. The caller then deletes the records
+// themselves in the same transaction.
+func Purge(tx *gorm.DB, refs []Reference, ids []uint) ([]Dependent, error) {
+	p := &Plan{seen: make(map[string]map[uint]bool)}
+	if err := p.purge(tx, refs, ids); err != nil {
+		return nil, err
+	}
+	return p.result(), nil
+}
+
+func (p *Plan) purge(tx *gorm.DB, refs []Reference, parentIDs []uint) error {
+	for _, ref := range refs {
+		if ref.Nullable {
+			result := tx.Table(ref.Table).Where(ref.Column+" IN ?", parentIDs).Update(ref.Column, nil)
+			if result.Error != nil {
+				return fmt.Errorf("failed to clear %s: %w", ref.Label, result.Error)
+			}
+			if result.RowsAffected > 0 {
+				p.count(ref.Label, Cleared, int(result.RowsAffected))
+			}
+			continue
+		}
+
+		var found []uint
+		if err := tx.Table(ref.Table).
+			Where(ref.Column+" IN ? AND deleted_at IS NOT NULL", parentIDs).
+			Pluck("id", &found).Error; err != nil {
+			return fmt.Errorf("failed to check %s: %w", ref.Label, err)
+		}
+		ids := p.unseen(ref.Table, found)
+		if len(ids) == 0 {
+			continue
+		}
+
+		if err := p.purge(tx, ref.Children, ids); err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM "+ref.Table+" WHERE id IN ?", ids).Error; err != nil {
+			return fmt.Errorf("failed to purge %s: %w", ref.Label, err)
+		}
+		p.count(ref.Label, Purged, len(ids))
+	}
+	return nil
+}
+
+func (p *Plan) result() []Dependent {
+	if p.dependents == nil {
+		return []Dependent{}
+	}
+	return p.dependents
+}
@@ -0,0 +1,126 @@
+package deletion
+
+// The reference lists live here rather than in their modules because deletes cascade across
+// modules that cannot import each other (a teacher delete reaches courses, a course delete
+// reaches grades). Children are followed on cascade and purge, so each list goes down to the
+// rows nothing else points at.
+
+var regradeChildren = []Reference{
+	{Table: "regrade_attachments", Column: "regrade_request_id", Label: "regrade attachments"},
+	{Table: "regrade_events", Column: "regrade_request_id", Label: "regrade events"},
+}
+
+var gradeChildren = []Reference{
+	{Table: "grade_curve_entries", Column: "grade_id", Label: "curve entries"},
+	{Table: "regrade_requests", Column: "grade_id", Label: "regrade requests", Children: regradeChildren},
+}
+
+var attemptChildren = []Reference{
+	{Table: "attempt_answers", Column: "attempt_id", Label: "attempt answers"},
+}
+
+var submissionChildren = []Reference{
+	{Table: "submission_versions", Column: "student_homework_id", Label: "submission versions", Children: []Reference{
+		{Table: "students_homework", Column: "graded_version_id", Label: "graded submissions", Nullable: true},
+		{Table: "submission_attachments", Column: "submission_version_id", Label: "submission attachments"},
+	}},
+	{Table: "submission_criterion_scores", Column: "student_homework_id", Label: "criterion scores"},
+	{Table: "regrade_requests", Column: "submission_id", Label: "regrade requests", Children: regradeChildren},
+	{Table: "similarity_matches", Column: "submission_a_id", Label: "similarity matches"},
+	{Table: "similarity_matches", Column: "submission_b_id", Label: "similarity matches"},
+}
+
+// Grades lists what references a grade
+var Grades = gradeChildren
+
+// Exams lists what references an exam
+var Exams = []Reference{
+	{Table: "grades", Column: "exam_id", Label: "grades", Children: gradeChildren},
+	{Table: "exam_papers", Column: "exam_id", Label: "exam papers", Children: []Reference{
+		{Table: "exam_paper_questions", Column: "paper_id", Label: "exam paper questions"},
+	}},
+	{Table: "exam_attempts", Column: "exam_id", Label: "exam attempts", Children: attemptChildren},
+	{Table: "exam_seat_assignments", Column: "exam_id", Label: "seat assignments"},
+	{Table: "grade_curves", Column: "exam_id", Label: "grade curves", Children: []Reference{
+		{Table: "grade_curve_entries", Column: "curve_id", Label: "curve entries"},
+	}},
+}
+
+// Homework lists what references a homework assignment
+var Homework = []Reference{
+	{Table: "students_homework", Column: "homework_id", Label: "submissions", Children: submissionChildren},
+	{Table: "similarity_reports", Column: "homework_id", Label: "similarity reports", Children: []Reference{
+		{Table: "similarity_matches", Column: "report_id", Label: "similarity matches"},
+	}},
+}
+
+// Courses lists what references a course
+var Courses = []Reference{
+	{Table: "student_courses", Column: "course_id", Label: "enrollments", Unique: []string{"student_id"}},
+	{Table: "exams", Column: "course_id", Label: "exams", Children: Exams},
+	{Table: "homework", Column: "course_id", Label: "homework", Children: Homework},
+	{Table: "attendances", Column: "course_id", Label: "attendance records"},
+	{Table: "questions", Column: "course_id", Label: "questions", Children: []Reference{
+		{Table: "question_options", Column: "question_id", Label: "question options"},
+		{Table: "exam_paper_questions", Column: "question_id", Label: "exam paper questions"},
+		{Table: "attempt_answers", Column: "question_id", Label: "attempt answers"},
+	}},
+	{Table: "class_periods", Column: "course_id", Label: "class periods"},
+	{Table: "course_coverages", Column: "course_id", Label: "coverages"},
+	{Table: "regrade_requests", Column: "course_id", Label: "regrade requests", Children: regradeChildren},
+	{Table: "report_card_comments", Column: "course_id", Label: "report card comments", Unique: []string{"student_id", "term"}},
+	{Table: "report_card_jobs", Column: "course_id", Label: "report card jobs"},
+	{Table: "application_courses", Column: "course_id", Label: "requested courses", Unique: []string{"application_id"}},
+}
+
+// Teachers lists what references a teacher
+var Teachers = []Reference{
+	{Table: "courses", Column: "teacher_id", Label: "courses", Children: Courses},
+	{Table: "homerooms", Column: "teacher_id", Label: "homerooms", Children: []Reference{
+		{Table: "homeroom_memberships", Column: "homeroom_id", Label: "homeroom memberships"},
+	}},
+	{Table: "leave_requests", Column: "teacher_id", Label: "leave requests", Children: []Reference{
+		{Table: "course_coverages", Column: "leave_request_id", Label: "coverages"},
+	}},
+	{Table: "course_coverages", Column: "substitute_id", Label: "coverages"},
+	{Table: "report_card_comments", Column: "teacher_id", Label: "report card comments"},
+	{Table: "risk_alerts", Column: "teacher_id", Label: "risk alerts"},
+	{Table: "application_reviews", Column: "reviewer_id", Label: "application reviews", Audit: true},
+	{Table: "departments", Column: "head_id", Label: "headed departments", Nullable: true},
+	{Table: "leave_requests", Column: "reviewer_id", Label: "reviewed leave requests", Nullable: true, Audit: true},
+	{Table: "regrade_requests", Column: "reviewer_id", Label: "reviewed regrade requests", Nullable: true, Audit: true},
+	{Table: "attendances", Column: "taken_by_id", Label: "attendance records taken", Nullable: true, Audit: true},
+}
+
+// Departments lists what references a department. On cascade the sub-departments are deleted
+// with it, so callers pass its whole subtree and leave out the parent_id reference.
+var Departments = []Reference{
+	{Table: "teachers", Column: "department_id", Label: "teachers", Children: Teachers},
+	{Table: "courses", Column: "department_id", Label: "courses", Children: Courses},
+	{Table: "departments", Column: "parent_id", Label: "sub-departments"},
+	{Table: "department_standings", Column: "department_id", Label: "department standings", Unique: []string{"student_id", "term"}},
+	{Table: "regrade_requests", Column: "escalated_department_id", Label: "escalated regrade requests", Nullable: true},
+}
+
+// Students lists what references a student
+var Students = []Reference{
+	{Table: "student_status_changes", Column: "student_id", Label: "status changes"},
+	{Table: "student_courses", Column: "student_id", Label: "enrollments"},
+	{Table: "attendances", Column: "student_id", Label: "attendance records"},
+	{Table: "grades", Column: "student_id", Label: "grades", Children: gradeChildren},
+	{Table: "students_homework", Column: "student_id", Label: "submissions", Children: submissionChildren},
+	{Table: "exam_attempts", Column: "student_id", Label: "exam attempts", Children: attemptChildren},
+	{Table: "exam_seat_assignments", Column: "student_id", Label: "seat assignments"},
+	{Table: "grade_curve_entries", Column: "student_id", Label: "curve entries"},
+	{Table: "regrade_requests", Column: "student_id", Label: "regrade requests", Children: regradeChildren},
+	{Table: "academic_standings", Column: "student_id", Label: "academic standings"},
+	{Table: "department_standings", Column: "student_id", Label: "department standings"},
+	{Table: "student_risk_scores", Column: "student_id", Label: "risk scores"},
+	{Table: "risk_alerts", Column: "student_id", Label: "risk alerts"},
+	{Table: "report_card_comments", Column: "student_id", Label: "report card comments"},
+	{Table: "guardian_students", Column: "student_id", Label: "guardian links"},
+	{Table: "homeroom_memberships", Column: "student_id", Label: "homeroom memberships"},
+	{Table: "similarity_matches", Column: "student_a_id", Label: "similarity matches"},
+	{Table: "similarity_matches", Column: "student_b_id", Label: "similarity matches"},
+	{Table: "applications", Column: "student_id", Label: "admission applications", Nullable: true},
+}
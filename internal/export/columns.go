@@ -0,0 +1,158 @@
+package export
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// column is one exported field, reached through a path of struct field indexes
+type column struct {
+	name  string
+	index []int
+}
+
+// cell is one formatted value; numeric cells are written as numbers in XLSX
+type cell struct {
+	text    string
+	numeric bool
+}
+
+// columnsOf lists the columns of a DTO type from its json tags. Nested structs are
+// flattened with their tag as a prefix (student.name -> student_name); slices of structs
+// and maps are left out.
+func columnsOf(t reflect.Type) []column {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == timeType {
+		return []column{{name: "value"}}
+	}
+	return structColumns(t, "", nil)
+}
+
+func structColumns(t reflect.Type, prefix string, parent []int) []column {
+	var columns []column
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := jsonName(f)
+		if name == "-" {
+			continue
+		}
+		index := append(append([]int{}, parent...), i)
+
+		ft := f.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		switch {
+		case ft == timeType:
+			columns = append(columns, column{name: prefix + name, index: index})
+		case ft.Kind() == reflect.Struct:
+			if f.Anonymous {
+				columns = append(columns, structColumns(ft, prefix, index)...)
+			} else {
+				columns = append(columns, structColumns(ft, prefix+name+"_", index)...)
+			}
+		case ft.Kind() == reflect.Map, ft.Kind() == reflect.Slice && !isScalar(ft.Elem()):
+			continue
+		default:
+			columns = append(columns, column{name: prefix + name, index: index})
+		}
+	}
+	return columns
+}
+
+// jsonName returns a field's json name, defaulting to its snake-cased Go name
+func jsonName(f reflect.StructField) string {
+	tag := strings.Split(f.Tag.Get("json"), ",")[0]
+	if tag != "" {
+		return tag
+	}
+	var b strings.Builder
+	for i, r := range f.Name {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToLower(b.String())
+}
+
+func isScalar(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t == timeType || t.Kind() != reflect.Struct && t.Kind() != reflect.Map && t.Kind() != reflect.Slice
+}
+
+// cellsOf formats a DTO's values in column order
+func cellsOf(v reflect.Value, columns []column) []cell {
+	cells := make([]cell, len(columns))
+	for i, col := range columns {
+		cells[i] = format(field(v, col.index))
+	}
+	return cells
+}
+
+// field follows an index path, returning an invalid value when a nil pointer is in the way
+func field(v reflect.Value, index []int) reflect.Value {
+	for _, i := range index {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v
+}
+
+// format renders a value as a cell; nil pointers and zero times are empty
+func format(v reflect.Value) cell {
+	for v.IsValid() && v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return cell{}
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return cell{}
+	}
+
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return cell{}
+		}
+		return cell{text: t.Format(time.RFC3339)}
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cell{text: strconv.FormatInt(v.Int(), 10), numeric: true}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cell{text: strconv.FormatUint(v.Uint(), 10), numeric: true}
+	case reflect.Float32, reflect.Float64:
+		return cell{text: strconv.FormatFloat(v.Float(), 'f', -1, 64), numeric: true}
+	case reflect.Bool:
+		return cell{text: strconv.FormatBool(v.Bool())}
+	case reflect.String:
+		return cell{text: v.String()}
+	case reflect.Slice:
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = format(v.Index(i)).text
+		}
+		return cell{text: strings.Join(parts, "; ")}
+	}
+	return cell{text: fmt.Sprint(v.Interface())}
+}
@@ -0,0 +1,153 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// csvEncoder writes rows as RFC 4180 CSV
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func newCSVEncoder(out io.Writer) *csvEncoder {
+	return &csvEncoder{w: csv.NewWriter(out)}
+}
+
+func (e *csvEncoder) header(names []string) error {
+	return e.w.Write(names)
+}
+
+func (e *csvEncoder) row(cells []cell) error {
+	record := make([]string, len(cells))
+	for i, c := range cells {
+		record[i] = c.text
+	}
+	return e.w.Write(record)
+}
+
+func (e *csvEncoder) close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// Static parts of a single-sheet workbook
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+</Types>`
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Export" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+</Relationships>`
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetEnd = `</sheetData></worksheet>`
+)
+
+// xlsxEncoder writes a single-sheet workbook. The sheet is the last part of the ZIP
+// and is streamed row by row with inline strings, so no shared string table is needed.
+type xlsxEncoder struct {
+	zw    *zip.Writer
+	sheet io.Writer
+	rows  int
+	err   error
+}
+
+func newXLSXEncoder(out io.Writer) *xlsxEncoder {
+	e := &xlsxEncoder{zw: zip.NewWriter(out)}
+	for _, part := range []struct{ name, body string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	} {
+		if e.err = e.writePart(part.name, part.body); e.err != nil {
+			return e
+		}
+	}
+	e.sheet, e.err = e.zw.Create("xl/worksheets/sheet1.xml")
+	if e.err == nil {
+		_, e.err = io.WriteString(e.sheet, xlsxSheetStart)
+	}
+	return e
+}
+
+func (e *xlsxEncoder) writePart(name, body string) error {
+	w, err := e.zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, body)
+	return err
+}
+
+func (e *xlsxEncoder) header(names []string) error {
+	cells := make([]cell, len(names))
+	for i, n := range names {
+		cells[i] = cell{text: n}
+	}
+	return e.row(cells)
+}
+
+func (e *xlsxEncoder) row(cells []cell) error {
+	if e.err != nil {
+		return e.err
+	}
+	e.rows++
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<row r="%d">`, e.rows)
+	for i, c := range cells {
+		ref := columnName(i) + fmt.Sprint(e.rows)
+		if c.numeric {
+			fmt.Fprintf(&b, `<c r="%s"><v>%s</v></c>`, ref, c.text)
+			continue
+		}
+		fmt.Fprintf(&b, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+		if err := xml.EscapeText(&b, []byte(c.text)); err != nil {
+			return err
+		}
+		b.WriteString(`</t></is></c>`)
+	}
+	b.WriteString(`</row>`)
+
+	_, e.err = io.WriteString(e.sheet, b.String())
+	return e.err
+}
+
+func (e *xlsxEncoder) close() error {
+	if e.err != nil {
+		return e.err
+	}
+	if _, err := io.WriteString(e.sheet, xlsxSheetEnd); err != nil {
+		return err
+	}
+	return e.zw.Close()
+}
+
+// columnName converts a zero-based column index to its spreadsheet letters (0 -> A, 26 -> AA)
+func columnName(i int) string {
+	name := ""
+	for i >= 0 {
+		name = string(rune('A'+i%26)) + name
+		i = i/26 - 1
+	}
+	return name
+}
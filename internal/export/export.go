@@ -0,0 +1,201 @@
+package export
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Format is the representation a list endpoint responds with
+type Format string
+
+const (
+	JSON Format = "json"
+	CSV  Format = "csv"
+	XLSX Format = "xlsx"
+)
+
+const (
+	csvContentType  = "text/csv"
+	xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// PageSize is how many rows StreamPages fetches per page; it matches the services' pagination cap
+const PageSize = 100
+
+// flushEvery is how many rows are written between flushes to the client
+const flushEvery = 200
+
+// Negotiate picks the response format from ?format=csv|xlsx|json, falling back to the Accept header
+func Negotiate(ctx *gin.Context) (Format, error) {
+	switch f := strings.ToLower(ctx.Query("format")); f {
+	case "":
+	case string(JSON), string(CSV), string(XLSX):
+		return Format(f), nil
+	default:
+		return "", fmt.Errorf("unsupported format %q (use json, csv or xlsx)", f)
+	}
+
+	accept := ctx.GetHeader("Accept")
+	switch {
+	case strings.Contains(accept, csvContentType):
+		return CSV, nil
+	case strings.Contains(accept, xlsxContentType):
+		return XLSX, nil
+	}
+	return JSON, nil
+}
+
+// Requested reports whether the client asked for a file export rather than JSON.
+// An unsupported format counts as requested so that Send or StreamPages can reject it.
+func Requested(ctx *gin.Context) bool {
+	f, err := Negotiate(ctx)
+	return err != nil || f != JSON
+}
+
+// Send writes a list of response DTOs as CSV or XLSX when the client asked for one and
+// reports whether it handled the response. For JSON it writes nothing and returns false.
+func Send(ctx *gin.Context, rows interface{}) bool {
+	format, err := Negotiate(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return true
+	}
+	if format == JSON {
+		return false
+	}
+
+	value := reflect.ValueOf(rows)
+	w := begin(ctx, format, columnsOf(value.Type().Elem()))
+	for i := 0; i < value.Len(); i++ {
+		if err := w.row(value.Index(i)); err != nil {
+			log.Printf("❌ Export of %s failed: %v", ctx.Request.URL.Path, err)
+			return true
+		}
+	}
+	w.finish()
+	return true
+}
+
+// PageFunc fetches one page of response DTOs as a slice
+type PageFunc func(limit, offset int) (interface{}, error)
+
+// StreamPages exports every row of a paginated list, starting at offset, fetching and writing
+// one page at a time so large tables are never held in memory at once
+func StreamPages(ctx *gin.Context, offset int, fetch PageFunc) {
+	format, err := Negotiate(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	// Fetch the first page before committing to a status code
+	page, err := fetch(PageSize, offset)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	value := reflect.ValueOf(page)
+	w := begin(ctx, format, columnsOf(value.Type().Elem()))
+	for {
+		for i := 0; i < value.Len(); i++ {
+			if err := w.row(value.Index(i)); err != nil {
+				log.Printf("❌ Export of %s failed: %v", ctx.Request.URL.Path, err)
+				return
+			}
+		}
+		if value.Len() < PageSize {
+			break
+		}
+
+		offset += PageSize
+		page, err = fetch(PageSize, offset)
+		if err != nil {
+			// Headers are already sent; the truncated file is the best we can do
+			log.Printf("❌ Export of %s failed at offset %d: %v", ctx.Request.URL.Path, offset, err)
+			return
+		}
+		value = reflect.ValueOf(page)
+	}
+	w.finish()
+}
+
+// writer streams rows in one format to the response
+type writer struct {
+	ctx     *gin.Context
+	columns []column
+	enc     encoder
+	written int
+}
+
+// encoder is implemented by the CSV and XLSX encoders
+type encoder interface {
+	header(names []string) error
+	row(cells []cell) error
+	close() error
+}
+
+// begin sets the download headers and writes the header row
+func begin(ctx *gin.Context, format Format, columns []column) *writer {
+	name := fileName(ctx.Request.URL.Path)
+	var enc encoder
+	switch format {
+	case XLSX:
+		ctx.Header("Content-Type", xlsxContentType)
+		ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+".xlsx"))
+		enc = newXLSXEncoder(ctx.Writer)
+	default:
+		ctx.Header("Content-Type", csvContentType+"; charset=utf-8")
+		ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+".csv"))
+		enc = newCSVEncoder(ctx.Writer)
+	}
+	ctx.Status(http.StatusOK)
+
+	w := &writer{ctx: ctx, columns: columns, enc: enc}
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = col.name
+	}
+	if err := enc.header(names); err != nil {
+		log.Printf("❌ Export of %s failed: %v", ctx.Request.URL.Path, err)
+	}
+	return w
+}
+
+// row writes one DTO, flushing to the client periodically
+func (w *writer) row(v reflect.Value) error {
+	if err := w.enc.row(cellsOf(v, w.columns)); err != nil {
+		return err
+	}
+	w.written++
+	if w.written%flushEvery == 0 {
+		w.ctx.Writer.Flush()
+	}
+	return nil
+}
+
+// finish completes the file
+func (w *writer) finish() {
+	if err := w.enc.close(); err != nil {
+		log.Printf("❌ Export of %s failed: %v", w.ctx.Request.URL.Path, err)
+	}
+	w.ctx.Writer.Flush()
+}
+
+// fileName derives a download name from the request path, e.g. /api/v1/grades/exam/3 -> grades-exam-3
+func fileName(path string) string {
+	path = strings.TrimPrefix(path, "/api/v1/")
+	name := strings.Trim(strings.ReplaceAll(path, "/", "-"), "-")
+	if name == "" {
+		return "export"
+	}
+	return name
+}
@@ -0,0 +1,322 @@
+package admission
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"school_management/internal/export"
+)
+
+// AdmissionController handles HTTP requests for admissions
+type AdmissionController struct {
+	service AdmissionService
+}
+
+// NewAdmissionController creates a new admission controller
+func NewAdmissionController(service AdmissionService) *AdmissionController {
+	return &AdmissionController{service: service}
+}
+
+// Create submits a new application
+func (c *AdmissionController) Create(ctx *gin.Context) {
+	var req CreateApplicationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.Create(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// GetByID retrieves an application with its documents and reviews
+func (c *AdmissionController) GetByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetByID(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetAll retrieves applications with pagination, filtered by ?stage, ?grade_level_id and ?academic_year
+func (c *AdmissionController) GetAll(ctx *gin.Context) {
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	offset, _ := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+
+	filter := ApplicationFilter{AcademicYear: ctx.Query("academic_year")}
+	if stage := ctx.Query("stage"); stage != "" {
+		if !IsValidStage(stage) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid stage"})
+			return
+		}
+		filter.Stage = Stage(stage)
+	}
+	if raw := ctx.Query("grade_level_id"); raw != "" {
+		levelID, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid grade level ID"})
+			return
+		}
+		id := uint(levelID)
+		filter.GradeLevelID = &id
+	}
+
+	if export.Requested(ctx) {
+		export.StreamPages(ctx, offset, func(limit, offset int) (interface{}, error) {
+			return c.service.GetAll(filter, limit, offset)
+		})
+		return
+	}
+
+	resp, err := c.service.GetAll(filter, limit, offset)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data":   resp,
+		"limit":  limit,
+		"offset": offset,
+		"count":  len(resp),
+	})
+}
+
+// Update updates an open application
+func (c *AdmissionController) Update(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	var req UpdateApplicationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.Update(uint(id), &req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Delete deletes an application
+func (c *AdmissionController) Delete(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	if err := c.service.Delete(uint(id)); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "application deleted successfully"})
+}
+
+// ChangeStage moves an application to another stage; accepting creates the student
+func (c *AdmissionController) ChangeStage(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	var req ChangeStageRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.ChangeStage(uint(id), &req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// AddDocument uploads a document (multipart field "file") to an application
+func (c *AdmissionController) AddDocument(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	if header.Size > MaxDocumentBytes {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("file is larger than %d MB", MaxDocumentBytes>>20)})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.AddDocument(uint(id), header.Filename, header.Header.Get("Content-Type"), data)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// GetDocument downloads one of an application's documents
+func (c *AdmissionController) GetDocument(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+	documentID, err := strconv.ParseUint(ctx.Param("documentId"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid document ID"})
+		return
+	}
+
+	document, err := c.service.GetDocument(uint(id), uint(documentID))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", document.Name))
+	ctx.Data(http.StatusOK, document.ContentType, document.Data)
+}
+
+// DeleteDocument removes a document from an application
+func (c *AdmissionController) DeleteDocument(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+	documentID, err := strconv.ParseUint(ctx.Param("documentId"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid document ID"})
+		return
+	}
+
+	if err := c.service.DeleteDocument(uint(id), uint(documentID)); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "document deleted successfully"})
+}
+
+// AddReview records a reviewer's score and notes on an application
+func (c *AdmissionController) AddReview(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	var req CreateReviewRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.AddReview(uint(id), &req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// GetCapacities retrieves every grade level's seats for ?academic_year
+func (c *AdmissionController) GetCapacities(ctx *gin.Context) {
+	resp, err := c.service.GetCapacities(ctx.Query("academic_year"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// SetCapacity sets a grade level's seats for an academic year
+func (c *AdmissionController) SetCapacity(ctx *gin.Context) {
+	var req SetCapacityRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.SetCapacity(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// RegisterRoutes registers admission routes
+func (c *AdmissionController) RegisterRoutes(rg *gin.RouterGroup) {
+	admissions := rg.Group("/admissions")
+	{
+		admissions.POST("/applications", c.Create)
+		admissions.GET("/applications", c.GetAll)
+		admissions.GET("/applications/:id", c.GetByID)
+		admissions.PUT("/applications/:id", c.Update)
+		admissions.DELETE("/applications/:id", c.Delete)
+		admissions.POST("/applications/:id/stage", c.ChangeStage)
+
+		// Documents and reviews
+		admissions.POST("/applications/:id/documents", c.AddDocument)
+		admissions.GET("/applications/:id/documents/:documentId", c.GetDocument)
+		admissions.DELETE("/applications/:id/documents/:documentId", c.DeleteDocument)
+		admissions.POST("/applications/:id/reviews", c.AddReview)
+
+		// Capacity
+		admissions.GET("/capacity", c.GetCapacities)
+		admissions.PUT("/capacity", c.SetCapacity)
+	}
+}
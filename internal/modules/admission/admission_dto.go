@@ -0,0 +1,108 @@
+package admission
+
+import "time"
+
+// CreateApplicationRequest represents the request body for submitting an application
+type CreateApplicationRequest struct {
+	FirstName      string `json:"first_name" binding:"required,min=2,max=50"`
+	LastName       string `json:"last_name" binding:"required,min=2,max=50"`
+	Email          string `json:"email" binding:"required,email,max=100"`
+	Phone          string `json:"phone" binding:"omitempty,max=20"`
+	DateOfBirth    string `json:"date_of_birth" binding:"required"` // Format: YYYY-MM-DD
+	Address        string `json:"address" binding:"omitempty"`
+	PreviousSchool string `json:"previous_school" binding:"omitempty,max=100"`
+	GradeLevelID   uint   `json:"grade_level_id" binding:"required"`
+	AcademicYear   string `json:"academic_year" binding:"required,len=9"` // e.g. 2026-2027
+	CourseIDs      []uint `json:"course_ids" binding:"omitempty"`         // enrolled when the offer is accepted
+}
+
+// UpdateApplicationRequest represents the request body for updating an open application
+type UpdateApplicationRequest struct {
+	FirstName      string `json:"first_name" binding:"omitempty,min=2,max=50"`
+	LastName       string `json:"last_name" binding:"omitempty,min=2,max=50"`
+	Email          string `json:"email" binding:"omitempty,email,max=100"`
+	Phone          string `json:"phone" binding:"omitempty,max=20"`
+	DateOfBirth    string `json:"date_of_birth" binding:"omitempty"` // Format: YYYY-MM-DD
+	Address        string `json:"address" binding:"omitempty"`
+	PreviousSchool string `json:"previous_school" binding:"omitempty,max=100"`
+	CourseIDs      []uint `json:"course_ids" binding:"omitempty"` // replaces the requested courses when given
+}
+
+// ChangeStageRequest represents the request body for moving an application to another stage
+type ChangeStageRequest struct {
+	Stage       string     `json:"stage" binding:"required,oneof=submitted reviewed interview offered accepted rejected"`
+	Reason      string     `json:"reason" binding:"omitempty,max=1000"`
+	InterviewAt *time.Time `json:"interview_at"` // required when moving to interview
+}
+
+// CreateReviewRequest represents the request body for reviewing an application
+type CreateReviewRequest struct {
+	ReviewerID uint   `json:"reviewer_id" binding:"required"`
+	Score      *int   `json:"score" binding:"required,min=0,max=100"`
+	Notes      string `json:"notes" binding:"omitempty,max=5000"`
+}
+
+// SetCapacityRequest represents the request body for setting a grade level's seats for a year
+type SetCapacityRequest struct {
+	GradeLevelID uint   `json:"grade_level_id" binding:"required"`
+	AcademicYear string `json:"academic_year" binding:"required,len=9"`
+	Seats        *int   `json:"seats" binding:"required,min=0"`
+}
+
+// ApplicationResponse represents the response body for application data
+type ApplicationResponse struct {
+	ID             uint       `json:"id"`
+	FirstName      string     `json:"first_name"`
+	LastName       string     `json:"last_name"`
+	Email          string     `json:"email"`
+	Phone          string     `json:"phone"`
+	DateOfBirth    time.Time  `json:"date_of_birth"`
+	Address        string     `json:"address"`
+	PreviousSchool string     `json:"previous_school"`
+	GradeLevelID   uint       `json:"grade_level_id"`
+	GradeLevelName string     `json:"grade_level_name"`
+	AcademicYear   string     `json:"academic_year"`
+	Stage          Stage      `json:"stage"`
+	StageChangedAt time.Time  `json:"stage_changed_at"`
+	InterviewAt    *time.Time `json:"interview_at"`
+	DecisionReason string     `json:"decision_reason"`
+	StudentID      *uint      `json:"student_id"`
+	EnrolledAt     *time.Time `json:"enrolled_at"` // nil until the student is active and enrolled
+	CourseIDs      []uint     `json:"course_ids"`
+	AverageScore   *float64   `json:"average_score"` // over all reviews; nil before the first
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	Documents []DocumentResponse `json:"documents,omitempty"`
+	Reviews   []ReviewResponse   `json:"reviews,omitempty"`
+}
+
+// DocumentResponse represents an uploaded document without its contents
+type DocumentResponse struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ReviewResponse represents a reviewer's score and notes
+type ReviewResponse struct {
+	ID           uint      `json:"id"`
+	ReviewerID   uint      `json:"reviewer_id"`
+	ReviewerName string    `json:"reviewer_name"`
+	Score        int       `json:"score"`
+	Notes        string    `json:"notes"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// CapacityResponse represents a grade level's seats for a year and how many are taken
+type CapacityResponse struct {
+	GradeLevelID   uint   `json:"grade_level_id"`
+	GradeLevelName string `json:"grade_level_name"`
+	AcademicYear   string `json:"academic_year"`
+	Seats          int    `json:"seats"`
+	Offered        int64  `json:"offered"`  // offers waiting for an answer
+	Accepted       int64  `json:"accepted"` // offers taken up
+	Remaining      int64  `json:"remaining"`
+}
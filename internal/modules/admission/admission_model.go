@@ -0,0 +1,127 @@
+package admission
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"school_management/internal/modules/course"
+	"school_management/internal/modules/homeroom"
+	"school_management/internal/modules/teacher"
+)
+
+// Stage is where an application is in the admissions pipeline
+type Stage string
+
+const (
+	StageSubmitted Stage = "submitted"
+	StageReviewed  Stage = "reviewed"
+	StageInterview Stage = "interview"
+	StageOffered   Stage = "offered"
+	StageAccepted  Stage = "accepted" // offer taken up; the student record exists
+	StageRejected  Stage = "rejected" // turned down by the school, or offer declined
+)
+
+// Application is a prospective student's application to a grade level for an academic year
+type Application struct {
+	gorm.Model
+	FirstName      string    `gorm:"not null;size:50" json:"first_name"`
+	LastName       string    `gorm:"not null;size:50" json:"last_name"`
+	Email          string    `gorm:"not null;size:100;index" json:"email"`
+	Phone          string    `gorm:"size:20" json:"phone"`
+	DateOfBirth    time.Time `gorm:"type:date;not null" json:"date_of_birth"`
+	Address        string    `gorm:"type:text" json:"address"`
+	PreviousSchool string    `gorm:"size:100" json:"previous_school"`
+	GradeLevelID   uint      `gorm:"not null;index:idx_application_level_year" json:"grade_level_id"`
+	AcademicYear   string    `gorm:"not null;size:9;index:idx_application_level_year" json:"academic_year"`
+
+	Stage          Stage      `gorm:"type:varchar(20);not null;default:'submitted';index" json:"stage"`
+	StageChangedAt time.Time  `gorm:"type:timestamp;not null" json:"stage_changed_at"`
+	InterviewAt    *time.Time `gorm:"type:timestamp" json:"interview_at"`
+	DecisionReason string     `gorm:"type:text" json:"decision_reason"`
+	StudentID      *uint      `gorm:"index;comment:set when the offer is accepted" json:"student_id"`
+	EnrolledAt     *time.Time `gorm:"type:timestamp;comment:set once the student is enrolled in the requested courses" json:"enrolled_at"`
+
+	// Belongs To relationship
+	GradeLevel homeroom.GradeLevel `gorm:"foreignKey:GradeLevelID" json:"grade_level,omitempty"`
+
+	// Has Many relationships
+	Courses   []ApplicationCourse   `gorm:"foreignKey:ApplicationID" json:"courses,omitempty"`
+	Documents []ApplicationDocument `gorm:"foreignKey:ApplicationID" json:"documents,omitempty"`
+	Reviews   []ApplicationReview   `gorm:"foreignKey:ApplicationID" json:"reviews,omitempty"`
+}
+
+// TableName specifies the table name for the Application model
+func (Application) TableName() string {
+	return "applications"
+}
+
+// Open reports whether the application is still moving through the pipeline
+func (a *Application) Open() bool {
+	return a.Stage != StageAccepted && a.Stage != StageRejected
+}
+
+// ApplicationCourse is a course the applicant is enrolled in once the offer is accepted and
+// they are an active student
+type ApplicationCourse struct {
+	gorm.Model
+	ApplicationID uint `gorm:"not null;uniqueIndex:idx_application_course" json:"application_id"`
+	CourseID      uint `gorm:"not null;uniqueIndex:idx_application_course" json:"course_id"`
+
+	// Belongs To relationship
+	Course course.Course `gorm:"foreignKey:CourseID" json:"course,omitempty"`
+}
+
+// TableName specifies the table name for the ApplicationCourse model
+func (ApplicationCourse) TableName() string {
+	return "application_courses"
+}
+
+// ApplicationDocument is a file supporting an application, such as a transcript or birth certificate
+type ApplicationDocument struct {
+	gorm.Model
+	ApplicationID uint   `gorm:"not null;index" json:"application_id"`
+	Name          string `gorm:"not null;size:255" json:"name"`
+	ContentType   string `gorm:"not null;size:100" json:"content_type"`
+	Size          int64  `gorm:"not null" json:"size"`
+	Data          []byte `gorm:"type:bytea;not null" json:"-"`
+}
+
+// TableName specifies the table name for the ApplicationDocument model
+func (ApplicationDocument) TableName() string {
+	return "application_documents"
+}
+
+// ApplicationReview is a reviewer's score and notes on an application
+type ApplicationReview struct {
+	gorm.Model
+	ApplicationID uint   `gorm:"not null;index" json:"application_id"`
+	ReviewerID    uint   `gorm:"not null;index" json:"reviewer_id"`
+	Score         int    `gorm:"not null;comment:0 to 100" json:"score"`
+	Notes         string `gorm:"type:text" json:"notes"`
+
+	// Belongs To relationship
+	Reviewer teacher.Teacher `gorm:"foreignKey:ReviewerID" json:"reviewer,omitempty"`
+}
+
+// TableName specifies the table name for the ApplicationReview model
+func (ApplicationReview) TableName() string {
+	return "application_reviews"
+}
+
+// Capacity is how many offers a grade level can make for an academic year.
+// Without a capacity row, offers for that grade level are not limited.
+type Capacity struct {
+	gorm.Model
+	GradeLevelID uint   `gorm:"not null;uniqueIndex:idx_capacity_level_year" json:"grade_level_id"`
+	AcademicYear string `gorm:"not null;size:9;uniqueIndex:idx_capacity_level_year" json:"academic_year"`
+	Seats        int    `gorm:"not null" json:"seats"`
+
+	// Belongs To relationship
+	GradeLevel homeroom.GradeLevel `gorm:"foreignKey:GradeLevelID" json:"grade_level,omitempty"`
+}
+
+// TableName specifies the table name for the Capacity model
+func (Capacity) TableName() string {
+	return "admission_capacities"
+}
@@ -0,0 +1,338 @@
+package admission
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"school_management/internal/modules/student"
+	"school_management/internal/modules/student_courses"
+)
+
+// ApplicationFilter narrows an application listing
+type ApplicationFilter struct {
+	Stage        Stage
+	GradeLevelID *uint
+	AcademicYear string
+}
+
+// AdmissionRepository defines the interface for admissions data access
+type AdmissionRepository interface {
+	// Applications
+	Create(application *Application, courseIDs []uint) error
+	GetByID(id uint) (*Application, error)
+	GetAll(filter ApplicationFilter, limit, offset int) ([]Application, error)
+	Update(application *Application) error
+	ReplaceCourses(applicationID uint, courseIDs []uint) error
+	Delete(id uint) error
+	Offer(application *Application) error
+	Accept(application *Application, st *student.Student, activation *student.StudentStatusChange, courseIDs []uint) error
+	GetAwaitingEnrollment() ([]Application, error)
+	Enroll(application *Application, courseIDs []uint) error
+
+	// Documents and reviews
+	CreateDocument(document *ApplicationDocument) error
+	GetDocument(applicationID, documentID uint) (*ApplicationDocument, error)
+	DeleteDocument(document *ApplicationDocument) error
+	CreateReview(review *ApplicationReview) error
+
+	// Capacity
+	GetCapacity(gradeLevelID uint, academicYear string) (*Capacity, error)
+	GetCapacities(academicYear string) ([]Capacity, error)
+	SaveCapacity(capacity *Capacity) error
+	CountSeatsTaken(gradeLevelID uint, academicYear string) (map[Stage]int64, error)
+}
+
+// admissionRepository implements AdmissionRepository
+type admissionRepository struct {
+	db *gorm.DB
+}
+
+// NewAdmissionRepository creates a new admission repository with dependency injection
+func NewAdmissionRepository(db *gorm.DB) AdmissionRepository {
+	return &admissionRepository{db: db}
+}
+
+// withoutDocumentData preloads documents without their contents
+func withoutDocumentData(db *gorm.DB) *gorm.DB {
+	return db.Select("id", "created_at", "updated_at", "application_id", "name", "content_type", "size")
+}
+
+// Create creates an application with its requested courses in one transaction
+func (r *admissionRepository) Create(application *Application, courseIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(application).Error; err != nil {
+			return fmt.Errorf("failed to create application: %w", err)
+		}
+		return createCourses(tx, application.ID, courseIDs)
+	})
+}
+
+func createCourses(tx *gorm.DB, applicationID uint, courseIDs []uint) error {
+	if len(courseIDs) == 0 {
+		return nil
+	}
+	courses := make([]ApplicationCourse, len(courseIDs))
+	for i, id := range courseIDs {
+		courses[i] = ApplicationCourse{ApplicationID: applicationID, CourseID: id}
+	}
+	if err := tx.Omit("Course").Create(&courses).Error; err != nil {
+		return fmt.Errorf("failed to save requested courses: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an application with its grade level, courses, reviews and document list
+func (r *admissionRepository) GetByID(id uint) (*Application, error) {
+	var application Application
+	if err := r.db.Preload("GradeLevel").Preload("Courses").
+		Preload("Reviews", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		Preload("Reviews.Reviewer").
+		Preload("Documents", withoutDocumentData).
+		First(&application, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+	return &application, nil
+}
+
+// GetAll retrieves applications with pagination, newest first
+func (r *admissionRepository) GetAll(filter ApplicationFilter, limit, offset int) ([]Application, error) {
+	var applications []Application
+	query := r.db.Preload("GradeLevel").Preload("Courses").Preload("Reviews")
+	if filter.Stage != "" {
+		query = query.Where("stage = ?", filter.Stage)
+	}
+	if filter.GradeLevelID != nil {
+		query = query.Where("grade_level_id = ?", *filter.GradeLevelID)
+	}
+	if filter.AcademicYear != "" {
+		query = query.Where("academic_year = ?", filter.AcademicYear)
+	}
+	if err := query.Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&applications).Error; err != nil {
+		return nil, fmt.Errorf("failed to get applications: %w", err)
+	}
+	return applications, nil
+}
+
+// Update updates an application's own fields
+func (r *admissionRepository) Update(application *Application) error {
+	if err := r.db.Omit(clause.Associations).Save(application).Error; err != nil {
+		return fmt.Errorf("failed to update application: %w", err)
+	}
+	return nil
+}
+
+// ReplaceCourses replaces an application's requested courses in one transaction
+func (r *admissionRepository) ReplaceCourses(applicationID uint, courseIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Hard delete so the unique application/course index allows a course to be requested again
+		if err := tx.Unscoped().Where("application_id = ?", applicationID).Delete(&ApplicationCourse{}).Error; err != nil {
+			return fmt.Errorf("failed to clear requested courses: %w", err)
+		}
+		return createCourses(tx, applicationID, courseIDs)
+	})
+}
+
+// Delete soft deletes an application
+func (r *admissionRepository) Delete(id uint) error {
+	if err := r.db.Delete(&Application{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete application: %w", err)
+	}
+	return nil
+}
+
+// Offer saves an application moved to offered if its grade level still has a seat for the year.
+// The capacity row is locked while the seats are counted, so concurrent offers cannot overbook.
+func (r *admissionRepository) Offer(application *Application) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var capacity Capacity
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("grade_level_id = ? AND academic_year = ?", application.GradeLevelID, application.AcademicYear).
+			First(&capacity).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to get admission capacity: %w", err)
+		}
+		if err == nil {
+			var used int64
+			if err := tx.Model(&Application{}).
+				Where("grade_level_id = ? AND academic_year = ? AND stage IN ? AND id <> ?",
+					application.GradeLevelID, application.AcademicYear, holdsSeat, application.ID).
+				Count(&used).Error; err != nil {
+				return fmt.Errorf("failed to count offers: %w", err)
+			}
+			if used >= int64(capacity.Seats) {
+				return fmt.Errorf("%s has no seats left for %s (%d of %d taken)",
+					application.GradeLevel.Name, application.AcademicYear, used, capacity.Seats)
+			}
+		}
+
+		if err := tx.Omit(clause.Associations).Save(application).Error; err != nil {
+			return fmt.Errorf("failed to update application: %w", err)
+		}
+		return nil
+	})
+}
+
+// Accept creates the student, their enrollments when courseIDs is not nil, and any pending
+// activation, and marks the application accepted, in one transaction
+func (r *admissionRepository) Accept(application *Application, st *student.Student, activation *student.StudentStatusChange, courseIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(st).Error; err != nil {
+			return fmt.Errorf("failed to create student: %w", err)
+		}
+
+		if courseIDs != nil {
+			if err := enroll(tx, application, st.ID, st.EnrollmentDate, courseIDs); err != nil {
+				return err
+			}
+		}
+
+		if activation != nil {
+			activation.StudentID = st.ID
+			if err := tx.Create(activation).Error; err != nil {
+				return fmt.Errorf("failed to schedule student activation: %w", err)
+			}
+		}
+
+		application.StudentID = &st.ID
+		if err := tx.Omit(clause.Associations).Save(application).Error; err != nil {
+			return fmt.Errorf("failed to update application: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetAwaitingEnrollment retrieves accepted applications, with their courses, whose student
+// has become active but is not enrolled yet
+func (r *admissionRepository) GetAwaitingEnrollment() ([]Application, error) {
+	var applications []Application
+	if err := r.db.Preload("Courses").
+		Where("stage = ? AND enrolled_at IS NULL", StageAccepted).
+		Where("student_id IN (SELECT id FROM students WHERE status = ? AND deleted_at IS NULL)", student.StatusActive).
+		Order("id").
+		Find(&applications).Error; err != nil {
+		return nil, fmt.Errorf("failed to get applications awaiting enrollment: %w", err)
+	}
+	return applications, nil
+}
+
+// Enroll enrolls an accepted application's student in the courses, skipping any they are
+// already in, and records the enrollment on the application, in one transaction
+func (r *admissionRepository) Enroll(application *Application, courseIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return enroll(tx, application, *application.StudentID, time.Now().Truncate(24*time.Hour), courseIDs)
+	})
+}
+
+// enroll creates the enrollments and sets the application's EnrolledAt
+func enroll(tx *gorm.DB, application *Application, studentID uint, date time.Time, courseIDs []uint) error {
+	if len(courseIDs) > 0 {
+		enrollments := make([]student_courses.StudentCourse, len(courseIDs))
+		for i, courseID := range courseIDs {
+			enrollments[i] = student_courses.StudentCourse{
+				StudentID:      studentID,
+				CourseID:       courseID,
+				EnrollmentDate: date,
+			}
+		}
+		if err := tx.Omit("Student", "Course").Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&enrollments).Error; err != nil {
+			return fmt.Errorf("failed to enroll student: %w", err)
+		}
+	}
+
+	now := time.Now()
+	application.EnrolledAt = &now
+	if err := tx.Model(&Application{}).Where("id = ?", application.ID).
+		Update("enrolled_at", now).Error; err != nil {
+		return fmt.Errorf("failed to update application: %w", err)
+	}
+	return nil
+}
+
+// CreateDocument saves an uploaded document
+func (r *admissionRepository) CreateDocument(document *ApplicationDocument) error {
+	if err := r.db.Create(document).Error; err != nil {
+		return fmt.Errorf("failed to save document: %w", err)
+	}
+	return nil
+}
+
+// GetDocument retrieves one of an application's documents with its contents
+func (r *admissionRepository) GetDocument(applicationID, documentID uint) (*ApplicationDocument, error) {
+	var document ApplicationDocument
+	if err := r.db.Where("application_id = ?", applicationID).First(&document, documentID).Error; err != nil {
+		return nil, fmt.Errorf("failed to get document: %w", err)
+	}
+	return &document, nil
+}
+
+// DeleteDocument soft deletes a document
+func (r *admissionRepository) DeleteDocument(document *ApplicationDocument) error {
+	if err := r.db.Delete(document).Error; err != nil {
+		return fmt.Errorf("failed to delete document: %w", err)
+	}
+	return nil
+}
+
+// CreateReview saves a review
+func (r *admissionRepository) CreateReview(review *ApplicationReview) error {
+	if err := r.db.Omit("Reviewer").Create(review).Error; err != nil {
+		return fmt.Errorf("failed to save review: %w", err)
+	}
+	return nil
+}
+
+// GetCapacity retrieves a grade level's capacity for an academic year
+func (r *admissionRepository) GetCapacity(gradeLevelID uint, academicYear string) (*Capacity, error) {
+	var capacity Capacity
+	if err := r.db.Where("grade_level_id = ? AND academic_year = ?", gradeLevelID, academicYear).
+		First(&capacity).Error; err != nil {
+		return nil, fmt.Errorf("failed to get capacity: %w", err)
+	}
+	return &capacity, nil
+}
+
+// GetCapacities retrieves every grade level's capacity for an academic year
+func (r *admissionRepository) GetCapacities(academicYear string) ([]Capacity, error) {
+	var capacities []Capacity
+	if err := r.db.Preload("GradeLevel").
+		Joins("JOIN grade_levels ON grade_levels.id = admission_capacities.grade_level_id").
+		Where("admission_capacities.academic_year = ?", academicYear).
+		Order("grade_levels.ordinal ASC").
+		Find(&capacities).Error; err != nil {
+		return nil, fmt.Errorf("failed to get capacities: %w", err)
+	}
+	return capacities, nil
+}
+
+// SaveCapacity creates or updates a capacity
+func (r *admissionRepository) SaveCapacity(capacity *Capacity) error {
+	if err := r.db.Omit("GradeLevel").Save(capacity).Error; err != nil {
+		return fmt.Errorf("failed to save capacity: %w", err)
+	}
+	return nil
+}
+
+// CountSeatsTaken counts a grade level's applications for a year in each stage that holds a seat
+func (r *admissionRepository) CountSeatsTaken(gradeLevelID uint, academicYear string) (map[Stage]int64, error) {
+	var rows []struct {
+		Stage Stage
+		Count int64
+	}
+	if err := r.db.Model(&Application{}).
+		Select("stage, COUNT(*) AS count").
+		Where("grade_level_id = ? AND academic_year = ? AND stage IN ?", gradeLevelID, academicYear, holdsSeat).
+		Group("stage").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count offers: %w", err)
+	}
+	counts := make(map[Stage]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Stage] = row.Count
+	}
+	return counts, nil
+}
@@ -0,0 +1,608 @@
+package admission
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"school_management/internal/modules/course"
+	"school_management/internal/modules/homeroom"
+	"school_management/internal/modules/student"
+	"school_management/internal/modules/student_courses"
+	"school_management/internal/modules/teacher"
+)
+
+// MaxDocumentBytes is the largest document accepted with an application
+const MaxDocumentBytes = 10 << 20
+
+// AdmissionService defines the business logic interface for admissions
+type AdmissionService interface {
+	Create(req *CreateApplicationRequest) (*ApplicationResponse, error)
+	GetByID(id uint) (*ApplicationResponse, error)
+	GetAll(filter ApplicationFilter, limit, offset int) ([]ApplicationResponse, error)
+	Update(id uint, req *UpdateApplicationRequest) (*ApplicationResponse, error)
+	Delete(id uint) error
+	ChangeStage(id uint, req *ChangeStageRequest) (*ApplicationResponse, error)
+	EnrollAdmitted() (int, error)
+
+	AddDocument(id uint, name, contentType string, data []byte) (*DocumentResponse, error)
+	GetDocument(id, documentID uint) (*ApplicationDocument, error)
+	DeleteDocument(id, documentID uint) error
+	AddReview(id uint, req *CreateReviewRequest) (*ReviewResponse, error)
+
+	SetCapacity(req *SetCapacityRequest) (*CapacityResponse, error)
+	GetCapacities(academicYear string) ([]CapacityResponse, error)
+}
+
+// admissionService implements AdmissionService
+type admissionService struct {
+	repo         AdmissionRepository
+	students     student.StudentService
+	studentRepo  student.StudentRepository
+	homeroomRepo homeroom.HomeroomRepository
+	courseRepo   course.CourseRepository
+	teacherRepo  teacher.TeacherRepository
+	assigner     student_courses.HomeworkAssigner
+}
+
+// NewAdmissionService creates a new admission service with DI
+func NewAdmissionService(repo AdmissionRepository, students student.StudentService, studentRepo student.StudentRepository,
+	homeroomRepo homeroom.HomeroomRepository, courseRepo course.CourseRepository, teacherRepo teacher.TeacherRepository,
+	assigner student_courses.HomeworkAssigner) AdmissionService {
+	return &admissionService{
+		repo:         repo,
+		students:     students,
+		studentRepo:  studentRepo,
+		homeroomRepo: homeroomRepo,
+		courseRepo:   courseRepo,
+		teacherRepo:  teacherRepo,
+		assigner:     assigner,
+	}
+}
+
+// Create submits a new application
+func (s *admissionService) Create(req *CreateApplicationRequest) (*ApplicationResponse, error) {
+	// Validate
+	dob, err := time.Parse("2006-01-02", req.DateOfBirth)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date of birth format (use YYYY-MM-DD): %w", err)
+	}
+	if err := course.ValidateAcademicYear(req.AcademicYear); err != nil {
+		return nil, err
+	}
+	level, err := s.homeroomRepo.GetGradeLevelByID(req.GradeLevelID)
+	if err != nil {
+		return nil, fmt.Errorf("grade level not found: %w", err)
+	}
+	courseIDs := uniqueIDs(req.CourseIDs)
+	if err := s.validateCourses(courseIDs, level, req.AcademicYear); err != nil {
+		return nil, err
+	}
+
+	// Map DTO to Model
+	application := &Application{
+		FirstName:      strings.TrimSpace(req.FirstName),
+		LastName:       strings.TrimSpace(req.LastName),
+		Email:          strings.TrimSpace(req.Email),
+		Phone:          req.Phone,
+		DateOfBirth:    dob,
+		Address:        req.Address,
+		PreviousSchool: req.PreviousSchool,
+		GradeLevelID:   req.GradeLevelID,
+		AcademicYear:   req.AcademicYear,
+		Stage:          StageSubmitted,
+		StageChangedAt: time.Now(),
+	}
+
+	// Create via repository
+	if err := s.repo.Create(application, courseIDs); err != nil {
+		return nil, err
+	}
+
+	return s.GetByID(application.ID)
+}
+
+// GetByID retrieves an application with its documents and reviews
+func (s *admissionService) GetByID(id uint) (*ApplicationResponse, error) {
+	application, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("application not found: %w", err)
+	}
+	return s.toDetailResponseDTO(application), nil
+}
+
+// GetAll retrieves applications with pagination
+func (s *admissionService) GetAll(filter ApplicationFilter, limit, offset int) ([]ApplicationResponse, error) {
+	// Validate pagination
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	applications, err := s.repo.GetAll(filter, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]ApplicationResponse, len(applications))
+	for i := range applications {
+		responses[i] = *s.toResponseDTO(&applications[i])
+	}
+	return responses, nil
+}
+
+// Update updates an open application's applicant data and requested courses
+func (s *admissionService) Update(id uint, req *UpdateApplicationRequest) (*ApplicationResponse, error) {
+	// Get existing
+	application, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("application not found: %w", err)
+	}
+	if !application.Open() {
+		return nil, fmt.Errorf("application is %s and can no longer be changed", application.Stage)
+	}
+
+	// Update fields
+	if req.FirstName != "" {
+		application.FirstName = strings.TrimSpace(req.FirstName)
+	}
+	if req.LastName != "" {
+		application.LastName = strings.TrimSpace(req.LastName)
+	}
+	if req.Email != "" {
+		application.Email = strings.TrimSpace(req.Email)
+	}
+	if req.Phone != "" {
+		application.Phone = req.Phone
+	}
+	if req.DateOfBirth != "" {
+		dob, err := time.Parse("2006-01-02", req.DateOfBirth)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date of birth format (use YYYY-MM-DD): %w", err)
+		}
+		application.DateOfBirth = dob
+	}
+	if req.Address != "" {
+		application.Address = req.Address
+	}
+	if req.PreviousSchool != "" {
+		application.PreviousSchool = req.PreviousSchool
+	}
+
+	courseIDs := uniqueIDs(req.CourseIDs)
+	if req.CourseIDs != nil {
+		if err := s.validateCourses(courseIDs, &application.GradeLevel, application.AcademicYear); err != nil {
+			return nil, err
+		}
+	}
+
+	// Save
+	if err := s.repo.Update(application); err != nil {
+		return nil, err
+	}
+	if req.CourseIDs != nil {
+		if err := s.repo.ReplaceCourses(id, courseIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetByID(id)
+}
+
+// Delete deletes an application that has not been accepted
+func (s *admissionService) Delete(id uint) error {
+	application, err := s.repo.GetByID(id)
+	if err != nil {
+		return fmt.Errorf("application not found: %w", err)
+	}
+	if application.Stage == StageAccepted {
+		return fmt.Errorf("accepted applications are kept with the student's record")
+	}
+	return s.repo.Delete(id)
+}
+
+// ChangeStage moves an application through the pipeline. Offers are limited by the grade
+// level's capacity, and accepting an offer creates the student and their enrollments.
+func (s *admissionService) ChangeStage(id uint, req *ChangeStageRequest) (*ApplicationResponse, error) {
+	// Get existing
+	application, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("application not found: %w", err)
+	}
+
+	// Validate
+	to := Stage(req.Stage)
+	if !CanTransition(application.Stage, to) {
+		return nil, fmt.Errorf("cannot move application from %s to %s", application.Stage, to)
+	}
+	switch to {
+	case StageInterview:
+		if req.InterviewAt == nil {
+			return nil, fmt.Errorf("interview_at is required to schedule an interview")
+		}
+		application.InterviewAt = req.InterviewAt
+	}
+
+	// Update fields
+	application.Stage = to
+	application.StageChangedAt = time.Now()
+	if req.Reason != "" {
+		application.DecisionReason = req.Reason
+	}
+
+	// Save; an offer takes a seat, so it is saved only while the grade level has one left
+	switch to {
+	case StageOffered:
+		if err := s.repo.Offer(application); err != nil {
+			return nil, err
+		}
+	case StageAccepted:
+		if err := s.accept(application); err != nil {
+			return nil, err
+		}
+	default:
+		if err := s.repo.Update(application); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetByID(id)
+}
+
+// accept creates the student from the application and enrolls them in the requested courses.
+// A student admitted for a year that has not started yet stays an applicant until it does, and
+// since only active students can be enrolled, EnrollAdmitted enrolls them once they are active.
+func (s *admissionService) accept(application *Application) error {
+	if existing, _ := s.studentRepo.GetByEmail(application.Email); existing != nil {
+		return fmt.Errorf("student %d already uses email %s", existing.ID, application.Email)
+	}
+
+	yearStart, _, err := course.AcademicYearBounds(application.AcademicYear)
+	if err != nil {
+		return err
+	}
+	today := time.Now().Truncate(24 * time.Hour)
+	startDate := yearStart
+	if today.After(yearStart) {
+		startDate = today
+	}
+	status := student.StatusActive
+	if startDate.After(today) {
+		status = student.StatusApplicant
+	}
+
+	st, err := s.students.Prepare(&student.CreateStudentRequest{
+		FirstName:      application.FirstName,
+		LastName:       application.LastName,
+		Email:          application.Email,
+		Phone:          application.Phone,
+		DateOfBirth:    application.DateOfBirth.Format("2006-01-02"),
+		EnrollmentDate: startDate.Format("2006-01-02"),
+		Status:         string(status),
+	})
+	if err != nil {
+		return fmt.Errorf("application cannot become a student: %w", err)
+	}
+
+	var activation *student.StudentStatusChange
+	if status == student.StatusApplicant {
+		activation = &student.StudentStatusChange{
+			FromStatus:    student.StatusApplicant,
+			ToStatus:      student.StatusActive,
+			EffectiveDate: startDate,
+			Reason:        fmt.Sprintf("Admitted to %s for %s", application.GradeLevel.Name, application.AcademicYear),
+		}
+	}
+
+	var courseIDs []uint
+	if st.IsActive() {
+		courseIDs = applicationCourseIDs(application)
+	}
+	if err := s.repo.Accept(application, st, activation, courseIDs); err != nil {
+		return err
+	}
+
+	s.assignOpenHomework(st.ID, courseIDs)
+	return nil
+}
+
+// EnrollAdmitted enrolls admitted students who have become active since their offer was
+// accepted in the courses their application requested. It returns how many were enrolled.
+func (s *admissionService) EnrollAdmitted() (int, error) {
+	applications, err := s.repo.GetAwaitingEnrollment()
+	if err != nil {
+		return 0, err
+	}
+
+	enrolled := 0
+	for i := range applications {
+		courseIDs := applicationCourseIDs(&applications[i])
+		if err := s.repo.Enroll(&applications[i], courseIDs); err != nil {
+			return enrolled, err
+		}
+		s.assignOpenHomework(*applications[i].StudentID, courseIDs)
+		enrolled++
+	}
+	return enrolled, nil
+}
+
+// assignOpenHomework assigns the courses' open homework; the student and enrollments are already saved
+func (s *admissionService) assignOpenHomework(studentID uint, courseIDs []uint) {
+	for _, courseID := range courseIDs {
+		if err := s.assigner.AssignOpenHomework(studentID, courseID); err != nil {
+			log.Printf("⚠️ Student %d admitted into course %d but open homework not assigned: %v", studentID, courseID, err)
+		}
+	}
+}
+
+// applicationCourseIDs lists the courses an application requested
+func applicationCourseIDs(application *Application) []uint {
+	courseIDs := make([]uint, len(application.Courses))
+	for i, c := range application.Courses {
+		courseIDs[i] = c.CourseID
+	}
+	return courseIDs
+}
+
+// AddDocument attaches an uploaded document to an open application
+func (s *admissionService) AddDocument(id uint, name, contentType string, data []byte) (*DocumentResponse, error) {
+	application, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("application not found: %w", err)
+	}
+	if !application.Open() {
+		return nil, fmt.Errorf("application is %s and can no longer be changed", application.Stage)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("document is empty")
+	}
+	if len(data) > MaxDocumentBytes {
+		return nil, fmt.Errorf("document is larger than %d MB", MaxDocumentBytes>>20)
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	document := &ApplicationDocument{
+		ApplicationID: id,
+		Name:          name,
+		ContentType:   contentType,
+		Size:          int64(len(data)),
+		Data:          data,
+	}
+	if err := s.repo.CreateDocument(document); err != nil {
+		return nil, err
+	}
+	return s.toDocumentResponse(document), nil
+}
+
+// GetDocument retrieves one of an application's documents with its contents
+func (s *admissionService) GetDocument(id, documentID uint) (*ApplicationDocument, error) {
+	document, err := s.repo.GetDocument(id, documentID)
+	if err != nil {
+		return nil, fmt.Errorf("document not found: %w", err)
+	}
+	return document, nil
+}
+
+// DeleteDocument removes a document from an open application
+func (s *admissionService) DeleteDocument(id, documentID uint) error {
+	application, err := s.repo.GetByID(id)
+	if err != nil {
+		return fmt.Errorf("application not found: %w", err)
+	}
+	if !application.Open() {
+		return fmt.Errorf("application is %s and can no longer be changed", application.Stage)
+	}
+	document, err := s.repo.GetDocument(id, documentID)
+	if err != nil {
+		return fmt.Errorf("document not found: %w", err)
+	}
+	return s.repo.DeleteDocument(document)
+}
+
+// AddReview records a reviewer's score and notes on an open application
+func (s *admissionService) AddReview(id uint, req *CreateReviewRequest) (*ReviewResponse, error) {
+	// Validate
+	application, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("application not found: %w", err)
+	}
+	if !application.Open() {
+		return nil, fmt.Errorf("application is %s and can no longer be reviewed", application.Stage)
+	}
+	reviewer, err := s.teacherRepo.GetByID(req.ReviewerID)
+	if err != nil {
+		return nil, fmt.Errorf("reviewer not found: %w", err)
+	}
+
+	// Create via repository
+	review := &ApplicationReview{
+		ApplicationID: id,
+		ReviewerID:    req.ReviewerID,
+		Score:         *req.Score,
+		Notes:         req.Notes,
+	}
+	if err := s.repo.CreateReview(review); err != nil {
+		return nil, err
+	}
+	review.Reviewer = *reviewer
+	return s.toReviewResponse(review), nil
+}
+
+// SetCapacity sets how many offers a grade level can make for an academic year
+func (s *admissionService) SetCapacity(req *SetCapacityRequest) (*CapacityResponse, error) {
+	// Validate
+	if err := course.ValidateAcademicYear(req.AcademicYear); err != nil {
+		return nil, err
+	}
+	level, err := s.homeroomRepo.GetGradeLevelByID(req.GradeLevelID)
+	if err != nil {
+		return nil, fmt.Errorf("grade level not found: %w", err)
+	}
+	taken, err := s.repo.CountSeatsTaken(req.GradeLevelID, req.AcademicYear)
+	if err != nil {
+		return nil, err
+	}
+	if used := taken[StageOffered] + taken[StageAccepted]; int64(*req.Seats) < used {
+		return nil, fmt.Errorf("%d offers are already made or accepted for %s", used, level.Name)
+	}
+
+	// Get existing
+	capacity, err := s.repo.GetCapacity(req.GradeLevelID, req.AcademicYear)
+	if err != nil {
+		capacity = &Capacity{GradeLevelID: req.GradeLevelID, AcademicYear: req.AcademicYear}
+	}
+	capacity.Seats = *req.Seats
+
+	// Save
+	if err := s.repo.SaveCapacity(capacity); err != nil {
+		return nil, err
+	}
+	capacity.GradeLevel = *level
+	return s.toCapacityResponse(capacity, taken), nil
+}
+
+// GetCapacities retrieves every grade level's seats for an academic year with how many are taken
+func (s *admissionService) GetCapacities(academicYear string) ([]CapacityResponse, error) {
+	if err := course.ValidateAcademicYear(academicYear); err != nil {
+		return nil, err
+	}
+	capacities, err := s.repo.GetCapacities(academicYear)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]CapacityResponse, 0, len(capacities))
+	for i := range capacities {
+		taken, err := s.repo.CountSeatsTaken(capacities[i].GradeLevelID, academicYear)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, *s.toCapacityResponse(&capacities[i], taken))
+	}
+	return responses, nil
+}
+
+// Validation methods
+func (s *admissionService) validateCourses(courseIDs []uint, level *homeroom.GradeLevel, academicYear string) error {
+	for _, id := range courseIDs {
+		c, err := s.courseRepo.GetByID(id)
+		if err != nil {
+			return fmt.Errorf("course %d not found: %w", id, err)
+		}
+		if c.AcademicYear != "" && c.AcademicYear != academicYear {
+			return fmt.Errorf("course %s runs in %s, not %s", c.Code, c.AcademicYear, academicYear)
+		}
+		if !c.AllowsGradeLevel(level.Ordinal) {
+			return fmt.Errorf("course %s is not open to %s", c.Code, level.Name)
+		}
+	}
+	return nil
+}
+
+// uniqueIDs drops duplicate and zero IDs, keeping the first occurrence's order
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if id == 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
+
+// DTO mapping methods
+func (s *admissionService) toResponseDTO(application *Application) *ApplicationResponse {
+	courseIDs := make([]uint, len(application.Courses))
+	for i, c := range application.Courses {
+		courseIDs[i] = c.CourseID
+	}
+
+	var average *float64
+	if len(application.Reviews) > 0 {
+		total := 0
+		for _, r := range application.Reviews {
+			total += r.Score
+		}
+		avg := math.Round(float64(total)/float64(len(application.Reviews))*100) / 100
+		average = &avg
+	}
+
+	return &ApplicationResponse{
+		ID:             application.ID,
+		FirstName:      application.FirstName,
+		LastName:       application.LastName,
+		Email:          application.Email,
+		Phone:          application.Phone,
+		DateOfBirth:    application.DateOfBirth,
+		Address:        application.Address,
+		PreviousSchool: application.PreviousSchool,
+		GradeLevelID:   application.GradeLevelID,
+		GradeLevelName: application.GradeLevel.Name,
+		AcademicYear:   application.AcademicYear,
+		Stage:          application.Stage,
+		StageChangedAt: application.StageChangedAt,
+		InterviewAt:    application.InterviewAt,
+		DecisionReason: application.DecisionReason,
+		StudentID:      application.StudentID,
+		EnrolledAt:     application.EnrolledAt,
+		CourseIDs:      courseIDs,
+		AverageScore:   average,
+		CreatedAt:      application.CreatedAt,
+		UpdatedAt:      application.UpdatedAt,
+	}
+}
+
+func (s *admissionService) toDetailResponseDTO(application *Application) *ApplicationResponse {
+	resp := s.toResponseDTO(application)
+	for i := range application.Documents {
+		resp.Documents = append(resp.Documents, *s.toDocumentResponse(&application.Documents[i]))
+	}
+	for i := range application.Reviews {
+		resp.Reviews = append(resp.Reviews, *s.toReviewResponse(&application.Reviews[i]))
+	}
+	return resp
+}
+
+func (s *admissionService) toDocumentResponse(document *ApplicationDocument) *DocumentResponse {
+	return &DocumentResponse{
+		ID:          document.ID,
+		Name:        document.Name,
+		ContentType: document.ContentType,
+		Size:        document.Size,
+		CreatedAt:   document.CreatedAt,
+	}
+}
+
+func (s *admissionService) toReviewResponse(review *ApplicationReview) *ReviewResponse {
+	return &ReviewResponse{
+		ID:           review.ID,
+		ReviewerID:   review.ReviewerID,
+		ReviewerName: strings.TrimSpace(review.Reviewer.FirstName + " " + review.Reviewer.LastName),
+		Score:        review.Score,
+		Notes:        review.Notes,
+		CreatedAt:    review.CreatedAt,
+	}
+}
+
+func (s *admissionService) toCapacityResponse(capacity *Capacity, taken map[Stage]int64) *CapacityResponse {
+	remaining := int64(capacity.Seats) - taken[StageOffered] - taken[StageAccepted]
+	if remaining < 0 {
+		remaining = 0
+	}
+	return &CapacityResponse{
+		GradeLevelID:   capacity.GradeLevelID,
+		GradeLevelName: capacity.GradeLevel.Name,
+		AcademicYear:   capacity.AcademicYear,
+		Seats:          capacity.Seats,
+		Offered:        taken[StageOffered],
+		Accepted:       taken[StageAccepted],
+		Remaining:      remaining,
+	}
+}
@@ -0,0 +1,31 @@
+package admission
+
+// transitions lists the stages an application may move to from each stage.
+// An interview is optional; accepted and rejected are final.
+var transitions = map[Stage][]Stage{
+	StageSubmitted: {StageReviewed, StageRejected},
+	StageReviewed:  {StageInterview, StageOffered, StageRejected},
+	StageInterview: {StageOffered, StageRejected},
+	StageOffered:   {StageAccepted, StageRejected},
+	StageAccepted:  {},
+	StageRejected:  {},
+}
+
+// CanTransition reports whether an application may move from one stage to another
+func CanTransition(from, to Stage) bool {
+	for _, allowed := range transitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidStage reports whether stage is a known admissions stage
+func IsValidStage(stage string) bool {
+	_, ok := transitions[Stage(stage)]
+	return ok
+}
+
+// holdsSeat lists the stages that count against a grade level's capacity
+var holdsSeat = []Stage{StageOffered, StageAccepted}
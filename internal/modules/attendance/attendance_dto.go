@@ -8,20 +8,25 @@ type CreateAttendanceRequest struct {
 	CourseID  uint   `json:"course_id" binding:"required"`
 	Date      string `json:"date" binding:"required"` // Format: YYYY-MM-DD
 	Status    string `json:"status" binding:"required,oneof=present absent late"`
+	TeacherID uint   `json:"teacher_id" binding:"required"` // teacher taking attendance; must teach or cover the course that day
 }
 
 // UpdateAttendanceRequest represents the request body for updating an attendance record
 type UpdateAttendanceRequest struct {
-	Status string `json:"status" binding:"omitempty,oneof=present absent late"`
+	Status    string `json:"status" binding:"omitempty,oneof=present absent late"`
+	TeacherID uint   `json:"teacher_id" binding:"required"` // teacher correcting it; must teach or cover the course that day
 }
 
 // AttendanceResponse represents the response body for attendance data
 type AttendanceResponse struct {
-	ID        uint      `json:"id"`
-	StudentID uint      `json:"student_id"`
-	CourseID  uint      `json:"course_id"`
-	Date      time.Time `json:"date"`
-	Status    string    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID          uint      `json:"id"`
+	StudentID   uint      `json:"student_id"`
+	StudentName string    `json:"student_name,omitempty"`
+	CourseID    uint      `json:"course_id"`
+	CourseName  string    `json:"course_name,omitempty"`
+	Date        time.Time `json:"date"`
+	Status      string    `json:"status"`
+	TakenByID   *uint     `json:"taken_by_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -24,6 +24,7 @@ type Attendance struct {
 	CourseID  uint             `gorm:"not null" json:"course_id"`
 	Date      time.Time        `gorm:"type:date;not null" json:"date"`
 	Status    AttendanceStatus `gorm:"type:varchar(20);not null;default:'present'" json:"status"`
+	TakenByID *uint            `gorm:"index" json:"taken_by_id"` // teacher who recorded or last corrected it; nil on older records
 
 	// Belongs To relationships
 	Student student.Student `gorm:"foreignKey:StudentID" json:"student,omitempty"`
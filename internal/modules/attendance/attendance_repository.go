@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+
+	"school_management/internal/modules/homeroom"
 )
 
 // AttendanceRepository defines the interface for attendance data access
@@ -15,6 +17,7 @@ type AttendanceRepository interface {
 	GetAll(limit, offset int) ([]Attendance, error)
 	GetByStudent(studentID uint) ([]Attendance, error)
 	GetByCourse(courseID uint) ([]Attendance, error)
+	GetByCourseAndHomeroom(courseID, homeroomID uint) ([]Attendance, error)
 	GetByDateRange(start, end time.Time) ([]Attendance, error)
 	GetByStudentAndCourse(studentID, courseID uint) ([]Attendance, error)
 	Update(attendance *Attendance) error
@@ -60,7 +63,7 @@ func (r *attendanceRepository) GetByIDWithRelations(id uint) (*Attendance, error
 // GetAll retrieves all attendance records with pagination
 func (r *attendanceRepository) GetAll(limit, offset int) ([]Attendance, error) {
 	var attendances []Attendance
-	if err := r.db.Limit(limit).Offset(offset).Find(&attendances).Error; err != nil {
+	if err := r.db.Preload("Student").Preload("Course").Limit(limit).Offset(offset).Find(&attendances).Error; err != nil {
 		return nil, fmt.Errorf("failed to get attendances: %w", err)
 	}
 	return attendances, nil
@@ -69,7 +72,7 @@ func (r *attendanceRepository) GetAll(limit, offset int) ([]Attendance, error) {
 // GetByStudent retrieves all attendance records for a student
 func (r *attendanceRepository) GetByStudent(studentID uint) ([]Attendance, error) {
 	var attendances []Attendance
-	if err := r.db.Where("student_id = ?", studentID).Find(&attendances).Error; err != nil {
+	if err := r.db.Preload("Student").Preload("Course").Where("student_id = ?", studentID).Find(&attendances).Error; err != nil {
 		return nil, fmt.Errorf("failed to get attendances by student: %w", err)
 	}
 	return attendances, nil
@@ -78,16 +81,27 @@ func (r *attendanceRepository) GetByStudent(studentID uint) ([]Attendance, error
 // GetByCourse retrieves all attendance records for a course
 func (r *attendanceRepository) GetByCourse(courseID uint) ([]Attendance, error) {
 	var attendances []Attendance
-	if err := r.db.Where("course_id = ?", courseID).Find(&attendances).Error; err != nil {
+	if err := r.db.Preload("Student").Preload("Course").Where("course_id = ?", courseID).Find(&attendances).Error; err != nil {
 		return nil, fmt.Errorf("failed to get attendances by course: %w", err)
 	}
 	return attendances, nil
 }
 
+// GetByCourseAndHomeroom retrieves a course's attendance records for students currently in a homeroom
+func (r *attendanceRepository) GetByCourseAndHomeroom(courseID, homeroomID uint) ([]Attendance, error) {
+	var attendances []Attendance
+	if err := r.db.Preload("Student").Preload("Course").
+		Scopes(homeroom.CurrentMembers("attendances.student_id", homeroomID)).
+		Where("course_id = ?", courseID).Find(&attendances).Error; err != nil {
+		return nil, fmt.Errorf("failed to get attendances by course and homeroom: %w", err)
+	}
+	return attendances, nil
+}
+
 // GetByDateRange retrieves attendance records within a date range
 func (r *attendanceRepository) GetByDateRange(start, end time.Time) ([]Attendance, error) {
 	var attendances []Attendance
-	if err := r.db.Where("date BETWEEN ? AND ?", start, end).Find(&attendances).Error; err != nil {
+	if err := r.db.Preload("Student").Preload("Course").Where("date BETWEEN ? AND ?", start, end).Find(&attendances).Error; err != nil {
 		return nil, fmt.Errorf("failed to get attendances by date range: %w", err)
 	}
 	return attendances, nil
@@ -96,7 +110,7 @@ func (r *attendanceRepository) GetByDateRange(start, end time.Time) ([]Attendanc
 // GetByStudentAndCourse retrieves attendance records for a specific student in a specific course
 func (r *attendanceRepository) GetByStudentAndCourse(studentID, courseID uint) ([]Attendance, error) {
 	var attendances []Attendance
-	if err := r.db.Where("student_id = ? AND course_id = ?", studentID, courseID).
+	if err := r.db.Preload("Student").Preload("Course").Where("student_id = ? AND course_id = ?", studentID, courseID).
 		Find(&attendances).Error; err != nil {
 		return nil, fmt.Errorf("failed to get attendances by student and course: %w", err)
 	}
@@ -2,7 +2,10 @@ package attendance
 
 import (
 	"fmt"
+	"strings"
 	"time"
+
+	"school_management/internal/modules/student"
 )
 
 // AttendanceService defines the business logic interface
@@ -10,19 +13,27 @@ type AttendanceService interface {
 	Create(req *CreateAttendanceRequest) (*AttendanceResponse, error)
 	GetByID(id uint) (*AttendanceResponse, error)
 	GetByStudent(studentID uint) ([]AttendanceResponse, error)
-	GetByCourse(courseID uint) ([]AttendanceResponse, error)
+	GetByCourse(courseID uint, homeroomID *uint) ([]AttendanceResponse, error)
 	Update(id uint, req *UpdateAttendanceRequest) (*AttendanceResponse, error)
 	Delete(id uint) error
 }
 
+// TakerChecker decides which teachers may take a course's attendance on a date: its own teacher,
+// or a substitute covering it. It is implemented by the substitute module, which depends on course.
+type TakerChecker interface {
+	CheckAttendanceTaker(teacherID, courseID uint, date time.Time) error
+}
+
 // attendanceService implements AttendanceService
 type attendanceService struct {
-	repo AttendanceRepository
+	repo        AttendanceRepository
+	studentRepo student.StudentRepository
+	takers      TakerChecker
 }
 
 // NewAttendanceService creates a new attendance service with DI
-func NewAttendanceService(repo AttendanceRepository) AttendanceService {
-	return &attendanceService{repo: repo}
+func NewAttendanceService(repo AttendanceRepository, studentRepo student.StudentRepository, takers TakerChecker) AttendanceService {
+	return &attendanceService{repo: repo, studentRepo: studentRepo, takers: takers}
 }
 
 // Create creates a new attendance record
@@ -31,12 +42,22 @@ func (s *attendanceService) Create(req *CreateAttendanceRequest) (*AttendanceRes
 	if err := s.validateCreateRequest(req); err != nil {
 		return nil, err
 	}
+	st, err := s.studentRepo.GetByID(req.StudentID)
+	if err != nil {
+		return nil, fmt.Errorf("student not found: %w", err)
+	}
+	if !st.IsActive() {
+		return nil, fmt.Errorf("student is %s; attendance can only be recorded for active students", st.Status)
+	}
 
 	// Parse date
 	date, err := time.Parse("2006-01-02", req.Date)
 	if err != nil {
 		return nil, fmt.Errorf("invalid date format (use YYYY-MM-DD): %w", err)
 	}
+	if err := s.takers.CheckAttendanceTaker(req.TeacherID, req.CourseID, date); err != nil {
+		return nil, err
+	}
 
 	// Map DTO to Model
 	att := &Attendance{
@@ -44,6 +65,7 @@ func (s *attendanceService) Create(req *CreateAttendanceRequest) (*AttendanceRes
 		CourseID:  req.CourseID,
 		Date:      date,
 		Status:    AttendanceStatus(req.Status),
+		TakenByID: &req.TeacherID,
 	}
 
 	// Create via repository
@@ -73,9 +95,15 @@ func (s *attendanceService) GetByStudent(studentID uint) ([]AttendanceResponse,
 	return s.toResponseDTOList(attendances), nil
 }
 
-// GetByCourse retrieves attendance records for a course
-func (s *attendanceService) GetByCourse(courseID uint) ([]AttendanceResponse, error) {
-	attendances, err := s.repo.GetByCourse(courseID)
+// GetByCourse retrieves attendance records for a course, optionally only for students currently in a homeroom
+func (s *attendanceService) GetByCourse(courseID uint, homeroomID *uint) ([]AttendanceResponse, error) {
+	var attendances []Attendance
+	var err error
+	if homeroomID != nil {
+		attendances, err = s.repo.GetByCourseAndHomeroom(courseID, *homeroomID)
+	} else {
+		attendances, err = s.repo.GetByCourse(courseID)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get attendances: %w", err)
 	}
@@ -94,11 +122,15 @@ func (s *attendanceService) Update(id uint, req *UpdateAttendanceRequest) (*Atte
 	if err != nil {
 		return nil, fmt.Errorf("attendance not found: %w", err)
 	}
+	if err := s.takers.CheckAttendanceTaker(req.TeacherID, att.CourseID, att.Date); err != nil {
+		return nil, err
+	}
 
 	// Update fields
 	if req.Status != "" {
 		att.Status = AttendanceStatus(req.Status)
 	}
+	att.TakenByID = &req.TeacherID
 
 	// Save
 	if err := s.repo.Update(att); err != nil {
@@ -132,6 +164,9 @@ func (s *attendanceService) validateCreateRequest(req *CreateAttendanceRequest)
 	if req.Date == "" {
 		return fmt.Errorf("date is required")
 	}
+	if req.TeacherID == 0 {
+		return fmt.Errorf("teacher ID is required")
+	}
 	if !s.isValidStatus(req.Status) {
 		return fmt.Errorf("invalid status (must be: present, absent, or late)")
 	}
@@ -139,6 +174,9 @@ func (s *attendanceService) validateCreateRequest(req *CreateAttendanceRequest)
 }
 
 func (s *attendanceService) validateUpdateRequest(req *UpdateAttendanceRequest) error {
+	if req.TeacherID == 0 {
+		return fmt.Errorf("teacher ID is required")
+	}
 	if req.Status != "" && !s.isValidStatus(req.Status) {
 		return fmt.Errorf("invalid status (must be: present, absent, or late)")
 	}
@@ -152,13 +190,16 @@ func (s *attendanceService) isValidStatus(status string) bool {
 // DTO mapping methods
 func (s *attendanceService) toResponseDTO(att *Attendance) *AttendanceResponse {
 	return &AttendanceResponse{
-		ID:        att.ID,
-		StudentID: att.StudentID,
-		CourseID:  att.CourseID,
-		Date:      att.Date,
-		Status:    string(att.Status),
-		CreatedAt: att.CreatedAt,
-		UpdatedAt: att.UpdatedAt,
+		ID:          att.ID,
+		StudentID:   att.StudentID,
+		StudentName: strings.TrimSpace(att.Student.FirstName + " " + att.Student.LastName),
+		CourseID:    att.CourseID,
+		CourseName:  att.Course.Name,
+		Date:        att.Date,
+		Status:      string(att.Status),
+		TakenByID:   att.TakenByID,
+		CreatedAt:   att.CreatedAt,
+		UpdatedAt:   att.UpdatedAt,
 	}
 }
 
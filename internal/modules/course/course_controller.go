@@ -1,10 +1,15 @@
 package course
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+
+	"school_management/internal/deletion"
+	"school_management/internal/export"
+	"school_management/internal/modules/department"
 )
 
 // CourseController handles HTTP requests for courses
@@ -27,6 +32,10 @@ func (c *CourseController) Create(ctx *gin.Context) {
 	}
 
 	resp, err := c.service.Create(&req)
+	if errors.Is(err, department.ErrNotHead) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -57,6 +66,13 @@ func (c *CourseController) GetAll(ctx *gin.Context) {
 	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
 	offset, _ := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
 
+	if export.Requested(ctx) {
+		export.StreamPages(ctx, offset, func(limit, offset int) (interface{}, error) {
+			return c.service.GetAll(limit, offset)
+		})
+		return
+	}
+
 	resp, err := c.service.GetAll(limit, offset)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -86,6 +102,10 @@ func (c *CourseController) Update(ctx *gin.Context) {
 	}
 
 	resp, err := c.service.Update(uint(id), &req)
+	if errors.Is(err, department.ErrNotHead) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -94,7 +114,8 @@ func (c *CourseController) Update(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, resp)
 }
 
-// Delete deletes a course
+// Delete deletes a course; an ?actor_id, when sent, must head the course's department, and
+// ?policy, ?reassign_to and ?dry_run decide what happens to the records that reference it
 func (c *CourseController) Delete(ctx *gin.Context) {
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
@@ -102,12 +123,39 @@ func (c *CourseController) Delete(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.service.Delete(uint(id)); err != nil {
+	var actorID uint64
+	if raw := ctx.Query("actor_id"); raw != "" {
+		if actorID, err = strconv.ParseUint(raw, 10, 32); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid actor_id"})
+			return
+		}
+	}
+
+	opts, err := deletion.ParseOptions(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := c.service.Delete(uint(id), uint(actorID), opts)
+	if errors.Is(err, deletion.ErrReferenced) || errors.Is(err, deletion.ErrConflict) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error(), "report": report})
+		return
+	}
+	if errors.Is(err, department.ErrNotHead) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "course deleted successfully"})
+	if report.DryRun {
+		ctx.JSON(http.StatusOK, gin.H{"message": "dry run; nothing was deleted", "report": report})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "course deleted successfully", "report": report})
 }
 
 // GetByDepartment retrieves courses by department
@@ -124,6 +172,71 @@ func (c *CourseController) GetByDepartment(ctx *gin.Context) {
 		return
 	}
 
+	if export.Send(ctx, resp) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// GetByTeacher retrieves the courses a teacher is assigned
+func (c *CourseController) GetByTeacher(ctx *gin.Context) {
+	teacherID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid teacher ID"})
+		return
+	}
+
+	resp, err := c.service.GetByTeacher(uint(teacherID))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// GetPeriods retrieves a course's weekly timetable
+func (c *CourseController) GetPeriods(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetPeriods(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// SetPeriods replaces a course's weekly timetable
+func (c *CourseController) SetPeriods(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	var req SetPeriodsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.SetPeriods(uint(id), &req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
 }
 
@@ -137,5 +250,8 @@ func (c *CourseController) RegisterRoutes(rg *gin.RouterGroup) {
 		courses.PUT("/:id", c.Update)
 		courses.DELETE("/:id", c.Delete)
 		courses.GET("/department/:deptId", c.GetByDepartment)
+		courses.GET("/:id/periods", c.GetPeriods)
+		courses.PUT("/:id/periods", c.SetPeriods)
 	}
+	rg.GET("/teachers/:id/courses", c.GetByTeacher)
 }
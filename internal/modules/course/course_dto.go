@@ -10,6 +10,12 @@ type CreateCourseRequest struct {
 	Credits      int    `json:"credits" binding:"required,min=1,max=10"`
 	DepartmentID uint   `json:"department_id" binding:"required"`
 	TeacherID    uint   `json:"teacher_id" binding:"required"`
+	AcademicYear string `json:"academic_year" binding:"omitempty,len=9"` // e.g. 2025-2026
+
+	MinGradeLevel *int `json:"min_grade_level" binding:"omitempty,min=1"` // Grade level ordinal
+	MaxGradeLevel *int `json:"max_grade_level" binding:"omitempty,min=1"` // Grade level ordinal
+
+	ActorID uint `json:"actor_id" binding:"omitempty"` // teacher making the change; when sent, must head the department
 }
 
 // UpdateCourseRequest represents the request body for updating a course
@@ -20,6 +26,12 @@ type UpdateCourseRequest struct {
 	Credits      int    `json:"credits" binding:"omitempty,min=1,max=10"`
 	DepartmentID uint   `json:"department_id" binding:"omitempty"`
 	TeacherID    uint   `json:"teacher_id" binding:"omitempty"`
+	AcademicYear string `json:"academic_year" binding:"omitempty,len=9"` // e.g. 2025-2026
+
+	MinGradeLevel *int `json:"min_grade_level" binding:"omitempty,min=0"` // Grade level ordinal; 0 removes the limit
+	MaxGradeLevel *int `json:"max_grade_level" binding:"omitempty,min=0"` // Grade level ordinal; 0 removes the limit
+
+	ActorID uint `json:"actor_id" binding:"omitempty"` // teacher making the change; when sent, must head the department
 }
 
 // CourseResponse represents the response body for course data
@@ -31,6 +43,32 @@ type CourseResponse struct {
 	Credits      int       `json:"credits"`
 	DepartmentID uint      `json:"department_id"`
 	TeacherID    uint      `json:"teacher_id"`
+	AcademicYear string    `json:"academic_year"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
+
+	MinGradeLevel *int `json:"min_grade_level"`
+	MaxGradeLevel *int `json:"max_grade_level"`
+}
+
+// PeriodRequest represents one weekly meeting in a course timetable
+type PeriodRequest struct {
+	Weekday   *int   `json:"weekday" binding:"required,min=0,max=6"` // 0 is Sunday
+	StartTime string `json:"start_time" binding:"required"`          // Format: HH:MM
+	EndTime   string `json:"end_time" binding:"required"`            // Format: HH:MM
+}
+
+// SetPeriodsRequest represents the request body for replacing a course's weekly timetable
+type SetPeriodsRequest struct {
+	Periods []PeriodRequest `json:"periods" binding:"omitempty,dive"` // empty clears the timetable
+}
+
+// PeriodResponse represents one weekly meeting of a course
+type PeriodResponse struct {
+	ID        uint   `json:"id"`
+	CourseID  uint   `json:"course_id"`
+	Weekday   int    `json:"weekday"`
+	DayName   string `json:"day_name"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
 }
@@ -10,12 +10,21 @@ import (
 type Course struct {
 	gorm.Model
 	Name         string `gorm:"not null;size:100" json:"name"`
-	Code         string `gorm:"uniqueIndex;not null;size:20" json:"code"`
+	Code         string `gorm:"uniqueIndex:idx_courses_active_code_year,where:deleted_at IS NULL;not null;size:20" json:"code"`
 	Description  string `gorm:"type:text" json:"description"`
 	Credits      int    `gorm:"not null;default:3" json:"credits"`
 	DepartmentID uint   `gorm:"not null" json:"department_id"`
 	TeacherID    uint   `gorm:"not null" json:"teacher_id"`
 
+	// Academic year the course runs in, e.g. 2025-2026; empty for courses not tied to a year.
+	// Codes are unique within a year so rollover can carry a course into the next one;
+	// deleted courses do not count, so a code can be reused once its course is deleted.
+	AcademicYear string `gorm:"size:9;not null;default:'';uniqueIndex:idx_courses_active_code_year;index" json:"academic_year"`
+
+	// Grade level range allowed to enroll, by grade level ordinal; nil means no limit
+	MinGradeLevel *int `json:"min_grade_level"`
+	MaxGradeLevel *int `json:"max_grade_level"`
+
 	// Belongs To relationships
 	Department department.Department `gorm:"foreignKey:DepartmentID" json:"department,omitempty"`
 	Teacher    teacher.Teacher       `gorm:"foreignKey:TeacherID" json:"teacher,omitempty"`
@@ -25,3 +34,19 @@ type Course struct {
 func (Course) TableName() string {
 	return "courses"
 }
+
+// RestrictsGradeLevel reports whether enrollment depends on the student's grade level
+func (c *Course) RestrictsGradeLevel() bool {
+	return c.MinGradeLevel != nil || c.MaxGradeLevel != nil
+}
+
+// AllowsGradeLevel reports whether a student at a grade level ordinal may enroll
+func (c *Course) AllowsGradeLevel(ordinal int) bool {
+	if c.MinGradeLevel != nil && ordinal < *c.MinGradeLevel {
+		return false
+	}
+	if c.MaxGradeLevel != nil && ordinal > *c.MaxGradeLevel {
+		return false
+	}
+	return true
+}
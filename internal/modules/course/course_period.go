@@ -0,0 +1,42 @@
+package course
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ClassPeriod is one weekly meeting of a course, e.g. Mondays 09:00-09:50.
+// Times are kept as minutes after midnight.
+type ClassPeriod struct {
+	gorm.Model
+	CourseID    uint         `gorm:"not null;index" json:"course_id"`
+	Weekday     time.Weekday `gorm:"not null" json:"weekday"` // 0 is Sunday
+	StartMinute int          `gorm:"not null" json:"start_minute"`
+	EndMinute   int          `gorm:"not null" json:"end_minute"`
+}
+
+// TableName specifies the table name for the ClassPeriod model
+func (ClassPeriod) TableName() string {
+	return "class_periods"
+}
+
+// Overlaps reports whether two periods meet at the same time on the same weekday
+func (p *ClassPeriod) Overlaps(other *ClassPeriod) bool {
+	return p.Weekday == other.Weekday && p.StartMinute < other.EndMinute && other.StartMinute < p.EndMinute
+}
+
+// ParseClock parses an HH:MM time of day into minutes after midnight
+func ParseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q (use HH:MM)", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// FormatClock formats minutes after midnight as HH:MM
+func FormatClock(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
@@ -2,8 +2,11 @@ package course
 
 import (
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
+
+	"school_management/internal/deletion"
 )
 
 // CourseRepository defines the interface for course data access
@@ -15,8 +18,15 @@ type CourseRepository interface {
 	GetByDepartment(deptID uint) ([]Course, error)
 	GetByTeacher(teacherID uint) ([]Course, error)
 	GetByCode(code string) (*Course, error)
+	GetByAcademicYear(year string) ([]Course, error)
 	Update(course *Course) error
 	Delete(id uint) error
+	PlanDelete(id uint, opts deletion.Options) (*deletion.Plan, error)
+	DeleteWithPlan(id uint, plan *deletion.Plan) error
+
+	// Timetable
+	GetPeriods(courseIDs []uint) ([]ClassPeriod, error)
+	ReplacePeriods(courseID uint, periods []ClassPeriod) error
 }
 
 // courseRepository implements CourseRepository
@@ -58,7 +68,7 @@ func (r *courseRepository) GetByIDWithRelations(id uint) (*Course, error) {
 // GetAll retrieves all courses with pagination
 func (r *courseRepository) GetAll(limit, offset int) ([]Course, error) {
 	var courses []Course
-	if err := r.db.Limit(limit).Offset(offset).Find(&courses).Error; err != nil {
+	if err := r.db.Order("id").Limit(limit).Offset(offset).Find(&courses).Error; err != nil {
 		return nil, fmt.Errorf("failed to get courses: %w", err)
 	}
 	return courses, nil
@@ -91,6 +101,15 @@ func (r *courseRepository) GetByCode(code string) (*Course, error) {
 	return &course, nil
 }
 
+// GetByAcademicYear retrieves all courses running in an academic year
+func (r *courseRepository) GetByAcademicYear(year string) ([]Course, error) {
+	var courses []Course
+	if err := r.db.Where("academic_year = ?", year).Order("code ASC").Find(&courses).Error; err != nil {
+		return nil, fmt.Errorf("failed to get courses by academic year: %w", err)
+	}
+	return courses, nil
+}
+
 // Update updates a course
 func (r *courseRepository) Update(course *Course) error {
 	if err := r.db.Save(course).Error; err != nil {
@@ -106,3 +125,56 @@ func (r *courseRepository) Delete(id uint) error {
 	}
 	return nil
 }
+
+// GetPeriods retrieves the weekly periods of the given courses in weekday and time order
+func (r *courseRepository) GetPeriods(courseIDs []uint) ([]ClassPeriod, error) {
+	var periods []ClassPeriod
+	if len(courseIDs) == 0 {
+		return periods, nil
+	}
+	if err := r.db.Where("course_id IN ?", courseIDs).
+		Order("weekday ASC, start_minute ASC").
+		Find(&periods).Error; err != nil {
+		return nil, fmt.Errorf("failed to get class periods: %w", err)
+	}
+	return periods, nil
+}
+
+// ReplacePeriods replaces a course's weekly periods in one transaction
+func (r *courseRepository) ReplacePeriods(courseID uint, periods []ClassPeriod) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("course_id = ?", courseID).Delete(&ClassPeriod{}).Error; err != nil {
+			return fmt.Errorf("failed to clear class periods: %w", err)
+		}
+		if len(periods) == 0 {
+			return nil
+		}
+		if err := tx.Create(&periods).Error; err != nil {
+			return fmt.Errorf("failed to save class periods: %w", err)
+		}
+		return nil
+	})
+}
+
+// PlanDelete works out what deleting the course does to the records that reference it
+func (r *courseRepository) PlanDelete(id uint, opts deletion.Options) (*deletion.Plan, error) {
+	return deletion.Inspect(r.db, deletion.Courses, []uint{id}, opts)
+}
+
+// DeleteWithPlan applies a delete plan and soft deletes the course in one transaction
+func (r *courseRepository) DeleteWithPlan(id uint, plan *deletion.Plan) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := deletion.Lock(tx, "courses", []uint{id}); err != nil {
+			return err
+		}
+		now := time.Now()
+		if err := plan.Apply(tx, now); err != nil {
+			return err
+		}
+		// Same timestamp as the cascaded rows, so a restore brings them back together
+		if err := tx.Model(&Course{}).Where("id = ?", id).Update("deleted_at", now).Error; err != nil {
+			return fmt.Errorf("failed to delete course: %w", err)
+		}
+		return nil
+	})
+}
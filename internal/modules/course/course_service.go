@@ -3,6 +3,10 @@ package course
 import (
 	"fmt"
 	"strings"
+	"time"
+
+	"school_management/internal/deletion"
+	"school_management/internal/modules/department"
 )
 
 // CourseService defines the business logic interface
@@ -12,18 +16,31 @@ type CourseService interface {
 	GetByIDWithRelations(id uint) (*CourseResponse, error)
 	GetAll(limit, offset int) ([]CourseResponse, error)
 	GetByDepartment(deptID uint) ([]CourseResponse, error)
+	GetByTeacher(teacherID uint) ([]CourseResponse, error)
 	Update(id uint, req *UpdateCourseRequest) (*CourseResponse, error)
-	Delete(id uint) error
+	Delete(id, actorID uint, opts deletion.Options) (*deletion.Report, error)
+	GetPeriods(courseID uint) ([]PeriodResponse, error)
+	SetPeriods(courseID uint, req *SetPeriodsRequest) ([]PeriodResponse, error)
+}
+
+// TeacherLoadChecker rejects assigning a teacher more teaching than the max-load rule allows.
+// It is implemented by the workload module, which depends on course.
+type TeacherLoadChecker interface {
+	// CheckTeacherLoad checks that the teacher can take one more course of the given credits
+	// in the academic year, not counting the course being changed (0 when creating)
+	CheckTeacherLoad(teacherID uint, academicYear string, credits int, excludeCourseID uint) error
 }
 
 // courseService implements CourseService
 type courseService struct {
-	repo CourseRepository
+	repo        CourseRepository
+	loads       TeacherLoadChecker
+	deptService department.DepartmentService
 }
 
 // NewCourseService creates a new course service with DI
-func NewCourseService(repo CourseRepository) CourseService {
-	return &courseService{repo: repo}
+func NewCourseService(repo CourseRepository, loads TeacherLoadChecker, deptService department.DepartmentService) CourseService {
+	return &courseService{repo: repo, loads: loads, deptService: deptService}
 }
 
 // Create creates a new course
@@ -32,6 +49,12 @@ func (s *courseService) Create(req *CreateCourseRequest) (*CourseResponse, error
 	if err := s.validateCreateRequest(req); err != nil {
 		return nil, err
 	}
+	if err := s.checkActor(req.ActorID, req.DepartmentID); err != nil {
+		return nil, err
+	}
+	if err := s.loads.CheckTeacherLoad(req.TeacherID, req.AcademicYear, req.Credits, 0); err != nil {
+		return nil, err
+	}
 
 	// Map DTO to Model
 	course := &Course{
@@ -41,6 +64,10 @@ func (s *courseService) Create(req *CreateCourseRequest) (*CourseResponse, error
 		Credits:      req.Credits,
 		DepartmentID: req.DepartmentID,
 		TeacherID:    req.TeacherID,
+		AcademicYear: req.AcademicYear,
+
+		MinGradeLevel: req.MinGradeLevel,
+		MaxGradeLevel: req.MaxGradeLevel,
 	}
 
 	// Create via repository
@@ -97,6 +124,15 @@ func (s *courseService) GetByDepartment(deptID uint) ([]CourseResponse, error) {
 	return s.toResponseDTOList(courses), nil
 }
 
+// GetByTeacher retrieves the courses a teacher is assigned
+func (s *courseService) GetByTeacher(teacherID uint) ([]CourseResponse, error) {
+	courses, err := s.repo.GetByTeacher(teacherID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get courses by teacher: %w", err)
+	}
+	return s.toResponseDTOList(courses), nil
+}
+
 // Update updates a course
 func (s *courseService) Update(id uint, req *UpdateCourseRequest) (*CourseResponse, error) {
 	// Validate
@@ -109,8 +145,17 @@ func (s *courseService) Update(id uint, req *UpdateCourseRequest) (*CourseRespon
 	if err != nil {
 		return nil, fmt.Errorf("course not found: %w", err)
 	}
+	if err := s.checkActor(req.ActorID, course.DepartmentID); err != nil {
+		return nil, err
+	}
+	if req.DepartmentID != 0 && req.DepartmentID != course.DepartmentID {
+		if err := s.checkActor(req.ActorID, req.DepartmentID); err != nil {
+			return nil, err
+		}
+	}
 
 	// Update fields
+	previous := *course
 	if req.Name != "" {
 		course.Name = req.Name
 	}
@@ -126,6 +171,23 @@ func (s *courseService) Update(id uint, req *UpdateCourseRequest) (*CourseRespon
 	if req.TeacherID != 0 {
 		course.TeacherID = req.TeacherID
 	}
+	if req.AcademicYear != "" {
+		course.AcademicYear = req.AcademicYear
+	}
+	if req.MinGradeLevel != nil {
+		course.MinGradeLevel = gradeLevelLimit(*req.MinGradeLevel)
+	}
+	if req.MaxGradeLevel != nil {
+		course.MaxGradeLevel = gradeLevelLimit(*req.MaxGradeLevel)
+	}
+	if err := s.validateGradeLevelRange(course.MinGradeLevel, course.MaxGradeLevel); err != nil {
+		return nil, err
+	}
+	if course.TeacherID != previous.TeacherID || course.Credits != previous.Credits || course.AcademicYear != previous.AcademicYear {
+		if err := s.loads.CheckTeacherLoad(course.TeacherID, course.AcademicYear, course.Credits, course.ID); err != nil {
+			return nil, err
+		}
+	}
 
 	// Save
 	if err := s.repo.Update(course); err != nil {
@@ -135,19 +197,102 @@ func (s *courseService) Update(id uint, req *UpdateCourseRequest) (*CourseRespon
 	return s.toResponseDTO(course), nil
 }
 
-// Delete deletes a course
-func (s *courseService) Delete(id uint) error {
+// Delete deletes a course; an actorID other than 0 must head the course's department. Enrollments,
+// exams and other records that still reference it refuse the delete, move to opts.ReassignTo,
+// or are deleted with it, depending on opts.Policy.
+func (s *courseService) Delete(id, actorID uint, opts deletion.Options) (*deletion.Report, error) {
 	// Check if exists
-	if _, err := s.repo.GetByID(id); err != nil {
-		return fmt.Errorf("course not found: %w", err)
+	course, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("course not found: %w", err)
+	}
+	if err := s.checkActor(actorID, course.DepartmentID); err != nil {
+		return nil, err
+	}
+	if opts.Policy == deletion.Reassign {
+		if opts.ReassignTo == id {
+			return nil, fmt.Errorf("cannot reassign a course's records to itself")
+		}
+		if _, err := s.repo.GetByID(opts.ReassignTo); err != nil {
+			return nil, fmt.Errorf("reassign target course not found: %w", err)
+		}
+	}
+
+	plan, err := s.repo.PlanDelete(id, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.DryRun {
+		return plan.Report(false), nil
+	}
+	if err := plan.Err(); err != nil {
+		return plan.Report(false), err
 	}
 
 	// Delete
-	if err := s.repo.Delete(id); err != nil {
-		return fmt.Errorf("failed to delete course: %w", err)
+	if err := s.repo.DeleteWithPlan(id, plan); err != nil {
+		if plan.Err() != nil {
+			// References added since the plan was made refuse it after all
+			return plan.Report(false), err
+		}
+		return nil, fmt.Errorf("failed to delete course: %w", err)
 	}
 
-	return nil
+	return plan.Report(true), nil
+}
+
+// GetPeriods retrieves a course's weekly timetable
+func (s *courseService) GetPeriods(courseID uint) ([]PeriodResponse, error) {
+	if _, err := s.repo.GetByID(courseID); err != nil {
+		return nil, fmt.Errorf("course not found: %w", err)
+	}
+
+	periods, err := s.repo.GetPeriods([]uint{courseID})
+	if err != nil {
+		return nil, err
+	}
+	return s.toPeriodResponseDTOList(periods), nil
+}
+
+// SetPeriods replaces a course's weekly timetable
+func (s *courseService) SetPeriods(courseID uint, req *SetPeriodsRequest) ([]PeriodResponse, error) {
+	if _, err := s.repo.GetByID(courseID); err != nil {
+		return nil, fmt.Errorf("course not found: %w", err)
+	}
+
+	// Map DTO to Model
+	periods := make([]ClassPeriod, len(req.Periods))
+	for i, p := range req.Periods {
+		start, err := ParseClock(p.StartTime)
+		if err != nil {
+			return nil, err
+		}
+		end, err := ParseClock(p.EndTime)
+		if err != nil {
+			return nil, err
+		}
+		periods[i] = ClassPeriod{CourseID: courseID, Weekday: time.Weekday(*p.Weekday), StartMinute: start, EndMinute: end}
+	}
+
+	// Validate
+	if err := s.validatePeriods(periods); err != nil {
+		return nil, err
+	}
+
+	// Save
+	if err := s.repo.ReplacePeriods(courseID, periods); err != nil {
+		return nil, err
+	}
+	return s.toPeriodResponseDTOList(periods), nil
+}
+
+// checkActor limits a teacher acting on a course to the departments they head. Changes made
+// without an actor are not checked.
+func (s *courseService) checkActor(actorID, departmentID uint) error {
+	if actorID == 0 {
+		return nil
+	}
+	return s.deptService.CheckHead(actorID, departmentID)
 }
 
 // Validation methods
@@ -167,13 +312,50 @@ func (s *courseService) validateCreateRequest(req *CreateCourseRequest) error {
 	if req.TeacherID == 0 {
 		return fmt.Errorf("teacher ID is required")
 	}
+	if req.AcademicYear != "" {
+		if err := ValidateAcademicYear(req.AcademicYear); err != nil {
+			return err
+		}
+	}
+	return s.validateGradeLevelRange(req.MinGradeLevel, req.MaxGradeLevel)
+}
+
+func (s *courseService) validateGradeLevelRange(lowest, highest *int) error {
+	if lowest != nil && highest != nil && *lowest > *highest {
+		return fmt.Errorf("min grade level cannot be above max grade level")
+	}
 	return nil
 }
 
+// gradeLevelLimit converts an update's grade level ordinal to a limit, where 0 removes it
+func gradeLevelLimit(ordinal int) *int {
+	if ordinal == 0 {
+		return nil
+	}
+	return &ordinal
+}
+
 func (s *courseService) validateUpdateRequest(req *UpdateCourseRequest) error {
 	if req.Credits != 0 && (req.Credits < 1 || req.Credits > 6) {
 		return fmt.Errorf("credits must be between 1 and 6")
 	}
+	if req.AcademicYear != "" {
+		return ValidateAcademicYear(req.AcademicYear)
+	}
+	return nil
+}
+
+func (s *courseService) validatePeriods(periods []ClassPeriod) error {
+	for i := range periods {
+		if periods[i].EndMinute <= periods[i].StartMinute {
+			return fmt.Errorf("period %d ends before it starts", i+1)
+		}
+		for j := i + 1; j < len(periods); j++ {
+			if periods[i].Overlaps(&periods[j]) {
+				return fmt.Errorf("periods %d and %d overlap", i+1, j+1)
+			}
+		}
+	}
 	return nil
 }
 
@@ -187,8 +369,12 @@ func (s *courseService) toResponseDTO(course *Course) *CourseResponse {
 		Credits:      course.Credits,
 		DepartmentID: course.DepartmentID,
 		TeacherID:    course.TeacherID,
+		AcademicYear: course.AcademicYear,
 		CreatedAt:    course.CreatedAt,
 		UpdatedAt:    course.UpdatedAt,
+
+		MinGradeLevel: course.MinGradeLevel,
+		MaxGradeLevel: course.MaxGradeLevel,
 	}
 }
 
@@ -199,3 +385,18 @@ func (s *courseService) toResponseDTOList(courses []Course) []CourseResponse {
 	}
 	return responses
 }
+
+func (s *courseService) toPeriodResponseDTOList(periods []ClassPeriod) []PeriodResponse {
+	responses := make([]PeriodResponse, len(periods))
+	for i, p := range periods {
+		responses[i] = PeriodResponse{
+			ID:        p.ID,
+			CourseID:  p.CourseID,
+			Weekday:   int(p.Weekday),
+			DayName:   p.Weekday.String(),
+			StartTime: FormatClock(p.StartMinute),
+			EndTime:   FormatClock(p.EndMinute),
+		}
+	}
+	return responses
+}
@@ -0,0 +1,50 @@
+package course
+
+import (
+	"fmt"
+	"time"
+)
+
+// Academic years run from July 1 to June 30 and are written as their two calendar years, e.g. "2025-2026".
+
+// ValidateAcademicYear checks an academic year of the form 2025-2026
+func ValidateAcademicYear(year string) error {
+	_, err := academicYearStart(year)
+	return err
+}
+
+// AcademicYearBounds returns the [start, end) range of an academic year
+func AcademicYearBounds(year string) (time.Time, time.Time, error) {
+	first, err := academicYearStart(year)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	start := time.Date(first, time.July, 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(1, 0, 0), nil
+}
+
+// AcademicYearOf returns the academic year containing t
+func AcademicYearOf(t time.Time) string {
+	first := t.Year()
+	if t.Month() < time.July {
+		first--
+	}
+	return fmt.Sprintf("%d-%d", first, first+1)
+}
+
+// NextAcademicYear returns the academic year following year
+func NextAcademicYear(year string) (string, error) {
+	first, err := academicYearStart(year)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d-%d", first+1, first+2), nil
+}
+
+func academicYearStart(year string) (int, error) {
+	var first, second int
+	if _, err := fmt.Sscanf(year, "%4d-%4d", &first, &second); err != nil || len(year) != 9 || second != first+1 {
+		return 0, fmt.Errorf("invalid academic year %q (use YYYY-YYYY, e.g. 2025-2026)", year)
+	}
+	return first, nil
+}
@@ -1,20 +1,27 @@
 package department
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+
+	"school_management/internal/admin"
+	"school_management/internal/deletion"
+	"school_management/internal/export"
 )
 
 // DepartmentController handles HTTP requests for departments
 type DepartmentController struct {
-	service DepartmentService
+	service    DepartmentService
+	adminToken string
 }
 
-// NewDepartmentController creates a new department controller
-func NewDepartmentController(service DepartmentService) *DepartmentController {
-	return &DepartmentController{service: service}
+// NewDepartmentController creates a new department controller. A request with adminToken in the
+// X-Admin-Token header may assign any department's head.
+func NewDepartmentController(service DepartmentService, adminToken string) *DepartmentController {
+	return &DepartmentController{service: service, adminToken: adminToken}
 }
 
 // Create godoc
@@ -36,7 +43,11 @@ func (c *DepartmentController) Create(ctx *gin.Context) {
 		return
 	}
 
-	resp, err := c.service.Create(&req)
+	resp, err := c.service.Create(&req, admin.Is(ctx, c.adminToken))
+	if errors.Is(err, ErrNotHead) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -87,6 +98,13 @@ func (c *DepartmentController) GetAll(ctx *gin.Context) {
 	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
 	offset, _ := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
 
+	if export.Requested(ctx) {
+		export.StreamPages(ctx, offset, func(limit, offset int) (interface{}, error) {
+			return c.service.GetAll(limit, offset)
+		})
+		return
+	}
+
 	resp, err := c.service.GetAll(limit, offset)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -126,7 +144,11 @@ func (c *DepartmentController) Update(ctx *gin.Context) {
 		return
 	}
 
-	resp, err := c.service.Update(uint(id), &req)
+	resp, err := c.service.Update(uint(id), &req, admin.Is(ctx, c.adminToken))
+	if errors.Is(err, ErrNotHead) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -137,13 +159,17 @@ func (c *DepartmentController) Update(ctx *gin.Context) {
 
 // Delete godoc
 // @Summary Delete department
-// @Description Delete a department by ID
+// @Description Delete a department by ID. Teachers, courses and sub-departments that reference it refuse the delete by default (409); policy=reassign moves them to reassign_to and policy=cascade deletes them with it. dry_run=true only reports what would be affected.
 // @Tags departments
 // @Accept json
 // @Produce json
 // @Param id path int true "Department ID"
-// @Success 200 {object} map[string]string
+// @Param policy query string false "restrict (default), reassign or cascade"
+// @Param reassign_to query int false "Department to move references to (required with reassign)"
+// @Param dry_run query bool false "Report what would be affected without deleting"
+// @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]interface{}
 // @Failure 500 {object} map[string]string
 // @Router /departments/{id} [delete]
 func (c *DepartmentController) Delete(ctx *gin.Context) {
@@ -152,13 +178,27 @@ func (c *DepartmentController) Delete(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
 		return
 	}
+	opts, err := deletion.ParseOptions(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
-	if err := c.service.Delete(uint(id)); err != nil {
+	report, err := c.service.Delete(uint(id), opts)
+	if errors.Is(err, deletion.ErrReferenced) || errors.Is(err, deletion.ErrConflict) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error(), "report": report})
+		return
+	}
+	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "department deleted successfully"})
+	if report.DryRun {
+		ctx.JSON(http.StatusOK, gin.H{"message": "dry run; nothing was deleted", "report": report})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "department deleted successfully", "report": report})
 }
 
 // Search godoc
@@ -185,9 +225,71 @@ func (c *DepartmentController) Search(ctx *gin.Context) {
 		return
 	}
 
+	if export.Send(ctx, resp) {
+		return
+	}
+
 	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
 }
 
+// GetChildren godoc
+// @Summary List sub-departments
+// @Description Get the departments directly below a department
+// @Tags departments
+// @Accept json
+// @Produce json
+// @Param id path int true "Department ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /departments/{id}/children [get]
+func (c *DepartmentController) GetChildren(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetChildren(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// GetDashboard godoc
+// @Summary Department dashboard
+// @Description Aggregate courses, teachers, enrollments and average grades for a department and everything below it
+// @Tags departments
+// @Accept json
+// @Produce json
+// @Param id path int true "Department ID"
+// @Success 200 {object} DashboardResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /departments/{id}/dashboard [get]
+func (c *DepartmentController) GetDashboard(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetDashboard(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
 // RegisterRoutes registers department routes
 func (c *DepartmentController) RegisterRoutes(rg *gin.RouterGroup) {
 	departments := rg.Group("/departments")
@@ -198,5 +300,7 @@ func (c *DepartmentController) RegisterRoutes(rg *gin.RouterGroup) {
 		departments.PUT("/:id", c.Update)
 		departments.DELETE("/:id", c.Delete)
 		departments.GET("/search", c.Search)
+		departments.GET("/:id/children", c.GetChildren)
+		departments.GET("/:id/dashboard", c.GetDashboard)
 	}
 }
@@ -6,12 +6,21 @@ import "time"
 type CreateDepartmentRequest struct {
 	Name        string `json:"name" binding:"required,min=2,max=100"`
 	Description string `json:"description" binding:"omitempty,max=500"`
+	ParentID    *uint  `json:"parent_id" binding:"omitempty"` // faculty the department belongs to
+	HeadID      *uint  `json:"head_id" binding:"omitempty"`   // teacher heading the department
+	ActorID     uint   `json:"actor_id" binding:"omitempty"`  // teacher making the change; see UpdateDepartmentRequest
 }
 
 // UpdateDepartmentRequest represents the request body for updating a department
 type UpdateDepartmentRequest struct {
 	Name        string `json:"name" binding:"omitempty,min=2,max=100"`
 	Description string `json:"description" binding:"omitempty,max=500"`
+	ParentID    *uint  `json:"parent_id" binding:"omitempty"` // 0 makes it a top-level department
+	HeadID      *uint  `json:"head_id" binding:"omitempty"`   // 0 removes the head
+
+	// Teacher making the change. Assigning a head needs one who heads the department or one
+	// above it, or the admin token, unless no department on the way has a head yet.
+	ActorID uint `json:"actor_id" binding:"omitempty"`
 }
 
 // DepartmentResponse represents the response body for department data
@@ -19,6 +28,24 @@ type DepartmentResponse struct {
 	ID          uint      `json:"id"`
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
+	ParentID    *uint     `json:"parent_id"`
+	HeadID      *uint     `json:"head_id"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
+
+// DashboardResponse aggregates a department and all of its sub-departments
+type DashboardResponse struct {
+	DepartmentID   uint     `json:"department_id"`
+	Name           string   `json:"name"`
+	HeadID         *uint    `json:"head_id"`
+	HeadName       string   `json:"head_name,omitempty"`
+	SubDepartments int      `json:"sub_departments"` // at every level below this one
+	Teachers       int64    `json:"teachers"`
+	Courses        int64    `json:"courses"`
+	Students       int64    `json:"students"` // distinct students enrolled in the courses
+	Enrollments    int64    `json:"enrollments"`
+	AverageGrade   *float64 `json:"average_grade"` // published exam grades as a percentage; null when there are none
+
+	Children []DashboardResponse `json:"children,omitempty"` // direct sub-departments, each with its own totals
+}
@@ -8,9 +8,36 @@ type Department struct {
 	gorm.Model
 	Name        string `gorm:"not null;size:100" json:"name"`
 	Description string `gorm:"type:text" json:"description"`
+
+	// Faculty or other department this one belongs to; nil for a top-level department
+	ParentID *uint `gorm:"index" json:"parent_id"`
+
+	// Teacher heading the department. The teacher module depends on this one, so the head
+	// is kept as an ID rather than a relation.
+	HeadID *uint `gorm:"index" json:"head_id"`
+
+	// Belongs To relationships
+	Parent *Department `gorm:"foreignKey:ParentID" json:"parent,omitempty"`
 }
 
 // TableName specifies the table name for the Department model
 func (Department) TableName() string {
 	return "departments"
 }
+
+// TeacherRef is the part of a teacher the department module needs
+type TeacherRef struct {
+	ID           uint
+	FirstName    string
+	LastName     string
+	DepartmentID uint
+}
+
+// DepartmentStats aggregates a set of departments' teaching
+type DepartmentStats struct {
+	Teachers     int64
+	Courses      int64
+	Students     int64 // distinct students enrolled in the courses
+	Enrollments  int64
+	AverageGrade *float64 // published exam grades as a percentage of the maximum; nil when there are none
+}
@@ -2,8 +2,11 @@ package department
 
 import (
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
+
+	"school_management/internal/deletion"
 )
 
 // DepartmentRepository defines the interface for department data access
@@ -14,6 +17,16 @@ type DepartmentRepository interface {
 	Update(dept *Department) error
 	Delete(id uint) error
 	Search(name string) ([]Department, error)
+
+	// Hierarchy
+	GetTree() ([]Department, error)
+	GetChildren(parentID uint) ([]Department, error)
+	GetTeacher(teacherID uint) (*TeacherRef, error)
+	GetStats(deptIDs []uint) (*DepartmentStats, error)
+
+	// Safe deletion
+	PlanDelete(ids []uint, opts deletion.Options) (*deletion.Plan, error)
+	DeleteWithPlan(ids []uint, plan *deletion.Plan) error
 }
 
 // departmentRepository implements DepartmentRepository
@@ -46,7 +59,7 @@ func (r *departmentRepository) GetByID(id uint) (*Department, error) {
 // GetAll retrieves all departments with pagination
 func (r *departmentRepository) GetAll(limit, offset int) ([]Department, error) {
 	var departments []Department
-	query := r.db.Limit(limit).Offset(offset)
+	query := r.db.Order("id").Limit(limit).Offset(offset)
 	if err := query.Find(&departments).Error; err != nil {
 		return nil, fmt.Errorf("failed to get departments: %w", err)
 	}
@@ -77,3 +90,113 @@ func (r *departmentRepository) Search(name string) ([]Department, error) {
 	}
 	return departments, nil
 }
+
+// GetTree retrieves every department's ID, parent and head
+func (r *departmentRepository) GetTree() ([]Department, error) {
+	var departments []Department
+	if err := r.db.Select("id", "name", "parent_id", "head_id").Find(&departments).Error; err != nil {
+		return nil, fmt.Errorf("failed to get department tree: %w", err)
+	}
+	return departments, nil
+}
+
+// GetChildren retrieves a department's direct sub-departments by name
+func (r *departmentRepository) GetChildren(parentID uint) ([]Department, error) {
+	var departments []Department
+	if err := r.db.Where("parent_id = ?", parentID).Order("name ASC").Find(&departments).Error; err != nil {
+		return nil, fmt.Errorf("failed to get sub-departments: %w", err)
+	}
+	return departments, nil
+}
+
+// GetTeacher retrieves a teacher's name and department
+func (r *departmentRepository) GetTeacher(teacherID uint) (*TeacherRef, error) {
+	var teachers []TeacherRef
+	if err := r.db.Table("teachers").
+		Select("id, first_name, last_name, department_id").
+		Where("id = ? AND deleted_at IS NULL", teacherID).
+		Scan(&teachers).Error; err != nil {
+		return nil, fmt.Errorf("failed to get teacher: %w", err)
+	}
+	if len(teachers) == 0 {
+		return nil, fmt.Errorf("failed to get teacher: %w", gorm.ErrRecordNotFound)
+	}
+	return &teachers[0], nil
+}
+
+// GetStats counts the teachers, courses and enrollments of a set of departments and averages
+// their published exam grades
+func (r *departmentRepository) GetStats(deptIDs []uint) (*DepartmentStats, error) {
+	var stats DepartmentStats
+	if err := r.db.Table("teachers").
+		Where("department_id IN ? AND deleted_at IS NULL", deptIDs).
+		Count(&stats.Teachers).Error; err != nil {
+		return nil, fmt.Errorf("failed to count teachers: %w", err)
+	}
+	if err := r.db.Table("courses").
+		Where("department_id IN ? AND deleted_at IS NULL", deptIDs).
+		Count(&stats.Courses).Error; err != nil {
+		return nil, fmt.Errorf("failed to count courses: %w", err)
+	}
+
+	var enrollments struct {
+		Enrollments int64
+		Students    int64
+	}
+	if err := r.db.Table("student_courses").
+		Select("COUNT(*) AS enrollments, COUNT(DISTINCT student_courses.student_id) AS students").
+		Joins("JOIN courses ON courses.id = student_courses.course_id AND courses.deleted_at IS NULL").
+		Where("courses.department_id IN ? AND student_courses.deleted_at IS NULL", deptIDs).
+		Scan(&enrollments).Error; err != nil {
+		return nil, fmt.Errorf("failed to count enrollments: %w", err)
+	}
+	stats.Enrollments = enrollments.Enrollments
+	stats.Students = enrollments.Students
+
+	var grades struct {
+		Average *float64
+	}
+	if err := r.db.Table("grades").
+		Select("AVG(grades.score * 100.0 / NULLIF(exams.max_score, 0)) AS average").
+		Joins("JOIN exams ON exams.id = grades.exam_id AND exams.deleted_at IS NULL").
+		Joins("JOIN courses ON courses.id = exams.course_id AND courses.deleted_at IS NULL").
+		Where("courses.department_id IN ? AND grades.status = ? AND grades.deleted_at IS NULL", deptIDs, "published").
+		Scan(&grades).Error; err != nil {
+		return nil, fmt.Errorf("failed to average grades: %w", err)
+	}
+	stats.AverageGrade = grades.Average
+	return &stats, nil
+}
+
+// PlanDelete works out what deleting the departments does to the records that reference them.
+// A cascade deletes a whole subtree, so sub-departments are not counted as references then.
+func (r *departmentRepository) PlanDelete(ids []uint, opts deletion.Options) (*deletion.Plan, error) {
+	refs := deletion.Departments
+	if opts.Policy == deletion.Cascade {
+		refs = nil
+		for _, ref := range deletion.Departments {
+			if ref.Column != "parent_id" {
+				refs = append(refs, ref)
+			}
+		}
+	}
+	return deletion.Inspect(r.db, refs, ids, opts)
+}
+
+// DeleteWithPlan applies a delete plan and soft deletes the departments in one transaction
+func (r *departmentRepository) DeleteWithPlan(ids []uint, plan *deletion.Plan) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := deletion.Lock(tx, "departments", ids); err != nil {
+			return err
+		}
+		now := time.Now()
+		if err := plan.Apply(tx, now); err != nil {
+			return err
+		}
+		// Same timestamp as the cascaded rows, so a restore brings them back together
+		if err := tx.Model(&Department{}).Where("id IN ?", ids).Update("deleted_at", now).Error; err != nil {
+			return fmt.Errorf("failed to delete department: %w", err)
+		}
+		return nil
+	})
+}
@@ -1,18 +1,31 @@
 package department
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+
+	"school_management/internal/deletion"
 )
 
+// ErrNotHead is returned when a teacher acts on a department they do not head
+var ErrNotHead = errors.New("permission denied")
+
 // DepartmentService defines the business logic interface
 type DepartmentService interface {
-	Create(req *CreateDepartmentRequest) (*DepartmentResponse, error)
+	Create(req *CreateDepartmentRequest, asAdmin bool) (*DepartmentResponse, error)
 	GetByID(id uint) (*DepartmentResponse, error)
 	GetAll(limit, offset int) ([]DepartmentResponse, error)
-	Update(id uint, req *UpdateDepartmentRequest) (*DepartmentResponse, error)
-	Delete(id uint) error
+	Update(id uint, req *UpdateDepartmentRequest, asAdmin bool) (*DepartmentResponse, error)
+	Delete(id uint, opts deletion.Options) (*deletion.Report, error)
 	Search(name string) ([]DepartmentResponse, error)
+
+	// Hierarchy and heads
+	GetChildren(id uint) ([]DepartmentResponse, error)
+	GetDashboard(id uint) (*DashboardResponse, error)
+	GetSubtreeIDs(id uint) ([]uint, error)
+	NearestHead(id uint) (*Department, error)
+	CheckHead(teacherID, departmentID uint) error
 }
 
 // departmentService implements DepartmentService
@@ -25,17 +38,35 @@ func NewDepartmentService(repo DepartmentRepository) DepartmentService {
 	return &departmentService{repo: repo}
 }
 
-// Create creates a new department
-func (s *departmentService) Create(req *CreateDepartmentRequest) (*DepartmentResponse, error) {
+// Create creates a new department. A head under a parent department is assigned like in Update.
+func (s *departmentService) Create(req *CreateDepartmentRequest, asAdmin bool) (*DepartmentResponse, error) {
 	// Validate
 	if err := s.validateCreateRequest(req); err != nil {
 		return nil, err
 	}
 
+	if req.ParentID != nil {
+		if _, err := s.repo.GetByID(*req.ParentID); err != nil {
+			return nil, fmt.Errorf("parent department not found: %w", err)
+		}
+	}
+	if req.HeadID != nil {
+		if _, err := s.repo.GetTeacher(*req.HeadID); err != nil {
+			return nil, fmt.Errorf("head teacher not found: %w", err)
+		}
+		if req.ParentID != nil && !asAdmin {
+			if err := s.CheckHead(req.ActorID, *req.ParentID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Map DTO to Model
 	dept := &Department{
 		Name:        req.Name,
 		Description: req.Description,
+		ParentID:    req.ParentID,
+		HeadID:      req.HeadID,
 	}
 
 	// Create via repository
@@ -74,8 +105,9 @@ func (s *departmentService) GetAll(limit, offset int) ([]DepartmentResponse, err
 	return s.toResponseDTOList(departments), nil
 }
 
-// Update updates a department
-func (s *departmentService) Update(id uint, req *UpdateDepartmentRequest) (*DepartmentResponse, error) {
+// Update updates a department. Changing its head needs req.ActorID to head the department or one
+// above it, unless asAdmin.
+func (s *departmentService) Update(id uint, req *UpdateDepartmentRequest, asAdmin bool) (*DepartmentResponse, error) {
 	// Validate
 	if err := s.validateUpdateRequest(req); err != nil {
 		return nil, err
@@ -94,6 +126,25 @@ func (s *departmentService) Update(id uint, req *UpdateDepartmentRequest) (*Depa
 	if req.Description != "" {
 		dept.Description = req.Description
 	}
+	if req.ParentID != nil {
+		if err := s.validateParent(dept.ID, *req.ParentID); err != nil {
+			return nil, err
+		}
+		dept.ParentID = optionalID(*req.ParentID)
+	}
+	if req.HeadID != nil && !sameID(dept.HeadID, *req.HeadID) {
+		if !asAdmin {
+			if err := s.CheckHead(req.ActorID, dept.ID); err != nil {
+				return nil, err
+			}
+		}
+		if *req.HeadID != 0 {
+			if _, err := s.repo.GetTeacher(*req.HeadID); err != nil {
+				return nil, fmt.Errorf("head teacher not found: %w", err)
+			}
+		}
+		dept.HeadID = optionalID(*req.HeadID)
+	}
 
 	// Save
 	if err := s.repo.Update(dept); err != nil {
@@ -103,19 +154,49 @@ func (s *departmentService) Update(id uint, req *UpdateDepartmentRequest) (*Depa
 	return s.toResponseDTO(dept), nil
 }
 
-// Delete deletes a department
-func (s *departmentService) Delete(id uint) error {
+// Delete deletes a department. Teachers, courses and sub-departments that still reference it
+// refuse the delete, move to opts.ReassignTo, or are deleted with it, depending on opts.Policy.
+func (s *departmentService) Delete(id uint, opts deletion.Options) (*deletion.Report, error) {
 	// Check if exists
 	if _, err := s.repo.GetByID(id); err != nil {
-		return fmt.Errorf("department not found: %w", err)
+		return nil, fmt.Errorf("department not found: %w", err)
+	}
+	t, err := s.loadTree()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []uint{id}
+	switch opts.Policy {
+	case deletion.Cascade:
+		ids = t.subtree(id)
+	case deletion.Reassign:
+		if err := s.validateReassignTarget(t, id, opts.ReassignTo); err != nil {
+			return nil, err
+		}
+	}
+
+	plan, err := s.repo.PlanDelete(ids, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.DryRun {
+		return plan.Report(false), nil
+	}
+	if err := plan.Err(); err != nil {
+		return plan.Report(false), err
 	}
 
 	// Delete
-	if err := s.repo.Delete(id); err != nil {
-		return fmt.Errorf("failed to delete department: %w", err)
+	if err := s.repo.DeleteWithPlan(ids, plan); err != nil {
+		if plan.Err() != nil {
+			// References added since the plan was made refuse it after all
+			return plan.Report(false), err
+		}
+		return nil, fmt.Errorf("failed to delete department: %w", err)
 	}
 
-	return nil
+	return plan.Report(true), nil
 }
 
 // Search searches departments by name
@@ -127,6 +208,151 @@ func (s *departmentService) Search(name string) ([]DepartmentResponse, error) {
 	return s.toResponseDTOList(departments), nil
 }
 
+// GetChildren retrieves a department's direct sub-departments
+func (s *departmentService) GetChildren(id uint) ([]DepartmentResponse, error) {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return nil, fmt.Errorf("department not found: %w", err)
+	}
+
+	children, err := s.repo.GetChildren(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.toResponseDTOList(children), nil
+}
+
+// GetDashboard aggregates a department with all of its sub-departments, and each direct
+// sub-department on its own
+func (s *departmentService) GetDashboard(id uint) (*DashboardResponse, error) {
+	dept, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("department not found: %w", err)
+	}
+	t, err := s.loadTree()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.dashboard(dept, t)
+	if err != nil {
+		return nil, err
+	}
+	children, err := s.repo.GetChildren(id)
+	if err != nil {
+		return nil, err
+	}
+	resp.Children = make([]DashboardResponse, len(children))
+	for i := range children {
+		child, err := s.dashboard(&children[i], t)
+		if err != nil {
+			return nil, err
+		}
+		resp.Children[i] = *child
+	}
+	return resp, nil
+}
+
+// GetSubtreeIDs retrieves a department's ID followed by the IDs of every department below it
+func (s *departmentService) GetSubtreeIDs(id uint) ([]uint, error) {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return nil, fmt.Errorf("department not found: %w", err)
+	}
+	t, err := s.loadTree()
+	if err != nil {
+		return nil, err
+	}
+	return t.subtree(id), nil
+}
+
+// NearestHead finds the closest department with a head, starting at the department itself and
+// walking up through its parents. It returns nil when no department on the way has a head.
+func (s *departmentService) NearestHead(id uint) (*Department, error) {
+	t, err := s.loadTree()
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range t.ancestry(id) {
+		if d.HeadID != nil {
+			return d, nil
+		}
+	}
+	return nil, nil
+}
+
+// CheckHead allows a teacher who heads the department, or any department above it; anyone else
+// gets ErrNotHead. While no department on the way has a head, anyone is allowed.
+func (s *departmentService) CheckHead(teacherID, departmentID uint) error {
+	t, err := s.loadTree()
+	if err != nil {
+		return err
+	}
+	headless := true
+	for _, d := range t.ancestry(departmentID) {
+		if d.HeadID == nil {
+			continue
+		}
+		if *d.HeadID == teacherID {
+			return nil
+		}
+		headless = false
+	}
+	if headless {
+		return nil
+	}
+	return fmt.Errorf("%w: teacher %d does not head department %d or a department above it", ErrNotHead, teacherID, departmentID)
+}
+
+func (s *departmentService) loadTree() (*tree, error) {
+	departments, err := s.repo.GetTree()
+	if err != nil {
+		return nil, err
+	}
+	return newTree(departments), nil
+}
+
+// dashboard aggregates one department together with everything below it
+func (s *departmentService) dashboard(dept *Department, t *tree) (*DashboardResponse, error) {
+	ids := t.subtree(dept.ID)
+	stats, err := s.repo.GetStats(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &DashboardResponse{
+		DepartmentID:   dept.ID,
+		Name:           dept.Name,
+		HeadID:         dept.HeadID,
+		SubDepartments: len(ids) - 1,
+		Teachers:       stats.Teachers,
+		Courses:        stats.Courses,
+		Students:       stats.Students,
+		Enrollments:    stats.Enrollments,
+		AverageGrade:   stats.AverageGrade,
+	}
+	if dept.HeadID != nil {
+		if head, err := s.repo.GetTeacher(*dept.HeadID); err == nil {
+			resp.HeadName = head.FirstName + " " + head.LastName
+		}
+	}
+	return resp, nil
+}
+
+// sameID reports whether an update's ID, where 0 means none, matches a nullable one
+func sameID(current *uint, id uint) bool {
+	if current == nil {
+		return id == 0
+	}
+	return *current == id
+}
+
+// optionalID converts an update's ID to a nullable one, where 0 clears it
+func optionalID(id uint) *uint {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
+
 // Validation methods
 func (s *departmentService) validateCreateRequest(req *CreateDepartmentRequest) error {
 	if strings.TrimSpace(req.Name) == "" {
@@ -145,12 +371,54 @@ func (s *departmentService) validateUpdateRequest(req *UpdateDepartmentRequest)
 	return nil
 }
 
+// validateParent rejects a parent that does not exist or would put the department inside itself
+func (s *departmentService) validateParent(id, parentID uint) error {
+	if parentID == 0 {
+		return nil
+	}
+	if parentID == id {
+		return fmt.Errorf("a department cannot be its own parent")
+	}
+	if _, err := s.repo.GetByID(parentID); err != nil {
+		return fmt.Errorf("parent department not found: %w", err)
+	}
+	t, err := s.loadTree()
+	if err != nil {
+		return err
+	}
+	for _, below := range t.subtree(id) {
+		if below == parentID {
+			return fmt.Errorf("department %d is below department %d and cannot be its parent", parentID, id)
+		}
+	}
+	return nil
+}
+
+// validateReassignTarget rejects moving a department's records to itself, to a department
+// that does not exist, or to one below it
+func (s *departmentService) validateReassignTarget(t *tree, id, target uint) error {
+	if target == id {
+		return fmt.Errorf("cannot reassign a department's records to itself")
+	}
+	if _, err := s.repo.GetByID(target); err != nil {
+		return fmt.Errorf("reassign target department not found: %w", err)
+	}
+	for _, below := range t.subtree(id) {
+		if below == target {
+			return fmt.Errorf("department %d is below department %d; its records cannot be reassigned there", target, id)
+		}
+	}
+	return nil
+}
+
 // DTO mapping methods
 func (s *departmentService) toResponseDTO(dept *Department) *DepartmentResponse {
 	return &DepartmentResponse{
 		ID:          dept.ID,
 		Name:        dept.Name,
 		Description: dept.Description,
+		ParentID:    dept.ParentID,
+		HeadID:      dept.HeadID,
 		CreatedAt:   dept.CreatedAt,
 		UpdatedAt:   dept.UpdatedAt,
 	}
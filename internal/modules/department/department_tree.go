@@ -0,0 +1,49 @@
+package department
+
+// tree is every department indexed for walking up to parents and down to sub-departments
+type tree struct {
+	byID     map[uint]*Department
+	children map[uint][]uint
+}
+
+func newTree(departments []Department) *tree {
+	t := &tree{byID: make(map[uint]*Department, len(departments)), children: make(map[uint][]uint)}
+	for i := range departments {
+		d := &departments[i]
+		t.byID[d.ID] = d
+		if d.ParentID != nil {
+			t.children[*d.ParentID] = append(t.children[*d.ParentID], d.ID)
+		}
+	}
+	return t
+}
+
+// ancestry lists a department followed by its parent, grandparent and so on up to the top
+func (t *tree) ancestry(id uint) []*Department {
+	var chain []*Department
+	seen := make(map[uint]bool)
+	for d := t.byID[id]; d != nil && !seen[d.ID]; {
+		seen[d.ID] = true
+		chain = append(chain, d)
+		if d.ParentID == nil {
+			break
+		}
+		d = t.byID[*d.ParentID]
+	}
+	return chain
+}
+
+// subtree lists a department's ID followed by every sub-department's, breadth first
+func (t *tree) subtree(id uint) []uint {
+	ids := []uint{id}
+	seen := map[uint]bool{id: true}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range t.children[ids[i]] {
+			if !seen[child] {
+				seen[child] = true
+				ids = append(ids, child)
+			}
+		}
+	}
+	return ids
+}
@@ -5,6 +5,8 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+
+	"school_management/internal/export"
 )
 
 // ExamController handles HTTP requests for exams
@@ -66,6 +68,10 @@ func (c *ExamController) GetByCourse(ctx *gin.Context) {
 		return
 	}
 
+	if export.Send(ctx, resp) {
+		return
+	}
+
 	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
 }
 
@@ -79,6 +85,10 @@ func (c *ExamController) GetUpcoming(ctx *gin.Context) {
 		return
 	}
 
+	if export.Send(ctx, resp) {
+		return
+	}
+
 	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
 }
 
@@ -121,6 +131,27 @@ func (c *ExamController) Delete(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"message": "exam deleted successfully"})
 }
 
+// GetConflicts lists overlapping exams that share students with an exam
+func (c *ExamController) GetConflicts(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetConflicts(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
 // RegisterRoutes registers exam routes
 func (c *ExamController) RegisterRoutes(rg *gin.RouterGroup) {
 	exams := rg.Group("/exams")
@@ -131,5 +162,6 @@ func (c *ExamController) RegisterRoutes(rg *gin.RouterGroup) {
 		exams.DELETE("/:id", c.Delete)
 		exams.GET("/course/:courseId", c.GetByCourse)
 		exams.GET("/upcoming", c.GetUpcoming)
+		exams.GET("/:id/conflicts", c.GetConflicts)
 	}
 }
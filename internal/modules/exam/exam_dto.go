@@ -30,3 +30,13 @@ type ExamResponse struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
+
+// ConflictResponse represents an overlapping exam and the students sitting both
+type ConflictResponse struct {
+	ExamID     uint      `json:"exam_id"`
+	Title      string    `json:"title"`
+	CourseID   uint      `json:"course_id"`
+	ExamDate   time.Time `json:"exam_date"`
+	Duration   int       `json:"duration"`
+	StudentIDs []uint    `json:"student_ids"`
+}
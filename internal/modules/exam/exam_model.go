@@ -24,3 +24,18 @@ type Exam struct {
 func (Exam) TableName() string {
 	return "exams"
 }
+
+// ExamConflict is one student booked into two overlapping exams
+type ExamConflict struct {
+	ExamID    uint
+	Title     string
+	CourseID  uint
+	ExamDate  time.Time
+	Duration  int
+	StudentID uint
+}
+
+// EndsAt returns when the exam finishes
+func (e *Exam) EndsAt() time.Time {
+	return e.ExamDate.Add(time.Duration(e.Duration) * time.Minute)
+}
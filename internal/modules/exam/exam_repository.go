@@ -16,6 +16,7 @@ type ExamRepository interface {
 	GetByCourse(courseID uint) ([]Exam, error)
 	GetUpcoming(limit int) ([]Exam, error)
 	GetByDateRange(start, end time.Time) ([]Exam, error)
+	GetConflicts(courseID uint, start, end time.Time, excludeID uint) ([]ExamConflict, error)
 	Update(exam *Exam) error
 	Delete(id uint) error
 }
@@ -95,6 +96,24 @@ func (r *examRepository) GetByDateRange(start, end time.Time) ([]Exam, error) {
 	return exams, nil
 }
 
+// GetConflicts retrieves exams overlapping [start, end) that share at least one
+// enrolled student with the course, one row per shared student
+func (r *examRepository) GetConflicts(courseID uint, start, end time.Time, excludeID uint) ([]ExamConflict, error) {
+	var conflicts []ExamConflict
+	if err := r.db.Model(&Exam{}).
+		Select("exams.id AS exam_id, exams.title, exams.course_id, exams.exam_date, exams.duration, other.student_id").
+		Joins("JOIN student_courses other ON other.course_id = exams.course_id AND other.deleted_at IS NULL").
+		Joins("JOIN student_courses own ON own.student_id = other.student_id AND own.course_id = ? AND own.deleted_at IS NULL", courseID).
+		Where("exams.id <> ?", excludeID).
+		Where("exams.exam_date < ?", end).
+		Where("exams.exam_date + exams.duration * INTERVAL '1 minute' > ?", start).
+		Order("exams.exam_date ASC, exams.id ASC, other.student_id ASC").
+		Scan(&conflicts).Error; err != nil {
+		return nil, fmt.Errorf("failed to get exam conflicts: %w", err)
+	}
+	return conflicts, nil
+}
+
 // Update updates an exam
 func (r *examRepository) Update(exam *Exam) error {
 	if err := r.db.Save(exam).Error; err != nil {
@@ -14,6 +14,7 @@ type ExamService interface {
 	GetUpcoming(limit int) ([]ExamResponse, error)
 	Update(id uint, req *UpdateExamRequest) (*ExamResponse, error)
 	Delete(id uint) error
+	GetConflicts(id uint) ([]ConflictResponse, error)
 }
 
 // examService implements ExamService
@@ -48,6 +49,11 @@ func (s *examService) Create(req *CreateExamRequest) (*ExamResponse, error) {
 		MaxScore: req.MaxScore,
 	}
 
+	// Check enrolled students are free for the whole sitting
+	if err := s.checkConflicts(ex); err != nil {
+		return nil, err
+	}
+
 	// Create via repository
 	if err := s.repo.Create(ex); err != nil {
 		return nil, fmt.Errorf("failed to create exam: %w", err)
@@ -118,6 +124,11 @@ func (s *examService) Update(id uint, req *UpdateExamRequest) (*ExamResponse, er
 	if req.MaxScore != 0 {
 		ex.MaxScore = req.MaxScore
 	}
+	if req.ExamDate != "" || req.Duration != 0 {
+		if err := s.checkConflicts(ex); err != nil {
+			return nil, err
+		}
+	}
 
 	// Save
 	if err := s.repo.Update(ex); err != nil {
@@ -140,6 +151,40 @@ func (s *examService) Delete(id uint) error {
 	return nil
 }
 
+// GetConflicts lists other exams that overlap this one for any enrolled student
+func (s *examService) GetConflicts(id uint) ([]ConflictResponse, error) {
+	ex, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("exam not found: %w", err)
+	}
+
+	conflicts, err := s.repo.GetConflicts(ex.CourseID, ex.ExamDate, ex.EndsAt(), ex.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check conflicts: %w", err)
+	}
+	return s.toConflictResponseDTOList(conflicts), nil
+}
+
+// checkConflicts rejects a schedule that double-books any student enrolled in the exam's course
+func (s *examService) checkConflicts(ex *Exam) error {
+	conflicts, err := s.repo.GetConflicts(ex.CourseID, ex.ExamDate, ex.EndsAt(), ex.ID)
+	if err != nil {
+		return fmt.Errorf("failed to check conflicts: %w", err)
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	grouped := s.toConflictResponseDTOList(conflicts)
+	first := grouped[0]
+	msg := fmt.Sprintf("scheduling conflict: %d student(s) also sit exam %d (%s) at %s",
+		len(first.StudentIDs), first.ExamID, first.Title, first.ExamDate.Format(time.RFC3339))
+	if len(grouped) > 1 {
+		msg += fmt.Sprintf(" and %d other exam(s)", len(grouped)-1)
+	}
+	return fmt.Errorf("%s", msg)
+}
+
 // Validation methods
 func (s *examService) validateCreateRequest(req *CreateExamRequest) error {
 	if strings.TrimSpace(req.Title) == "" {
@@ -191,3 +236,24 @@ func (s *examService) toResponseDTOList(exams []Exam) []ExamResponse {
 	}
 	return responses
 }
+
+func (s *examService) toConflictResponseDTOList(conflicts []ExamConflict) []ConflictResponse {
+	responses := []ConflictResponse{}
+	index := make(map[uint]int)
+	for _, c := range conflicts {
+		i, ok := index[c.ExamID]
+		if !ok {
+			i = len(responses)
+			index[c.ExamID] = i
+			responses = append(responses, ConflictResponse{
+				ExamID:   c.ExamID,
+				Title:    c.Title,
+				CourseID: c.CourseID,
+				ExamDate: c.ExamDate,
+				Duration: c.Duration,
+			})
+		}
+		responses[i].StudentIDs = append(responses[i].StudentIDs, c.StudentID)
+	}
+	return responses
+}
@@ -0,0 +1,169 @@
+package exam_seating
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"school_management/internal/export"
+)
+
+// ExamSeatingController handles HTTP requests for exam rooms and seating plans
+type ExamSeatingController struct {
+	service ExamSeatingService
+}
+
+// NewExamSeatingController creates a new exam seating controller
+func NewExamSeatingController(service ExamSeatingService) *ExamSeatingController {
+	return &ExamSeatingController{service: service}
+}
+
+// CreateRoom creates a new exam room
+func (c *ExamSeatingController) CreateRoom(ctx *gin.Context) {
+	var req CreateRoomRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.CreateRoom(&req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// GetRoom retrieves an exam room by ID
+func (c *ExamSeatingController) GetRoom(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetRoom(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetAllRooms retrieves all exam rooms
+func (c *ExamSeatingController) GetAllRooms(ctx *gin.Context) {
+	resp, err := c.service.GetAllRooms()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// UpdateRoom updates an exam room
+func (c *ExamSeatingController) UpdateRoom(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	var req UpdateRoomRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.UpdateRoom(uint(id), &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// DeleteRoom deletes an exam room
+func (c *ExamSeatingController) DeleteRoom(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	if err := c.service.DeleteRoom(uint(id)); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "exam room deleted successfully"})
+}
+
+// GenerateSeating builds a new seating plan for an exam
+func (c *ExamSeatingController) GenerateSeating(ctx *gin.Context) {
+	examID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid exam ID"})
+		return
+	}
+
+	var req GenerateSeatingRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	resp, err := c.service.GenerateSeating(uint(examID), &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetSeating retrieves an exam's seating plan
+func (c *ExamSeatingController) GetSeating(ctx *gin.Context) {
+	examID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid exam ID"})
+		return
+	}
+
+	resp, err := c.service.GetSeating(uint(examID))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// RegisterRoutes registers exam room and seating routes
+func (c *ExamSeatingController) RegisterRoutes(rg *gin.RouterGroup) {
+	rooms := rg.Group("/exam-rooms")
+	{
+		rooms.POST("", c.CreateRoom)
+		rooms.GET("", c.GetAllRooms)
+		rooms.GET("/:id", c.GetRoom)
+		rooms.PUT("/:id", c.UpdateRoom)
+		rooms.DELETE("/:id", c.DeleteRoom)
+	}
+
+	exams := rg.Group("/exams")
+	{
+		exams.POST("/:id/seating", c.GenerateSeating)
+		exams.GET("/:id/seating", c.GetSeating)
+	}
+}
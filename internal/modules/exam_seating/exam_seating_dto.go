@@ -0,0 +1,60 @@
+package exam_seating
+
+import "time"
+
+// CreateRoomRequest represents the request body for creating an exam room
+type CreateRoomRequest struct {
+	Name        string `json:"name" binding:"required,min=1,max=100"`
+	Building    string `json:"building" binding:"omitempty,max=100"`
+	Capacity    int    `json:"capacity" binding:"required,min=1,max=2000"`
+	SeatsPerRow int    `json:"seats_per_row" binding:"omitempty,min=0,max=200"`
+}
+
+// UpdateRoomRequest represents the request body for updating an exam room
+type UpdateRoomRequest struct {
+	Name        string `json:"name" binding:"omitempty,min=1,max=100"`
+	Building    string `json:"building" binding:"omitempty,max=100"`
+	Capacity    int    `json:"capacity" binding:"omitempty,min=1,max=2000"`
+	SeatsPerRow *int   `json:"seats_per_row" binding:"omitempty,min=0,max=200"`
+}
+
+// RoomResponse represents the response body for exam room data
+type RoomResponse struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Building    string    `json:"building"`
+	Capacity    int       `json:"capacity"`
+	SeatsPerRow int       `json:"seats_per_row"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// GenerateSeatingRequest represents the request body for generating a seating plan.
+// Without RoomIDs every room that is free during the exam is considered.
+type GenerateSeatingRequest struct {
+	RoomIDs []uint `json:"room_ids" binding:"omitempty"`
+}
+
+// SeatingResponse represents an exam's seating plan
+type SeatingResponse struct {
+	ExamID        uint                  `json:"exam_id"`
+	TotalStudents int                   `json:"total_students"`
+	Rooms         []RoomSeatingResponse `json:"rooms"`
+}
+
+// RoomSeatingResponse represents the seats taken in one room
+type RoomSeatingResponse struct {
+	RoomID   uint           `json:"room_id"`
+	RoomName string         `json:"room_name"`
+	Building string         `json:"building"`
+	Capacity int            `json:"capacity"`
+	Seats    []SeatResponse `json:"seats"`
+}
+
+// SeatResponse represents one student's seat
+type SeatResponse struct {
+	StudentID   uint   `json:"student_id"`
+	StudentName string `json:"student_name"`
+	SeatNumber  int    `json:"seat_number"`
+	SeatLabel   string `json:"seat_label"`
+}
@@ -0,0 +1,55 @@
+package exam_seating
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"school_management/internal/modules/exam"
+	"school_management/internal/modules/student"
+)
+
+type ExamRoom struct {
+	gorm.Model
+	Name        string `gorm:"uniqueIndex;not null;size:100" json:"name"`
+	Building    string `gorm:"size:100" json:"building"`
+	Capacity    int    `gorm:"not null" json:"capacity"`
+	SeatsPerRow int    `gorm:"not null;default:0;comment:0 numbers seats without rows" json:"seats_per_row"`
+}
+
+// TableName specifies the table name for the ExamRoom model
+func (ExamRoom) TableName() string {
+	return "exam_rooms"
+}
+
+// SeatLabel returns the printable label for a 1-based seat number, e.g. "C4"
+func (r *ExamRoom) SeatLabel(seat int) string {
+	if r.SeatsPerRow <= 0 {
+		return fmt.Sprintf("%d", seat)
+	}
+	row := (seat - 1) / r.SeatsPerRow
+	label := ""
+	for row >= 0 {
+		label = string(rune('A'+row%26)) + label
+		row = row/26 - 1
+	}
+	return fmt.Sprintf("%s%d", label, (seat-1)%r.SeatsPerRow+1)
+}
+
+type SeatAssignment struct {
+	gorm.Model
+	ExamID     uint `gorm:"not null;uniqueIndex:idx_exam_seat_student;uniqueIndex:idx_exam_room_seat" json:"exam_id"`
+	StudentID  uint `gorm:"not null;uniqueIndex:idx_exam_seat_student" json:"student_id"`
+	RoomID     uint `gorm:"not null;uniqueIndex:idx_exam_room_seat;index" json:"room_id"`
+	SeatNumber int  `gorm:"not null;uniqueIndex:idx_exam_room_seat" json:"seat_number"`
+
+	// Belongs To relationships
+	Exam    exam.Exam       `gorm:"foreignKey:ExamID" json:"exam,omitempty"`
+	Student student.Student `gorm:"foreignKey:StudentID" json:"student,omitempty"`
+	Room    ExamRoom        `gorm:"foreignKey:RoomID" json:"room,omitempty"`
+}
+
+// TableName specifies the table name for the SeatAssignment model
+func (SeatAssignment) TableName() string {
+	return "exam_seat_assignments"
+}
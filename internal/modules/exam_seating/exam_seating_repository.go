@@ -0,0 +1,128 @@
+package exam_seating
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ExamSeatingRepository defines the interface for exam room and seating data access
+type ExamSeatingRepository interface {
+	// Rooms
+	CreateRoom(room *ExamRoom) error
+	GetRoomByID(id uint) (*ExamRoom, error)
+	GetRoomsByIDs(ids []uint) ([]ExamRoom, error)
+	GetAllRooms() ([]ExamRoom, error)
+	GetBusyRoomIDs(start, end time.Time, excludeExamID uint) ([]uint, error)
+	UpdateRoom(room *ExamRoom) error
+	DeleteRoom(id uint) error
+
+	// Seating
+	ReplaceSeating(examID uint, seats []SeatAssignment) error
+	GetSeatingByExam(examID uint) ([]SeatAssignment, error)
+}
+
+// examSeatingRepository implements ExamSeatingRepository
+type examSeatingRepository struct {
+	db *gorm.DB
+}
+
+// NewExamSeatingRepository creates a new exam seating repository with dependency injection
+func NewExamSeatingRepository(db *gorm.DB) ExamSeatingRepository {
+	return &examSeatingRepository{db: db}
+}
+
+// CreateRoom creates a new exam room
+func (r *examSeatingRepository) CreateRoom(room *ExamRoom) error {
+	if err := r.db.Create(room).Error; err != nil {
+		return fmt.Errorf("failed to create exam room: %w", err)
+	}
+	return nil
+}
+
+// GetRoomByID retrieves an exam room by ID
+func (r *examSeatingRepository) GetRoomByID(id uint) (*ExamRoom, error) {
+	var room ExamRoom
+	if err := r.db.First(&room, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get exam room: %w", err)
+	}
+	return &room, nil
+}
+
+// GetRoomsByIDs retrieves exam rooms by their IDs
+func (r *examSeatingRepository) GetRoomsByIDs(ids []uint) ([]ExamRoom, error) {
+	var rooms []ExamRoom
+	if err := r.db.Where("id IN ?", ids).Find(&rooms).Error; err != nil {
+		return nil, fmt.Errorf("failed to get exam rooms: %w", err)
+	}
+	return rooms, nil
+}
+
+// GetAllRooms retrieves all exam rooms
+func (r *examSeatingRepository) GetAllRooms() ([]ExamRoom, error) {
+	var rooms []ExamRoom
+	if err := r.db.Order("name ASC").Find(&rooms).Error; err != nil {
+		return nil, fmt.Errorf("failed to get exam rooms: %w", err)
+	}
+	return rooms, nil
+}
+
+// GetBusyRoomIDs retrieves rooms seating another exam that overlaps [start, end)
+func (r *examSeatingRepository) GetBusyRoomIDs(start, end time.Time, excludeExamID uint) ([]uint, error) {
+	var ids []uint
+	if err := r.db.Model(&SeatAssignment{}).
+		Distinct("exam_seat_assignments.room_id").
+		Joins("JOIN exams ON exams.id = exam_seat_assignments.exam_id AND exams.deleted_at IS NULL").
+		Where("exam_seat_assignments.exam_id <> ?", excludeExamID).
+		Where("exams.exam_date < ?", end).
+		Where("exams.exam_date + exams.duration * INTERVAL '1 minute' > ?", start).
+		Pluck("exam_seat_assignments.room_id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to get busy exam rooms: %w", err)
+	}
+	return ids, nil
+}
+
+// UpdateRoom updates an exam room
+func (r *examSeatingRepository) UpdateRoom(room *ExamRoom) error {
+	if err := r.db.Save(room).Error; err != nil {
+		return fmt.Errorf("failed to update exam room: %w", err)
+	}
+	return nil
+}
+
+// DeleteRoom soft deletes an exam room
+func (r *examSeatingRepository) DeleteRoom(id uint) error {
+	if err := r.db.Delete(&ExamRoom{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete exam room: %w", err)
+	}
+	return nil
+}
+
+// ReplaceSeating replaces an exam's seating plan in a single transaction
+func (r *examSeatingRepository) ReplaceSeating(examID uint, seats []SeatAssignment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("exam_id = ?", examID).Delete(&SeatAssignment{}).Error; err != nil {
+			return fmt.Errorf("failed to clear seating plan: %w", err)
+		}
+		if len(seats) == 0 {
+			return nil
+		}
+		if err := tx.Omit("Exam", "Student", "Room").CreateInBatches(&seats, 500).Error; err != nil {
+			return fmt.Errorf("failed to save seating plan: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetSeatingByExam retrieves an exam's seating plan ordered by room and seat
+func (r *examSeatingRepository) GetSeatingByExam(examID uint) ([]SeatAssignment, error) {
+	var seats []SeatAssignment
+	if err := r.db.Preload("Room").Preload("Student").
+		Where("exam_id = ?", examID).
+		Order("room_id ASC, seat_number ASC").
+		Find(&seats).Error; err != nil {
+		return nil, fmt.Errorf("failed to get seating plan: %w", err)
+	}
+	return seats, nil
+}
@@ -0,0 +1,283 @@
+package exam_seating
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"school_management/internal/modules/exam"
+	"school_management/internal/modules/student_courses"
+)
+
+// ExamSeatingService defines the business logic interface
+type ExamSeatingService interface {
+	// Rooms
+	CreateRoom(req *CreateRoomRequest) (*RoomResponse, error)
+	GetRoom(id uint) (*RoomResponse, error)
+	GetAllRooms() ([]RoomResponse, error)
+	UpdateRoom(id uint, req *UpdateRoomRequest) (*RoomResponse, error)
+	DeleteRoom(id uint) error
+
+	// Seating
+	GenerateSeating(examID uint, req *GenerateSeatingRequest) (*SeatingResponse, error)
+	GetSeating(examID uint) (*SeatingResponse, error)
+}
+
+// examSeatingService implements ExamSeatingService
+type examSeatingService struct {
+	repo           ExamSeatingRepository
+	examRepo       exam.ExamRepository
+	enrollmentRepo student_courses.StudentCourseRepository
+}
+
+// NewExamSeatingService creates a new exam seating service with DI
+func NewExamSeatingService(repo ExamSeatingRepository, examRepo exam.ExamRepository, enrollmentRepo student_courses.StudentCourseRepository) ExamSeatingService {
+	return &examSeatingService{repo: repo, examRepo: examRepo, enrollmentRepo: enrollmentRepo}
+}
+
+// CreateRoom creates a new exam room
+func (s *examSeatingService) CreateRoom(req *CreateRoomRequest) (*RoomResponse, error) {
+	// Validate
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if req.Capacity <= 0 {
+		return nil, fmt.Errorf("capacity must be greater than 0")
+	}
+
+	// Map DTO to Model
+	room := &ExamRoom{
+		Name:        req.Name,
+		Building:    req.Building,
+		Capacity:    req.Capacity,
+		SeatsPerRow: req.SeatsPerRow,
+	}
+
+	// Create via repository
+	if err := s.repo.CreateRoom(room); err != nil {
+		return nil, fmt.Errorf("failed to create exam room: %w", err)
+	}
+
+	return s.toRoomResponseDTO(room), nil
+}
+
+// GetRoom retrieves an exam room by ID
+func (s *examSeatingService) GetRoom(id uint) (*RoomResponse, error) {
+	room, err := s.repo.GetRoomByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("exam room not found: %w", err)
+	}
+	return s.toRoomResponseDTO(room), nil
+}
+
+// GetAllRooms retrieves all exam rooms
+func (s *examSeatingService) GetAllRooms() ([]RoomResponse, error) {
+	rooms, err := s.repo.GetAllRooms()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exam rooms: %w", err)
+	}
+
+	responses := make([]RoomResponse, len(rooms))
+	for i, room := range rooms {
+		responses[i] = *s.toRoomResponseDTO(&room)
+	}
+	return responses, nil
+}
+
+// UpdateRoom updates an exam room
+func (s *examSeatingService) UpdateRoom(id uint, req *UpdateRoomRequest) (*RoomResponse, error) {
+	// Get existing
+	room, err := s.repo.GetRoomByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("exam room not found: %w", err)
+	}
+
+	// Update fields
+	if req.Name != "" {
+		room.Name = req.Name
+	}
+	if req.Building != "" {
+		room.Building = req.Building
+	}
+	if req.Capacity != 0 {
+		room.Capacity = req.Capacity
+	}
+	if req.SeatsPerRow != nil {
+		room.SeatsPerRow = *req.SeatsPerRow
+	}
+
+	// Save
+	if err := s.repo.UpdateRoom(room); err != nil {
+		return nil, fmt.Errorf("failed to update exam room: %w", err)
+	}
+
+	return s.toRoomResponseDTO(room), nil
+}
+
+// DeleteRoom deletes an exam room
+func (s *examSeatingService) DeleteRoom(id uint) error {
+	if _, err := s.repo.GetRoomByID(id); err != nil {
+		return fmt.Errorf("exam room not found: %w", err)
+	}
+
+	if err := s.repo.DeleteRoom(id); err != nil {
+		return fmt.Errorf("failed to delete exam room: %w", err)
+	}
+
+	return nil
+}
+
+// GenerateSeating seats every student enrolled in the exam's course, filling the
+// largest free rooms first. Rooms already seating an overlapping exam are skipped.
+// Any previous plan for the exam is replaced.
+func (s *examSeatingService) GenerateSeating(examID uint, req *GenerateSeatingRequest) (*SeatingResponse, error) {
+	ex, err := s.examRepo.GetByID(examID)
+	if err != nil {
+		return nil, fmt.Errorf("exam not found: %w", err)
+	}
+
+	enrollments, err := s.enrollmentRepo.GetByCourse(ex.CourseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enrolled students: %w", err)
+	}
+	studentIDs := make([]uint, len(enrollments))
+	for i, e := range enrollments {
+		studentIDs[i] = e.StudentID
+	}
+	sort.Slice(studentIDs, func(i, j int) bool { return studentIDs[i] < studentIDs[j] })
+
+	rooms, err := s.availableRooms(ex, req.RoomIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	capacity := 0
+	for _, room := range rooms {
+		capacity += room.Capacity
+	}
+	if capacity < len(studentIDs) {
+		return nil, fmt.Errorf("not enough seats: %d students but only %d free seats", len(studentIDs), capacity)
+	}
+
+	seats := make([]SeatAssignment, 0, len(studentIDs))
+	next := 0
+	for _, room := range rooms {
+		for seat := 1; seat <= room.Capacity && next < len(studentIDs); seat++ {
+			seats = append(seats, SeatAssignment{
+				ExamID:     ex.ID,
+				StudentID:  studentIDs[next],
+				RoomID:     room.ID,
+				SeatNumber: seat,
+			})
+			next++
+		}
+	}
+
+	if err := s.repo.ReplaceSeating(ex.ID, seats); err != nil {
+		return nil, fmt.Errorf("failed to save seating plan: %w", err)
+	}
+
+	return s.GetSeating(ex.ID)
+}
+
+// GetSeating retrieves an exam's seating plan
+func (s *examSeatingService) GetSeating(examID uint) (*SeatingResponse, error) {
+	if _, err := s.examRepo.GetByID(examID); err != nil {
+		return nil, fmt.Errorf("exam not found: %w", err)
+	}
+
+	seats, err := s.repo.GetSeatingByExam(examID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seating plan: %w", err)
+	}
+
+	return s.toSeatingResponseDTO(examID, seats), nil
+}
+
+// availableRooms returns the candidate rooms free during the exam, largest first
+func (s *examSeatingService) availableRooms(ex *exam.Exam, roomIDs []uint) ([]ExamRoom, error) {
+	var rooms []ExamRoom
+	var err error
+	if len(roomIDs) > 0 {
+		rooms, err = s.repo.GetRoomsByIDs(roomIDs)
+		if err == nil && len(rooms) != len(roomIDs) {
+			return nil, fmt.Errorf("one or more exam rooms not found")
+		}
+	} else {
+		rooms, err = s.repo.GetAllRooms()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exam rooms: %w", err)
+	}
+
+	busyIDs, err := s.repo.GetBusyRoomIDs(ex.ExamDate, ex.EndsAt(), ex.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check room availability: %w", err)
+	}
+	busy := make(map[uint]bool, len(busyIDs))
+	for _, id := range busyIDs {
+		busy[id] = true
+	}
+
+	free := make([]ExamRoom, 0, len(rooms))
+	for _, room := range rooms {
+		if busy[room.ID] {
+			if len(roomIDs) > 0 {
+				return nil, fmt.Errorf("exam room %s is booked for an overlapping exam", room.Name)
+			}
+			continue
+		}
+		free = append(free, room)
+	}
+
+	sort.SliceStable(free, func(i, j int) bool {
+		if free[i].Capacity != free[j].Capacity {
+			return free[i].Capacity > free[j].Capacity
+		}
+		return free[i].ID < free[j].ID
+	})
+	return free, nil
+}
+
+// DTO mapping methods
+func (s *examSeatingService) toRoomResponseDTO(room *ExamRoom) *RoomResponse {
+	return &RoomResponse{
+		ID:          room.ID,
+		Name:        room.Name,
+		Building:    room.Building,
+		Capacity:    room.Capacity,
+		SeatsPerRow: room.SeatsPerRow,
+		CreatedAt:   room.CreatedAt,
+		UpdatedAt:   room.UpdatedAt,
+	}
+}
+
+func (s *examSeatingService) toSeatingResponseDTO(examID uint, seats []SeatAssignment) *SeatingResponse {
+	resp := &SeatingResponse{
+		ExamID:        examID,
+		TotalStudents: len(seats),
+		Rooms:         []RoomSeatingResponse{},
+	}
+
+	index := make(map[uint]int)
+	for _, seat := range seats {
+		i, ok := index[seat.RoomID]
+		if !ok {
+			i = len(resp.Rooms)
+			index[seat.RoomID] = i
+			resp.Rooms = append(resp.Rooms, RoomSeatingResponse{
+				RoomID:   seat.Room.ID,
+				RoomName: seat.Room.Name,
+				Building: seat.Room.Building,
+				Capacity: seat.Room.Capacity,
+			})
+		}
+		resp.Rooms[i].Seats = append(resp.Rooms[i].Seats, SeatResponse{
+			StudentID:   seat.StudentID,
+			StudentName: seat.Student.FirstName + " " + seat.Student.LastName,
+			SeatNumber:  seat.SeatNumber,
+			SeatLabel:   seat.Room.SeatLabel(seat.SeatNumber),
+		})
+	}
+	return resp
+}
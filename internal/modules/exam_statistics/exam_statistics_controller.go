@@ -0,0 +1,46 @@
+package exam_statistics
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExamStatisticsController handles HTTP requests for exam statistics
+type ExamStatisticsController struct {
+	service ExamStatisticsService
+}
+
+// NewExamStatisticsController creates a new exam statistics controller
+func NewExamStatisticsController(service ExamStatisticsService) *ExamStatisticsController {
+	return &ExamStatisticsController{service: service}
+}
+
+// GetStatistics retrieves the score distribution and item analysis of an exam
+func (c *ExamStatisticsController) GetStatistics(ctx *gin.Context) {
+	examID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid exam ID"})
+		return
+	}
+
+	passMark, _ := strconv.ParseFloat(ctx.DefaultQuery("pass_mark", "50"), 64)
+	buckets, _ := strconv.Atoi(ctx.DefaultQuery("buckets", "10"))
+
+	resp, err := c.service.GetStatistics(uint(examID), passMark, buckets)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// RegisterRoutes registers exam statistics routes
+func (c *ExamStatisticsController) RegisterRoutes(rg *gin.RouterGroup) {
+	exams := rg.Group("/exams")
+	{
+		exams.GET("/:id/statistics", c.GetStatistics)
+	}
+}
@@ -0,0 +1,39 @@
+package exam_statistics
+
+// StatisticsResponse represents the score distribution of an exam
+type StatisticsResponse struct {
+	ExamID            uint               `json:"exam_id"`
+	MaxScore          float64            `json:"max_score"`
+	Count             int                `json:"count"`
+	Mean              float64            `json:"mean"`
+	Median            float64            `json:"median"`
+	StdDev            float64            `json:"std_dev"`
+	Min               float64            `json:"min"`
+	Max               float64            `json:"max"`
+	PassMark          float64            `json:"pass_mark"` // Percent of MaxScore
+	PassRate          float64            `json:"pass_rate"` // Percent of students at or above the pass mark
+	Percentiles       map[string]float64 `json:"percentiles"`
+	Histogram         []BucketResponse   `json:"histogram"`
+	ItemAnalysis      []ItemResponse     `json:"item_analysis,omitempty"`
+	ItemAnalysisCount int                `json:"item_analysis_attempts"`
+}
+
+// BucketResponse represents one histogram bucket, bounded in percent of MaxScore
+type BucketResponse struct {
+	FromPercent float64 `json:"from_percent"`
+	ToPercent   float64 `json:"to_percent"`
+	Count       int     `json:"count"`
+}
+
+// ItemResponse represents the item analysis of one exam question.
+// Difficulty is the mean share of points earned (higher is easier); discrimination is
+// the difficulty in the top 27% of attempts minus the difficulty in the bottom 27%.
+type ItemResponse struct {
+	QuestionID     uint    `json:"question_id"`
+	Prompt         string  `json:"prompt"`
+	Type           string  `json:"type"`
+	Points         float64 `json:"points"`
+	Responses      int     `json:"responses"`
+	Difficulty     float64 `json:"difficulty"`
+	Discrimination float64 `json:"discrimination"`
+}
@@ -0,0 +1,82 @@
+package exam_statistics
+
+import (
+	"math"
+	"sort"
+)
+
+// upperLowerShare is the share of attempts in each of the upper and lower groups used
+// for the discrimination index (Kelley's 27%)
+const upperLowerShare = 0.27
+
+// mean returns the arithmetic mean of values
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// stdDev returns the population standard deviation of values
+func stdDev(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	m := mean(values)
+	var sum float64
+	for _, v := range values {
+		sum += (v - m) * (v - m)
+	}
+	return math.Sqrt(sum / float64(len(values)))
+}
+
+// percentile returns the p-th percentile (0-100) of sorted values using linear interpolation
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(rank-float64(lo))
+}
+
+// histogram counts scores into buckets of equal width over [0, maxScore];
+// a score equal to maxScore falls in the last bucket
+func histogram(scores []float64, maxScore float64, buckets int) []BucketResponse {
+	width := 100 / float64(buckets)
+	result := make([]BucketResponse, buckets)
+	for i := range result {
+		result[i] = BucketResponse{FromPercent: float64(i) * width, ToPercent: float64(i+1) * width}
+	}
+	if maxScore <= 0 {
+		return result
+	}
+	for _, s := range scores {
+		i := int(s / maxScore * 100 / width)
+		if i >= buckets {
+			i = buckets - 1
+		}
+		if i < 0 {
+			i = 0
+		}
+		result[i].Count++
+	}
+	return result
+}
+
+// round2 rounds to two decimal places for display
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// sortedCopy returns values sorted ascending without modifying the input
+func sortedCopy(values []float64) []float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	return sorted
+}
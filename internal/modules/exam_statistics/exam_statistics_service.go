@@ -0,0 +1,198 @@
+package exam_statistics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"school_management/internal/modules/exam"
+	"school_management/internal/modules/grade"
+	"school_management/internal/modules/online_exam"
+)
+
+// ExamStatisticsService defines the business logic interface
+type ExamStatisticsService interface {
+	GetStatistics(examID uint, passMark float64, buckets int) (*StatisticsResponse, error)
+}
+
+// examStatisticsService implements ExamStatisticsService
+type examStatisticsService struct {
+	examRepo       exam.ExamRepository
+	gradeRepo      grade.GradeRepository
+	onlineExamRepo online_exam.OnlineExamRepository
+}
+
+// NewExamStatisticsService creates a new exam statistics service with DI
+func NewExamStatisticsService(examRepo exam.ExamRepository, gradeRepo grade.GradeRepository, onlineExamRepo online_exam.OnlineExamRepository) ExamStatisticsService {
+	return &examStatisticsService{examRepo: examRepo, gradeRepo: gradeRepo, onlineExamRepo: onlineExamRepo}
+}
+
+// GetStatistics summarizes an exam's grades, drafts included. passMark is a percent
+// of the exam's MaxScore; buckets is the number of histogram buckets.
+func (s *examStatisticsService) GetStatistics(examID uint, passMark float64, buckets int) (*StatisticsResponse, error) {
+	if passMark <= 0 || passMark > 100 {
+		passMark = 50
+	}
+	if buckets <= 0 || buckets > 100 {
+		buckets = 10
+	}
+
+	ex, err := s.examRepo.GetByID(examID)
+	if err != nil {
+		return nil, fmt.Errorf("exam not found: %w", err)
+	}
+
+	grades, err := s.gradeRepo.GetByExam(examID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get grades: %w", err)
+	}
+
+	scores := make([]float64, len(grades))
+	for i, gr := range grades {
+		scores[i] = gr.Score
+	}
+	sorted := sortedCopy(scores)
+
+	resp := &StatisticsResponse{
+		ExamID:      ex.ID,
+		MaxScore:    ex.MaxScore,
+		Count:       len(scores),
+		PassMark:    passMark,
+		Percentiles: map[string]float64{},
+		Histogram:   histogram(scores, ex.MaxScore, buckets),
+	}
+	if len(sorted) > 0 {
+		passing := 0
+		for _, score := range scores {
+			if score >= ex.MaxScore*passMark/100 {
+				passing++
+			}
+		}
+		resp.Mean = round2(mean(scores))
+		resp.Median = round2(percentile(sorted, 50))
+		resp.StdDev = round2(stdDev(scores))
+		resp.Min = sorted[0]
+		resp.Max = sorted[len(sorted)-1]
+		resp.PassRate = round2(float64(passing) / float64(len(scores)) * 100)
+		for _, p := range []float64{10, 25, 50, 75, 90} {
+			resp.Percentiles[fmt.Sprintf("p%.0f", p)] = round2(percentile(sorted, p))
+		}
+	}
+
+	items, attempts, err := s.itemAnalysis(examID)
+	if err != nil {
+		return nil, err
+	}
+	resp.ItemAnalysis = items
+	resp.ItemAnalysisCount = attempts
+
+	return resp, nil
+}
+
+// itemAnalysis computes difficulty and discrimination per question over the exam's
+// fully graded online attempts. Exams sat offline have no attempts and no item analysis.
+func (s *examStatisticsService) itemAnalysis(examID uint) ([]ItemResponse, int, error) {
+	attempts, err := s.onlineExamRepo.GetAttemptsByExam(examID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get exam attempts: %w", err)
+	}
+
+	graded := make([]online_exam.ExamAttempt, 0, len(attempts))
+	questionSet := make(map[uint]bool)
+	for _, attempt := range attempts {
+		if attempt.Status != online_exam.AttemptGraded {
+			continue
+		}
+		graded = append(graded, attempt)
+		for _, qid := range attempt.QuestionOrder {
+			questionSet[qid] = true
+		}
+	}
+	if len(graded) == 0 {
+		return nil, 0, nil
+	}
+
+	questionIDs := make([]uint, 0, len(questionSet))
+	for qid := range questionSet {
+		questionIDs = append(questionIDs, qid)
+	}
+	questions, err := s.onlineExamRepo.GetQuestionsByIDs(questionIDs)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get exam questions: %w", err)
+	}
+
+	// Rank attempts by total points earned to form the upper and lower groups
+	totals := make([]float64, len(graded))
+	for i, attempt := range graded {
+		for _, a := range attempt.Answers {
+			if a.PointsAwarded != nil {
+				totals[i] += *a.PointsAwarded
+			}
+		}
+	}
+	order := make([]int, len(graded))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return totals[order[a]] > totals[order[b]] })
+
+	groupSize := int(math.Round(float64(len(graded)) * upperLowerShare))
+	if groupSize < 1 {
+		groupSize = 1
+	}
+	upper := make(map[int]bool, groupSize)
+	lower := make(map[int]bool, groupSize)
+	if len(graded) >= 2 {
+		if groupSize > len(graded)/2 {
+			groupSize = len(graded) / 2
+		}
+		for _, i := range order[:groupSize] {
+			upper[i] = true
+		}
+		for _, i := range order[len(order)-groupSize:] {
+			lower[i] = true
+		}
+	}
+
+	items := make([]ItemResponse, 0, len(questions))
+	for _, q := range questions {
+		if q.Points <= 0 {
+			continue
+		}
+		var all, top, bottom []float64
+		for i, attempt := range graded {
+			for _, a := range attempt.Answers {
+				if a.QuestionID != q.ID || a.PointsAwarded == nil {
+					continue
+				}
+				share := *a.PointsAwarded / q.Points
+				all = append(all, share)
+				if upper[i] {
+					top = append(top, share)
+				}
+				if lower[i] {
+					bottom = append(bottom, share)
+				}
+			}
+		}
+		if len(all) == 0 {
+			continue
+		}
+
+		item := ItemResponse{
+			QuestionID: q.ID,
+			Prompt:     q.Prompt,
+			Type:       string(q.Type),
+			Points:     q.Points,
+			Responses:  len(all),
+			Difficulty: round2(mean(all)),
+		}
+		if len(top) > 0 && len(bottom) > 0 {
+			item.Discrimination = round2(mean(top) - mean(bottom))
+		}
+		items = append(items, item)
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i].QuestionID < items[j].QuestionID })
+	return items, len(graded), nil
+}
@@ -5,6 +5,8 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+
+	"school_management/internal/export"
 )
 
 // GradeController handles HTTP requests for grades
@@ -66,10 +68,14 @@ func (c *GradeController) GetByStudent(ctx *gin.Context) {
 		return
 	}
 
+	if export.Send(ctx, resp) {
+		return
+	}
+
 	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
 }
 
-// GetByExam retrieves grades for an exam
+// GetByExam retrieves grades for an exam, optionally filtered by ?homeroom_id
 func (c *GradeController) GetByExam(ctx *gin.Context) {
 	examID, err := strconv.ParseUint(ctx.Param("examId"), 10, 32)
 	if err != nil {
@@ -77,12 +83,27 @@ func (c *GradeController) GetByExam(ctx *gin.Context) {
 		return
 	}
 
-	resp, err := c.service.GetByExam(uint(examID))
+	var homeroomID *uint
+	if raw := ctx.Query("homeroom_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid homeroom ID"})
+			return
+		}
+		hid := uint(id)
+		homeroomID = &hid
+	}
+
+	resp, err := c.service.GetByExam(uint(examID), homeroomID)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	if export.Send(ctx, resp) {
+		return
+	}
+
 	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
 }
 
@@ -142,6 +163,23 @@ func (c *GradeController) Delete(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"message": "grade deleted successfully"})
 }
 
+// PublishExam releases an exam's draft grades
+func (c *GradeController) PublishExam(ctx *gin.Context) {
+	examID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid exam ID"})
+		return
+	}
+
+	resp, err := c.service.PublishExam(uint(examID))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
 // RegisterRoutes registers grade routes
 func (c *GradeController) RegisterRoutes(rg *gin.RouterGroup) {
 	grades := rg.Group("/grades")
@@ -154,4 +192,9 @@ func (c *GradeController) RegisterRoutes(rg *gin.RouterGroup) {
 		grades.GET("/exam/:examId", c.GetByExam)
 		grades.GET("/student/:studentId/average", c.GetStudentAverage)
 	}
+
+	exams := rg.Group("/exams")
+	{
+		exams.POST("/:id/publish", c.PublishExam)
+	}
 }
@@ -16,10 +16,20 @@ type UpdateGradeRequest struct {
 
 // GradeResponse represents the response body for grade data
 type GradeResponse struct {
-	ID        uint      `json:"id"`
-	StudentID uint      `json:"student_id"`
-	ExamID    uint      `json:"exam_id"`
-	Score     float64   `json:"score"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID          uint       `json:"id"`
+	StudentID   uint       `json:"student_id"`
+	StudentName string     `json:"student_name,omitempty"`
+	ExamID      uint       `json:"exam_id"`
+	ExamTitle   string     `json:"exam_title,omitempty"`
+	Score       *float64   `json:"score"`
+	Status      string     `json:"status"`
+	PublishedAt *time.Time `json:"published_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// PublishResponse represents the result of releasing an exam's grades
+type PublishResponse struct {
+	ExamID    uint  `json:"exam_id"`
+	Published int64 `json:"published"`
 }
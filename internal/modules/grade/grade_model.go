@@ -1,17 +1,29 @@
 package grade
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 
 	"school_management/internal/modules/exam"
 	"school_management/internal/modules/student"
 )
 
+// GradeStatus represents whether a grade has been released to students
+type GradeStatus string
+
+const (
+	GradeDraft     GradeStatus = "draft"     // visible to teachers only
+	GradePublished GradeStatus = "published" // released to students and guardians
+)
+
 type Grade struct {
 	gorm.Model
-	StudentID uint    `gorm:"not null" json:"student_id"`
-	ExamID    uint    `gorm:"not null" json:"exam_id"`
-	Score     float64 `gorm:"not null" json:"score"`
+	StudentID   uint        `gorm:"not null" json:"student_id"`
+	ExamID      uint        `gorm:"not null" json:"exam_id"`
+	Score       float64     `gorm:"not null" json:"score"`
+	Status      GradeStatus `gorm:"type:varchar(20);not null;default:'published';index;comment:grades that predate publishing stay visible" json:"status"`
+	PublishedAt *time.Time  `gorm:"type:timestamp" json:"published_at"`
 
 	// Belongs To relationships
 	Student student.Student `gorm:"foreignKey:StudentID" json:"student,omitempty"`
@@ -2,8 +2,11 @@ package grade
 
 import (
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
+
+	"school_management/internal/modules/homeroom"
 )
 
 // GradeRepository defines the interface for grade data access
@@ -14,8 +17,11 @@ type GradeRepository interface {
 	GetAll(limit, offset int) ([]Grade, error)
 	GetByStudent(studentID uint) ([]Grade, error)
 	GetByExam(examID uint) ([]Grade, error)
+	GetByExamAndHomeroom(examID, homeroomID uint) ([]Grade, error)
+	GetByStudentAndExam(studentID, examID uint) (*Grade, error)
 	GetStudentAverage(studentID uint) (float64, error)
 	GetExamAverage(examID uint) (float64, error)
+	PublishByExam(examID uint, at time.Time) (int64, error)
 	Update(grade *Grade) error
 	Delete(id uint) error
 }
@@ -59,16 +65,16 @@ func (r *gradeRepository) GetByIDWithRelations(id uint) (*Grade, error) {
 // GetAll retrieves all grades with pagination
 func (r *gradeRepository) GetAll(limit, offset int) ([]Grade, error) {
 	var grades []Grade
-	if err := r.db.Limit(limit).Offset(offset).Find(&grades).Error; err != nil {
+	if err := r.db.Preload("Student").Preload("Exam").Limit(limit).Offset(offset).Find(&grades).Error; err != nil {
 		return nil, fmt.Errorf("failed to get grades: %w", err)
 	}
 	return grades, nil
 }
 
-// GetByStudent retrieves all grades for a student
+// GetByStudent retrieves all published grades for a student
 func (r *gradeRepository) GetByStudent(studentID uint) ([]Grade, error) {
 	var grades []Grade
-	if err := r.db.Where("student_id = ?", studentID).Find(&grades).Error; err != nil {
+	if err := r.db.Preload("Student").Preload("Exam").Where("student_id = ? AND status = ?", studentID, GradePublished).Find(&grades).Error; err != nil {
 		return nil, fmt.Errorf("failed to get grades by student: %w", err)
 	}
 	return grades, nil
@@ -77,17 +83,38 @@ func (r *gradeRepository) GetByStudent(studentID uint) ([]Grade, error) {
 // GetByExam retrieves all grades for an exam
 func (r *gradeRepository) GetByExam(examID uint) ([]Grade, error) {
 	var grades []Grade
-	if err := r.db.Where("exam_id = ?", examID).Find(&grades).Error; err != nil {
+	if err := r.db.Preload("Student").Preload("Exam").Where("exam_id = ?", examID).Find(&grades).Error; err != nil {
 		return nil, fmt.Errorf("failed to get grades by exam: %w", err)
 	}
 	return grades, nil
 }
 
-// GetStudentAverage calculates the average grade for a student
+// GetByExamAndHomeroom retrieves an exam's grades for students currently in a homeroom
+func (r *gradeRepository) GetByExamAndHomeroom(examID, homeroomID uint) ([]Grade, error) {
+	var grades []Grade
+	if err := r.db.Preload("Student").Preload("Exam").
+		Scopes(homeroom.CurrentMembers("grades.student_id", homeroomID)).
+		Where("exam_id = ?", examID).Find(&grades).Error; err != nil {
+		return nil, fmt.Errorf("failed to get grades by exam and homeroom: %w", err)
+	}
+	return grades, nil
+}
+
+// GetByStudentAndExam retrieves a student's grade for an exam
+func (r *gradeRepository) GetByStudentAndExam(studentID, examID uint) (*Grade, error) {
+	var grade Grade
+	if err := r.db.Where("student_id = ? AND exam_id = ?", studentID, examID).
+		First(&grade).Error; err != nil {
+		return nil, fmt.Errorf("failed to get grade: %w", err)
+	}
+	return &grade, nil
+}
+
+// GetStudentAverage calculates the average published grade for a student
 func (r *gradeRepository) GetStudentAverage(studentID uint) (float64, error) {
 	var avg float64
 	if err := r.db.Model(&Grade{}).
-		Where("student_id = ?", studentID).
+		Where("student_id = ? AND status = ?", studentID, GradePublished).
 		Select("AVG(score)").
 		Scan(&avg).Error; err != nil {
 		return 0, fmt.Errorf("failed to calculate student average: %w", err)
@@ -107,6 +134,17 @@ func (r *gradeRepository) GetExamAverage(examID uint) (float64, error) {
 	return avg, nil
 }
 
+// PublishByExam releases every draft grade for an exam
+func (r *gradeRepository) PublishByExam(examID uint, at time.Time) (int64, error) {
+	result := r.db.Model(&Grade{}).
+		Where("exam_id = ? AND status = ?", examID, GradeDraft).
+		Updates(map[string]interface{}{"status": GradePublished, "published_at": at})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to publish grades: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
 // Update updates a grade
 func (r *gradeRepository) Update(grade *Grade) error {
 	if err := r.db.Save(grade).Error; err != nil {
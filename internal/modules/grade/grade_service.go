@@ -2,6 +2,10 @@ package grade
 
 import (
 	"fmt"
+	"strings"
+	"time"
+
+	"school_management/internal/modules/exam"
 )
 
 // GradeService defines the business logic interface
@@ -9,20 +13,22 @@ type GradeService interface {
 	Create(req *CreateGradeRequest) (*GradeResponse, error)
 	GetByID(id uint) (*GradeResponse, error)
 	GetByStudent(studentID uint) ([]GradeResponse, error)
-	GetByExam(examID uint) ([]GradeResponse, error)
+	GetByExam(examID uint, homeroomID *uint) ([]GradeResponse, error)
 	GetStudentAverage(studentID uint) (float64, error)
 	Update(id uint, req *UpdateGradeRequest) (*GradeResponse, error)
 	Delete(id uint) error
+	PublishExam(examID uint) (*PublishResponse, error)
 }
 
 // gradeService implements GradeService
 type gradeService struct {
-	repo GradeRepository
+	repo     GradeRepository
+	examRepo exam.ExamRepository
 }
 
 // NewGradeService creates a new grade service with DI
-func NewGradeService(repo GradeRepository) GradeService {
-	return &gradeService{repo: repo}
+func NewGradeService(repo GradeRepository, examRepo exam.ExamRepository) GradeService {
+	return &gradeService{repo: repo, examRepo: examRepo}
 }
 
 // Create creates a new grade
@@ -32,11 +38,12 @@ func (s *gradeService) Create(req *CreateGradeRequest) (*GradeResponse, error) {
 		return nil, err
 	}
 
-	// Map DTO to Model
+	// Map DTO to Model; grades stay drafts until the exam is published
 	gr := &Grade{
 		StudentID: req.StudentID,
 		ExamID:    req.ExamID,
 		Score:     req.Score,
+		Status:    GradeDraft,
 	}
 
 	// Create via repository
@@ -48,16 +55,18 @@ func (s *gradeService) Create(req *CreateGradeRequest) (*GradeResponse, error) {
 	return s.toResponseDTO(gr), nil
 }
 
-// GetByID retrieves a grade by ID
+// GetByID retrieves a grade by ID, hiding its score until it is published
 func (s *gradeService) GetByID(id uint) (*GradeResponse, error) {
 	gr, err := s.repo.GetByID(id)
 	if err != nil {
 		return nil, fmt.Errorf("grade not found: %w", err)
 	}
-	return s.toResponseDTO(gr), nil
+	resp := s.toResponseDTO(gr)
+	hideDraftGrade(resp)
+	return resp, nil
 }
 
-// GetByStudent retrieves grades for a student
+// GetByStudent retrieves a student's published grades
 func (s *gradeService) GetByStudent(studentID uint) ([]GradeResponse, error) {
 	grades, err := s.repo.GetByStudent(studentID)
 	if err != nil {
@@ -66,16 +75,22 @@ func (s *gradeService) GetByStudent(studentID uint) ([]GradeResponse, error) {
 	return s.toResponseDTOList(grades), nil
 }
 
-// GetByExam retrieves grades for an exam
-func (s *gradeService) GetByExam(examID uint) ([]GradeResponse, error) {
-	grades, err := s.repo.GetByExam(examID)
+// GetByExam retrieves all grades for an exam, drafts included, optionally only for students currently in a homeroom
+func (s *gradeService) GetByExam(examID uint, homeroomID *uint) ([]GradeResponse, error) {
+	var grades []Grade
+	var err error
+	if homeroomID != nil {
+		grades, err = s.repo.GetByExamAndHomeroom(examID, *homeroomID)
+	} else {
+		grades, err = s.repo.GetByExam(examID)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get grades: %w", err)
 	}
 	return s.toResponseDTOList(grades), nil
 }
 
-// GetStudentAverage calculates a student's average grade
+// GetStudentAverage calculates a student's average over published grades
 func (s *gradeService) GetStudentAverage(studentID uint) (float64, error) {
 	avg, err := s.repo.GetStudentAverage(studentID)
 	if err != nil {
@@ -97,9 +112,12 @@ func (s *gradeService) Update(id uint, req *UpdateGradeRequest) (*GradeResponse,
 		return nil, fmt.Errorf("grade not found: %w", err)
 	}
 
-	// Update fields
-	if req.Score != 0 {
+	// Update fields; a changed score goes back to draft until the exam is published again,
+	// as a homework re-grade does
+	if req.Score != 0 && req.Score != gr.Score {
 		gr.Score = req.Score
+		gr.Status = GradeDraft
+		gr.PublishedAt = nil
 	}
 
 	// Save
@@ -123,6 +141,21 @@ func (s *gradeService) Delete(id uint) error {
 	return nil
 }
 
+// PublishExam releases an exam's draft grades to students. Grades entered after
+// publishing start as drafts again and go out on the next publish.
+func (s *gradeService) PublishExam(examID uint) (*PublishResponse, error) {
+	if _, err := s.examRepo.GetByID(examID); err != nil {
+		return nil, fmt.Errorf("exam not found: %w", err)
+	}
+
+	published, err := s.repo.PublishByExam(examID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish grades: %w", err)
+	}
+
+	return &PublishResponse{ExamID: examID, Published: published}, nil
+}
+
 // Validation methods
 func (s *gradeService) validateCreateRequest(req *CreateGradeRequest) error {
 	if req.StudentID == 0 {
@@ -147,13 +180,25 @@ func (s *gradeService) validateUpdateRequest(req *UpdateGradeRequest) error {
 // DTO mapping methods
 func (s *gradeService) toResponseDTO(gr *Grade) *GradeResponse {
 	return &GradeResponse{
-		ID:        gr.ID,
-		StudentID: gr.StudentID,
-		ExamID:    gr.ExamID,
-		Score:     gr.Score,
-		CreatedAt: gr.CreatedAt,
-		UpdatedAt: gr.UpdatedAt,
+		ID:          gr.ID,
+		StudentID:   gr.StudentID,
+		StudentName: strings.TrimSpace(gr.Student.FirstName + " " + gr.Student.LastName),
+		ExamID:      gr.ExamID,
+		ExamTitle:   gr.Exam.Title,
+		Score:       &gr.Score,
+		Status:      string(gr.Status),
+		PublishedAt: gr.PublishedAt,
+		CreatedAt:   gr.CreatedAt,
+		UpdatedAt:   gr.UpdatedAt,
+	}
+}
+
+// hideDraftGrade clears the score of a grade that is not published yet
+func hideDraftGrade(resp *GradeResponse) {
+	if resp.Status != string(GradeDraft) {
+		return
 	}
+	resp.Score = nil
 }
 
 func (s *gradeService) toResponseDTOList(grades []Grade) []GradeResponse {
@@ -0,0 +1,137 @@
+package grade_curve
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"school_management/internal/export"
+)
+
+// GradeCurveController handles HTTP requests for grade curves
+type GradeCurveController struct {
+	service GradeCurveService
+}
+
+// NewGradeCurveController creates a new grade curve controller
+func NewGradeCurveController(service GradeCurveService) *GradeCurveController {
+	return &GradeCurveController{service: service}
+}
+
+// Preview computes a curve for an exam without applying it
+func (c *GradeCurveController) Preview(ctx *gin.Context) {
+	examID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid exam ID"})
+		return
+	}
+
+	var req CurveRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.Preview(uint(examID), &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Apply applies a curve to an exam's grades
+func (c *GradeCurveController) Apply(ctx *gin.Context) {
+	examID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid exam ID"})
+		return
+	}
+
+	var req CurveRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.Apply(uint(examID), &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// GetByExam retrieves the curves applied to an exam
+func (c *GradeCurveController) GetByExam(ctx *gin.Context) {
+	examID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid exam ID"})
+		return
+	}
+
+	resp, err := c.service.GetByExam(uint(examID))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// GetByID retrieves a curve by ID
+func (c *GradeCurveController) GetByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetByID(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Revert restores the raw scores a curve replaced
+func (c *GradeCurveController) Revert(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.Revert(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// RegisterRoutes registers grade curve routes
+func (c *GradeCurveController) RegisterRoutes(rg *gin.RouterGroup) {
+	exams := rg.Group("/exams")
+	{
+		exams.POST("/:id/curves/preview", c.Preview)
+		exams.POST("/:id/curves", c.Apply)
+		exams.GET("/:id/curves", c.GetByExam)
+	}
+
+	curves := rg.Group("/curves")
+	{
+		curves.GET("/:id", c.GetByID)
+		curves.POST("/:id/revert", c.Revert)
+	}
+}
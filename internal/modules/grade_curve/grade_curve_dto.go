@@ -0,0 +1,41 @@
+package grade_curve
+
+import "time"
+
+// CurveRequest represents the request body for previewing or applying a curve
+type CurveRequest struct {
+	Method       string   `json:"method" binding:"required,oneof=flat linear sqrt distribution"`
+	Points       *float64 `json:"points" binding:"omitempty"`               // flat
+	TargetMean   *float64 `json:"target_mean" binding:"omitempty,min=0"`    // linear, distribution
+	TargetStdDev *float64 `json:"target_std_dev" binding:"omitempty,min=0"` // distribution
+}
+
+// CurveResponse represents a curve preview or an applied curve
+type CurveResponse struct {
+	ID         uint            `json:"id,omitempty"` // Zero for previews
+	ExamID     uint            `json:"exam_id"`
+	Method     string          `json:"method"`
+	Params     CurveParams     `json:"params"`
+	Preview    bool            `json:"preview"`
+	MeanBefore float64         `json:"mean_before"`
+	MeanAfter  float64         `json:"mean_after"`
+	AppliedAt  *time.Time      `json:"applied_at,omitempty"`
+	RevertedAt *time.Time      `json:"reverted_at,omitempty"`
+	Entries    []EntryResponse `json:"entries"`
+}
+
+// EntryResponse represents one student's raw and curved score
+type EntryResponse struct {
+	GradeID     uint    `json:"grade_id"`
+	StudentID   uint    `json:"student_id"`
+	RawScore    float64 `json:"raw_score"`
+	CurvedScore float64 `json:"curved_score"`
+}
+
+// RevertResponse represents the result of reverting a curve. Grades changed
+// since the curve was applied (e.g. by a regrade) are left alone and listed as skipped.
+type RevertResponse struct {
+	CurveID         uint   `json:"curve_id"`
+	Restored        int    `json:"restored"`
+	SkippedGradeIDs []uint `json:"skipped_grade_ids"`
+}
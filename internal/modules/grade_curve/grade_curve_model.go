@@ -0,0 +1,69 @@
+package grade_curve
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"school_management/internal/modules/exam"
+	"school_management/internal/modules/grade"
+)
+
+// CurveMethod identifies how a curve maps raw scores to curved scores
+type CurveMethod string
+
+const (
+	CurveFlat         CurveMethod = "flat"         // add a fixed number of points
+	CurveLinear       CurveMethod = "linear"       // scale every score so the mean hits a target
+	CurveSquareRoot   CurveMethod = "sqrt"         // MaxScore * sqrt(score / MaxScore)
+	CurveDistribution CurveMethod = "distribution" // map z-scores onto a target mean and standard deviation
+)
+
+// CurveParams holds the parameters of a curve; which fields apply depends on the method
+type CurveParams struct {
+	Points       float64 `json:"points,omitempty"`
+	TargetMean   float64 `json:"target_mean,omitempty"`
+	TargetStdDev float64 `json:"target_std_dev,omitempty"`
+}
+
+// GradeCurve is a curve applied to an exam's grades. Each entry keeps the raw
+// score it replaced so the curve can be reverted.
+type GradeCurve struct {
+	gorm.Model
+	ExamID     uint        `gorm:"not null;index" json:"exam_id"`
+	Method     CurveMethod `gorm:"type:varchar(20);not null" json:"method"`
+	Params     CurveParams `gorm:"type:text;serializer:json" json:"params"`
+	MeanBefore float64     `gorm:"not null" json:"mean_before"`
+	MeanAfter  float64     `gorm:"not null" json:"mean_after"`
+	AppliedAt  time.Time   `gorm:"type:timestamp;not null" json:"applied_at"`
+	RevertedAt *time.Time  `gorm:"type:timestamp" json:"reverted_at"`
+
+	// Belongs To relationship
+	Exam exam.Exam `gorm:"foreignKey:ExamID" json:"exam,omitempty"`
+
+	// Has Many relationship
+	Entries []CurveEntry `gorm:"foreignKey:CurveID" json:"entries,omitempty"`
+}
+
+// TableName specifies the table name for the GradeCurve model
+func (GradeCurve) TableName() string {
+	return "grade_curves"
+}
+
+// CurveEntry records one grade's raw and curved score under a curve
+type CurveEntry struct {
+	gorm.Model
+	CurveID     uint    `gorm:"not null;uniqueIndex:idx_curve_grade" json:"curve_id"`
+	GradeID     uint    `gorm:"not null;uniqueIndex:idx_curve_grade" json:"grade_id"`
+	StudentID   uint    `gorm:"not null" json:"student_id"`
+	RawScore    float64 `gorm:"not null" json:"raw_score"`
+	CurvedScore float64 `gorm:"not null" json:"curved_score"`
+
+	// Belongs To relationship
+	Grade grade.Grade `gorm:"foreignKey:GradeID" json:"grade,omitempty"`
+}
+
+// TableName specifies the table name for the CurveEntry model
+func (CurveEntry) TableName() string {
+	return "grade_curve_entries"
+}
@@ -0,0 +1,118 @@
+package grade_curve
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"school_management/internal/modules/grade"
+)
+
+// GradeCurveRepository defines the interface for grade curve data access
+type GradeCurveRepository interface {
+	Apply(curve *GradeCurve) error
+	GetByID(id uint) (*GradeCurve, error)
+	GetByExam(examID uint) ([]GradeCurve, error)
+	GetActiveByExam(examID uint) (*GradeCurve, error)
+	Revert(curve *GradeCurve, at time.Time) (restored int, skipped []uint, err error)
+}
+
+// gradeCurveRepository implements GradeCurveRepository
+type gradeCurveRepository struct {
+	db *gorm.DB
+}
+
+// NewGradeCurveRepository creates a new grade curve repository with dependency injection
+func NewGradeCurveRepository(db *gorm.DB) GradeCurveRepository {
+	return &gradeCurveRepository{db: db}
+}
+
+// Apply saves a curve with its entries and writes the curved scores to the grades in one
+// transaction. A grade whose score changes goes back to draft until it is published again.
+func (r *gradeCurveRepository) Apply(curve *GradeCurve) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Exam", "Entries.Grade").Create(curve).Error; err != nil {
+			return fmt.Errorf("failed to save grade curve: %w", err)
+		}
+		for _, entry := range curve.Entries {
+			if err := tx.Model(&grade.Grade{}).Where("id = ? AND score <> ?", entry.GradeID, entry.CurvedScore).
+				Updates(redraft(entry.CurvedScore)).Error; err != nil {
+				return fmt.Errorf("failed to apply curve to grade %d: %w", entry.GradeID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// GetByID retrieves a curve with its entries
+func (r *gradeCurveRepository) GetByID(id uint) (*GradeCurve, error) {
+	var curve GradeCurve
+	if err := r.db.Preload("Entries", func(db *gorm.DB) *gorm.DB { return db.Order("grade_id ASC") }).
+		First(&curve, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get grade curve: %w", err)
+	}
+	return &curve, nil
+}
+
+// GetByExam retrieves all curves applied to an exam, newest first
+func (r *gradeCurveRepository) GetByExam(examID uint) ([]GradeCurve, error) {
+	var curves []GradeCurve
+	if err := r.db.Where("exam_id = ?", examID).Order("applied_at DESC").Find(&curves).Error; err != nil {
+		return nil, fmt.Errorf("failed to get grade curves by exam: %w", err)
+	}
+	return curves, nil
+}
+
+// GetActiveByExam retrieves the exam's curve that has not been reverted
+func (r *gradeCurveRepository) GetActiveByExam(examID uint) (*GradeCurve, error) {
+	var curve GradeCurve
+	if err := r.db.Preload("Entries").
+		Where("exam_id = ? AND reverted_at IS NULL", examID).
+		First(&curve).Error; err != nil {
+		return nil, fmt.Errorf("failed to get active grade curve: %w", err)
+	}
+	return &curve, nil
+}
+
+// Revert restores each grade's raw score, but only where the grade still holds the
+// curved score, and marks the curve reverted in one transaction. Like Apply, a grade whose
+// score changes goes back to draft.
+func (r *gradeCurveRepository) Revert(curve *GradeCurve, at time.Time) (int, []uint, error) {
+	restored := 0
+	skipped := []uint{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, entry := range curve.Entries {
+			query := tx.Model(&grade.Grade{}).Where("id = ? AND score = ?", entry.GradeID, entry.CurvedScore)
+			var result *gorm.DB
+			if entry.RawScore == entry.CurvedScore {
+				result = query.Update("updated_at", at)
+			} else {
+				result = query.Updates(redraft(entry.RawScore))
+			}
+			if result.Error != nil {
+				return fmt.Errorf("failed to restore grade %d: %w", entry.GradeID, result.Error)
+			}
+			if result.RowsAffected == 0 {
+				skipped = append(skipped, entry.GradeID)
+				continue
+			}
+			restored++
+		}
+		if err := tx.Model(curve).Update("reverted_at", at).Error; err != nil {
+			return fmt.Errorf("failed to mark curve reverted: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	curve.RevertedAt = &at
+	return restored, skipped, nil
+}
+
+// redraft is the update that gives a grade a new score and takes it back to draft, as editing
+// a published grade does
+func redraft(score float64) map[string]interface{} {
+	return map[string]interface{}{"score": score, "status": grade.GradeDraft, "published_at": nil}
+}
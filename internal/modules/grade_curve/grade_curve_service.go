@@ -0,0 +1,256 @@
+package grade_curve
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"school_management/internal/modules/exam"
+	"school_management/internal/modules/grade"
+)
+
+// GradeCurveService defines the business logic interface
+type GradeCurveService interface {
+	Preview(examID uint, req *CurveRequest) (*CurveResponse, error)
+	Apply(examID uint, req *CurveRequest) (*CurveResponse, error)
+	GetByID(id uint) (*CurveResponse, error)
+	GetByExam(examID uint) ([]CurveResponse, error)
+	Revert(id uint) (*RevertResponse, error)
+}
+
+// gradeCurveService implements GradeCurveService
+type gradeCurveService struct {
+	repo      GradeCurveRepository
+	examRepo  exam.ExamRepository
+	gradeRepo grade.GradeRepository
+}
+
+// NewGradeCurveService creates a new grade curve service with DI
+func NewGradeCurveService(repo GradeCurveRepository, examRepo exam.ExamRepository, gradeRepo grade.GradeRepository) GradeCurveService {
+	return &gradeCurveService{repo: repo, examRepo: examRepo, gradeRepo: gradeRepo}
+}
+
+// Preview computes a curve over the exam's grades without saving anything
+func (s *gradeCurveService) Preview(examID uint, req *CurveRequest) (*CurveResponse, error) {
+	curve, err := s.compute(examID, req)
+	if err != nil {
+		return nil, err
+	}
+	return s.toResponseDTO(curve, true), nil
+}
+
+// Apply computes a curve and writes the curved scores to the exam's grades
+func (s *gradeCurveService) Apply(examID uint, req *CurveRequest) (*CurveResponse, error) {
+	curve, err := s.compute(examID, req)
+	if err != nil {
+		return nil, err
+	}
+	curve.AppliedAt = time.Now()
+
+	if err := s.repo.Apply(curve); err != nil {
+		return nil, fmt.Errorf("failed to apply curve: %w", err)
+	}
+
+	return s.toResponseDTO(curve, false), nil
+}
+
+// GetByID retrieves a curve with its raw and curved scores
+func (s *gradeCurveService) GetByID(id uint) (*CurveResponse, error) {
+	curve, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("grade curve not found: %w", err)
+	}
+	return s.toResponseDTO(curve, false), nil
+}
+
+// GetByExam retrieves the curves applied to an exam
+func (s *gradeCurveService) GetByExam(examID uint) ([]CurveResponse, error) {
+	curves, err := s.repo.GetByExam(examID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get grade curves: %w", err)
+	}
+
+	responses := make([]CurveResponse, len(curves))
+	for i, curve := range curves {
+		responses[i] = *s.toResponseDTO(&curve, false)
+	}
+	return responses, nil
+}
+
+// Revert restores the raw scores recorded when the curve was applied
+func (s *gradeCurveService) Revert(id uint) (*RevertResponse, error) {
+	curve, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("grade curve not found: %w", err)
+	}
+
+	if curve.RevertedAt != nil {
+		return nil, fmt.Errorf("grade curve was already reverted at %s", curve.RevertedAt.Format(time.RFC3339))
+	}
+
+	restored, skipped, err := s.repo.Revert(curve, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to revert curve: %w", err)
+	}
+
+	return &RevertResponse{CurveID: curve.ID, Restored: restored, SkippedGradeIDs: skipped}, nil
+}
+
+// compute builds an unsaved curve mapping every grade of the exam to its curved score.
+// Curves always start from raw scores, so an exam with an active curve is refused.
+func (s *gradeCurveService) compute(examID uint, req *CurveRequest) (*GradeCurve, error) {
+	ex, err := s.examRepo.GetByID(examID)
+	if err != nil {
+		return nil, fmt.Errorf("exam not found: %w", err)
+	}
+
+	if active, err := s.repo.GetActiveByExam(examID); err == nil {
+		return nil, fmt.Errorf("exam already has curve %d applied; revert it first", active.ID)
+	}
+
+	params, err := s.validateCurveRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	grades, err := s.gradeRepo.GetByExam(examID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get grades: %w", err)
+	}
+	if len(grades) == 0 {
+		return nil, fmt.Errorf("exam has no grades to curve")
+	}
+
+	raw := make([]float64, len(grades))
+	for i, gr := range grades {
+		raw[i] = gr.Score
+	}
+	curved, err := curveScores(CurveMethod(req.Method), params, raw, ex.MaxScore)
+	if err != nil {
+		return nil, err
+	}
+
+	curve := &GradeCurve{
+		ExamID:     examID,
+		Method:     CurveMethod(req.Method),
+		Params:     params,
+		MeanBefore: round2(meanOf(raw)),
+		MeanAfter:  round2(meanOf(curved)),
+		Entries:    make([]CurveEntry, len(grades)),
+	}
+	for i, gr := range grades {
+		curve.Entries[i] = CurveEntry{
+			GradeID:     gr.ID,
+			StudentID:   gr.StudentID,
+			RawScore:    gr.Score,
+			CurvedScore: curved[i],
+		}
+	}
+	return curve, nil
+}
+
+// curveScores maps raw scores through the curve, clamped to [0, maxScore] and rounded to 2 decimals
+func curveScores(method CurveMethod, params CurveParams, raw []float64, maxScore float64) ([]float64, error) {
+	mean := meanOf(raw)
+	var stdDev float64
+	for _, score := range raw {
+		stdDev += (score - mean) * (score - mean)
+	}
+	stdDev = math.Sqrt(stdDev / float64(len(raw)))
+
+	curved := make([]float64, len(raw))
+	for i, score := range raw {
+		var value float64
+		switch method {
+		case CurveFlat:
+			value = score + params.Points
+		case CurveLinear:
+			if mean == 0 {
+				return nil, fmt.Errorf("cannot scale to a target mean when every score is 0")
+			}
+			value = score * params.TargetMean / mean
+		case CurveSquareRoot:
+			value = maxScore * math.Sqrt(math.Max(score, 0)/maxScore)
+		case CurveDistribution:
+			if stdDev == 0 {
+				value = params.TargetMean
+			} else {
+				value = params.TargetMean + (score-mean)/stdDev*params.TargetStdDev
+			}
+		default:
+			return nil, fmt.Errorf("unknown curve method %q", method)
+		}
+		curved[i] = round2(math.Min(math.Max(value, 0), maxScore))
+	}
+	return curved, nil
+}
+
+func meanOf(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// Validation methods
+func (s *gradeCurveService) validateCurveRequest(req *CurveRequest) (CurveParams, error) {
+	var params CurveParams
+	switch CurveMethod(req.Method) {
+	case CurveFlat:
+		if req.Points == nil || *req.Points == 0 {
+			return params, fmt.Errorf("points is required for a flat curve")
+		}
+		params.Points = *req.Points
+	case CurveLinear:
+		if req.TargetMean == nil {
+			return params, fmt.Errorf("target_mean is required for a linear curve")
+		}
+		params.TargetMean = *req.TargetMean
+	case CurveSquareRoot:
+		// No parameters
+	case CurveDistribution:
+		if req.TargetMean == nil || req.TargetStdDev == nil {
+			return params, fmt.Errorf("target_mean and target_std_dev are required for a distribution curve")
+		}
+		params.TargetMean = *req.TargetMean
+		params.TargetStdDev = *req.TargetStdDev
+	default:
+		return params, fmt.Errorf("invalid curve method (must be: flat, linear, sqrt, or distribution)")
+	}
+	return params, nil
+}
+
+// DTO mapping methods
+func (s *gradeCurveService) toResponseDTO(curve *GradeCurve, preview bool) *CurveResponse {
+	resp := &CurveResponse{
+		ID:         curve.ID,
+		ExamID:     curve.ExamID,
+		Method:     string(curve.Method),
+		Params:     curve.Params,
+		Preview:    preview,
+		MeanBefore: curve.MeanBefore,
+		MeanAfter:  curve.MeanAfter,
+		RevertedAt: curve.RevertedAt,
+		Entries:    make([]EntryResponse, len(curve.Entries)),
+	}
+	if !preview {
+		resp.AppliedAt = &curve.AppliedAt
+	}
+	for i, entry := range curve.Entries {
+		resp.Entries[i] = EntryResponse{
+			GradeID:     entry.GradeID,
+			StudentID:   entry.StudentID,
+			RawScore:    entry.RawScore,
+			CurvedScore: entry.CurvedScore,
+		}
+	}
+	return resp
+}
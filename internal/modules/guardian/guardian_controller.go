@@ -0,0 +1,373 @@
+package guardian
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"school_management/internal/export"
+)
+
+// GuardianController handles HTTP requests for guardians
+type GuardianController struct {
+	service GuardianService
+}
+
+// NewGuardianController creates a new guardian controller
+func NewGuardianController(service GuardianService) *GuardianController {
+	return &GuardianController{service: service}
+}
+
+// Create creates a new guardian
+func (c *GuardianController) Create(ctx *gin.Context) {
+	var req CreateGuardianRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.Create(&req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// GetByID retrieves a guardian with their linked students
+func (c *GuardianController) GetByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetByID(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetAll retrieves all guardians with pagination
+func (c *GuardianController) GetAll(ctx *gin.Context) {
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	offset, _ := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+
+	if export.Requested(ctx) {
+		export.StreamPages(ctx, offset, func(limit, offset int) (interface{}, error) {
+			return c.service.GetAll(limit, offset)
+		})
+		return
+	}
+
+	resp, err := c.service.GetAll(limit, offset)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data":   resp,
+		"limit":  limit,
+		"offset": offset,
+		"count":  len(resp),
+	})
+}
+
+// Update updates a guardian's contact details
+func (c *GuardianController) Update(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	var req UpdateGuardianRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.Update(uint(id), &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Delete deletes a guardian
+func (c *GuardianController) Delete(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	if err := c.service.Delete(uint(id)); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "guardian deleted successfully"})
+}
+
+// LinkStudent links a guardian to a student
+func (c *GuardianController) LinkStudent(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	var req LinkStudentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.LinkStudent(uint(id), &req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// UpdateLink changes a guardian's relationship to a student or their permissions
+func (c *GuardianController) UpdateLink(ctx *gin.Context) {
+	guardianID, studentID, ok := linkIDs(ctx)
+	if !ok {
+		return
+	}
+
+	var req UpdateLinkRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.UpdateLink(guardianID, studentID, &req)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UnlinkStudent removes the link between a guardian and a student
+func (c *GuardianController) UnlinkStudent(ctx *gin.Context) {
+	guardianID, studentID, ok := linkIDs(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.service.UnlinkStudent(guardianID, studentID); err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "guardian unlinked successfully"})
+}
+
+// GetStudentGuardians lists a student's guardians and what each may do
+func (c *GuardianController) GetStudentGuardians(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetStudentGuardians(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// GetChildren lists the students a guardian is linked to
+func (c *GuardianController) GetChildren(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetChildren(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// GetChildAttendance retrieves a child's attendance, optionally limited by ?from= and ?to= (YYYY-MM-DD)
+func (c *GuardianController) GetChildAttendance(ctx *gin.Context) {
+	guardianID, studentID, ok := linkIDs(ctx)
+	if !ok {
+		return
+	}
+
+	var from, to *time.Time
+	for _, q := range []struct {
+		name   string
+		target **time.Time
+	}{{"from", &from}, {"to", &to}} {
+		if v := ctx.Query(q.name); v != "" {
+			t, err := time.Parse("2006-01-02", v)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + q.name + " date (use YYYY-MM-DD)"})
+				return
+			}
+			*q.target = &t
+		}
+	}
+
+	resp, err := c.service.GetChildAttendance(guardianID, studentID, from, to)
+	if errors.Is(err, ErrNotPermitted) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// GetChildGrades retrieves a child's published exam results
+func (c *GuardianController) GetChildGrades(ctx *gin.Context) {
+	guardianID, studentID, ok := linkIDs(ctx)
+	if !ok {
+		return
+	}
+
+	resp, err := c.service.GetChildGrades(guardianID, studentID)
+	if errors.Is(err, ErrNotPermitted) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// GetChildHomework retrieves a child's outstanding homework
+func (c *GuardianController) GetChildHomework(ctx *gin.Context) {
+	guardianID, studentID, ok := linkIDs(ctx)
+	if !ok {
+		return
+	}
+
+	resp, err := c.service.GetChildHomework(guardianID, studentID)
+	if errors.Is(err, ErrNotPermitted) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// GetChildExams retrieves a child's upcoming exams within ?days= (default 30)
+func (c *GuardianController) GetChildExams(ctx *gin.Context) {
+	guardianID, studentID, ok := linkIDs(ctx)
+	if !ok {
+		return
+	}
+	days, _ := strconv.Atoi(ctx.DefaultQuery("days", strconv.Itoa(DefaultExamWindowDays)))
+
+	resp, err := c.service.GetChildExams(guardianID, studentID, days)
+	if errors.Is(err, ErrNotPermitted) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// linkIDs parses the guardian and student IDs of a guardian-student route, responding 400 when either is invalid
+func linkIDs(ctx *gin.Context) (guardianID, studentID uint, ok bool) {
+	gid, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid guardian ID"})
+		return 0, 0, false
+	}
+	sid, err := strconv.ParseUint(ctx.Param("studentId"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid student ID"})
+		return 0, 0, false
+	}
+	return uint(gid), uint(sid), true
+}
+
+// RegisterRoutes registers guardian routes
+func (c *GuardianController) RegisterRoutes(rg *gin.RouterGroup) {
+	guardians := rg.Group("/guardians")
+	{
+		guardians.POST("", c.Create)
+		guardians.GET("/:id", c.GetByID)
+		guardians.GET("", c.GetAll)
+		guardians.PUT("/:id", c.Update)
+		guardians.DELETE("/:id", c.Delete)
+
+		// Student links
+		guardians.POST("/:id/students", c.LinkStudent)
+		guardians.PUT("/:id/students/:studentId", c.UpdateLink)
+		guardians.DELETE("/:id/students/:studentId", c.UnlinkStudent)
+
+		// Guardian-scoped views; only students linked to the guardian are visible
+		guardians.GET("/:id/children", c.GetChildren)
+		guardians.GET("/:id/children/:studentId/attendance", c.GetChildAttendance)
+		guardians.GET("/:id/children/:studentId/grades", c.GetChildGrades)
+		guardians.GET("/:id/children/:studentId/homework", c.GetChildHomework)
+		guardians.GET("/:id/children/:studentId/exams", c.GetChildExams)
+	}
+
+	students := rg.Group("/students")
+	{
+		students.GET("/:id/guardians", c.GetStudentGuardians)
+	}
+}
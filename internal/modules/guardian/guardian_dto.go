@@ -0,0 +1,114 @@
+package guardian
+
+import "time"
+
+// CreateGuardianRequest represents the request body for creating a guardian
+type CreateGuardianRequest struct {
+	FirstName string `json:"first_name" binding:"required,min=2,max=50"`
+	LastName  string `json:"last_name" binding:"required,min=2,max=50"`
+	Email     string `json:"email" binding:"required,email,max=100"`
+	Phone     string `json:"phone" binding:"omitempty,max=20"`
+	AltPhone  string `json:"alt_phone" binding:"omitempty,max=20"`
+	Address   string `json:"address" binding:"omitempty"`
+}
+
+// UpdateGuardianRequest represents the request body for updating a guardian
+type UpdateGuardianRequest struct {
+	FirstName string `json:"first_name" binding:"omitempty,min=2,max=50"`
+	LastName  string `json:"last_name" binding:"omitempty,min=2,max=50"`
+	Email     string `json:"email" binding:"omitempty,email,max=100"`
+	Phone     string `json:"phone" binding:"omitempty,max=20"`
+	AltPhone  string `json:"alt_phone" binding:"omitempty,max=20"`
+	Address   string `json:"address" binding:"omitempty"`
+}
+
+// GuardianResponse represents the response body for guardian data
+type GuardianResponse struct {
+	ID        uint      `json:"id"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	Email     string    `json:"email"`
+	Phone     string    `json:"phone"`
+	AltPhone  string    `json:"alt_phone"`
+	Address   string    `json:"address"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Students []LinkResponse `json:"students,omitempty"`
+}
+
+// LinkStudentRequest represents the request body for linking a guardian to a student.
+// Omitted flags take the defaults: custody, pick-up and reports on, emergency contact off.
+type LinkStudentRequest struct {
+	StudentID        uint   `json:"student_id" binding:"required"`
+	Relationship     string `json:"relationship" binding:"required,oneof=mother father guardian grandparent sibling other"`
+	HasCustody       *bool  `json:"has_custody"`
+	MayPickUp        *bool  `json:"may_pick_up"`
+	ReceivesReports  *bool  `json:"receives_reports"`
+	EmergencyContact *bool  `json:"emergency_contact"`
+}
+
+// UpdateLinkRequest represents the request body for changing a guardian's relationship or permissions
+type UpdateLinkRequest struct {
+	Relationship     string `json:"relationship" binding:"omitempty,oneof=mother father guardian grandparent sibling other"`
+	HasCustody       *bool  `json:"has_custody"`
+	MayPickUp        *bool  `json:"may_pick_up"`
+	ReceivesReports  *bool  `json:"receives_reports"`
+	EmergencyContact *bool  `json:"emergency_contact"`
+}
+
+// LinkResponse represents a guardian-student link
+type LinkResponse struct {
+	ID               uint      `json:"id"`
+	GuardianID       uint      `json:"guardian_id"`
+	GuardianName     string    `json:"guardian_name,omitempty"`
+	GuardianPhone    string    `json:"guardian_phone,omitempty"`
+	StudentID        uint      `json:"student_id"`
+	StudentName      string    `json:"student_name,omitempty"`
+	Relationship     string    `json:"relationship"`
+	HasCustody       bool      `json:"has_custody"`
+	MayPickUp        bool      `json:"may_pick_up"`
+	ReceivesReports  bool      `json:"receives_reports"`
+	EmergencyContact bool      `json:"emergency_contact"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// ChildAttendanceResponse represents one attendance record shown to a guardian
+type ChildAttendanceResponse struct {
+	Date       time.Time `json:"date"`
+	CourseID   uint      `json:"course_id"`
+	CourseName string    `json:"course_name"`
+	Status     string    `json:"status"`
+}
+
+// ChildGradeResponse represents one published exam result shown to a guardian
+type ChildGradeResponse struct {
+	ExamID      uint       `json:"exam_id"`
+	ExamTitle   string     `json:"exam_title"`
+	CourseName  string     `json:"course_name"`
+	ExamDate    time.Time  `json:"exam_date"`
+	Score       float64    `json:"score"`
+	MaxScore    float64    `json:"max_score"`
+	Percent     float64    `json:"percent"`
+	PublishedAt *time.Time `json:"published_at"`
+}
+
+// ChildHomeworkResponse represents one outstanding homework assignment shown to a guardian
+type ChildHomeworkResponse struct {
+	HomeworkID uint      `json:"homework_id"`
+	Title      string    `json:"title"`
+	CourseName string    `json:"course_name"`
+	DueDate    time.Time `json:"due_date"`
+	Status     string    `json:"status"`
+	Overdue    bool      `json:"overdue"`
+}
+
+// ChildExamResponse represents one upcoming exam shown to a guardian
+type ChildExamResponse struct {
+	ExamID     uint      `json:"exam_id"`
+	Title      string    `json:"title"`
+	CourseName string    `json:"course_name"`
+	ExamDate   time.Time `json:"exam_date"`
+	Duration   int       `json:"duration"`
+	MaxScore   float64   `json:"max_score"`
+}
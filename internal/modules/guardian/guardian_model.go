@@ -0,0 +1,100 @@
+package guardian
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"school_management/internal/modules/student"
+)
+
+// Relationship describes how a guardian is related to a student
+type Relationship string
+
+const (
+	RelationshipMother      Relationship = "mother"
+	RelationshipFather      Relationship = "father"
+	RelationshipGuardian    Relationship = "guardian"
+	RelationshipGrandparent Relationship = "grandparent"
+	RelationshipSibling     Relationship = "sibling"
+	RelationshipOther       Relationship = "other"
+)
+
+type Guardian struct {
+	gorm.Model
+	FirstName string `gorm:"not null;size:50" json:"first_name"`
+	LastName  string `gorm:"not null;size:50" json:"last_name"`
+	Email     string `gorm:"uniqueIndex;not null;size:100" json:"email"`
+	Phone     string `gorm:"size:20" json:"phone"`
+	AltPhone  string `gorm:"size:20" json:"alt_phone"`
+	Address   string `gorm:"type:text" json:"address"`
+
+	// Has Many relationship
+	Links []GuardianStudent `gorm:"foreignKey:GuardianID" json:"links,omitempty"`
+}
+
+// TableName specifies the table name for the Guardian model
+func (Guardian) TableName() string {
+	return "guardians"
+}
+
+// GuardianStudent links a guardian to one student with what the guardian is permitted to do.
+// The guardian's views of the student's grades and attendance need ReceivesReports; those of
+// homework and upcoming exams need HasCustody.
+type GuardianStudent struct {
+	gorm.Model
+	GuardianID       uint         `gorm:"not null;uniqueIndex:idx_guardian_student" json:"guardian_id"`
+	StudentID        uint         `gorm:"not null;uniqueIndex:idx_guardian_student;index" json:"student_id"`
+	Relationship     Relationship `gorm:"type:varchar(20);not null" json:"relationship"`
+	HasCustody       bool         `gorm:"not null;default:true" json:"has_custody"`
+	MayPickUp        bool         `gorm:"not null;default:true" json:"may_pick_up"`
+	ReceivesReports  bool         `gorm:"not null;default:true" json:"receives_reports"`
+	EmergencyContact bool         `gorm:"not null;default:false" json:"emergency_contact"`
+
+	// Belongs To relationships
+	Guardian Guardian        `gorm:"foreignKey:GuardianID" json:"guardian,omitempty"`
+	Student  student.Student `gorm:"foreignKey:StudentID" json:"student,omitempty"`
+}
+
+// TableName specifies the table name for the GuardianStudent model
+func (GuardianStudent) TableName() string {
+	return "guardian_students"
+}
+
+// ChildAttendance is one attendance record of a guardian's child
+type ChildAttendance struct {
+	Date       time.Time
+	CourseID   uint
+	CourseName string
+	Status     string
+}
+
+// ChildGrade is one published exam result of a guardian's child
+type ChildGrade struct {
+	ExamID      uint
+	ExamTitle   string
+	CourseName  string
+	ExamDate    time.Time
+	Score       float64
+	MaxScore    float64
+	PublishedAt *time.Time
+}
+
+// ChildHomework is one outstanding homework assignment of a guardian's child
+type ChildHomework struct {
+	HomeworkID uint
+	Title      string
+	CourseName string
+	DueDate    time.Time
+	Status     string
+}
+
+// ChildExam is one upcoming exam in a guardian's child's courses
+type ChildExam struct {
+	ExamID     uint
+	Title      string
+	CourseName string
+	ExamDate   time.Time
+	Duration   int
+	MaxScore   float64
+}
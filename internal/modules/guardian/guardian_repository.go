@@ -0,0 +1,229 @@
+package guardian
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"school_management/internal/modules/grade"
+	"school_management/internal/modules/students_homework"
+)
+
+// GuardianRepository defines the interface for guardian data access
+type GuardianRepository interface {
+	Create(guardian *Guardian) error
+	GetByID(id uint) (*Guardian, error)
+	GetAll(limit, offset int) ([]Guardian, error)
+	GetByEmail(email string) (*Guardian, error)
+	Update(guardian *Guardian) error
+	Delete(id uint) error
+
+	// Student links
+	CreateLink(link *GuardianStudent) error
+	GetLink(guardianID, studentID uint) (*GuardianStudent, error)
+	GetLinksByGuardian(guardianID uint) ([]GuardianStudent, error)
+	GetLinksByStudent(studentID uint) ([]GuardianStudent, error)
+	UpdateLink(link *GuardianStudent) error
+	DeleteLink(id uint) error
+
+	// Child views
+	GetChildAttendance(studentID uint, from, to *time.Time) ([]ChildAttendance, error)
+	GetChildGrades(studentID uint) ([]ChildGrade, error)
+	GetChildHomework(studentID uint) ([]ChildHomework, error)
+	GetChildExams(studentID uint, from, until time.Time) ([]ChildExam, error)
+}
+
+// guardianRepository implements GuardianRepository
+type guardianRepository struct {
+	db *gorm.DB
+}
+
+// NewGuardianRepository creates a new guardian repository with dependency injection
+func NewGuardianRepository(db *gorm.DB) GuardianRepository {
+	return &guardianRepository{db: db}
+}
+
+// Create creates a new guardian
+func (r *guardianRepository) Create(guardian *Guardian) error {
+	if err := r.db.Create(guardian).Error; err != nil {
+		return fmt.Errorf("failed to create guardian: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a guardian by ID
+func (r *guardianRepository) GetByID(id uint) (*Guardian, error) {
+	var guardian Guardian
+	if err := r.db.First(&guardian, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get guardian: %w", err)
+	}
+	return &guardian, nil
+}
+
+// GetAll retrieves all guardians with pagination
+func (r *guardianRepository) GetAll(limit, offset int) ([]Guardian, error) {
+	var guardians []Guardian
+	if err := r.db.Order("last_name ASC, first_name ASC, id ASC").
+		Limit(limit).Offset(offset).Find(&guardians).Error; err != nil {
+		return nil, fmt.Errorf("failed to get guardians: %w", err)
+	}
+	return guardians, nil
+}
+
+// GetByEmail retrieves a guardian by email
+func (r *guardianRepository) GetByEmail(email string) (*Guardian, error) {
+	var guardian Guardian
+	if err := r.db.Where("email = ?", email).First(&guardian).Error; err != nil {
+		return nil, fmt.Errorf("failed to get guardian by email: %w", err)
+	}
+	return &guardian, nil
+}
+
+// Update updates a guardian
+func (r *guardianRepository) Update(guardian *Guardian) error {
+	if err := r.db.Omit("Links").Save(guardian).Error; err != nil {
+		return fmt.Errorf("failed to update guardian: %w", err)
+	}
+	return nil
+}
+
+// Delete soft deletes a guardian together with their student links
+func (r *guardianRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("guardian_id = ?", id).Delete(&GuardianStudent{}).Error; err != nil {
+			return fmt.Errorf("failed to delete guardian links: %w", err)
+		}
+		if err := tx.Delete(&Guardian{}, id).Error; err != nil {
+			return fmt.Errorf("failed to delete guardian: %w", err)
+		}
+		return nil
+	})
+}
+
+// CreateLink links a guardian to a student
+func (r *guardianRepository) CreateLink(link *GuardianStudent) error {
+	if err := r.db.Omit("Guardian", "Student").Create(link).Error; err != nil {
+		return fmt.Errorf("failed to link guardian to student: %w", err)
+	}
+	return nil
+}
+
+// GetLink retrieves the link between a guardian and a student
+func (r *guardianRepository) GetLink(guardianID, studentID uint) (*GuardianStudent, error) {
+	var link GuardianStudent
+	if err := r.db.Preload("Guardian").Preload("Student").
+		Where("guardian_id = ? AND student_id = ?", guardianID, studentID).
+		First(&link).Error; err != nil {
+		return nil, fmt.Errorf("failed to get guardian link: %w", err)
+	}
+	return &link, nil
+}
+
+// GetLinksByGuardian retrieves a guardian's student links with the students preloaded
+func (r *guardianRepository) GetLinksByGuardian(guardianID uint) ([]GuardianStudent, error) {
+	var links []GuardianStudent
+	if err := r.db.Preload("Student").Where("guardian_id = ?", guardianID).
+		Order("student_id ASC").Find(&links).Error; err != nil {
+		return nil, fmt.Errorf("failed to get links by guardian: %w", err)
+	}
+	return links, nil
+}
+
+// GetLinksByStudent retrieves a student's guardian links with the guardians preloaded
+func (r *guardianRepository) GetLinksByStudent(studentID uint) ([]GuardianStudent, error) {
+	var links []GuardianStudent
+	if err := r.db.Preload("Guardian").Where("student_id = ?", studentID).
+		Order("emergency_contact DESC, guardian_id ASC").Find(&links).Error; err != nil {
+		return nil, fmt.Errorf("failed to get links by student: %w", err)
+	}
+	return links, nil
+}
+
+// UpdateLink updates a link's relationship and permissions
+func (r *guardianRepository) UpdateLink(link *GuardianStudent) error {
+	if err := r.db.Omit("Guardian", "Student").Save(link).Error; err != nil {
+		return fmt.Errorf("failed to update guardian link: %w", err)
+	}
+	return nil
+}
+
+// DeleteLink soft deletes a link
+func (r *guardianRepository) DeleteLink(id uint) error {
+	if err := r.db.Delete(&GuardianStudent{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete guardian link: %w", err)
+	}
+	return nil
+}
+
+// GetChildAttendance retrieves a student's attendance records, newest first, optionally within a date range
+func (r *guardianRepository) GetChildAttendance(studentID uint, from, to *time.Time) ([]ChildAttendance, error) {
+	var result []ChildAttendance
+	query := r.db.Table("attendances").
+		Select("attendances.date, attendances.course_id, courses.name AS course_name, attendances.status").
+		Joins("JOIN courses ON courses.id = attendances.course_id").
+		Where("attendances.student_id = ? AND attendances.deleted_at IS NULL", studentID)
+	if from != nil {
+		query = query.Where("attendances.date >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("attendances.date <= ?", *to)
+	}
+	if err := query.Order("attendances.date DESC, courses.name ASC").Scan(&result).Error; err != nil {
+		return nil, fmt.Errorf("failed to get child attendance: %w", err)
+	}
+	return result, nil
+}
+
+// GetChildGrades retrieves a student's published exam results, newest exam first.
+// Draft grades are never returned.
+func (r *guardianRepository) GetChildGrades(studentID uint) ([]ChildGrade, error) {
+	var result []ChildGrade
+	if err := r.db.Model(&grade.Grade{}).
+		Select("grades.exam_id, exams.title AS exam_title, courses.name AS course_name, exams.exam_date, "+
+			"grades.score, exams.max_score, grades.published_at").
+		Joins("JOIN exams ON exams.id = grades.exam_id AND exams.deleted_at IS NULL").
+		Joins("JOIN courses ON courses.id = exams.course_id").
+		Where("grades.student_id = ? AND grades.status = ?", studentID, grade.GradePublished).
+		Order("exams.exam_date DESC").
+		Scan(&result).Error; err != nil {
+		return nil, fmt.Errorf("failed to get child grades: %w", err)
+	}
+	return result, nil
+}
+
+// GetChildHomework retrieves a student's outstanding homework (pending, missing or returned for revision), soonest due first
+func (r *guardianRepository) GetChildHomework(studentID uint) ([]ChildHomework, error) {
+	var result []ChildHomework
+	if err := r.db.Table("students_homework").
+		Select("students_homework.homework_id, homework.title, courses.name AS course_name, "+
+			"homework.due_date, students_homework.status").
+		Joins("JOIN homework ON homework.id = students_homework.homework_id AND homework.deleted_at IS NULL").
+		Joins("JOIN courses ON courses.id = homework.course_id").
+		Where("students_homework.student_id = ? AND students_homework.deleted_at IS NULL", studentID).
+		Where("students_homework.status IN ?", []students_homework.HomeworkStatus{
+			students_homework.HomeworkPending, students_homework.HomeworkMissing, students_homework.HomeworkReturned,
+		}).
+		Order("homework.due_date ASC").
+		Scan(&result).Error; err != nil {
+		return nil, fmt.Errorf("failed to get child homework: %w", err)
+	}
+	return result, nil
+}
+
+// GetChildExams retrieves exams in [from, until) for the courses a student is currently enrolled in
+func (r *guardianRepository) GetChildExams(studentID uint, from, until time.Time) ([]ChildExam, error) {
+	var result []ChildExam
+	if err := r.db.Table("exams").
+		Select("exams.id AS exam_id, exams.title, courses.name AS course_name, "+
+			"exams.exam_date, exams.duration, exams.max_score").
+		Joins("JOIN courses ON courses.id = exams.course_id").
+		Joins("JOIN student_courses ON student_courses.course_id = exams.course_id "+
+			"AND student_courses.student_id = ? AND student_courses.deleted_at IS NULL", studentID).
+		Where("exams.deleted_at IS NULL AND exams.exam_date >= ? AND exams.exam_date < ?", from, until).
+		Order("exams.exam_date ASC").
+		Scan(&result).Error; err != nil {
+		return nil, fmt.Errorf("failed to get child exams: %w", err)
+	}
+	return result, nil
+}
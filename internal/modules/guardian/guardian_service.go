@@ -0,0 +1,480 @@
+package guardian
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"school_management/internal/modules/student"
+)
+
+// ErrNotPermitted is returned when a guardian's link to a student does not allow a view
+var ErrNotPermitted = errors.New("permission denied")
+
+// DefaultExamWindowDays is how far ahead a guardian's upcoming exams are listed by default
+const DefaultExamWindowDays = 30
+
+// GuardianService defines the business logic interface
+type GuardianService interface {
+	Create(req *CreateGuardianRequest) (*GuardianResponse, error)
+	GetByID(id uint) (*GuardianResponse, error)
+	GetAll(limit, offset int) ([]GuardianResponse, error)
+	Update(id uint, req *UpdateGuardianRequest) (*GuardianResponse, error)
+	Delete(id uint) error
+
+	// Student links
+	LinkStudent(guardianID uint, req *LinkStudentRequest) (*LinkResponse, error)
+	UpdateLink(guardianID, studentID uint, req *UpdateLinkRequest) (*LinkResponse, error)
+	UnlinkStudent(guardianID, studentID uint) error
+	GetStudentGuardians(studentID uint) ([]LinkResponse, error)
+
+	// Guardian-scoped views of their own children
+	GetChildren(guardianID uint) ([]LinkResponse, error)
+	GetChildAttendance(guardianID, studentID uint, from, to *time.Time) ([]ChildAttendanceResponse, error)
+	GetChildGrades(guardianID, studentID uint) ([]ChildGradeResponse, error)
+	GetChildHomework(guardianID, studentID uint) ([]ChildHomeworkResponse, error)
+	GetChildExams(guardianID, studentID uint, days int) ([]ChildExamResponse, error)
+}
+
+// guardianService implements GuardianService
+type guardianService struct {
+	repo        GuardianRepository
+	studentRepo student.StudentRepository
+}
+
+// NewGuardianService creates a new guardian service with DI
+func NewGuardianService(repo GuardianRepository, studentRepo student.StudentRepository) GuardianService {
+	return &guardianService{repo: repo, studentRepo: studentRepo}
+}
+
+// Create creates a new guardian
+func (s *guardianService) Create(req *CreateGuardianRequest) (*GuardianResponse, error) {
+	// Validate
+	if err := s.validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	if existing, _ := s.repo.GetByEmail(req.Email); existing != nil {
+		return nil, fmt.Errorf("a guardian with this email already exists")
+	}
+
+	// Map DTO to Model
+	guardian := &Guardian{
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Email:     req.Email,
+		Phone:     req.Phone,
+		AltPhone:  req.AltPhone,
+		Address:   req.Address,
+	}
+
+	// Create via repository
+	if err := s.repo.Create(guardian); err != nil {
+		return nil, fmt.Errorf("failed to create guardian: %w", err)
+	}
+
+	return s.toResponseDTO(guardian), nil
+}
+
+// GetByID retrieves a guardian with their linked students
+func (s *guardianService) GetByID(id uint) (*GuardianResponse, error) {
+	guardian, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("guardian not found: %w", err)
+	}
+
+	links, err := s.repo.GetLinksByGuardian(id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := s.toResponseDTO(guardian)
+	resp.Students = s.toLinkResponseList(links)
+	return resp, nil
+}
+
+// GetAll retrieves all guardians with pagination
+func (s *guardianService) GetAll(limit, offset int) ([]GuardianResponse, error) {
+	// Validate pagination
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	guardians, err := s.repo.GetAll(limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guardians: %w", err)
+	}
+
+	responses := make([]GuardianResponse, len(guardians))
+	for i := range guardians {
+		responses[i] = *s.toResponseDTO(&guardians[i])
+	}
+	return responses, nil
+}
+
+// Update updates a guardian's contact details
+func (s *guardianService) Update(id uint, req *UpdateGuardianRequest) (*GuardianResponse, error) {
+	// Validate
+	if err := s.validateUpdateRequest(req); err != nil {
+		return nil, err
+	}
+
+	// Get existing
+	guardian, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("guardian not found: %w", err)
+	}
+
+	// Update fields
+	if req.FirstName != "" {
+		guardian.FirstName = req.FirstName
+	}
+	if req.LastName != "" {
+		guardian.LastName = req.LastName
+	}
+	if req.Email != "" && req.Email != guardian.Email {
+		if existing, _ := s.repo.GetByEmail(req.Email); existing != nil {
+			return nil, fmt.Errorf("a guardian with this email already exists")
+		}
+		guardian.Email = req.Email
+	}
+	if req.Phone != "" {
+		guardian.Phone = req.Phone
+	}
+	if req.AltPhone != "" {
+		guardian.AltPhone = req.AltPhone
+	}
+	if req.Address != "" {
+		guardian.Address = req.Address
+	}
+
+	// Save
+	if err := s.repo.Update(guardian); err != nil {
+		return nil, fmt.Errorf("failed to update guardian: %w", err)
+	}
+
+	return s.toResponseDTO(guardian), nil
+}
+
+// Delete deletes a guardian and unlinks them from their students
+func (s *guardianService) Delete(id uint) error {
+	// Check if exists
+	if _, err := s.repo.GetByID(id); err != nil {
+		return fmt.Errorf("guardian not found: %w", err)
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete guardian: %w", err)
+	}
+	return nil
+}
+
+// LinkStudent links a guardian to a student
+func (s *guardianService) LinkStudent(guardianID uint, req *LinkStudentRequest) (*LinkResponse, error) {
+	// Validate
+	if _, err := s.repo.GetByID(guardianID); err != nil {
+		return nil, fmt.Errorf("guardian not found: %w", err)
+	}
+	if _, err := s.studentRepo.GetByID(req.StudentID); err != nil {
+		return nil, fmt.Errorf("student not found: %w", err)
+	}
+	if existing, _ := s.repo.GetLink(guardianID, req.StudentID); existing != nil {
+		return nil, fmt.Errorf("guardian is already linked to this student")
+	}
+
+	// Map DTO to Model
+	link := &GuardianStudent{
+		GuardianID:       guardianID,
+		StudentID:        req.StudentID,
+		Relationship:     Relationship(req.Relationship),
+		HasCustody:       flagOr(req.HasCustody, true),
+		MayPickUp:        flagOr(req.MayPickUp, true),
+		ReceivesReports:  flagOr(req.ReceivesReports, true),
+		EmergencyContact: flagOr(req.EmergencyContact, false),
+	}
+
+	// Create via repository
+	if err := s.repo.CreateLink(link); err != nil {
+		return nil, err
+	}
+
+	created, err := s.repo.GetLink(guardianID, req.StudentID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toLinkResponse(created), nil
+}
+
+// UpdateLink changes a guardian's relationship to a student or their permissions
+func (s *guardianService) UpdateLink(guardianID, studentID uint, req *UpdateLinkRequest) (*LinkResponse, error) {
+	// Get existing
+	link, err := s.repo.GetLink(guardianID, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("guardian link not found: %w", err)
+	}
+
+	// Update fields
+	if req.Relationship != "" {
+		link.Relationship = Relationship(req.Relationship)
+	}
+	link.HasCustody = flagOr(req.HasCustody, link.HasCustody)
+	link.MayPickUp = flagOr(req.MayPickUp, link.MayPickUp)
+	link.ReceivesReports = flagOr(req.ReceivesReports, link.ReceivesReports)
+	link.EmergencyContact = flagOr(req.EmergencyContact, link.EmergencyContact)
+
+	// Save
+	if err := s.repo.UpdateLink(link); err != nil {
+		return nil, err
+	}
+	return s.toLinkResponse(link), nil
+}
+
+// UnlinkStudent removes the link between a guardian and a student
+func (s *guardianService) UnlinkStudent(guardianID, studentID uint) error {
+	link, err := s.repo.GetLink(guardianID, studentID)
+	if err != nil {
+		return fmt.Errorf("guardian link not found: %w", err)
+	}
+	return s.repo.DeleteLink(link.ID)
+}
+
+// GetStudentGuardians lists a student's guardians with their permissions, emergency contacts first
+func (s *guardianService) GetStudentGuardians(studentID uint) ([]LinkResponse, error) {
+	if _, err := s.studentRepo.GetByID(studentID); err != nil {
+		return nil, fmt.Errorf("student not found: %w", err)
+	}
+
+	links, err := s.repo.GetLinksByStudent(studentID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toLinkResponseList(links), nil
+}
+
+// GetChildren lists the students a guardian is linked to
+func (s *guardianService) GetChildren(guardianID uint) ([]LinkResponse, error) {
+	if _, err := s.repo.GetByID(guardianID); err != nil {
+		return nil, fmt.Errorf("guardian not found: %w", err)
+	}
+
+	links, err := s.repo.GetLinksByGuardian(guardianID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toLinkResponseList(links), nil
+}
+
+// GetChildAttendance retrieves a linked child's attendance, optionally within a date range
+func (s *guardianService) GetChildAttendance(guardianID, studentID uint, from, to *time.Time) ([]ChildAttendanceResponse, error) {
+	if err := s.authorize(guardianID, studentID, receivesReports); err != nil {
+		return nil, err
+	}
+
+	records, err := s.repo.GetChildAttendance(studentID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]ChildAttendanceResponse, len(records))
+	for i, r := range records {
+		responses[i] = ChildAttendanceResponse{
+			Date:       r.Date,
+			CourseID:   r.CourseID,
+			CourseName: r.CourseName,
+			Status:     r.Status,
+		}
+	}
+	return responses, nil
+}
+
+// GetChildGrades retrieves a linked child's published exam results
+func (s *guardianService) GetChildGrades(guardianID, studentID uint) ([]ChildGradeResponse, error) {
+	if err := s.authorize(guardianID, studentID, receivesReports); err != nil {
+		return nil, err
+	}
+
+	grades, err := s.repo.GetChildGrades(studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]ChildGradeResponse, len(grades))
+	for i, g := range grades {
+		var percent float64
+		if g.MaxScore > 0 {
+			percent = math.Round(g.Score/g.MaxScore*1000) / 10
+		}
+		responses[i] = ChildGradeResponse{
+			ExamID:      g.ExamID,
+			ExamTitle:   g.ExamTitle,
+			CourseName:  g.CourseName,
+			ExamDate:    g.ExamDate,
+			Score:       g.Score,
+			MaxScore:    g.MaxScore,
+			Percent:     percent,
+			PublishedAt: g.PublishedAt,
+		}
+	}
+	return responses, nil
+}
+
+// GetChildHomework retrieves a linked child's outstanding homework
+func (s *guardianService) GetChildHomework(guardianID, studentID uint) ([]ChildHomeworkResponse, error) {
+	if err := s.authorize(guardianID, studentID, hasCustody); err != nil {
+		return nil, err
+	}
+
+	homework, err := s.repo.GetChildHomework(studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	responses := make([]ChildHomeworkResponse, len(homework))
+	for i, h := range homework {
+		responses[i] = ChildHomeworkResponse{
+			HomeworkID: h.HomeworkID,
+			Title:      h.Title,
+			CourseName: h.CourseName,
+			DueDate:    h.DueDate,
+			Status:     h.Status,
+			Overdue:    h.DueDate.Before(now),
+		}
+	}
+	return responses, nil
+}
+
+// GetChildExams retrieves a linked child's exams in the next days
+func (s *guardianService) GetChildExams(guardianID, studentID uint, days int) ([]ChildExamResponse, error) {
+	if err := s.authorize(guardianID, studentID, hasCustody); err != nil {
+		return nil, err
+	}
+	if days <= 0 || days > 365 {
+		days = DefaultExamWindowDays
+	}
+
+	now := time.Now()
+	exams, err := s.repo.GetChildExams(studentID, now, now.AddDate(0, 0, days))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]ChildExamResponse, len(exams))
+	for i, e := range exams {
+		responses[i] = ChildExamResponse{
+			ExamID:     e.ExamID,
+			Title:      e.Title,
+			CourseName: e.CourseName,
+			ExamDate:   e.ExamDate,
+			Duration:   e.Duration,
+			MaxScore:   e.MaxScore,
+		}
+	}
+	return responses, nil
+}
+
+// authorize confirms the student is one of the guardian's children and that their link
+// permits the view. Any other student is reported as not found so the response does not
+// reveal whether they exist.
+func (s *guardianService) authorize(guardianID, studentID uint, permits linkPermission) error {
+	link, err := s.repo.GetLink(guardianID, studentID)
+	if err != nil {
+		return fmt.Errorf("student not found among guardian's children: %w", err)
+	}
+	if !permits.allows(link) {
+		return fmt.Errorf("%w: guardian's link to the student does not have %s set", ErrNotPermitted, permits.flag)
+	}
+	return nil
+}
+
+// linkPermission names the link flag a guardian-scoped view requires
+type linkPermission struct {
+	flag   string
+	allows func(link *GuardianStudent) bool
+}
+
+var (
+	receivesReports = linkPermission{"receives_reports", func(link *GuardianStudent) bool { return link.ReceivesReports }}
+	hasCustody      = linkPermission{"has_custody", func(link *GuardianStudent) bool { return link.HasCustody }}
+)
+
+func flagOr(flag *bool, fallback bool) bool {
+	if flag == nil {
+		return fallback
+	}
+	return *flag
+}
+
+// Validation methods
+func (s *guardianService) validateCreateRequest(req *CreateGuardianRequest) error {
+	if strings.TrimSpace(req.FirstName) == "" {
+		return fmt.Errorf("first name is required")
+	}
+	if strings.TrimSpace(req.LastName) == "" {
+		return fmt.Errorf("last name is required")
+	}
+	if strings.TrimSpace(req.Email) == "" {
+		return fmt.Errorf("email is required")
+	}
+	if !s.isValidEmail(req.Email) {
+		return fmt.Errorf("invalid email format")
+	}
+	return nil
+}
+
+func (s *guardianService) validateUpdateRequest(req *UpdateGuardianRequest) error {
+	if req.Email != "" && !s.isValidEmail(req.Email) {
+		return fmt.Errorf("invalid email format")
+	}
+	return nil
+}
+
+func (s *guardianService) isValidEmail(email string) bool {
+	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+	return emailRegex.MatchString(email)
+}
+
+// DTO mapping methods
+func (s *guardianService) toResponseDTO(guardian *Guardian) *GuardianResponse {
+	return &GuardianResponse{
+		ID:        guardian.ID,
+		FirstName: guardian.FirstName,
+		LastName:  guardian.LastName,
+		Email:     guardian.Email,
+		Phone:     guardian.Phone,
+		AltPhone:  guardian.AltPhone,
+		Address:   guardian.Address,
+		CreatedAt: guardian.CreatedAt,
+		UpdatedAt: guardian.UpdatedAt,
+	}
+}
+
+func (s *guardianService) toLinkResponse(link *GuardianStudent) *LinkResponse {
+	return &LinkResponse{
+		ID:               link.ID,
+		GuardianID:       link.GuardianID,
+		GuardianName:     strings.TrimSpace(link.Guardian.FirstName + " " + link.Guardian.LastName),
+		GuardianPhone:    link.Guardian.Phone,
+		StudentID:        link.StudentID,
+		StudentName:      strings.TrimSpace(link.Student.FirstName + " " + link.Student.LastName),
+		Relationship:     string(link.Relationship),
+		HasCustody:       link.HasCustody,
+		MayPickUp:        link.MayPickUp,
+		ReceivesReports:  link.ReceivesReports,
+		EmergencyContact: link.EmergencyContact,
+		CreatedAt:        link.CreatedAt,
+	}
+}
+
+func (s *guardianService) toLinkResponseList(links []GuardianStudent) []LinkResponse {
+	responses := make([]LinkResponse, len(links))
+	for i := range links {
+		responses[i] = *s.toLinkResponse(&links[i])
+	}
+	return responses
+}
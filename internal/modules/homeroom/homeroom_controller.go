@@ -0,0 +1,339 @@
+package homeroom
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"school_management/internal/export"
+)
+
+// HomeroomController handles HTTP requests for grade levels and homerooms
+type HomeroomController struct {
+	service HomeroomService
+}
+
+// NewHomeroomController creates a new homeroom controller
+func NewHomeroomController(service HomeroomService) *HomeroomController {
+	return &HomeroomController{service: service}
+}
+
+// CreateGradeLevel creates a new grade level
+func (c *HomeroomController) CreateGradeLevel(ctx *gin.Context) {
+	var req CreateGradeLevelRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.CreateGradeLevel(&req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// GetGradeLevelByID retrieves a grade level by ID
+func (c *HomeroomController) GetGradeLevelByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetGradeLevelByID(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetGradeLevels retrieves all grade levels
+func (c *HomeroomController) GetGradeLevels(ctx *gin.Context) {
+	resp, err := c.service.GetGradeLevels()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// UpdateGradeLevel updates a grade level
+func (c *HomeroomController) UpdateGradeLevel(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	var req UpdateGradeLevelRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.UpdateGradeLevel(uint(id), &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// DeleteGradeLevel deletes a grade level
+func (c *HomeroomController) DeleteGradeLevel(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	if err := c.service.DeleteGradeLevel(uint(id)); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "grade level deleted successfully"})
+}
+
+// GetCohort lists the students currently at a grade level, optionally for ?academic_year=
+func (c *HomeroomController) GetCohort(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetCohort(uint(id), ctx.Query("academic_year"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// Create creates a new homeroom
+func (c *HomeroomController) Create(ctx *gin.Context) {
+	var req CreateHomeroomRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.Create(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// GetByID retrieves a homeroom by ID
+func (c *HomeroomController) GetByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetByID(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetAll retrieves homerooms, optionally filtered by ?academic_year= and ?grade_level_id=
+func (c *HomeroomController) GetAll(ctx *gin.Context) {
+	filter := HomeroomFilter{AcademicYear: ctx.Query("academic_year")}
+	if raw := ctx.Query("grade_level_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid grade level ID"})
+			return
+		}
+		gradeLevelID := uint(id)
+		filter.GradeLevelID = &gradeLevelID
+	}
+
+	resp, err := c.service.GetAll(filter)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// Update updates a homeroom
+func (c *HomeroomController) Update(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	var req UpdateHomeroomRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.Update(uint(id), &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Delete deletes a homeroom
+func (c *HomeroomController) Delete(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	if err := c.service.Delete(uint(id)); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "homeroom deleted successfully"})
+}
+
+// AssignStudent places a student in a homeroom
+func (c *HomeroomController) AssignStudent(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	var req AssignStudentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.AssignStudent(uint(id), &req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// RemoveStudent takes a student out of a homeroom
+func (c *HomeroomController) RemoveStudent(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+	studentID, err := strconv.ParseUint(ctx.Param("studentId"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid student ID"})
+		return
+	}
+
+	if err := c.service.RemoveStudent(uint(id), uint(studentID)); err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "student removed from homeroom successfully"})
+}
+
+// GetRoster lists a homeroom's current students
+func (c *HomeroomController) GetRoster(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetRoster(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// GetStudentHistory lists every homeroom a student has been in
+func (c *HomeroomController) GetStudentHistory(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetStudentHistory(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// RegisterRoutes registers grade level and homeroom routes
+func (c *HomeroomController) RegisterRoutes(rg *gin.RouterGroup) {
+	levels := rg.Group("/grade-levels")
+	{
+		levels.POST("", c.CreateGradeLevel)
+		levels.GET("", c.GetGradeLevels)
+		levels.GET("/:id", c.GetGradeLevelByID)
+		levels.PUT("/:id", c.UpdateGradeLevel)
+		levels.DELETE("/:id", c.DeleteGradeLevel)
+		levels.GET("/:id/students", c.GetCohort)
+	}
+
+	homerooms := rg.Group("/homerooms")
+	{
+		homerooms.POST("", c.Create)
+		homerooms.GET("", c.GetAll)
+		homerooms.GET("/:id", c.GetByID)
+		homerooms.PUT("/:id", c.Update)
+		homerooms.DELETE("/:id", c.Delete)
+		homerooms.GET("/:id/students", c.GetRoster)
+		homerooms.POST("/:id/students", c.AssignStudent)
+		homerooms.DELETE("/:id/students/:studentId", c.RemoveStudent)
+	}
+
+	students := rg.Group("/students")
+	{
+		students.GET("/:id/homerooms", c.GetStudentHistory)
+	}
+}
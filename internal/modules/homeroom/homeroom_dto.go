@@ -0,0 +1,81 @@
+package homeroom
+
+import "time"
+
+// CreateGradeLevelRequest represents the request body for creating a grade level
+type CreateGradeLevelRequest struct {
+	Name        string `json:"name" binding:"required,min=1,max=50"`
+	Ordinal     int    `json:"ordinal" binding:"required,min=1"`
+	Description string `json:"description" binding:"omitempty,max=500"`
+}
+
+// UpdateGradeLevelRequest represents the request body for updating a grade level
+type UpdateGradeLevelRequest struct {
+	Name        string `json:"name" binding:"omitempty,min=1,max=50"`
+	Description string `json:"description" binding:"omitempty,max=500"`
+}
+
+// GradeLevelResponse represents the response body for grade level data
+type GradeLevelResponse struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Ordinal     int       `json:"ordinal"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CreateHomeroomRequest represents the request body for creating a homeroom
+type CreateHomeroomRequest struct {
+	Name         string `json:"name" binding:"required,min=1,max=50"`
+	AcademicYear string `json:"academic_year" binding:"required"` // Format: YYYY-YYYY
+	GradeLevelID uint   `json:"grade_level_id" binding:"required"`
+	TeacherID    uint   `json:"teacher_id" binding:"required"`
+	Room         string `json:"room" binding:"omitempty,max=50"`
+	Capacity     int    `json:"capacity" binding:"omitempty,min=0"`
+}
+
+// UpdateHomeroomRequest represents the request body for updating a homeroom
+type UpdateHomeroomRequest struct {
+	Name      string `json:"name" binding:"omitempty,min=1,max=50"`
+	TeacherID uint   `json:"teacher_id" binding:"omitempty"`
+	Room      string `json:"room" binding:"omitempty,max=50"`
+	Capacity  *int   `json:"capacity" binding:"omitempty,min=0"`
+}
+
+// HomeroomResponse represents the response body for homeroom data
+type HomeroomResponse struct {
+	ID             uint      `json:"id"`
+	Name           string    `json:"name"`
+	AcademicYear   string    `json:"academic_year"`
+	GradeLevelID   uint      `json:"grade_level_id"`
+	GradeLevelName string    `json:"grade_level_name,omitempty"`
+	TeacherID      uint      `json:"teacher_id"`
+	TeacherName    string    `json:"teacher_name,omitempty"`
+	Room           string    `json:"room"`
+	Capacity       int       `json:"capacity"`
+	MemberCount    int64     `json:"member_count"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// AssignStudentRequest represents the request body for placing a student in a homeroom.
+// Any homeroom the student is currently in is ended the day before StartDate.
+type AssignStudentRequest struct {
+	StudentID uint   `json:"student_id" binding:"required"`
+	StartDate string `json:"start_date" binding:"omitempty"` // Format: YYYY-MM-DD, defaults to today
+}
+
+// MembershipResponse represents a student's membership of a homeroom
+type MembershipResponse struct {
+	ID             uint       `json:"id"`
+	StudentID      uint       `json:"student_id"`
+	StudentName    string     `json:"student_name,omitempty"`
+	HomeroomID     uint       `json:"homeroom_id"`
+	HomeroomName   string     `json:"homeroom_name,omitempty"`
+	AcademicYear   string     `json:"academic_year,omitempty"`
+	GradeLevelID   uint       `json:"grade_level_id,omitempty"`
+	GradeLevelName string     `json:"grade_level_name,omitempty"`
+	StartDate      time.Time  `json:"start_date"`
+	EndDate        *time.Time `json:"end_date"`
+}
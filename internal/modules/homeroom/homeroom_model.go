@@ -0,0 +1,85 @@
+package homeroom
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"school_management/internal/modules/student"
+	"school_management/internal/modules/teacher"
+)
+
+// GradeLevel is a year level such as Grade 1 to Grade 12. Ordinal orders the levels
+// and is what course eligibility ranges refer to.
+type GradeLevel struct {
+	gorm.Model
+	Name        string `gorm:"uniqueIndex;not null;size:50" json:"name"`
+	Ordinal     int    `gorm:"uniqueIndex;not null" json:"ordinal"`
+	Description string `gorm:"type:text" json:"description"`
+}
+
+// TableName specifies the table name for the GradeLevel model
+func (GradeLevel) TableName() string {
+	return "grade_levels"
+}
+
+// Homeroom is a class of students at one grade level for an academic year, with a homeroom teacher
+type Homeroom struct {
+	gorm.Model
+	Name         string `gorm:"not null;size:50;uniqueIndex:idx_homeroom_year" json:"name"`
+	AcademicYear string `gorm:"not null;size:9;uniqueIndex:idx_homeroom_year;comment:e.g. 2025-2026" json:"academic_year"`
+	GradeLevelID uint   `gorm:"not null;index" json:"grade_level_id"`
+	TeacherID    uint   `gorm:"not null;index" json:"teacher_id"`
+	Room         string `gorm:"size:50" json:"room"`
+	Capacity     int    `gorm:"not null;default:0;comment:0 means unlimited" json:"capacity"`
+
+	// Belongs To relationships
+	GradeLevel GradeLevel      `gorm:"foreignKey:GradeLevelID" json:"grade_level,omitempty"`
+	Teacher    teacher.Teacher `gorm:"foreignKey:TeacherID" json:"teacher,omitempty"`
+}
+
+// TableName specifies the table name for the Homeroom model
+func (Homeroom) TableName() string {
+	return "homerooms"
+}
+
+// Membership places a student in a homeroom from StartDate until EndDate.
+// A student has at most one open membership (no EndDate) at a time.
+type Membership struct {
+	gorm.Model
+	StudentID  uint       `gorm:"not null;index" json:"student_id"`
+	HomeroomID uint       `gorm:"not null;index" json:"homeroom_id"`
+	StartDate  time.Time  `gorm:"type:date;not null" json:"start_date"`
+	EndDate    *time.Time `gorm:"type:date" json:"end_date"`
+
+	// Belongs To relationships
+	Student  student.Student `gorm:"foreignKey:StudentID" json:"student,omitempty"`
+	Homeroom Homeroom        `gorm:"foreignKey:HomeroomID" json:"homeroom,omitempty"`
+}
+
+// TableName specifies the table name for the Membership model
+func (Membership) TableName() string {
+	return "homeroom_memberships"
+}
+
+// CurrentMembers restricts a query to rows whose student is currently in a homeroom.
+// column names the query's student ID column, e.g. "attendances.student_id".
+func CurrentMembers(column string, homeroomID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		date := today()
+		return db.Where(column+" IN (SELECT student_id FROM homeroom_memberships "+
+			"WHERE homeroom_id = ? AND "+currentClause("")+" AND deleted_at IS NULL)", homeroomID, date, date)
+	}
+}
+
+// today is the date membership start and end dates are compared against
+func today() string {
+	return time.Now().UTC().Format("2006-01-02")
+}
+
+// currentClause matches memberships that have started and have not ended; EndDate is the last
+// day in the homeroom. It takes today's date twice. prefix qualifies the columns, e.g.
+// "homeroom_memberships.".
+func currentClause(prefix string) string {
+	return prefix + "start_date <= ? AND (" + prefix + "end_date IS NULL OR " + prefix + "end_date >= ?)"
+}
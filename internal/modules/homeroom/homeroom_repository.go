@@ -0,0 +1,284 @@
+package homeroom
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// HomeroomFilter narrows a homeroom listing
+type HomeroomFilter struct {
+	AcademicYear string
+	GradeLevelID *uint
+}
+
+// HomeroomRepository defines the interface for grade level and homeroom data access
+type HomeroomRepository interface {
+	// Grade levels
+	CreateGradeLevel(level *GradeLevel) error
+	GetGradeLevelByID(id uint) (*GradeLevel, error)
+	GetGradeLevels() ([]GradeLevel, error)
+	UpdateGradeLevel(level *GradeLevel) error
+	DeleteGradeLevel(id uint) error
+	CountHomeroomsByGradeLevel(gradeLevelID uint) (int64, error)
+
+	// Homerooms
+	Create(homeroom *Homeroom) error
+	GetByID(id uint) (*Homeroom, error)
+	GetAll(filter HomeroomFilter) ([]Homeroom, error)
+	Update(homeroom *Homeroom) error
+	Delete(id uint) error
+
+	// Memberships
+	GetMemberCounts(homeroomIDs []uint) (map[uint]int64, error)
+	GetCurrentMembership(studentID uint) (*Membership, error)
+	GetOpenMembership(studentID uint) (*Membership, error)
+	GetYearMembership(studentID uint, academicYear string) (*Membership, error)
+	AssignStudent(previous, membership *Membership) error
+	EndMembership(membership *Membership) error
+	GetRoster(homeroomID uint) ([]Membership, error)
+	GetCohort(gradeLevelID uint, academicYear string) ([]Membership, error)
+	GetMembershipsByStudent(studentID uint) ([]Membership, error)
+}
+
+// homeroomRepository implements HomeroomRepository
+type homeroomRepository struct {
+	db *gorm.DB
+}
+
+// NewHomeroomRepository creates a new homeroom repository with dependency injection
+func NewHomeroomRepository(db *gorm.DB) HomeroomRepository {
+	return &homeroomRepository{db: db}
+}
+
+// CreateGradeLevel creates a new grade level
+func (r *homeroomRepository) CreateGradeLevel(level *GradeLevel) error {
+	if err := r.db.Create(level).Error; err != nil {
+		return fmt.Errorf("failed to create grade level: %w", err)
+	}
+	return nil
+}
+
+// GetGradeLevelByID retrieves a grade level by ID
+func (r *homeroomRepository) GetGradeLevelByID(id uint) (*GradeLevel, error) {
+	var level GradeLevel
+	if err := r.db.First(&level, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get grade level: %w", err)
+	}
+	return &level, nil
+}
+
+// GetGradeLevels retrieves all grade levels in order
+func (r *homeroomRepository) GetGradeLevels() ([]GradeLevel, error) {
+	var levels []GradeLevel
+	if err := r.db.Order("ordinal ASC").Find(&levels).Error; err != nil {
+		return nil, fmt.Errorf("failed to get grade levels: %w", err)
+	}
+	return levels, nil
+}
+
+// UpdateGradeLevel updates a grade level
+func (r *homeroomRepository) UpdateGradeLevel(level *GradeLevel) error {
+	if err := r.db.Save(level).Error; err != nil {
+		return fmt.Errorf("failed to update grade level: %w", err)
+	}
+	return nil
+}
+
+// DeleteGradeLevel soft deletes a grade level
+func (r *homeroomRepository) DeleteGradeLevel(id uint) error {
+	if err := r.db.Delete(&GradeLevel{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete grade level: %w", err)
+	}
+	return nil
+}
+
+// CountHomeroomsByGradeLevel counts the homerooms at a grade level
+func (r *homeroomRepository) CountHomeroomsByGradeLevel(gradeLevelID uint) (int64, error) {
+	var count int64
+	if err := r.db.Model(&Homeroom{}).Where("grade_level_id = ?", gradeLevelID).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count homerooms: %w", err)
+	}
+	return count, nil
+}
+
+// Create creates a new homeroom
+func (r *homeroomRepository) Create(homeroom *Homeroom) error {
+	if err := r.db.Omit("GradeLevel", "Teacher").Create(homeroom).Error; err != nil {
+		return fmt.Errorf("failed to create homeroom: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a homeroom with its grade level and teacher
+func (r *homeroomRepository) GetByID(id uint) (*Homeroom, error) {
+	var homeroom Homeroom
+	if err := r.db.Preload("GradeLevel").Preload("Teacher").First(&homeroom, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get homeroom: %w", err)
+	}
+	return &homeroom, nil
+}
+
+// GetAll retrieves homerooms, optionally for one academic year or grade level
+func (r *homeroomRepository) GetAll(filter HomeroomFilter) ([]Homeroom, error) {
+	var homerooms []Homeroom
+	query := r.db.Preload("GradeLevel").Preload("Teacher")
+	if filter.AcademicYear != "" {
+		query = query.Where("academic_year = ?", filter.AcademicYear)
+	}
+	if filter.GradeLevelID != nil {
+		query = query.Where("grade_level_id = ?", *filter.GradeLevelID)
+	}
+	if err := query.Order("academic_year DESC, name ASC").Find(&homerooms).Error; err != nil {
+		return nil, fmt.Errorf("failed to get homerooms: %w", err)
+	}
+	return homerooms, nil
+}
+
+// Update updates a homeroom
+func (r *homeroomRepository) Update(homeroom *Homeroom) error {
+	if err := r.db.Omit("GradeLevel", "Teacher").Save(homeroom).Error; err != nil {
+		return fmt.Errorf("failed to update homeroom: %w", err)
+	}
+	return nil
+}
+
+// Delete soft deletes a homeroom
+func (r *homeroomRepository) Delete(id uint) error {
+	if err := r.db.Delete(&Homeroom{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete homeroom: %w", err)
+	}
+	return nil
+}
+
+// GetMemberCounts counts the current members of each homeroom
+func (r *homeroomRepository) GetMemberCounts(homeroomIDs []uint) (map[uint]int64, error) {
+	counts := make(map[uint]int64, len(homeroomIDs))
+	if len(homeroomIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		HomeroomID uint
+		Members    int64
+	}
+	if err := r.db.Model(&Membership{}).
+		Select("homeroom_id, COUNT(*) AS members").
+		Where("homeroom_id IN ? AND end_date IS NULL", homeroomIDs).
+		Group("homeroom_id").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count homeroom members: %w", err)
+	}
+	for _, row := range rows {
+		counts[row.HomeroomID] = row.Members
+	}
+	return counts, nil
+}
+
+// GetCurrentMembership retrieves the membership a student is in today with its homeroom and grade level
+func (r *homeroomRepository) GetCurrentMembership(studentID uint) (*Membership, error) {
+	var membership Membership
+	date := today()
+	if err := r.db.Preload("Homeroom.GradeLevel").
+		Where("student_id = ?", studentID).
+		Where(currentClause(""), date, date).
+		Order("start_date DESC, id DESC").
+		First(&membership).Error; err != nil {
+		return nil, fmt.Errorf("failed to get current homeroom: %w", err)
+	}
+	return &membership, nil
+}
+
+// GetYearMembership retrieves a student's latest membership in a homeroom of an academic year,
+// with its homeroom and grade level
+func (r *homeroomRepository) GetYearMembership(studentID uint, academicYear string) (*Membership, error) {
+	var membership Membership
+	if err := r.db.Preload("Homeroom.GradeLevel").
+		Joins("JOIN homerooms ON homerooms.id = homeroom_memberships.homeroom_id AND homerooms.deleted_at IS NULL").
+		Where("homeroom_memberships.student_id = ? AND homerooms.academic_year = ?", studentID, academicYear).
+		Order("homeroom_memberships.start_date DESC, homeroom_memberships.id DESC").
+		First(&membership).Error; err != nil {
+		return nil, fmt.Errorf("failed to get homeroom for %s: %w", academicYear, err)
+	}
+	return &membership, nil
+}
+
+// GetOpenMembership retrieves a student's open membership, which may not have started yet
+func (r *homeroomRepository) GetOpenMembership(studentID uint) (*Membership, error) {
+	var membership Membership
+	if err := r.db.Preload("Homeroom.GradeLevel").
+		Where("student_id = ? AND end_date IS NULL", studentID).
+		First(&membership).Error; err != nil {
+		return nil, fmt.Errorf("failed to get open homeroom: %w", err)
+	}
+	return &membership, nil
+}
+
+// AssignStudent ends the student's previous membership, if any, and starts the new one in one transaction
+func (r *homeroomRepository) AssignStudent(previous, membership *Membership) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if previous != nil {
+			if err := tx.Model(previous).Update("end_date", previous.EndDate).Error; err != nil {
+				return fmt.Errorf("failed to end previous homeroom: %w", err)
+			}
+		}
+		if err := tx.Omit("Student", "Homeroom").Create(membership).Error; err != nil {
+			return fmt.Errorf("failed to assign homeroom: %w", err)
+		}
+		return nil
+	})
+}
+
+// EndMembership records a membership's end date
+func (r *homeroomRepository) EndMembership(membership *Membership) error {
+	if err := r.db.Model(membership).Update("end_date", membership.EndDate).Error; err != nil {
+		return fmt.Errorf("failed to end homeroom membership: %w", err)
+	}
+	return nil
+}
+
+// GetRoster retrieves a homeroom's current members with the students preloaded
+func (r *homeroomRepository) GetRoster(homeroomID uint) ([]Membership, error) {
+	var memberships []Membership
+	date := today()
+	if err := r.db.Preload("Student").
+		Joins("JOIN students ON students.id = homeroom_memberships.student_id").
+		Where("homeroom_memberships.homeroom_id = ?", homeroomID).
+		Where(currentClause("homeroom_memberships."), date, date).
+		Order("students.last_name ASC, students.first_name ASC").
+		Find(&memberships).Error; err != nil {
+		return nil, fmt.Errorf("failed to get homeroom roster: %w", err)
+	}
+	return memberships, nil
+}
+
+// GetCohort retrieves the current members of every homeroom at a grade level, optionally for one academic year
+func (r *homeroomRepository) GetCohort(gradeLevelID uint, academicYear string) ([]Membership, error) {
+	var memberships []Membership
+	date := today()
+	query := r.db.Preload("Student").Preload("Homeroom").
+		Joins("JOIN homerooms ON homerooms.id = homeroom_memberships.homeroom_id AND homerooms.deleted_at IS NULL").
+		Joins("JOIN students ON students.id = homeroom_memberships.student_id").
+		Where("homerooms.grade_level_id = ?", gradeLevelID).
+		Where(currentClause("homeroom_memberships."), date, date)
+	if academicYear != "" {
+		query = query.Where("homerooms.academic_year = ?", academicYear)
+	}
+	if err := query.Order("homerooms.name ASC, students.last_name ASC, students.first_name ASC").
+		Find(&memberships).Error; err != nil {
+		return nil, fmt.Errorf("failed to get cohort: %w", err)
+	}
+	return memberships, nil
+}
+
+// GetMembershipsByStudent retrieves a student's homeroom history, newest first
+func (r *homeroomRepository) GetMembershipsByStudent(studentID uint) ([]Membership, error) {
+	var memberships []Membership
+	if err := r.db.Preload("Homeroom.GradeLevel").
+		Where("student_id = ?", studentID).
+		Order("start_date DESC, id DESC").
+		Find(&memberships).Error; err != nil {
+		return nil, fmt.Errorf("failed to get homeroom history: %w", err)
+	}
+	return memberships, nil
+}
@@ -0,0 +1,500 @@
+package homeroom
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"school_management/internal/modules/course"
+	"school_management/internal/modules/student"
+	"school_management/internal/modules/teacher"
+)
+
+// HomeroomService defines the business logic interface
+type HomeroomService interface {
+	// Grade levels
+	CreateGradeLevel(req *CreateGradeLevelRequest) (*GradeLevelResponse, error)
+	GetGradeLevelByID(id uint) (*GradeLevelResponse, error)
+	GetGradeLevels() ([]GradeLevelResponse, error)
+	UpdateGradeLevel(id uint, req *UpdateGradeLevelRequest) (*GradeLevelResponse, error)
+	DeleteGradeLevel(id uint) error
+	GetCohort(gradeLevelID uint, academicYear string) ([]MembershipResponse, error)
+
+	// Homerooms
+	Create(req *CreateHomeroomRequest) (*HomeroomResponse, error)
+	GetByID(id uint) (*HomeroomResponse, error)
+	GetAll(filter HomeroomFilter) ([]HomeroomResponse, error)
+	Update(id uint, req *UpdateHomeroomRequest) (*HomeroomResponse, error)
+	Delete(id uint) error
+
+	// Memberships
+	AssignStudent(homeroomID uint, req *AssignStudentRequest) (*MembershipResponse, error)
+	RemoveStudent(homeroomID, studentID uint) error
+	GetRoster(homeroomID uint) ([]MembershipResponse, error)
+	GetStudentHistory(studentID uint) ([]MembershipResponse, error)
+
+	// CheckEligibility returns an error when a course's grade level range excludes the student
+	CheckEligibility(studentID, courseID uint) error
+}
+
+// homeroomService implements HomeroomService
+type homeroomService struct {
+	repo        HomeroomRepository
+	studentRepo student.StudentRepository
+	teacherRepo teacher.TeacherRepository
+	courseRepo  course.CourseRepository
+}
+
+// NewHomeroomService creates a new homeroom service with DI
+func NewHomeroomService(repo HomeroomRepository, studentRepo student.StudentRepository, teacherRepo teacher.TeacherRepository, courseRepo course.CourseRepository) HomeroomService {
+	return &homeroomService{repo: repo, studentRepo: studentRepo, teacherRepo: teacherRepo, courseRepo: courseRepo}
+}
+
+// CreateGradeLevel creates a new grade level
+func (s *homeroomService) CreateGradeLevel(req *CreateGradeLevelRequest) (*GradeLevelResponse, error) {
+	// Validate
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, fmt.Errorf("grade level name is required")
+	}
+	if req.Ordinal < 1 {
+		return nil, fmt.Errorf("ordinal must be at least 1")
+	}
+
+	// Map DTO to Model
+	level := &GradeLevel{
+		Name:        req.Name,
+		Ordinal:     req.Ordinal,
+		Description: req.Description,
+	}
+
+	// Create via repository
+	if err := s.repo.CreateGradeLevel(level); err != nil {
+		return nil, err
+	}
+
+	return s.toGradeLevelResponse(level), nil
+}
+
+// GetGradeLevelByID retrieves a grade level by ID
+func (s *homeroomService) GetGradeLevelByID(id uint) (*GradeLevelResponse, error) {
+	level, err := s.repo.GetGradeLevelByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("grade level not found: %w", err)
+	}
+	return s.toGradeLevelResponse(level), nil
+}
+
+// GetGradeLevels retrieves all grade levels in order
+func (s *homeroomService) GetGradeLevels() ([]GradeLevelResponse, error) {
+	levels, err := s.repo.GetGradeLevels()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]GradeLevelResponse, len(levels))
+	for i := range levels {
+		responses[i] = *s.toGradeLevelResponse(&levels[i])
+	}
+	return responses, nil
+}
+
+// UpdateGradeLevel updates a grade level's name or description. The ordinal is fixed
+// because course eligibility ranges refer to it.
+func (s *homeroomService) UpdateGradeLevel(id uint, req *UpdateGradeLevelRequest) (*GradeLevelResponse, error) {
+	// Get existing
+	level, err := s.repo.GetGradeLevelByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("grade level not found: %w", err)
+	}
+
+	// Update fields
+	if req.Name != "" {
+		level.Name = req.Name
+	}
+	if req.Description != "" {
+		level.Description = req.Description
+	}
+
+	// Save
+	if err := s.repo.UpdateGradeLevel(level); err != nil {
+		return nil, err
+	}
+	return s.toGradeLevelResponse(level), nil
+}
+
+// DeleteGradeLevel deletes a grade level that no homeroom uses
+func (s *homeroomService) DeleteGradeLevel(id uint) error {
+	if _, err := s.repo.GetGradeLevelByID(id); err != nil {
+		return fmt.Errorf("grade level not found: %w", err)
+	}
+
+	count, err := s.repo.CountHomeroomsByGradeLevel(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("grade level is used by %d homerooms", count)
+	}
+
+	return s.repo.DeleteGradeLevel(id)
+}
+
+// GetCohort lists the students currently at a grade level, across its homerooms
+func (s *homeroomService) GetCohort(gradeLevelID uint, academicYear string) ([]MembershipResponse, error) {
+	level, err := s.repo.GetGradeLevelByID(gradeLevelID)
+	if err != nil {
+		return nil, fmt.Errorf("grade level not found: %w", err)
+	}
+
+	memberships, err := s.repo.GetCohort(gradeLevelID, academicYear)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := s.toMembershipResponseList(memberships)
+	for i := range responses {
+		responses[i].GradeLevelID = level.ID
+		responses[i].GradeLevelName = level.Name
+	}
+	return responses, nil
+}
+
+// Create creates a new homeroom
+func (s *homeroomService) Create(req *CreateHomeroomRequest) (*HomeroomResponse, error) {
+	// Validate
+	if err := s.validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetGradeLevelByID(req.GradeLevelID); err != nil {
+		return nil, fmt.Errorf("grade level not found: %w", err)
+	}
+	if _, err := s.teacherRepo.GetByID(req.TeacherID); err != nil {
+		return nil, fmt.Errorf("teacher not found: %w", err)
+	}
+
+	// Map DTO to Model
+	homeroom := &Homeroom{
+		Name:         req.Name,
+		AcademicYear: req.AcademicYear,
+		GradeLevelID: req.GradeLevelID,
+		TeacherID:    req.TeacherID,
+		Room:         req.Room,
+		Capacity:     req.Capacity,
+	}
+
+	// Create via repository
+	if err := s.repo.Create(homeroom); err != nil {
+		return nil, err
+	}
+
+	return s.GetByID(homeroom.ID)
+}
+
+// GetByID retrieves a homeroom with its member count
+func (s *homeroomService) GetByID(id uint) (*HomeroomResponse, error) {
+	homeroom, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("homeroom not found: %w", err)
+	}
+
+	counts, err := s.repo.GetMemberCounts([]uint{id})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := s.toResponseDTO(homeroom)
+	resp.MemberCount = counts[id]
+	return resp, nil
+}
+
+// GetAll retrieves homerooms, optionally for one academic year or grade level
+func (s *homeroomService) GetAll(filter HomeroomFilter) ([]HomeroomResponse, error) {
+	homerooms, err := s.repo.GetAll(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, len(homerooms))
+	for i, h := range homerooms {
+		ids[i] = h.ID
+	}
+	counts, err := s.repo.GetMemberCounts(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]HomeroomResponse, len(homerooms))
+	for i := range homerooms {
+		responses[i] = *s.toResponseDTO(&homerooms[i])
+		responses[i].MemberCount = counts[homerooms[i].ID]
+	}
+	return responses, nil
+}
+
+// Update updates a homeroom
+func (s *homeroomService) Update(id uint, req *UpdateHomeroomRequest) (*HomeroomResponse, error) {
+	// Get existing
+	homeroom, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("homeroom not found: %w", err)
+	}
+
+	// Update fields
+	if req.Name != "" {
+		homeroom.Name = req.Name
+	}
+	if req.TeacherID != 0 && req.TeacherID != homeroom.TeacherID {
+		if _, err := s.teacherRepo.GetByID(req.TeacherID); err != nil {
+			return nil, fmt.Errorf("teacher not found: %w", err)
+		}
+		homeroom.TeacherID = req.TeacherID
+	}
+	if req.Room != "" {
+		homeroom.Room = req.Room
+	}
+	if req.Capacity != nil {
+		homeroom.Capacity = *req.Capacity
+	}
+
+	// Save
+	if err := s.repo.Update(homeroom); err != nil {
+		return nil, err
+	}
+	return s.GetByID(id)
+}
+
+// Delete deletes a homeroom that has no current members
+func (s *homeroomService) Delete(id uint) error {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return fmt.Errorf("homeroom not found: %w", err)
+	}
+
+	counts, err := s.repo.GetMemberCounts([]uint{id})
+	if err != nil {
+		return err
+	}
+	if counts[id] > 0 {
+		return fmt.Errorf("homeroom still has %d students; move them first", counts[id])
+	}
+
+	return s.repo.Delete(id)
+}
+
+// AssignStudent places a student in a homeroom, ending the homeroom they are currently in
+func (s *homeroomService) AssignStudent(homeroomID uint, req *AssignStudentRequest) (*MembershipResponse, error) {
+	// Validate
+	start := time.Now().Truncate(24 * time.Hour)
+	if req.StartDate != "" {
+		date, err := time.Parse("2006-01-02", req.StartDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start date format (use YYYY-MM-DD): %w", err)
+		}
+		start = date
+	}
+
+	homeroom, err := s.repo.GetByID(homeroomID)
+	if err != nil {
+		return nil, fmt.Errorf("homeroom not found: %w", err)
+	}
+	st, err := s.studentRepo.GetByID(req.StudentID)
+	if err != nil {
+		return nil, fmt.Errorf("student not found: %w", err)
+	}
+	if st.Status != student.StatusActive && st.Status != student.StatusApplicant {
+		return nil, fmt.Errorf("student is %s; only active students and applicants can be placed in a homeroom", st.Status)
+	}
+
+	previous, _ := s.repo.GetOpenMembership(req.StudentID)
+	if previous != nil {
+		if previous.HomeroomID == homeroomID {
+			return nil, fmt.Errorf("student is already in this homeroom")
+		}
+		if !start.After(previous.StartDate) {
+			return nil, fmt.Errorf("start date must be after the current homeroom's start date (%s)",
+				previous.StartDate.Format("2006-01-02"))
+		}
+		end := start.AddDate(0, 0, -1)
+		previous.EndDate = &end
+	}
+
+	if homeroom.Capacity > 0 {
+		counts, err := s.repo.GetMemberCounts([]uint{homeroomID})
+		if err != nil {
+			return nil, err
+		}
+		if counts[homeroomID] >= int64(homeroom.Capacity) {
+			return nil, fmt.Errorf("homeroom is full (capacity %d)", homeroom.Capacity)
+		}
+	}
+
+	membership := &Membership{
+		StudentID:  req.StudentID,
+		HomeroomID: homeroomID,
+		StartDate:  start,
+	}
+
+	// Save
+	if err := s.repo.AssignStudent(previous, membership); err != nil {
+		return nil, err
+	}
+
+	membership.Student = *st
+	membership.Homeroom = *homeroom
+	return s.toMembershipResponse(membership), nil
+}
+
+// RemoveStudent ends a student's membership of a homeroom today
+func (s *homeroomService) RemoveStudent(homeroomID, studentID uint) error {
+	membership, err := s.repo.GetCurrentMembership(studentID)
+	if err != nil || membership.HomeroomID != homeroomID {
+		return fmt.Errorf("student is not in this homeroom")
+	}
+
+	today := time.Now().Truncate(24 * time.Hour)
+	membership.EndDate = &today
+	return s.repo.EndMembership(membership)
+}
+
+// GetRoster lists a homeroom's current students
+func (s *homeroomService) GetRoster(homeroomID uint) ([]MembershipResponse, error) {
+	homeroom, err := s.repo.GetByID(homeroomID)
+	if err != nil {
+		return nil, fmt.Errorf("homeroom not found: %w", err)
+	}
+
+	memberships, err := s.repo.GetRoster(homeroomID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range memberships {
+		memberships[i].Homeroom = *homeroom
+	}
+	return s.toMembershipResponseList(memberships), nil
+}
+
+// GetStudentHistory lists every homeroom a student has been in, newest first
+func (s *homeroomService) GetStudentHistory(studentID uint) ([]MembershipResponse, error) {
+	st, err := s.studentRepo.GetByID(studentID)
+	if err != nil {
+		return nil, fmt.Errorf("student not found: %w", err)
+	}
+
+	memberships, err := s.repo.GetMembershipsByStudent(studentID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range memberships {
+		memberships[i].Student = *st
+	}
+	return s.toMembershipResponseList(memberships), nil
+}
+
+// CheckEligibility returns an error when a course's grade level range excludes the student.
+// Courses without a range are open to every grade level. The grade level is the one of the
+// student's homeroom in the course's academic year, or of today's homeroom for a course without one.
+func (s *homeroomService) CheckEligibility(studentID, courseID uint) error {
+	c, err := s.courseRepo.GetByID(courseID)
+	if err != nil {
+		return fmt.Errorf("course not found: %w", err)
+	}
+	if !c.RestrictsGradeLevel() {
+		return nil
+	}
+
+	var membership *Membership
+	if c.AcademicYear != "" {
+		membership, err = s.repo.GetYearMembership(studentID, c.AcademicYear)
+		if err != nil {
+			return fmt.Errorf("course %s is limited to %s; student has no homeroom in %s and so no grade level",
+				c.Code, gradeRange(c), c.AcademicYear)
+		}
+	} else if membership, err = s.repo.GetCurrentMembership(studentID); err != nil {
+		return fmt.Errorf("course %s is limited to %s; student has no homeroom and so no grade level",
+			c.Code, gradeRange(c))
+	}
+	level := membership.Homeroom.GradeLevel
+	if !c.AllowsGradeLevel(level.Ordinal) {
+		return fmt.Errorf("course %s is limited to %s; student is in %s", c.Code, gradeRange(c), level.Name)
+	}
+	return nil
+}
+
+// gradeRange describes a course's grade level range by ordinal
+func gradeRange(c *course.Course) string {
+	switch {
+	case c.MinGradeLevel != nil && c.MaxGradeLevel != nil:
+		return fmt.Sprintf("grade levels %d-%d", *c.MinGradeLevel, *c.MaxGradeLevel)
+	case c.MinGradeLevel != nil:
+		return fmt.Sprintf("grade level %d and above", *c.MinGradeLevel)
+	default:
+		return fmt.Sprintf("grade level %d and below", *c.MaxGradeLevel)
+	}
+}
+
+// Validation methods
+func (s *homeroomService) validateCreateRequest(req *CreateHomeroomRequest) error {
+	if strings.TrimSpace(req.Name) == "" {
+		return fmt.Errorf("homeroom name is required")
+	}
+	if err := course.ValidateAcademicYear(req.AcademicYear); err != nil {
+		return err
+	}
+	if req.GradeLevelID == 0 {
+		return fmt.Errorf("grade level ID is required")
+	}
+	if req.TeacherID == 0 {
+		return fmt.Errorf("teacher ID is required")
+	}
+	return nil
+}
+
+// DTO mapping methods
+func (s *homeroomService) toGradeLevelResponse(level *GradeLevel) *GradeLevelResponse {
+	return &GradeLevelResponse{
+		ID:          level.ID,
+		Name:        level.Name,
+		Ordinal:     level.Ordinal,
+		Description: level.Description,
+		CreatedAt:   level.CreatedAt,
+		UpdatedAt:   level.UpdatedAt,
+	}
+}
+
+func (s *homeroomService) toResponseDTO(homeroom *Homeroom) *HomeroomResponse {
+	return &HomeroomResponse{
+		ID:             homeroom.ID,
+		Name:           homeroom.Name,
+		AcademicYear:   homeroom.AcademicYear,
+		GradeLevelID:   homeroom.GradeLevelID,
+		GradeLevelName: homeroom.GradeLevel.Name,
+		TeacherID:      homeroom.TeacherID,
+		TeacherName:    strings.TrimSpace(homeroom.Teacher.FirstName + " " + homeroom.Teacher.LastName),
+		Room:           homeroom.Room,
+		Capacity:       homeroom.Capacity,
+		CreatedAt:      homeroom.CreatedAt,
+		UpdatedAt:      homeroom.UpdatedAt,
+	}
+}
+
+func (s *homeroomService) toMembershipResponse(membership *Membership) *MembershipResponse {
+	return &MembershipResponse{
+		ID:             membership.ID,
+		StudentID:      membership.StudentID,
+		StudentName:    strings.TrimSpace(membership.Student.FirstName + " " + membership.Student.LastName),
+		HomeroomID:     membership.HomeroomID,
+		HomeroomName:   membership.Homeroom.Name,
+		AcademicYear:   membership.Homeroom.AcademicYear,
+		GradeLevelID:   membership.Homeroom.GradeLevelID,
+		GradeLevelName: membership.Homeroom.GradeLevel.Name,
+		StartDate:      membership.StartDate,
+		EndDate:        membership.EndDate,
+	}
+}
+
+func (s *homeroomService) toMembershipResponseList(memberships []Membership) []MembershipResponse {
+	responses := make([]MembershipResponse, len(memberships))
+	for i := range memberships {
+		responses[i] = *s.toMembershipResponse(&memberships[i])
+	}
+	return responses
+}
@@ -6,18 +6,22 @@ import (
 	"gorm.io/gorm"
 
 	"school_management/internal/modules/course"
+	"school_management/internal/modules/rubric"
 )
 
 type Homework struct {
 	gorm.Model
-	Title       string    `gorm:"not null;size:200" json:"title"`
-	Description string    `gorm:"type:text" json:"description"`
-	CourseID    uint      `gorm:"not null" json:"course_id"`
-	DueDate     time.Time `gorm:"type:timestamp;not null" json:"due_date"`
-	MaxScore    float64   `gorm:"not null;default:100" json:"max_score"`
+	Title            string    `gorm:"not null;size:200" json:"title"`
+	Description      string    `gorm:"type:text" json:"description"`
+	CourseID         uint      `gorm:"not null" json:"course_id"`
+	DueDate          time.Time `gorm:"type:timestamp;not null" json:"due_date"`
+	MaxScore         float64   `gorm:"not null;default:100" json:"max_score"`
+	MaxResubmissions int       `gorm:"not null;default:0;comment:Resubmissions allowed before the due date" json:"max_resubmissions"`
+	RubricID         *uint     `json:"rubric_id"`
 
-	// Belongs To relationship
-	Course course.Course `gorm:"foreignKey:CourseID" json:"course,omitempty"`
+	// Belongs To relationships
+	Course course.Course  `gorm:"foreignKey:CourseID" json:"course,omitempty"`
+	Rubric *rubric.Rubric `gorm:"foreignKey:RubricID" json:"rubric,omitempty"`
 }
 
 // TableName specifies the table name for the Homework model
@@ -2,8 +2,11 @@ package homework
 
 import (
 	"fmt"
+	"log"
 	"strings"
 	"time"
+
+	"school_management/internal/modules/rubric"
 )
 
 // HomeworkService defines the business logic interface
@@ -16,14 +19,21 @@ type HomeworkService interface {
 	Delete(id uint) error
 }
 
+// SubmissionAssigner creates the per-student submission rows for newly created homework
+type SubmissionAssigner interface {
+	AssignHomework(homeworkID, courseID uint) error
+}
+
 // homeworkService implements HomeworkService
 type homeworkService struct {
-	repo HomeworkRepository
+	repo       HomeworkRepository
+	assigner   SubmissionAssigner
+	rubricRepo rubric.RubricRepository
 }
 
 // NewHomeworkService creates a new homework service with DI
-func NewHomeworkService(repo HomeworkRepository) HomeworkService {
-	return &homeworkService{repo: repo}
+func NewHomeworkService(repo HomeworkRepository, assigner SubmissionAssigner, rubricRepo rubric.RubricRepository) HomeworkService {
+	return &homeworkService{repo: repo, assigner: assigner, rubricRepo: rubricRepo}
 }
 
 // Create creates a new homework assignment
@@ -41,11 +51,19 @@ func (s *homeworkService) Create(req *CreateHomeworkRequest) (*HomeworkResponse,
 
 	// Map DTO to Model
 	hw := &Homework{
-		Title:       req.Title,
-		Description: req.Description,
-		CourseID:    req.CourseID,
-		DueDate:     dueDate,
-		MaxScore:    req.MaxScore,
+		Title:            req.Title,
+		Description:      req.Description,
+		CourseID:         req.CourseID,
+		DueDate:          dueDate,
+		MaxScore:         req.MaxScore,
+		MaxResubmissions: req.MaxResubmissions,
+		RubricID:         req.RubricID,
+	}
+	if hw.RubricID != nil && *hw.RubricID == 0 {
+		hw.RubricID = nil
+	}
+	if err := s.validateRubric(hw.RubricID); err != nil {
+		return nil, err
 	}
 
 	// Create via repository
@@ -53,6 +71,11 @@ func (s *homeworkService) Create(req *CreateHomeworkRequest) (*HomeworkResponse,
 		return nil, fmt.Errorf("failed to create homework: %w", err)
 	}
 
+	// Assign to every enrolled student; the homework itself is already saved
+	if err := s.assigner.AssignHomework(hw.ID, hw.CourseID); err != nil {
+		log.Printf("⚠️ Homework %d created but not assigned to students: %v", hw.ID, err)
+	}
+
 	// Map Model to Response DTO
 	return s.toResponseDTO(hw), nil
 }
@@ -118,6 +141,19 @@ func (s *homeworkService) Update(id uint, req *UpdateHomeworkRequest) (*Homework
 	if req.MaxScore != 0 {
 		hw.MaxScore = req.MaxScore
 	}
+	if req.MaxResubmissions != nil {
+		hw.MaxResubmissions = *req.MaxResubmissions
+	}
+	if req.RubricID != nil {
+		if *req.RubricID == 0 {
+			hw.RubricID = nil
+		} else {
+			if err := s.validateRubric(req.RubricID); err != nil {
+				return nil, err
+			}
+			hw.RubricID = req.RubricID
+		}
+	}
 
 	// Save
 	if err := s.repo.Update(hw); err != nil {
@@ -154,6 +190,20 @@ func (s *homeworkService) validateCreateRequest(req *CreateHomeworkRequest) erro
 	if req.MaxScore <= 0 {
 		return fmt.Errorf("max score must be greater than 0")
 	}
+	if req.MaxResubmissions < 0 {
+		return fmt.Errorf("max resubmissions cannot be negative")
+	}
+	return nil
+}
+
+// validateRubric checks that a rubric to grade with exists
+func (s *homeworkService) validateRubric(rubricID *uint) error {
+	if rubricID == nil {
+		return nil
+	}
+	if _, err := s.rubricRepo.GetByID(*rubricID); err != nil {
+		return fmt.Errorf("rubric not found: %w", err)
+	}
 	return nil
 }
 
@@ -161,20 +211,25 @@ func (s *homeworkService) validateUpdateRequest(req *UpdateHomeworkRequest) erro
 	if req.MaxScore != 0 && req.MaxScore <= 0 {
 		return fmt.Errorf("max score must be greater than 0")
 	}
+	if req.MaxResubmissions != nil && *req.MaxResubmissions < 0 {
+		return fmt.Errorf("max resubmissions cannot be negative")
+	}
 	return nil
 }
 
 // DTO mapping methods
 func (s *homeworkService) toResponseDTO(hw *Homework) *HomeworkResponse {
 	return &HomeworkResponse{
-		ID:          hw.ID,
-		Title:       hw.Title,
-		Description: hw.Description,
-		CourseID:    hw.CourseID,
-		DueDate:     hw.DueDate,
-		MaxScore:    hw.MaxScore,
-		CreatedAt:   hw.CreatedAt,
-		UpdatedAt:   hw.UpdatedAt,
+		ID:               hw.ID,
+		Title:            hw.Title,
+		Description:      hw.Description,
+		CourseID:         hw.CourseID,
+		DueDate:          hw.DueDate,
+		MaxScore:         hw.MaxScore,
+		MaxResubmissions: hw.MaxResubmissions,
+		RubricID:         hw.RubricID,
+		CreatedAt:        hw.CreatedAt,
+		UpdatedAt:        hw.UpdatedAt,
 	}
 }
 
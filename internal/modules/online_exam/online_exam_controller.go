@@ -0,0 +1,291 @@
+package online_exam
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"school_management/internal/export"
+)
+
+// OnlineExamController handles HTTP requests for the question bank and online exam attempts
+type OnlineExamController struct {
+	service OnlineExamService
+}
+
+// NewOnlineExamController creates a new online exam controller
+func NewOnlineExamController(service OnlineExamService) *OnlineExamController {
+	return &OnlineExamController{service: service}
+}
+
+// CreateQuestion adds a question to the question bank
+func (c *OnlineExamController) CreateQuestion(ctx *gin.Context) {
+	var req CreateQuestionRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.CreateQuestion(&req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// GetQuestion retrieves a question by ID
+func (c *OnlineExamController) GetQuestion(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetQuestion(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetQuestionsByCourse retrieves a course's question bank
+func (c *OnlineExamController) GetQuestionsByCourse(ctx *gin.Context) {
+	courseID, err := strconv.ParseUint(ctx.Param("courseId"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid course ID"})
+		return
+	}
+
+	resp, err := c.service.GetQuestionsByCourse(uint(courseID))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// UpdateQuestion updates a question
+func (c *OnlineExamController) UpdateQuestion(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	var req UpdateQuestionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.UpdateQuestion(uint(id), &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// DeleteQuestion deletes a question
+func (c *OnlineExamController) DeleteQuestion(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	if err := c.service.DeleteQuestion(uint(id)); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "question deleted successfully"})
+}
+
+// SetPaper sets the questions that make up an exam's paper
+func (c *OnlineExamController) SetPaper(ctx *gin.Context) {
+	examID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid exam ID"})
+		return
+	}
+
+	var req SetPaperRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.SetPaper(uint(examID), &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetPaper retrieves an exam's paper
+func (c *OnlineExamController) GetPaper(ctx *gin.Context) {
+	examID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid exam ID"})
+		return
+	}
+
+	resp, err := c.service.GetPaper(uint(examID))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// StartAttempt starts or resumes a student's exam attempt
+func (c *OnlineExamController) StartAttempt(ctx *gin.Context) {
+	examID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid exam ID"})
+		return
+	}
+
+	var req StartAttemptRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.StartAttempt(uint(examID), &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// GetAttempt retrieves an exam attempt
+func (c *OnlineExamController) GetAttempt(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetAttempt(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// SaveAnswers saves answers on an in-progress attempt
+func (c *OnlineExamController) SaveAnswers(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	var req SaveAnswersRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.SaveAnswers(uint(id), &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// SubmitAttempt submits an attempt for grading
+func (c *OnlineExamController) SubmitAttempt(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.SubmitAttempt(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ScoreAnswer manually scores one answer of a submitted attempt
+func (c *OnlineExamController) ScoreAnswer(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	questionID, err := strconv.ParseUint(ctx.Param("questionId"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid question ID"})
+		return
+	}
+
+	var req ScoreAnswerRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.ScoreAnswer(uint(id), uint(questionID), &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// RegisterRoutes registers question bank, exam paper and attempt routes
+func (c *OnlineExamController) RegisterRoutes(rg *gin.RouterGroup) {
+	questions := rg.Group("/question-bank")
+	{
+		questions.POST("", c.CreateQuestion)
+		questions.GET("/:id", c.GetQuestion)
+		questions.PUT("/:id", c.UpdateQuestion)
+		questions.DELETE("/:id", c.DeleteQuestion)
+		questions.GET("/course/:courseId", c.GetQuestionsByCourse)
+	}
+
+	exams := rg.Group("/exams")
+	{
+		exams.PUT("/:id/paper", c.SetPaper)
+		exams.GET("/:id/paper", c.GetPaper)
+		exams.POST("/:id/attempts", c.StartAttempt)
+	}
+
+	attempts := rg.Group("/attempts")
+	{
+		attempts.GET("/:id", c.GetAttempt)
+		attempts.PUT("/:id/answers", c.SaveAnswers)
+		attempts.POST("/:id/submit", c.SubmitAttempt)
+		attempts.POST("/:id/answers/:questionId/score", c.ScoreAnswer)
+	}
+}
@@ -0,0 +1,131 @@
+package online_exam
+
+import "time"
+
+// CreateQuestionRequest represents the request body for adding a question to a course's bank
+type CreateQuestionRequest struct {
+	CourseID        uint            `json:"course_id" binding:"required"`
+	Type            string          `json:"type" binding:"required,oneof=multiple_choice true_false short_answer numeric"`
+	Prompt          string          `json:"prompt" binding:"required,max=5000"`
+	Points          float64         `json:"points" binding:"omitempty,min=0,max=1000"` // Defaults to 1
+	Options         []OptionRequest `json:"options" binding:"omitempty,dive"`          // multiple_choice
+	CorrectBool     *bool           `json:"correct_bool" binding:"omitempty"`          // true_false
+	AcceptedAnswers []string        `json:"accepted_answers" binding:"omitempty"`      // short_answer
+	NumericAnswer   *float64        `json:"numeric_answer" binding:"omitempty"`        // numeric
+	Tolerance       float64         `json:"tolerance" binding:"omitempty,min=0"`       // numeric
+}
+
+// OptionRequest represents a multiple choice option
+type OptionRequest struct {
+	Text      string `json:"text" binding:"required,max=1000"`
+	IsCorrect bool   `json:"is_correct"`
+}
+
+// UpdateQuestionRequest represents the request body for updating a question.
+// Options cannot be changed once created because past answers reference them.
+type UpdateQuestionRequest struct {
+	Prompt          string   `json:"prompt" binding:"omitempty,max=5000"`
+	Points          float64  `json:"points" binding:"omitempty,min=0,max=1000"`
+	CorrectBool     *bool    `json:"correct_bool" binding:"omitempty"`
+	AcceptedAnswers []string `json:"accepted_answers" binding:"omitempty"`
+	NumericAnswer   *float64 `json:"numeric_answer" binding:"omitempty"`
+	Tolerance       *float64 `json:"tolerance" binding:"omitempty,min=0"`
+}
+
+// OptionResponse represents a multiple choice option including whether it is correct
+type OptionResponse struct {
+	ID        uint   `json:"id"`
+	Text      string `json:"text"`
+	IsCorrect bool   `json:"is_correct"`
+	Position  int    `json:"position"`
+}
+
+// QuestionResponse represents the response body for a bank question, answer key included
+type QuestionResponse struct {
+	ID              uint             `json:"id"`
+	CourseID        uint             `json:"course_id"`
+	Type            string           `json:"type"`
+	Prompt          string           `json:"prompt"`
+	Points          float64          `json:"points"`
+	Options         []OptionResponse `json:"options,omitempty"`
+	CorrectBool     *bool            `json:"correct_bool,omitempty"`
+	AcceptedAnswers []string         `json:"accepted_answers,omitempty"`
+	NumericAnswer   *float64         `json:"numeric_answer,omitempty"`
+	Tolerance       float64          `json:"tolerance"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+}
+
+// SetPaperRequest represents the request body for assembling an exam paper
+type SetPaperRequest struct {
+	QuestionIDs   []uint `json:"question_ids" binding:"required,min=1"`
+	Randomize     bool   `json:"randomize"`
+	QuestionCount int    `json:"question_count" binding:"omitempty,min=0"` // Questions drawn per student, 0 for all
+}
+
+// PaperResponse represents the response body for an exam paper
+type PaperResponse struct {
+	ExamID        uint               `json:"exam_id"`
+	Randomize     bool               `json:"randomize"`
+	QuestionCount int                `json:"question_count"`
+	Questions     []QuestionResponse `json:"questions"`
+}
+
+// StartAttemptRequest represents the request body for starting an exam attempt
+type StartAttemptRequest struct {
+	StudentID uint `json:"student_id" binding:"required"`
+}
+
+// SaveAnswersRequest represents the request body for saving answers during an attempt
+type SaveAnswersRequest struct {
+	Answers []AnswerRequest `json:"answers" binding:"required,min=1,dive"`
+}
+
+// AnswerRequest represents an answer to one question
+type AnswerRequest struct {
+	QuestionID        uint   `json:"question_id" binding:"required"`
+	Response          string `json:"response" binding:"omitempty,max=5000"`   // true_false, short_answer, numeric
+	SelectedOptionIDs []uint `json:"selected_option_ids" binding:"omitempty"` // multiple_choice
+}
+
+// ScoreAnswerRequest represents the request body for manually scoring an answer
+type ScoreAnswerRequest struct {
+	Points *float64 `json:"points" binding:"required,min=0"`
+}
+
+// AttemptOptionResponse represents a multiple choice option as shown to the student
+type AttemptOptionResponse struct {
+	ID   uint   `json:"id"`
+	Text string `json:"text"`
+}
+
+// AnswerResponse represents a saved answer
+type AnswerResponse struct {
+	Response          string   `json:"response"`
+	SelectedOptionIDs []uint   `json:"selected_option_ids"`
+	IsCorrect         *bool    `json:"is_correct"`
+	PointsAwarded     *float64 `json:"points_awarded"`
+}
+
+// AttemptQuestionResponse represents a question as delivered in an attempt, without its answer key
+type AttemptQuestionResponse struct {
+	QuestionID uint                    `json:"question_id"`
+	Type       string                  `json:"type"`
+	Prompt     string                  `json:"prompt"`
+	Points     float64                 `json:"points"`
+	Options    []AttemptOptionResponse `json:"options,omitempty"`
+	Answer     *AnswerResponse         `json:"answer"`
+}
+
+// AttemptResponse represents the response body for an exam attempt
+type AttemptResponse struct {
+	ID          uint                      `json:"id"`
+	ExamID      uint                      `json:"exam_id"`
+	StudentID   uint                      `json:"student_id"`
+	Status      string                    `json:"status"`
+	StartedAt   time.Time                 `json:"started_at"`
+	Deadline    time.Time                 `json:"deadline"`
+	SubmittedAt *time.Time                `json:"submitted_at"`
+	Score       *float64                  `json:"score"`
+	Questions   []AttemptQuestionResponse `json:"questions"`
+}
@@ -0,0 +1,69 @@
+package online_exam
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// autoGrade scores an answer against the question's answer key.
+// It returns false when the answer needs a teacher to score it, which happens for
+// short answers that match none of the accepted answers.
+func autoGrade(q *Question, a *AttemptAnswer) bool {
+	correct := false
+	response := strings.TrimSpace(a.Response)
+
+	switch q.Type {
+	case QuestionMultipleChoice:
+		correct = sameOptions(q.Options, a.SelectedOptionIDs)
+	case QuestionTrueFalse:
+		value, err := strconv.ParseBool(response)
+		correct = err == nil && q.CorrectBool != nil && value == *q.CorrectBool
+	case QuestionNumeric:
+		value, err := strconv.ParseFloat(response, 64)
+		correct = err == nil && q.NumericAnswer != nil && math.Abs(value-*q.NumericAnswer) <= q.Tolerance
+	case QuestionShortAnswer:
+		if response != "" {
+			for _, accepted := range q.AcceptedAnswers {
+				if normalizeAnswer(accepted) == normalizeAnswer(response) {
+					correct = true
+					break
+				}
+			}
+			if !correct {
+				return false
+			}
+		}
+	}
+
+	points := 0.0
+	if correct {
+		points = q.Points
+	}
+	a.IsCorrect = &correct
+	a.PointsAwarded = &points
+	return true
+}
+
+// sameOptions reports whether exactly the correct options were selected
+func sameOptions(options []QuestionOption, selected []uint) bool {
+	chosen := make(map[uint]bool, len(selected))
+	for _, id := range selected {
+		chosen[id] = true
+	}
+	if len(chosen) == 0 {
+		return false
+	}
+	for _, option := range options {
+		if option.IsCorrect != chosen[option.ID] {
+			return false
+		}
+		delete(chosen, option.ID)
+	}
+	return len(chosen) == 0
+}
+
+// normalizeAnswer lowercases an answer and collapses whitespace for comparison
+func normalizeAnswer(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
@@ -0,0 +1,144 @@
+package online_exam
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"school_management/internal/modules/course"
+	"school_management/internal/modules/exam"
+	"school_management/internal/modules/student"
+)
+
+// QuestionType represents the kind of answer a question expects
+type QuestionType string
+
+const (
+	QuestionMultipleChoice QuestionType = "multiple_choice"
+	QuestionTrueFalse      QuestionType = "true_false"
+	QuestionShortAnswer    QuestionType = "short_answer"
+	QuestionNumeric        QuestionType = "numeric"
+)
+
+// AttemptStatus represents the state of a student's exam attempt
+type AttemptStatus string
+
+const (
+	AttemptInProgress AttemptStatus = "in_progress"
+	AttemptSubmitted  AttemptStatus = "submitted" // awaiting manual review of some answers
+	AttemptGraded     AttemptStatus = "graded"
+)
+
+// Question is an entry in a course's question bank
+type Question struct {
+	gorm.Model
+	CourseID        uint         `gorm:"not null;index" json:"course_id"`
+	Type            QuestionType `gorm:"type:varchar(20);not null" json:"type"`
+	Prompt          string       `gorm:"type:text;not null" json:"prompt"`
+	Points          float64      `gorm:"not null;default:1" json:"points"`
+	CorrectBool     *bool        `json:"correct_bool"`                                      // true_false
+	AcceptedAnswers []string     `gorm:"type:text;serializer:json" json:"accepted_answers"` // short_answer
+	NumericAnswer   *float64     `json:"numeric_answer"`                                    // numeric
+	Tolerance       float64      `gorm:"not null;default:0" json:"tolerance"`               // numeric
+
+	// Belongs To relationship
+	Course course.Course `gorm:"foreignKey:CourseID" json:"course,omitempty"`
+
+	// Has Many relationship
+	Options []QuestionOption `gorm:"foreignKey:QuestionID" json:"options,omitempty"`
+}
+
+// TableName specifies the table name for the Question model
+func (Question) TableName() string {
+	return "questions"
+}
+
+// QuestionOption is a choice of a multiple choice question
+type QuestionOption struct {
+	gorm.Model
+	QuestionID uint   `gorm:"not null;index" json:"question_id"`
+	Text       string `gorm:"type:text;not null" json:"text"`
+	IsCorrect  bool   `gorm:"not null;default:false" json:"is_correct"`
+	Position   int    `gorm:"not null;default:0" json:"position"`
+}
+
+// TableName specifies the table name for the QuestionOption model
+func (QuestionOption) TableName() string {
+	return "question_options"
+}
+
+// ExamPaper is the set of bank questions an exam is delivered with
+type ExamPaper struct {
+	gorm.Model
+	ExamID        uint `gorm:"not null;uniqueIndex" json:"exam_id"`
+	Randomize     bool `gorm:"not null;default:false" json:"randomize"`
+	QuestionCount int  `gorm:"not null;default:0;comment:Questions drawn per student, 0 for all" json:"question_count"`
+
+	// Belongs To relationship
+	Exam exam.Exam `gorm:"foreignKey:ExamID" json:"exam,omitempty"`
+
+	// Has Many relationship
+	Questions []ExamPaperQuestion `gorm:"foreignKey:PaperID" json:"questions,omitempty"`
+}
+
+// TableName specifies the table name for the ExamPaper model
+func (ExamPaper) TableName() string {
+	return "exam_papers"
+}
+
+// ExamPaperQuestion places a bank question on a paper
+type ExamPaperQuestion struct {
+	gorm.Model
+	PaperID    uint `gorm:"not null;uniqueIndex:idx_paper_question" json:"paper_id"`
+	QuestionID uint `gorm:"not null;uniqueIndex:idx_paper_question" json:"question_id"`
+	Position   int  `gorm:"not null;default:0" json:"position"`
+
+	// Belongs To relationship
+	Question Question `gorm:"foreignKey:QuestionID" json:"question,omitempty"`
+}
+
+// TableName specifies the table name for the ExamPaperQuestion model
+func (ExamPaperQuestion) TableName() string {
+	return "exam_paper_questions"
+}
+
+// ExamAttempt is a student's timed sitting of an online exam
+type ExamAttempt struct {
+	gorm.Model
+	ExamID        uint          `gorm:"not null;uniqueIndex:idx_exam_attempt" json:"exam_id"`
+	StudentID     uint          `gorm:"not null;uniqueIndex:idx_exam_attempt" json:"student_id"`
+	QuestionOrder []uint        `gorm:"type:text;serializer:json" json:"question_order"`
+	StartedAt     time.Time     `gorm:"type:timestamp;not null" json:"started_at"`
+	Deadline      time.Time     `gorm:"type:timestamp;not null" json:"deadline"`
+	SubmittedAt   *time.Time    `gorm:"type:timestamp" json:"submitted_at"`
+	Status        AttemptStatus `gorm:"type:varchar(20);not null;default:'in_progress'" json:"status"`
+	Score         *float64      `json:"score"` // Scaled to Exam.MaxScore once graded
+
+	// Belongs To relationships
+	Exam    exam.Exam       `gorm:"foreignKey:ExamID" json:"exam,omitempty"`
+	Student student.Student `gorm:"foreignKey:StudentID" json:"student,omitempty"`
+
+	// Has Many relationship
+	Answers []AttemptAnswer `gorm:"foreignKey:AttemptID" json:"answers,omitempty"`
+}
+
+// TableName specifies the table name for the ExamAttempt model
+func (ExamAttempt) TableName() string {
+	return "exam_attempts"
+}
+
+// AttemptAnswer is a student's answer to one question of an attempt
+type AttemptAnswer struct {
+	gorm.Model
+	AttemptID         uint     `gorm:"not null;uniqueIndex:idx_attempt_question" json:"attempt_id"`
+	QuestionID        uint     `gorm:"not null;uniqueIndex:idx_attempt_question" json:"question_id"`
+	Response          string   `gorm:"type:text" json:"response"`
+	SelectedOptionIDs []uint   `gorm:"type:text;serializer:json" json:"selected_option_ids"`
+	IsCorrect         *bool    `json:"is_correct"`     // nil until graded
+	PointsAwarded     *float64 `json:"points_awarded"` // nil until graded
+}
+
+// TableName specifies the table name for the AttemptAnswer model
+func (AttemptAnswer) TableName() string {
+	return "attempt_answers"
+}
@@ -0,0 +1,223 @@
+package online_exam
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OnlineExamRepository defines the interface for question bank, paper and attempt data access
+type OnlineExamRepository interface {
+	// Question bank
+	CreateQuestion(question *Question) error
+	GetQuestionByID(id uint) (*Question, error)
+	GetQuestionsByIDs(ids []uint) ([]Question, error)
+	GetQuestionsByCourse(courseID uint) ([]Question, error)
+	UpdateQuestion(question *Question) error
+	DeleteQuestion(id uint) error
+
+	// Exam papers
+	SavePaper(paper *ExamPaper) error
+	GetPaperByExam(examID uint) (*ExamPaper, error)
+
+	// Attempts
+	CreateAttempt(attempt *ExamAttempt) error
+	GetAttemptByID(id uint) (*ExamAttempt, error)
+	GetAttemptByExamAndStudent(examID, studentID uint) (*ExamAttempt, error)
+	GetAttemptsByExam(examID uint) ([]ExamAttempt, error)
+	GetExpiredAttempts(now time.Time) ([]ExamAttempt, error)
+	UpdateAttempt(attempt *ExamAttempt) error
+	SaveAnswers(answers []AttemptAnswer) error
+}
+
+// onlineExamRepository implements OnlineExamRepository
+type onlineExamRepository struct {
+	db *gorm.DB
+}
+
+// NewOnlineExamRepository creates a new online exam repository with dependency injection
+func NewOnlineExamRepository(db *gorm.DB) OnlineExamRepository {
+	return &onlineExamRepository{db: db}
+}
+
+// withOptions preloads multiple choice options in display order
+func (r *onlineExamRepository) withOptions() *gorm.DB {
+	return r.db.Preload("Options", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC")
+	})
+}
+
+// CreateQuestion creates a new question together with its options
+func (r *onlineExamRepository) CreateQuestion(question *Question) error {
+	if err := r.db.Omit("Course").Create(question).Error; err != nil {
+		return fmt.Errorf("failed to create question: %w", err)
+	}
+	return nil
+}
+
+// GetQuestionByID retrieves a question by ID with options preloaded
+func (r *onlineExamRepository) GetQuestionByID(id uint) (*Question, error) {
+	var question Question
+	if err := r.withOptions().First(&question, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get question: %w", err)
+	}
+	return &question, nil
+}
+
+// GetQuestionsByIDs retrieves questions by ID with options preloaded
+func (r *onlineExamRepository) GetQuestionsByIDs(ids []uint) ([]Question, error) {
+	var questions []Question
+	if len(ids) == 0 {
+		return questions, nil
+	}
+	if err := r.withOptions().Where("id IN ?", ids).Find(&questions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	return questions, nil
+}
+
+// GetQuestionsByCourse retrieves a course's question bank
+func (r *onlineExamRepository) GetQuestionsByCourse(courseID uint) ([]Question, error) {
+	var questions []Question
+	if err := r.withOptions().Where("course_id = ?", courseID).Find(&questions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get questions by course: %w", err)
+	}
+	return questions, nil
+}
+
+// UpdateQuestion updates a question's own fields (options are left untouched)
+func (r *onlineExamRepository) UpdateQuestion(question *Question) error {
+	if err := r.db.Omit(clause.Associations).Save(question).Error; err != nil {
+		return fmt.Errorf("failed to update question: %w", err)
+	}
+	return nil
+}
+
+// DeleteQuestion soft deletes a question
+func (r *onlineExamRepository) DeleteQuestion(id uint) error {
+	if err := r.db.Delete(&Question{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete question: %w", err)
+	}
+	return nil
+}
+
+// SavePaper creates or replaces the paper of an exam in one transaction
+func (r *onlineExamRepository) SavePaper(paper *ExamPaper) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var existing ExamPaper
+		err := tx.Where("exam_id = ?", paper.ExamID).First(&existing).Error
+		if err == nil {
+			paper.ID = existing.ID
+			paper.CreatedAt = existing.CreatedAt
+			if err := tx.Unscoped().Where("paper_id = ?", existing.ID).
+				Delete(&ExamPaperQuestion{}).Error; err != nil {
+				return fmt.Errorf("failed to clear paper questions: %w", err)
+			}
+		} else if err != gorm.ErrRecordNotFound {
+			return fmt.Errorf("failed to get exam paper: %w", err)
+		}
+
+		if err := tx.Omit(clause.Associations).Save(paper).Error; err != nil {
+			return fmt.Errorf("failed to save exam paper: %w", err)
+		}
+		for i := range paper.Questions {
+			paper.Questions[i].PaperID = paper.ID
+		}
+		if err := tx.Omit("Question").Create(&paper.Questions).Error; err != nil {
+			return fmt.Errorf("failed to save paper questions: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetPaperByExam retrieves an exam's paper with its questions in order
+func (r *onlineExamRepository) GetPaperByExam(examID uint) (*ExamPaper, error) {
+	var paper ExamPaper
+	if err := r.db.
+		Preload("Questions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Preload("Questions.Question").
+		Preload("Questions.Question.Options", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Where("exam_id = ?", examID).
+		First(&paper).Error; err != nil {
+		return nil, fmt.Errorf("failed to get exam paper: %w", err)
+	}
+	return &paper, nil
+}
+
+// CreateAttempt creates a new exam attempt
+func (r *onlineExamRepository) CreateAttempt(attempt *ExamAttempt) error {
+	if err := r.db.Omit(clause.Associations).Create(attempt).Error; err != nil {
+		return fmt.Errorf("failed to create exam attempt: %w", err)
+	}
+	return nil
+}
+
+// GetAttemptByID retrieves an attempt with its answers
+func (r *onlineExamRepository) GetAttemptByID(id uint) (*ExamAttempt, error) {
+	var attempt ExamAttempt
+	if err := r.db.Preload("Answers").First(&attempt, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get exam attempt: %w", err)
+	}
+	return &attempt, nil
+}
+
+// GetAttemptByExamAndStudent retrieves a student's attempt at an exam
+func (r *onlineExamRepository) GetAttemptByExamAndStudent(examID, studentID uint) (*ExamAttempt, error) {
+	var attempt ExamAttempt
+	if err := r.db.Preload("Answers").
+		Where("exam_id = ? AND student_id = ?", examID, studentID).
+		First(&attempt).Error; err != nil {
+		return nil, fmt.Errorf("failed to get exam attempt: %w", err)
+	}
+	return &attempt, nil
+}
+
+// GetAttemptsByExam retrieves all attempts at an exam with their answers
+func (r *onlineExamRepository) GetAttemptsByExam(examID uint) ([]ExamAttempt, error) {
+	var attempts []ExamAttempt
+	if err := r.db.Preload("Answers").Where("exam_id = ?", examID).Find(&attempts).Error; err != nil {
+		return nil, fmt.Errorf("failed to get exam attempts: %w", err)
+	}
+	return attempts, nil
+}
+
+// GetExpiredAttempts retrieves in-progress attempts whose time is up
+func (r *onlineExamRepository) GetExpiredAttempts(now time.Time) ([]ExamAttempt, error) {
+	var attempts []ExamAttempt
+	if err := r.db.Preload("Answers").
+		Where("status = ? AND deadline < ?", AttemptInProgress, now).
+		Find(&attempts).Error; err != nil {
+		return nil, fmt.Errorf("failed to get expired exam attempts: %w", err)
+	}
+	return attempts, nil
+}
+
+// UpdateAttempt updates an attempt's own fields
+func (r *onlineExamRepository) UpdateAttempt(attempt *ExamAttempt) error {
+	if err := r.db.Omit(clause.Associations).Save(attempt).Error; err != nil {
+		return fmt.Errorf("failed to update exam attempt: %w", err)
+	}
+	return nil
+}
+
+// SaveAnswers inserts answers or overwrites the existing answer to the same question
+func (r *onlineExamRepository) SaveAnswers(answers []AttemptAnswer) error {
+	if len(answers) == 0 {
+		return nil
+	}
+	if err := r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "attempt_id"}, {Name: "question_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"response", "selected_option_ids", "is_correct", "points_awarded", "updated_at",
+		}),
+	}).Create(&answers).Error; err != nil {
+		return fmt.Errorf("failed to save answers: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,654 @@
+package online_exam
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"school_management/internal/modules/exam"
+	"school_management/internal/modules/grade"
+	"school_management/internal/modules/student_courses"
+)
+
+// OnlineExamService defines the business logic interface
+type OnlineExamService interface {
+	// Question bank
+	CreateQuestion(req *CreateQuestionRequest) (*QuestionResponse, error)
+	GetQuestion(id uint) (*QuestionResponse, error)
+	GetQuestionsByCourse(courseID uint) ([]QuestionResponse, error)
+	UpdateQuestion(id uint, req *UpdateQuestionRequest) (*QuestionResponse, error)
+	DeleteQuestion(id uint) error
+
+	// Exam papers
+	SetPaper(examID uint, req *SetPaperRequest) (*PaperResponse, error)
+	GetPaper(examID uint) (*PaperResponse, error)
+
+	// Attempts
+	StartAttempt(examID uint, req *StartAttemptRequest) (*AttemptResponse, error)
+	GetAttempt(id uint) (*AttemptResponse, error)
+	SaveAnswers(id uint, req *SaveAnswersRequest) (*AttemptResponse, error)
+	SubmitAttempt(id uint) (*AttemptResponse, error)
+	ScoreAnswer(id, questionID uint, req *ScoreAnswerRequest) (*AttemptResponse, error)
+	FinalizeExpired() (int, error)
+}
+
+// onlineExamService implements OnlineExamService
+type onlineExamService struct {
+	repo           OnlineExamRepository
+	examRepo       exam.ExamRepository
+	gradeRepo      grade.GradeRepository
+	enrollmentRepo student_courses.StudentCourseRepository
+}
+
+// NewOnlineExamService creates a new online exam service with DI
+func NewOnlineExamService(repo OnlineExamRepository, examRepo exam.ExamRepository, gradeRepo grade.GradeRepository, enrollmentRepo student_courses.StudentCourseRepository) OnlineExamService {
+	return &onlineExamService{repo: repo, examRepo: examRepo, gradeRepo: gradeRepo, enrollmentRepo: enrollmentRepo}
+}
+
+// CreateQuestion adds a question to a course's question bank
+func (s *onlineExamService) CreateQuestion(req *CreateQuestionRequest) (*QuestionResponse, error) {
+	// Validate
+	if err := s.validateCreateQuestionRequest(req); err != nil {
+		return nil, err
+	}
+
+	// Map DTO to Model
+	points := req.Points
+	if points == 0 {
+		points = 1
+	}
+	q := &Question{
+		CourseID:        req.CourseID,
+		Type:            QuestionType(req.Type),
+		Prompt:          req.Prompt,
+		Points:          points,
+		CorrectBool:     req.CorrectBool,
+		AcceptedAnswers: req.AcceptedAnswers,
+		NumericAnswer:   req.NumericAnswer,
+		Tolerance:       req.Tolerance,
+	}
+	for i, o := range req.Options {
+		q.Options = append(q.Options, QuestionOption{Text: o.Text, IsCorrect: o.IsCorrect, Position: i})
+	}
+
+	// Create via repository
+	if err := s.repo.CreateQuestion(q); err != nil {
+		return nil, fmt.Errorf("failed to create question: %w", err)
+	}
+
+	return s.toQuestionResponseDTO(q), nil
+}
+
+// GetQuestion retrieves a question by ID
+func (s *onlineExamService) GetQuestion(id uint) (*QuestionResponse, error) {
+	q, err := s.repo.GetQuestionByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("question not found: %w", err)
+	}
+	return s.toQuestionResponseDTO(q), nil
+}
+
+// GetQuestionsByCourse retrieves a course's question bank
+func (s *onlineExamService) GetQuestionsByCourse(courseID uint) ([]QuestionResponse, error) {
+	questions, err := s.repo.GetQuestionsByCourse(courseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	responses := make([]QuestionResponse, len(questions))
+	for i, q := range questions {
+		responses[i] = *s.toQuestionResponseDTO(&q)
+	}
+	return responses, nil
+}
+
+// UpdateQuestion updates a question's prompt, points or answer key
+func (s *onlineExamService) UpdateQuestion(id uint, req *UpdateQuestionRequest) (*QuestionResponse, error) {
+	// Get existing
+	q, err := s.repo.GetQuestionByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("question not found: %w", err)
+	}
+
+	// Update fields
+	if req.Prompt != "" {
+		q.Prompt = req.Prompt
+	}
+	if req.Points != 0 {
+		q.Points = req.Points
+	}
+	if req.CorrectBool != nil {
+		q.CorrectBool = req.CorrectBool
+	}
+	if req.AcceptedAnswers != nil {
+		q.AcceptedAnswers = req.AcceptedAnswers
+	}
+	if req.NumericAnswer != nil {
+		q.NumericAnswer = req.NumericAnswer
+	}
+	if req.Tolerance != nil {
+		q.Tolerance = *req.Tolerance
+	}
+
+	// Save
+	if err := s.repo.UpdateQuestion(q); err != nil {
+		return nil, fmt.Errorf("failed to update question: %w", err)
+	}
+
+	return s.toQuestionResponseDTO(q), nil
+}
+
+// DeleteQuestion deletes a question from the bank
+func (s *onlineExamService) DeleteQuestion(id uint) error {
+	if _, err := s.repo.GetQuestionByID(id); err != nil {
+		return fmt.Errorf("question not found: %w", err)
+	}
+
+	if err := s.repo.DeleteQuestion(id); err != nil {
+		return fmt.Errorf("failed to delete question: %w", err)
+	}
+
+	return nil
+}
+
+// SetPaper assembles an exam's paper from questions in the exam course's bank
+func (s *onlineExamService) SetPaper(examID uint, req *SetPaperRequest) (*PaperResponse, error) {
+	ex, err := s.examRepo.GetByID(examID)
+	if err != nil {
+		return nil, fmt.Errorf("exam not found: %w", err)
+	}
+
+	if req.QuestionCount > len(req.QuestionIDs) {
+		return nil, fmt.Errorf("question count cannot exceed the %d questions on the paper", len(req.QuestionIDs))
+	}
+
+	questions, err := s.repo.GetQuestionsByIDs(req.QuestionIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]Question, len(questions))
+	for _, q := range questions {
+		byID[q.ID] = q
+	}
+
+	paper := &ExamPaper{
+		ExamID:        examID,
+		Randomize:     req.Randomize,
+		QuestionCount: req.QuestionCount,
+	}
+	seen := make(map[uint]bool, len(req.QuestionIDs))
+	for i, id := range req.QuestionIDs {
+		q, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("question %d not found", id)
+		}
+		if q.CourseID != ex.CourseID {
+			return nil, fmt.Errorf("question %d does not belong to the exam's course", id)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("question %d listed more than once", id)
+		}
+		seen[id] = true
+		paper.Questions = append(paper.Questions, ExamPaperQuestion{QuestionID: id, Position: i})
+	}
+
+	if err := s.repo.SavePaper(paper); err != nil {
+		return nil, fmt.Errorf("failed to save exam paper: %w", err)
+	}
+
+	return s.GetPaper(examID)
+}
+
+// GetPaper retrieves an exam's paper, answer keys included
+func (s *onlineExamService) GetPaper(examID uint) (*PaperResponse, error) {
+	paper, err := s.repo.GetPaperByExam(examID)
+	if err != nil {
+		return nil, fmt.Errorf("exam paper not found: %w", err)
+	}
+
+	resp := &PaperResponse{
+		ExamID:        paper.ExamID,
+		Randomize:     paper.Randomize,
+		QuestionCount: paper.QuestionCount,
+		Questions:     make([]QuestionResponse, len(paper.Questions)),
+	}
+	for i, pq := range paper.Questions {
+		resp.Questions[i] = *s.toQuestionResponseDTO(&pq.Question)
+	}
+	return resp, nil
+}
+
+// StartAttempt starts, or resumes, a student's timed attempt at an exam of a course they are
+// enrolled in. The exam window runs from ExamDate until Duration minutes after it; an attempt
+// lasts Duration minutes but never past the window's close.
+func (s *onlineExamService) StartAttempt(examID uint, req *StartAttemptRequest) (*AttemptResponse, error) {
+	ex, err := s.examRepo.GetByID(examID)
+	if err != nil {
+		return nil, fmt.Errorf("exam not found: %w", err)
+	}
+	if _, err := s.enrollmentRepo.GetByStudentAndCourse(req.StudentID, ex.CourseID); err != nil {
+		return nil, fmt.Errorf("student is not enrolled in the exam's course: %w", err)
+	}
+
+	if existing, _ := s.repo.GetAttemptByExamAndStudent(examID, req.StudentID); existing != nil {
+		if existing.Status != AttemptInProgress {
+			return nil, fmt.Errorf("student has already completed this exam")
+		}
+		return s.renderAttempt(existing, s.marksPublished(existing))
+	}
+
+	now := time.Now()
+	duration := time.Duration(ex.Duration) * time.Minute
+	closes := ex.ExamDate.Add(duration)
+	if now.Before(ex.ExamDate) {
+		return nil, fmt.Errorf("exam opens at %s", ex.ExamDate.Format(time.RFC3339))
+	}
+	if now.After(closes) {
+		return nil, fmt.Errorf("exam is closed")
+	}
+	deadline := now.Add(duration)
+	if deadline.After(closes) {
+		deadline = closes
+	}
+
+	paper, err := s.repo.GetPaperByExam(examID)
+	if err != nil {
+		return nil, fmt.Errorf("exam has no paper: %w", err)
+	}
+
+	attempt := &ExamAttempt{
+		ExamID:        examID,
+		StudentID:     req.StudentID,
+		QuestionOrder: s.drawQuestions(paper),
+		StartedAt:     now,
+		Deadline:      deadline,
+		Status:        AttemptInProgress,
+	}
+	if err := s.repo.CreateAttempt(attempt); err != nil {
+		return nil, fmt.Errorf("failed to start exam attempt: %w", err)
+	}
+
+	return s.renderAttempt(attempt, s.marksPublished(attempt))
+}
+
+// GetAttempt retrieves an attempt, submitting it first if its time is up
+func (s *onlineExamService) GetAttempt(id uint) (*AttemptResponse, error) {
+	attempt, err := s.repo.GetAttemptByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("exam attempt not found: %w", err)
+	}
+
+	if attempt.Status == AttemptInProgress && time.Now().After(attempt.Deadline) {
+		if err := s.finalize(attempt); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.renderAttempt(attempt, s.marksPublished(attempt))
+}
+
+// SaveAnswers saves answers while the attempt is in progress
+func (s *onlineExamService) SaveAnswers(id uint, req *SaveAnswersRequest) (*AttemptResponse, error) {
+	attempt, err := s.repo.GetAttemptByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("exam attempt not found: %w", err)
+	}
+
+	if attempt.Status != AttemptInProgress {
+		return nil, fmt.Errorf("exam attempt has already been submitted")
+	}
+	if time.Now().After(attempt.Deadline) {
+		if err := s.finalize(attempt); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("time is up; the attempt has been submitted")
+	}
+
+	onPaper := make(map[uint]bool, len(attempt.QuestionOrder))
+	for _, qid := range attempt.QuestionOrder {
+		onPaper[qid] = true
+	}
+
+	answers := make([]AttemptAnswer, len(req.Answers))
+	for i, a := range req.Answers {
+		if !onPaper[a.QuestionID] {
+			return nil, fmt.Errorf("question %d is not part of this attempt", a.QuestionID)
+		}
+		answers[i] = AttemptAnswer{
+			AttemptID:         attempt.ID,
+			QuestionID:        a.QuestionID,
+			Response:          a.Response,
+			SelectedOptionIDs: a.SelectedOptionIDs,
+		}
+	}
+
+	if err := s.repo.SaveAnswers(answers); err != nil {
+		return nil, fmt.Errorf("failed to save answers: %w", err)
+	}
+
+	return s.GetAttempt(id)
+}
+
+// SubmitAttempt ends an attempt and auto-grades it
+func (s *onlineExamService) SubmitAttempt(id uint) (*AttemptResponse, error) {
+	attempt, err := s.repo.GetAttemptByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("exam attempt not found: %w", err)
+	}
+
+	if attempt.Status != AttemptInProgress {
+		return nil, fmt.Errorf("exam attempt has already been submitted")
+	}
+
+	if err := s.finalize(attempt); err != nil {
+		return nil, err
+	}
+
+	return s.renderAttempt(attempt, s.marksPublished(attempt))
+}
+
+// ScoreAnswer lets a teacher score an answer that could not be auto-graded,
+// or override an automatic score. The exam grade is written once every answer is scored.
+func (s *onlineExamService) ScoreAnswer(id, questionID uint, req *ScoreAnswerRequest) (*AttemptResponse, error) {
+	attempt, err := s.repo.GetAttemptByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("exam attempt not found: %w", err)
+	}
+
+	if attempt.Status == AttemptInProgress {
+		return nil, fmt.Errorf("exam attempt has not been submitted yet")
+	}
+
+	q, err := s.repo.GetQuestionByID(questionID)
+	if err != nil {
+		return nil, fmt.Errorf("question not found: %w", err)
+	}
+	if *req.Points > q.Points {
+		return nil, fmt.Errorf("points cannot exceed the question's %.2f points", q.Points)
+	}
+
+	var answer *AttemptAnswer
+	for i := range attempt.Answers {
+		if attempt.Answers[i].QuestionID == questionID {
+			answer = &attempt.Answers[i]
+		}
+	}
+	if answer == nil {
+		return nil, fmt.Errorf("question %d is not part of this attempt", questionID)
+	}
+
+	correct := *req.Points == q.Points
+	answer.PointsAwarded = req.Points
+	answer.IsCorrect = &correct
+	if err := s.repo.SaveAnswers([]AttemptAnswer{*answer}); err != nil {
+		return nil, fmt.Errorf("failed to score answer: %w", err)
+	}
+
+	if err := s.completeIfScored(attempt); err != nil {
+		return nil, err
+	}
+
+	return s.renderAttempt(attempt, true)
+}
+
+// FinalizeExpired submits every in-progress attempt whose time is up
+func (s *onlineExamService) FinalizeExpired() (int, error) {
+	attempts, err := s.repo.GetExpiredAttempts(time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range attempts {
+		if err := s.finalize(&attempts[i]); err != nil {
+			return i, err
+		}
+	}
+	return len(attempts), nil
+}
+
+// drawQuestions picks the attempt's questions from the paper, shuffling them if the paper is randomized
+func (s *onlineExamService) drawQuestions(paper *ExamPaper) []uint {
+	ids := make([]uint, len(paper.Questions))
+	for i, pq := range paper.Questions {
+		ids[i] = pq.QuestionID
+	}
+
+	if paper.Randomize {
+		rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+		rng.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+	}
+	if paper.QuestionCount > 0 && paper.QuestionCount < len(ids) {
+		ids = ids[:paper.QuestionCount]
+	}
+	return ids
+}
+
+// finalize closes an attempt, auto-grades every answer and writes the grade when nothing needs review
+func (s *onlineExamService) finalize(attempt *ExamAttempt) error {
+	questions, err := s.repo.GetQuestionsByIDs(attempt.QuestionOrder)
+	if err != nil {
+		return err
+	}
+
+	answers := make(map[uint]*AttemptAnswer, len(attempt.Answers))
+	for i := range attempt.Answers {
+		answers[attempt.Answers[i].QuestionID] = &attempt.Answers[i]
+	}
+
+	graded := make([]AttemptAnswer, 0, len(questions))
+	for i := range questions {
+		q := &questions[i]
+		a, ok := answers[q.ID]
+		if !ok {
+			a = &AttemptAnswer{AttemptID: attempt.ID, QuestionID: q.ID}
+		}
+		autoGrade(q, a)
+		graded = append(graded, *a)
+	}
+	if err := s.repo.SaveAnswers(graded); err != nil {
+		return fmt.Errorf("failed to grade answers: %w", err)
+	}
+	attempt.Answers = graded
+
+	now := time.Now()
+	attempt.SubmittedAt = &now
+	attempt.Status = AttemptSubmitted
+	if err := s.repo.UpdateAttempt(attempt); err != nil {
+		return fmt.Errorf("failed to submit exam attempt: %w", err)
+	}
+
+	return s.completeIfScored(attempt)
+}
+
+// completeIfScored computes the exam score once every answer has points and writes it to the student's grade
+func (s *onlineExamService) completeIfScored(attempt *ExamAttempt) error {
+	questions, err := s.repo.GetQuestionsByIDs(attempt.QuestionOrder)
+	if err != nil {
+		return err
+	}
+
+	var total, awarded float64
+	for _, q := range questions {
+		total += q.Points
+	}
+	for _, a := range attempt.Answers {
+		if a.PointsAwarded == nil {
+			return nil
+		}
+		awarded += *a.PointsAwarded
+	}
+
+	ex, err := s.examRepo.GetByID(attempt.ExamID)
+	if err != nil {
+		return fmt.Errorf("exam not found: %w", err)
+	}
+
+	score := 0.0
+	if total > 0 {
+		score = awarded / total * ex.MaxScore
+	}
+	attempt.Score = &score
+	attempt.Status = AttemptGraded
+	if err := s.repo.UpdateAttempt(attempt); err != nil {
+		return fmt.Errorf("failed to grade exam attempt: %w", err)
+	}
+
+	return s.writeGrade(attempt.StudentID, attempt.ExamID, score)
+}
+
+// writeGrade creates or updates the student's grade for the exam. A changed score goes back to
+// draft, so a published grade is not silently replaced before it is published again.
+func (s *onlineExamService) writeGrade(studentID, examID uint, score float64) error {
+	gr, err := s.gradeRepo.GetByStudentAndExam(studentID, examID)
+	if err != nil {
+		gr = &grade.Grade{StudentID: studentID, ExamID: examID, Score: score, Status: grade.GradeDraft}
+		if err := s.gradeRepo.Create(gr); err != nil {
+			return fmt.Errorf("failed to record exam grade: %w", err)
+		}
+		return nil
+	}
+
+	if gr.Score == score {
+		return nil
+	}
+	gr.Score = score
+	gr.Status = grade.GradeDraft
+	gr.PublishedAt = nil
+	if err := s.gradeRepo.Update(gr); err != nil {
+		return fmt.Errorf("failed to record exam grade: %w", err)
+	}
+	return nil
+}
+
+// Validation methods
+func (s *onlineExamService) validateCreateQuestionRequest(req *CreateQuestionRequest) error {
+	if req.CourseID == 0 {
+		return fmt.Errorf("course ID is required")
+	}
+	if strings.TrimSpace(req.Prompt) == "" {
+		return fmt.Errorf("prompt is required")
+	}
+
+	switch QuestionType(req.Type) {
+	case QuestionMultipleChoice:
+		if len(req.Options) < 2 {
+			return fmt.Errorf("multiple choice questions need at least 2 options")
+		}
+		hasCorrect := false
+		for _, o := range req.Options {
+			hasCorrect = hasCorrect || o.IsCorrect
+		}
+		if !hasCorrect {
+			return fmt.Errorf("multiple choice questions need at least one correct option")
+		}
+	case QuestionTrueFalse:
+		if req.CorrectBool == nil {
+			return fmt.Errorf("true/false questions need correct_bool")
+		}
+	case QuestionNumeric:
+		if req.NumericAnswer == nil {
+			return fmt.Errorf("numeric questions need numeric_answer")
+		}
+	case QuestionShortAnswer:
+		// Accepted answers are optional; unmatched answers are left for manual scoring
+	default:
+		return fmt.Errorf("invalid question type (must be: multiple_choice, true_false, short_answer, or numeric)")
+	}
+
+	if QuestionType(req.Type) != QuestionMultipleChoice && len(req.Options) > 0 {
+		return fmt.Errorf("only multiple choice questions have options")
+	}
+	return nil
+}
+
+// DTO mapping methods
+func (s *onlineExamService) toQuestionResponseDTO(q *Question) *QuestionResponse {
+	resp := &QuestionResponse{
+		ID:              q.ID,
+		CourseID:        q.CourseID,
+		Type:            string(q.Type),
+		Prompt:          q.Prompt,
+		Points:          q.Points,
+		CorrectBool:     q.CorrectBool,
+		AcceptedAnswers: q.AcceptedAnswers,
+		NumericAnswer:   q.NumericAnswer,
+		Tolerance:       q.Tolerance,
+		CreatedAt:       q.CreatedAt,
+		UpdatedAt:       q.UpdatedAt,
+	}
+	for _, o := range q.Options {
+		resp.Options = append(resp.Options, OptionResponse{
+			ID:        o.ID,
+			Text:      o.Text,
+			IsCorrect: o.IsCorrect,
+			Position:  o.Position,
+		})
+	}
+	return resp
+}
+
+// marksPublished reports whether the attempt's exam grade has been released to the student
+func (s *onlineExamService) marksPublished(attempt *ExamAttempt) bool {
+	gr, err := s.gradeRepo.GetByStudentAndExam(attempt.StudentID, attempt.ExamID)
+	return err == nil && gr.Status == grade.GradePublished
+}
+
+// renderAttempt maps an attempt to its student view: questions in attempt order, without
+// answer keys. Score and per-answer marks are left out unless withMarks is set.
+func (s *onlineExamService) renderAttempt(attempt *ExamAttempt, withMarks bool) (*AttemptResponse, error) {
+	questions, err := s.repo.GetQuestionsByIDs(attempt.QuestionOrder)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*Question, len(questions))
+	for i := range questions {
+		byID[questions[i].ID] = &questions[i]
+	}
+	answers := make(map[uint]*AttemptAnswer, len(attempt.Answers))
+	for i := range attempt.Answers {
+		answers[attempt.Answers[i].QuestionID] = &attempt.Answers[i]
+	}
+
+	resp := &AttemptResponse{
+		ID:          attempt.ID,
+		ExamID:      attempt.ExamID,
+		StudentID:   attempt.StudentID,
+		Status:      string(attempt.Status),
+		StartedAt:   attempt.StartedAt,
+		Deadline:    attempt.Deadline,
+		SubmittedAt: attempt.SubmittedAt,
+		Questions:   make([]AttemptQuestionResponse, 0, len(attempt.QuestionOrder)),
+	}
+	if withMarks {
+		resp.Score = attempt.Score
+	}
+
+	for _, qid := range attempt.QuestionOrder {
+		q, ok := byID[qid]
+		if !ok {
+			continue
+		}
+		item := AttemptQuestionResponse{
+			QuestionID: q.ID,
+			Type:       string(q.Type),
+			Prompt:     q.Prompt,
+			Points:     q.Points,
+		}
+		for _, o := range q.Options {
+			item.Options = append(item.Options, AttemptOptionResponse{ID: o.ID, Text: o.Text})
+		}
+		if a, ok := answers[qid]; ok {
+			item.Answer = &AnswerResponse{
+				Response:          a.Response,
+				SelectedOptionIDs: a.SelectedOptionIDs,
+			}
+			if withMarks {
+				item.Answer.IsCorrect = a.IsCorrect
+				item.Answer.PointsAwarded = a.PointsAwarded
+			}
+		}
+		resp.Questions = append(resp.Questions, item)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,214 @@
+package regrade
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"school_management/internal/export"
+	"school_management/internal/modules/department"
+)
+
+// RegradeController handles HTTP requests for regrade requests
+type RegradeController struct {
+	service RegradeService
+}
+
+// NewRegradeController creates a new regrade controller
+func NewRegradeController(service RegradeService) *RegradeController {
+	return &RegradeController{service: service}
+}
+
+// Create files a new regrade request
+func (c *RegradeController) Create(ctx *gin.Context) {
+	var req CreateRegradeRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.Create(&req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// GetByID retrieves a regrade request with its history
+func (c *RegradeController) GetByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetByID(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetByStudent retrieves regrade requests filed by a student
+func (c *RegradeController) GetByStudent(ctx *gin.Context) {
+	studentID, err := strconv.ParseUint(ctx.Param("studentId"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid student ID"})
+		return
+	}
+
+	resp, err := c.service.GetByStudent(uint(studentID))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// GetByCourse retrieves regrade requests for a course
+func (c *RegradeController) GetByCourse(ctx *gin.Context) {
+	courseID, err := strconv.ParseUint(ctx.Param("courseId"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid course ID"})
+		return
+	}
+
+	resp, err := c.service.GetByCourse(uint(courseID))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// GetEscalatedByDepartment retrieves escalated requests awaiting a department decision
+func (c *RegradeController) GetEscalatedByDepartment(ctx *gin.Context) {
+	departmentID, err := strconv.ParseUint(ctx.Param("departmentId"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid department ID"})
+		return
+	}
+
+	resp, err := c.service.GetEscalatedByDepartment(uint(departmentID))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// StartReview takes a regrade request under review
+func (c *RegradeController) StartReview(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	var req StartReviewRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.StartReview(uint(id), &req)
+	if errors.Is(err, department.ErrNotHead) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Decide accepts or rejects a regrade request
+func (c *RegradeController) Decide(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	var req DecisionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.Decide(uint(id), &req)
+	if errors.Is(err, department.ErrNotHead) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Escalate escalates a rejected regrade request to the department head
+func (c *RegradeController) Escalate(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	var req EscalateRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	resp, err := c.service.Escalate(uint(id), &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// RegisterRoutes registers regrade request routes
+func (c *RegradeController) RegisterRoutes(rg *gin.RouterGroup) {
+	regrades := rg.Group("/regrade-requests")
+	{
+		regrades.POST("", c.Create)
+		regrades.GET("/:id", c.GetByID)
+		regrades.POST("/:id/review", c.StartReview)
+		regrades.POST("/:id/decision", c.Decide)
+		regrades.POST("/:id/escalate", c.Escalate)
+		regrades.GET("/student/:studentId", c.GetByStudent)
+		regrades.GET("/course/:courseId", c.GetByCourse)
+		regrades.GET("/department/:departmentId/escalated", c.GetEscalatedByDepartment)
+	}
+}
@@ -0,0 +1,87 @@
+package regrade
+
+import "time"
+
+// CreateRegradeRequest represents the request body for filing a regrade request.
+// Exactly one of GradeID (exam grade) or SubmissionID (homework) must be set.
+type CreateRegradeRequest struct {
+	StudentID    uint                `json:"student_id" binding:"required"`
+	GradeID      *uint               `json:"grade_id" binding:"omitempty"`
+	SubmissionID *uint               `json:"submission_id" binding:"omitempty"`
+	Reason       string              `json:"reason" binding:"required,min=10,max=5000"`
+	Attachments  []AttachmentRequest `json:"attachments" binding:"omitempty,dive"`
+}
+
+// AttachmentRequest represents a file attached to a regrade request
+type AttachmentRequest struct {
+	FileName    string `json:"file_name" binding:"required,max=255"`
+	FileURL     string `json:"file_url" binding:"required,max=500"`
+	ContentType string `json:"content_type" binding:"omitempty,max=100"`
+	SizeBytes   int64  `json:"size_bytes" binding:"omitempty,min=0"`
+}
+
+// StartReviewRequest represents the request body for taking a regrade request under review
+type StartReviewRequest struct {
+	ReviewerID uint   `json:"reviewer_id" binding:"required"`
+	Note       string `json:"note" binding:"omitempty,max=2000"`
+}
+
+// DecisionRequest represents the request body for deciding a regrade request
+type DecisionRequest struct {
+	ReviewerID    uint     `json:"reviewer_id" binding:"required"`
+	Accept        bool     `json:"accept"`
+	AdjustedScore *float64 `json:"adjusted_score" binding:"omitempty,min=0"` // Required when accepting
+	Decision      string   `json:"decision" binding:"required,max=5000"`
+}
+
+// EscalateRequest represents the request body for escalating a rejected regrade request
+type EscalateRequest struct {
+	Note string `json:"note" binding:"omitempty,max=2000"`
+}
+
+// RegradeResponse represents the response body for regrade request data
+type RegradeResponse struct {
+	ID                    uint       `json:"id"`
+	StudentID             uint       `json:"student_id"`
+	TargetType            string     `json:"target_type"`
+	GradeID               *uint      `json:"grade_id"`
+	SubmissionID          *uint      `json:"submission_id"`
+	CourseID              uint       `json:"course_id"`
+	Reason                string     `json:"reason"`
+	Status                string     `json:"status"`
+	OriginalScore         float64    `json:"original_score"`
+	AdjustedScore         *float64   `json:"adjusted_score"`
+	PublishedAt           time.Time  `json:"published_at"`
+	AppealDeadline        time.Time  `json:"appeal_deadline"`
+	ReviewerID            *uint      `json:"reviewer_id"`
+	Decision              string     `json:"decision"`
+	DecidedAt             *time.Time `json:"decided_at"`
+	EscalatedDepartmentID *uint      `json:"escalated_department_id"`
+	EscalatedAt           *time.Time `json:"escalated_at"`
+	CreatedAt             time.Time  `json:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at"`
+
+	Attachments []AttachmentResponse `json:"attachments,omitempty"`
+	Events      []EventResponse      `json:"events,omitempty"`
+}
+
+// AttachmentResponse represents the response body for a regrade attachment
+type AttachmentResponse struct {
+	ID          uint   `json:"id"`
+	FileName    string `json:"file_name"`
+	FileURL     string `json:"file_url"`
+	ContentType string `json:"content_type"`
+	SizeBytes   int64  `json:"size_bytes"`
+}
+
+// EventResponse represents one entry in a regrade request's history
+type EventResponse struct {
+	ID         uint      `json:"id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	ActorID    *uint     `json:"actor_id"`
+	Note       string    `json:"note"`
+	OldScore   *float64  `json:"old_score"`
+	NewScore   *float64  `json:"new_score"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,105 @@
+package regrade
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"school_management/internal/modules/department"
+	"school_management/internal/modules/student"
+	"school_management/internal/modules/teacher"
+)
+
+// AppealWindowDays is how long after a grade is published a student may file a regrade request
+const AppealWindowDays = 14
+
+// TargetType identifies what kind of score a regrade request contests
+type TargetType string
+
+const (
+	TargetExamGrade TargetType = "exam_grade" // a grade.Grade row
+	TargetHomework  TargetType = "homework"   // a students_homework submission score
+)
+
+// RequestStatus represents the state of a regrade request
+type RequestStatus string
+
+const (
+	RequestOpen        RequestStatus = "open"
+	RequestUnderReview RequestStatus = "under_review"
+	RequestAccepted    RequestStatus = "accepted"
+	RequestRejected    RequestStatus = "rejected"
+)
+
+type RegradeRequest struct {
+	gorm.Model
+	StudentID     uint          `gorm:"not null;index" json:"student_id"`
+	TargetType    TargetType    `gorm:"type:varchar(20);not null" json:"target_type"`
+	GradeID       *uint         `gorm:"index" json:"grade_id"`
+	SubmissionID  *uint         `gorm:"index" json:"submission_id"`
+	CourseID      uint          `gorm:"not null;index" json:"course_id"`
+	Reason        string        `gorm:"type:text;not null" json:"reason"`
+	Status        RequestStatus `gorm:"type:varchar(20);not null;default:'open';index" json:"status"`
+	OriginalScore float64       `gorm:"not null" json:"original_score"`
+	AdjustedScore *float64      `json:"adjusted_score"`
+	PublishedAt   time.Time     `gorm:"type:timestamp;not null;comment:publication of the contested score; starts the appeal window" json:"published_at"`
+	ReviewerID    *uint         `json:"reviewer_id"`
+	Decision      string        `gorm:"type:text" json:"decision"`
+	DecidedAt     *time.Time    `gorm:"type:timestamp" json:"decided_at"`
+
+	// Escalation to the head of the course's department after a rejection
+	EscalatedDepartmentID *uint      `gorm:"index" json:"escalated_department_id"`
+	EscalatedAt           *time.Time `gorm:"type:timestamp" json:"escalated_at"`
+
+	// Belongs To relationships
+	Student             student.Student        `gorm:"foreignKey:StudentID" json:"student,omitempty"`
+	Reviewer            *teacher.Teacher       `gorm:"foreignKey:ReviewerID" json:"reviewer,omitempty"`
+	EscalatedDepartment *department.Department `gorm:"foreignKey:EscalatedDepartmentID" json:"escalated_department,omitempty"`
+
+	// Has Many relationships
+	Attachments []RegradeAttachment `gorm:"foreignKey:RegradeRequestID" json:"attachments,omitempty"`
+	Events      []RegradeEvent      `gorm:"foreignKey:RegradeRequestID" json:"events,omitempty"`
+}
+
+// TableName specifies the table name for the RegradeRequest model
+func (RegradeRequest) TableName() string {
+	return "regrade_requests"
+}
+
+// IsClosed reports whether the request has reached a decision that cannot be escalated further
+func (r *RegradeRequest) IsClosed() bool {
+	return r.Status == RequestAccepted || (r.Status == RequestRejected && r.EscalatedDepartmentID != nil)
+}
+
+// RegradeAttachment is a supporting file attached to a regrade request
+type RegradeAttachment struct {
+	gorm.Model
+	RegradeRequestID uint   `gorm:"not null;index" json:"regrade_request_id"`
+	FileName         string `gorm:"not null;size:255" json:"file_name"`
+	FileURL          string `gorm:"not null;size:500" json:"file_url"`
+	ContentType      string `gorm:"size:100" json:"content_type"`
+	SizeBytes        int64  `json:"size_bytes"`
+}
+
+// TableName specifies the table name for the RegradeAttachment model
+func (RegradeAttachment) TableName() string {
+	return "regrade_attachments"
+}
+
+// RegradeEvent is one entry in a regrade request's history. Events that change
+// the contested score record both the old and new values.
+type RegradeEvent struct {
+	gorm.Model
+	RegradeRequestID uint          `gorm:"not null;index" json:"regrade_request_id"`
+	FromStatus       RequestStatus `gorm:"type:varchar(20)" json:"from_status"`
+	ToStatus         RequestStatus `gorm:"type:varchar(20);not null" json:"to_status"`
+	ActorID          *uint         `json:"actor_id"` // teacher acting; nil for the student
+	Note             string        `gorm:"type:text" json:"note"`
+	OldScore         *float64      `json:"old_score"`
+	NewScore         *float64      `json:"new_score"`
+}
+
+// TableName specifies the table name for the RegradeEvent model
+func (RegradeEvent) TableName() string {
+	return "regrade_events"
+}
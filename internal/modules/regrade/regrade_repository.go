@@ -0,0 +1,158 @@
+package regrade
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"school_management/internal/modules/grade"
+	"school_management/internal/modules/students_homework"
+)
+
+// RegradeRepository defines the interface for regrade request data access
+type RegradeRepository interface {
+	Create(request *RegradeRequest) error
+	GetByID(id uint) (*RegradeRequest, error)
+	GetByIDWithHistory(id uint) (*RegradeRequest, error)
+	GetByStudent(studentID uint) ([]RegradeRequest, error)
+	GetByCourse(courseID uint) ([]RegradeRequest, error)
+	GetEscalatedByDepartment(departmentIDs []uint) ([]RegradeRequest, error)
+	HasActiveForTarget(gradeID, submissionID *uint) (bool, error)
+	SaveTransition(request *RegradeRequest, event *RegradeEvent) error
+	SaveAccepted(request *RegradeRequest, event *RegradeEvent) error
+}
+
+// regradeRepository implements RegradeRepository
+type regradeRepository struct {
+	db *gorm.DB
+}
+
+// NewRegradeRepository creates a new regrade repository with dependency injection
+func NewRegradeRepository(db *gorm.DB) RegradeRepository {
+	return &regradeRepository{db: db}
+}
+
+// Create files a new regrade request with its attachments and opening event
+func (r *regradeRepository) Create(request *RegradeRequest) error {
+	if err := r.db.Omit("Student", "Reviewer", "EscalatedDepartment").Create(request).Error; err != nil {
+		return fmt.Errorf("failed to create regrade request: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a regrade request by ID
+func (r *regradeRepository) GetByID(id uint) (*RegradeRequest, error) {
+	var request RegradeRequest
+	if err := r.db.First(&request, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get regrade request: %w", err)
+	}
+	return &request, nil
+}
+
+// GetByIDWithHistory retrieves a regrade request with attachments and events preloaded
+func (r *regradeRepository) GetByIDWithHistory(id uint) (*RegradeRequest, error) {
+	var request RegradeRequest
+	if err := r.db.Preload("Attachments").
+		Preload("Events", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC, id ASC") }).
+		First(&request, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get regrade request: %w", err)
+	}
+	return &request, nil
+}
+
+// GetByStudent retrieves all regrade requests filed by a student
+func (r *regradeRepository) GetByStudent(studentID uint) ([]RegradeRequest, error) {
+	var requests []RegradeRequest
+	if err := r.db.Where("student_id = ?", studentID).Order("created_at DESC").Find(&requests).Error; err != nil {
+		return nil, fmt.Errorf("failed to get regrade requests by student: %w", err)
+	}
+	return requests, nil
+}
+
+// GetByCourse retrieves all regrade requests for a course
+func (r *regradeRepository) GetByCourse(courseID uint) ([]RegradeRequest, error) {
+	var requests []RegradeRequest
+	if err := r.db.Where("course_id = ?", courseID).Order("created_at DESC").Find(&requests).Error; err != nil {
+		return nil, fmt.Errorf("failed to get regrade requests by course: %w", err)
+	}
+	return requests, nil
+}
+
+// GetEscalatedByDepartment retrieves requests escalated to any of the departments that still await a decision
+func (r *regradeRepository) GetEscalatedByDepartment(departmentIDs []uint) ([]RegradeRequest, error) {
+	var requests []RegradeRequest
+	if err := r.db.Where("escalated_department_id IN ? AND status IN ?", departmentIDs,
+		[]RequestStatus{RequestOpen, RequestUnderReview}).
+		Order("escalated_at ASC").
+		Find(&requests).Error; err != nil {
+		return nil, fmt.Errorf("failed to get escalated regrade requests: %w", err)
+	}
+	return requests, nil
+}
+
+// HasActiveForTarget reports whether the score already has an undecided regrade request
+func (r *regradeRepository) HasActiveForTarget(gradeID, submissionID *uint) (bool, error) {
+	query := r.db.Model(&RegradeRequest{}).Where("status IN ?", []RequestStatus{RequestOpen, RequestUnderReview})
+	if gradeID != nil {
+		query = query.Where("grade_id = ?", *gradeID)
+	} else {
+		query = query.Where("submission_id = ?", *submissionID)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check active regrade requests: %w", err)
+	}
+	return count > 0, nil
+}
+
+// SaveTransition saves a request's new state and its history event in one transaction
+func (r *regradeRepository) SaveTransition(request *RegradeRequest, event *RegradeEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return r.saveTransition(tx, request, event)
+	})
+}
+
+// SaveAccepted writes an accepted request's adjusted score back to the contested
+// grade or submission, together with the request state and its history event. The grade goes
+// back to draft; the submission's rubric criterion scores are cleared as they no longer add up.
+func (r *regradeRepository) SaveAccepted(request *RegradeRequest, event *RegradeEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var result *gorm.DB
+		switch request.TargetType {
+		case TargetExamGrade:
+			result = tx.Model(&grade.Grade{}).Where("id = ?", *request.GradeID).
+				Updates(map[string]interface{}{"score": *request.AdjustedScore, "status": grade.GradeDraft, "published_at": nil})
+		case TargetHomework:
+			result = tx.Model(&students_homework.StudentHomework{}).Where("id = ?", *request.SubmissionID).
+				Update("score", *request.AdjustedScore)
+		default:
+			return fmt.Errorf("unknown regrade target type %q", request.TargetType)
+		}
+		if result.Error != nil {
+			return fmt.Errorf("failed to write adjusted score: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("contested score no longer exists")
+		}
+		if request.TargetType == TargetHomework {
+			if err := tx.Unscoped().Where("student_homework_id = ?", *request.SubmissionID).
+				Delete(&students_homework.CriterionScore{}).Error; err != nil {
+				return fmt.Errorf("failed to clear criterion scores: %w", err)
+			}
+		}
+		return r.saveTransition(tx, request, event)
+	})
+}
+
+func (r *regradeRepository) saveTransition(tx *gorm.DB, request *RegradeRequest, event *RegradeEvent) error {
+	if err := tx.Omit("Student", "Reviewer", "EscalatedDepartment", "Attachments", "Events").
+		Save(request).Error; err != nil {
+		return fmt.Errorf("failed to save regrade request: %w", err)
+	}
+	event.RegradeRequestID = request.ID
+	if err := tx.Create(event).Error; err != nil {
+		return fmt.Errorf("failed to record regrade event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,450 @@
+package regrade
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"school_management/internal/modules/course"
+	"school_management/internal/modules/department"
+	"school_management/internal/modules/exam"
+	"school_management/internal/modules/grade"
+	"school_management/internal/modules/homework"
+	"school_management/internal/modules/students_homework"
+)
+
+// RegradeService defines the business logic interface
+type RegradeService interface {
+	Create(req *CreateRegradeRequest) (*RegradeResponse, error)
+	GetByID(id uint) (*RegradeResponse, error)
+	GetByStudent(studentID uint) ([]RegradeResponse, error)
+	GetByCourse(courseID uint) ([]RegradeResponse, error)
+	GetEscalatedByDepartment(departmentID uint) ([]RegradeResponse, error)
+	StartReview(id uint, req *StartReviewRequest) (*RegradeResponse, error)
+	Decide(id uint, req *DecisionRequest) (*RegradeResponse, error)
+	Escalate(id uint, req *EscalateRequest) (*RegradeResponse, error)
+}
+
+// regradeService implements RegradeService
+type regradeService struct {
+	repo           RegradeRepository
+	gradeRepo      grade.GradeRepository
+	examRepo       exam.ExamRepository
+	submissionRepo students_homework.StudentHomeworkRepository
+	homeworkRepo   homework.HomeworkRepository
+	courseRepo     course.CourseRepository
+	deptService    department.DepartmentService
+}
+
+// NewRegradeService creates a new regrade service with DI
+func NewRegradeService(
+	repo RegradeRepository,
+	gradeRepo grade.GradeRepository,
+	examRepo exam.ExamRepository,
+	submissionRepo students_homework.StudentHomeworkRepository,
+	homeworkRepo homework.HomeworkRepository,
+	courseRepo course.CourseRepository,
+	deptService department.DepartmentService,
+) RegradeService {
+	return &regradeService{
+		repo:           repo,
+		gradeRepo:      gradeRepo,
+		examRepo:       examRepo,
+		submissionRepo: submissionRepo,
+		homeworkRepo:   homeworkRepo,
+		courseRepo:     courseRepo,
+		deptService:    deptService,
+	}
+}
+
+// contested is the score a regrade request targets
+type contested struct {
+	score       float64
+	maxScore    float64
+	courseID    uint
+	publishedAt time.Time
+}
+
+// Create files a regrade request against a published exam grade or homework score
+func (s *regradeService) Create(req *CreateRegradeRequest) (*RegradeResponse, error) {
+	// Validate
+	if err := s.validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+
+	target := TargetExamGrade
+	if req.SubmissionID != nil {
+		target = TargetHomework
+	}
+
+	c, err := s.resolveTarget(target, req.StudentID, req.GradeID, req.SubmissionID)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := c.publishedAt.AddDate(0, 0, AppealWindowDays)
+	if time.Now().After(deadline) {
+		return nil, fmt.Errorf("appeal window closed on %s", deadline.Format(time.RFC3339))
+	}
+
+	active, err := s.repo.HasActiveForTarget(req.GradeID, req.SubmissionID)
+	if err != nil {
+		return nil, err
+	}
+	if active {
+		return nil, fmt.Errorf("a regrade request for this score is already in progress")
+	}
+
+	// Map DTO to Model
+	request := &RegradeRequest{
+		StudentID:     req.StudentID,
+		TargetType:    target,
+		GradeID:       req.GradeID,
+		SubmissionID:  req.SubmissionID,
+		CourseID:      c.courseID,
+		Reason:        req.Reason,
+		Status:        RequestOpen,
+		OriginalScore: c.score,
+		PublishedAt:   c.publishedAt,
+		Events:        []RegradeEvent{{ToStatus: RequestOpen, Note: "regrade request filed"}},
+	}
+	for _, a := range req.Attachments {
+		request.Attachments = append(request.Attachments, RegradeAttachment{
+			FileName:    a.FileName,
+			FileURL:     a.FileURL,
+			ContentType: a.ContentType,
+			SizeBytes:   a.SizeBytes,
+		})
+	}
+
+	// Create via repository
+	if err := s.repo.Create(request); err != nil {
+		return nil, fmt.Errorf("failed to file regrade request: %w", err)
+	}
+
+	return s.toResponseDTO(request), nil
+}
+
+// GetByID retrieves a regrade request with its attachments and history
+func (s *regradeService) GetByID(id uint) (*RegradeResponse, error) {
+	request, err := s.repo.GetByIDWithHistory(id)
+	if err != nil {
+		return nil, fmt.Errorf("regrade request not found: %w", err)
+	}
+	return s.toResponseDTO(request), nil
+}
+
+// GetByStudent retrieves regrade requests filed by a student
+func (s *regradeService) GetByStudent(studentID uint) ([]RegradeResponse, error) {
+	requests, err := s.repo.GetByStudent(studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get regrade requests: %w", err)
+	}
+	return s.toResponseDTOList(requests), nil
+}
+
+// GetByCourse retrieves regrade requests for a course
+func (s *regradeService) GetByCourse(courseID uint) ([]RegradeResponse, error) {
+	requests, err := s.repo.GetByCourse(courseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get regrade requests: %w", err)
+	}
+	return s.toResponseDTOList(requests), nil
+}
+
+// GetEscalatedByDepartment retrieves the escalated requests awaiting a decision from the head of
+// a department, including those escalated to any department below it
+func (s *regradeService) GetEscalatedByDepartment(departmentID uint) ([]RegradeResponse, error) {
+	deptIDs, err := s.deptService.GetSubtreeIDs(departmentID)
+	if err != nil {
+		return nil, err
+	}
+	requests, err := s.repo.GetEscalatedByDepartment(deptIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get escalated regrade requests: %w", err)
+	}
+	return s.toResponseDTOList(requests), nil
+}
+
+// StartReview takes an open request under review
+func (s *regradeService) StartReview(id uint, req *StartReviewRequest) (*RegradeResponse, error) {
+	// Get existing
+	request, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("regrade request not found: %w", err)
+	}
+
+	if request.Status != RequestOpen {
+		return nil, fmt.Errorf("only open regrade requests can be taken under review (status: %s)", request.Status)
+	}
+	if err := s.checkReviewer(request, req.ReviewerID); err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	event := &RegradeEvent{FromStatus: request.Status, ToStatus: RequestUnderReview, ActorID: &req.ReviewerID, Note: req.Note}
+	request.Status = RequestUnderReview
+	request.ReviewerID = &req.ReviewerID
+
+	// Save
+	if err := s.repo.SaveTransition(request, event); err != nil {
+		return nil, fmt.Errorf("failed to start review: %w", err)
+	}
+
+	return s.GetByID(request.ID)
+}
+
+// Decide accepts or rejects a request under review. Accepting writes the adjusted
+// score back to the contested grade and records the change in the request history; an exam
+// grade goes back to draft until it is published again.
+func (s *regradeService) Decide(id uint, req *DecisionRequest) (*RegradeResponse, error) {
+	// Get existing
+	request, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("regrade request not found: %w", err)
+	}
+
+	if request.Status != RequestUnderReview {
+		return nil, fmt.Errorf("only regrade requests under review can be decided (status: %s)", request.Status)
+	}
+	if err := s.checkReviewer(request, req.ReviewerID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	event := &RegradeEvent{FromStatus: request.Status, ActorID: &req.ReviewerID, Note: req.Decision}
+	request.ReviewerID = &req.ReviewerID
+	request.Decision = req.Decision
+	request.DecidedAt = &now
+
+	if !req.Accept {
+		event.ToStatus = RequestRejected
+		request.Status = RequestRejected
+		if err := s.repo.SaveTransition(request, event); err != nil {
+			return nil, fmt.Errorf("failed to reject regrade request: %w", err)
+		}
+		return s.GetByID(request.ID)
+	}
+
+	if req.AdjustedScore == nil {
+		return nil, fmt.Errorf("adjusted score is required when accepting a regrade request")
+	}
+	c, err := s.resolveTarget(request.TargetType, request.StudentID, request.GradeID, request.SubmissionID)
+	if err != nil {
+		return nil, err
+	}
+	if *req.AdjustedScore > c.maxScore {
+		return nil, fmt.Errorf("adjusted score cannot exceed the maximum of %.2f", c.maxScore)
+	}
+
+	event.ToStatus = RequestAccepted
+	event.OldScore = &c.score
+	event.NewScore = req.AdjustedScore
+	request.Status = RequestAccepted
+	request.AdjustedScore = req.AdjustedScore
+
+	if err := s.repo.SaveAccepted(request, event); err != nil {
+		return nil, fmt.Errorf("failed to accept regrade request: %w", err)
+	}
+
+	return s.GetByID(request.ID)
+}
+
+// Escalate sends a rejected request to the head of the course's department, or to the nearest
+// department above it with a head, reopening it for a second review
+func (s *regradeService) Escalate(id uint, req *EscalateRequest) (*RegradeResponse, error) {
+	// Get existing
+	request, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("regrade request not found: %w", err)
+	}
+
+	if request.Status != RequestRejected {
+		return nil, fmt.Errorf("only rejected regrade requests can be escalated (status: %s)", request.Status)
+	}
+	if request.IsClosed() {
+		return nil, fmt.Errorf("regrade request has already been escalated to department %d", *request.EscalatedDepartmentID)
+	}
+
+	crs, err := s.courseRepo.GetByID(request.CourseID)
+	if err != nil {
+		return nil, fmt.Errorf("course not found: %w", err)
+	}
+	departmentID := crs.DepartmentID
+	headed, err := s.deptService.NearestHead(crs.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+	if headed != nil {
+		departmentID = headed.ID
+	}
+
+	// Update fields
+	now := time.Now()
+	note := fmt.Sprintf("escalated to department %d", departmentID)
+	if strings.TrimSpace(req.Note) != "" {
+		note += ": " + req.Note
+	}
+	event := &RegradeEvent{FromStatus: request.Status, ToStatus: RequestOpen, Note: note}
+	request.Status = RequestOpen
+	request.EscalatedDepartmentID = &departmentID
+	request.EscalatedAt = &now
+	request.ReviewerID = nil
+	request.Decision = ""
+	request.DecidedAt = nil
+
+	// Save
+	if err := s.repo.SaveTransition(request, event); err != nil {
+		return nil, fmt.Errorf("failed to escalate regrade request: %w", err)
+	}
+
+	return s.GetByID(request.ID)
+}
+
+// checkReviewer limits a request to the course's teacher or the head of its department, or of a
+// department above it. An escalated request is limited to the head of the department it was
+// escalated to, or of a department above it; departments without any head leave it open to anyone.
+func (s *regradeService) checkReviewer(request *RegradeRequest, reviewerID uint) error {
+	if request.EscalatedDepartmentID != nil {
+		return s.deptService.CheckHead(reviewerID, *request.EscalatedDepartmentID)
+	}
+
+	crs, err := s.courseRepo.GetByID(request.CourseID)
+	if err != nil {
+		return fmt.Errorf("course not found: %w", err)
+	}
+	if crs.TeacherID == reviewerID {
+		return nil
+	}
+	headed, err := s.deptService.NearestHead(crs.DepartmentID)
+	if err != nil {
+		return err
+	}
+	if headed == nil {
+		return fmt.Errorf("%w: teacher %d does not teach course %d", department.ErrNotHead, reviewerID, crs.ID)
+	}
+	return s.deptService.CheckHead(reviewerID, crs.DepartmentID)
+}
+
+// resolveTarget loads the contested score and checks it belongs to the student and has been published
+func (s *regradeService) resolveTarget(target TargetType, studentID uint, gradeID, submissionID *uint) (*contested, error) {
+	switch target {
+	case TargetExamGrade:
+		gr, err := s.gradeRepo.GetByID(*gradeID)
+		if err != nil {
+			return nil, fmt.Errorf("grade not found: %w", err)
+		}
+		if gr.StudentID != studentID {
+			return nil, fmt.Errorf("grade %d does not belong to student %d", gr.ID, studentID)
+		}
+		if gr.Status != grade.GradePublished {
+			return nil, fmt.Errorf("grade has not been published yet")
+		}
+		ex, err := s.examRepo.GetByID(gr.ExamID)
+		if err != nil {
+			return nil, fmt.Errorf("exam not found: %w", err)
+		}
+		publishedAt := gr.UpdatedAt
+		if gr.PublishedAt != nil {
+			publishedAt = *gr.PublishedAt
+		}
+		return &contested{score: gr.Score, maxScore: ex.MaxScore, courseID: ex.CourseID, publishedAt: publishedAt}, nil
+
+	case TargetHomework:
+		submission, err := s.submissionRepo.GetByID(*submissionID)
+		if err != nil {
+			return nil, fmt.Errorf("submission not found: %w", err)
+		}
+		if submission.StudentID != studentID {
+			return nil, fmt.Errorf("submission %d does not belong to student %d", submission.ID, studentID)
+		}
+		if submission.Status != students_homework.HomeworkGraded || submission.Score == nil {
+			return nil, fmt.Errorf("submission has not been graded")
+		}
+		if submission.GradeStatus != students_homework.GradePublished {
+			return nil, fmt.Errorf("homework grade has not been published yet")
+		}
+		hw, err := s.homeworkRepo.GetByID(submission.HomeworkID)
+		if err != nil {
+			return nil, fmt.Errorf("homework not found: %w", err)
+		}
+		publishedAt := submission.UpdatedAt
+		if submission.GradePublishedAt != nil {
+			publishedAt = *submission.GradePublishedAt
+		}
+		return &contested{score: *submission.Score, maxScore: hw.MaxScore, courseID: hw.CourseID, publishedAt: publishedAt}, nil
+	}
+
+	return nil, fmt.Errorf("unknown regrade target type %q", target)
+}
+
+// Validation methods
+func (s *regradeService) validateCreateRequest(req *CreateRegradeRequest) error {
+	if req.StudentID == 0 {
+		return fmt.Errorf("student ID is required")
+	}
+	if (req.GradeID == nil) == (req.SubmissionID == nil) {
+		return fmt.Errorf("exactly one of grade_id or submission_id is required")
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		return fmt.Errorf("reason is required")
+	}
+	return nil
+}
+
+// DTO mapping methods
+func (s *regradeService) toResponseDTO(request *RegradeRequest) *RegradeResponse {
+	resp := &RegradeResponse{
+		ID:                    request.ID,
+		StudentID:             request.StudentID,
+		TargetType:            string(request.TargetType),
+		GradeID:               request.GradeID,
+		SubmissionID:          request.SubmissionID,
+		CourseID:              request.CourseID,
+		Reason:                request.Reason,
+		Status:                string(request.Status),
+		OriginalScore:         request.OriginalScore,
+		AdjustedScore:         request.AdjustedScore,
+		PublishedAt:           request.PublishedAt,
+		AppealDeadline:        request.PublishedAt.AddDate(0, 0, AppealWindowDays),
+		ReviewerID:            request.ReviewerID,
+		Decision:              request.Decision,
+		DecidedAt:             request.DecidedAt,
+		EscalatedDepartmentID: request.EscalatedDepartmentID,
+		EscalatedAt:           request.EscalatedAt,
+		CreatedAt:             request.CreatedAt,
+		UpdatedAt:             request.UpdatedAt,
+	}
+
+	for _, a := range request.Attachments {
+		resp.Attachments = append(resp.Attachments, AttachmentResponse{
+			ID:          a.ID,
+			FileName:    a.FileName,
+			FileURL:     a.FileURL,
+			ContentType: a.ContentType,
+			SizeBytes:   a.SizeBytes,
+		})
+	}
+	for _, e := range request.Events {
+		resp.Events = append(resp.Events, EventResponse{
+			ID:         e.ID,
+			FromStatus: string(e.FromStatus),
+			ToStatus:   string(e.ToStatus),
+			ActorID:    e.ActorID,
+			Note:       e.Note,
+			OldScore:   e.OldScore,
+			NewScore:   e.NewScore,
+			CreatedAt:  e.CreatedAt,
+		})
+	}
+
+	return resp
+}
+
+func (s *regradeService) toResponseDTOList(requests []RegradeRequest) []RegradeResponse {
+	responses := make([]RegradeResponse, len(requests))
+	for i, request := range requests {
+		responses[i] = *s.toResponseDTO(&request)
+	}
+	return responses
+}
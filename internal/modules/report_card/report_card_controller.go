@@ -0,0 +1,283 @@
+package report_card
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"school_management/internal/export"
+)
+
+// ReportCardController handles HTTP requests for report cards
+type ReportCardController struct {
+	service ReportCardService
+}
+
+// NewReportCardController creates a new report card controller
+func NewReportCardController(service ReportCardService) *ReportCardController {
+	return &ReportCardController{service: service}
+}
+
+// templateQuery reads the optional template_id query parameter
+func templateQuery(ctx *gin.Context) (*uint, bool) {
+	raw := ctx.Query("template_id")
+	if raw == "" {
+		return nil, true
+	}
+	id, err := strconv.ParseUint(raw, 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid template ID"})
+		return nil, false
+	}
+	templateID := uint(id)
+	return &templateID, true
+}
+
+// sendFile writes a generated file as a download
+func sendFile(ctx *gin.Context, contentType, name string, data []byte) {
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	ctx.Data(http.StatusOK, contentType, data)
+}
+
+// GetReportCard renders a student's report card PDF for ?term= (default: current term)
+func (c *ReportCardController) GetReportCard(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+	templateID, ok := templateQuery(ctx)
+	if !ok {
+		return
+	}
+
+	doc, name, err := c.service.Generate(uint(id), ctx.Query("term"), templateID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	sendFile(ctx, "application/pdf", name, doc)
+}
+
+// SaveComment records a teacher's comment for a student's report card
+func (c *ReportCardController) SaveComment(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	var req SaveCommentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.SaveComment(uint(id), &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetComments retrieves a student's report card comments for ?term=
+func (c *ReportCardController) GetComments(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetComments(uint(id), ctx.Query("term"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// StartCourseJob queues a ZIP of report cards for every student in a course
+func (c *ReportCardController) StartCourseJob(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+	templateID, ok := templateQuery(ctx)
+	if !ok {
+		return
+	}
+
+	resp, err := c.service.StartCourseJob(uint(id), ctx.Query("term"), templateID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, resp)
+}
+
+// GetJob retrieves a bulk report card job
+func (c *ReportCardController) GetJob(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetJob(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// DownloadJob downloads a completed job's ZIP archive
+func (c *ReportCardController) DownloadJob(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	archive, name, err := c.service.GetJobArchive(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	sendFile(ctx, "application/zip", name, archive)
+}
+
+// CreateTemplate creates a report card template
+func (c *ReportCardController) CreateTemplate(ctx *gin.Context) {
+	var req CreateTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.CreateTemplate(&req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// GetTemplate retrieves a report card template by ID
+func (c *ReportCardController) GetTemplate(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetTemplate(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetAllTemplates retrieves all report card templates
+func (c *ReportCardController) GetAllTemplates(ctx *gin.Context) {
+	resp, err := c.service.GetAllTemplates()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// GetDefaultTemplateBody returns the built-in layout as a starting point for custom templates
+func (c *ReportCardController) GetDefaultTemplateBody(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"body": DefaultTemplateBody})
+}
+
+// UpdateTemplate updates a report card template
+func (c *ReportCardController) UpdateTemplate(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	var req UpdateTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.UpdateTemplate(uint(id), &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// DeleteTemplate deletes a report card template
+func (c *ReportCardController) DeleteTemplate(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	if err := c.service.DeleteTemplate(uint(id)); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "report card template deleted successfully"})
+}
+
+// RegisterRoutes registers report card routes
+func (c *ReportCardController) RegisterRoutes(rg *gin.RouterGroup) {
+	students := rg.Group("/students")
+	{
+		students.GET("/:id/report-card", c.GetReportCard)
+		students.GET("/:id/report-card/comments", c.GetComments)
+		students.PUT("/:id/report-card/comments", c.SaveComment)
+	}
+
+	rg.POST("/courses/:id/report-cards", c.StartCourseJob)
+
+	jobs := rg.Group("/report-card-jobs")
+	{
+		jobs.GET("/:id", c.GetJob)
+		jobs.GET("/:id/download", c.DownloadJob)
+	}
+
+	templates := rg.Group("/report-card-templates")
+	{
+		templates.POST("", c.CreateTemplate)
+		templates.GET("", c.GetAllTemplates)
+		templates.GET("/built-in", c.GetDefaultTemplateBody)
+		templates.GET("/:id", c.GetTemplate)
+		templates.PUT("/:id", c.UpdateTemplate)
+		templates.DELETE("/:id", c.DeleteTemplate)
+	}
+}
@@ -0,0 +1,65 @@
+package report_card
+
+import "time"
+
+// CreateTemplateRequest represents the request body for creating a report card template
+type CreateTemplateRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	Body        string `json:"body" binding:"required"`
+	IsDefault   bool   `json:"is_default"`
+}
+
+// UpdateTemplateRequest represents the request body for updating a report card template
+type UpdateTemplateRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Body        string `json:"body"`
+	IsDefault   *bool  `json:"is_default"`
+}
+
+// TemplateResponse represents a report card template
+type TemplateResponse struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Body        string    `json:"body"`
+	IsDefault   bool      `json:"is_default"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// SaveCommentRequest represents the request body for a teacher's report card comment.
+// TeacherID defaults to the course's teacher.
+type SaveCommentRequest struct {
+	CourseID  uint   `json:"course_id" binding:"required"`
+	Term      string `json:"term" binding:"required"`
+	TeacherID uint   `json:"teacher_id"`
+	Comment   string `json:"comment" binding:"required"`
+}
+
+// CommentResponse represents a teacher's report card comment
+type CommentResponse struct {
+	ID          uint      `json:"id"`
+	StudentID   uint      `json:"student_id"`
+	CourseID    uint      `json:"course_id"`
+	Term        string    `json:"term"`
+	TeacherID   uint      `json:"teacher_id"`
+	TeacherName string    `json:"teacher_name"`
+	Comment     string    `json:"comment"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// JobResponse represents a bulk report card job
+type JobResponse struct {
+	ID           uint       `json:"id"`
+	CourseID     uint       `json:"course_id"`
+	Term         string     `json:"term"`
+	TemplateID   *uint      `json:"template_id"`
+	Status       string     `json:"status"`
+	StudentCount int        `json:"student_count"`
+	StartedAt    *time.Time `json:"started_at"`
+	CompletedAt  *time.Time `json:"completed_at"`
+	Error        string     `json:"error,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
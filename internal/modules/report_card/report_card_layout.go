@@ -0,0 +1,219 @@
+package report_card
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"school_management/internal/pdf"
+)
+
+// Template bodies are Go text/templates executed against ReportCardData. Each line of the
+// output is laid out by its prefix:
+//
+//	# Title           large heading
+//	## Section        section heading
+//	---               horizontal rule
+//	| a | b | c |     table row; the first row of a block is the header,
+//	                  and separator rows such as |---|---| are skipped
+//	(blank line)      vertical space
+//	anything else     wrapped paragraph text
+
+// DefaultTemplateBody is the built-in layout, used when the school has not saved a default template
+const DefaultTemplateBody = `# Report Card
+Student: {{.StudentName}} (ID {{.StudentID}})
+Term: {{.Term}}    Issued: {{.IssuedOn}}
+---
+## Courses
+| Course | Teacher | Credits | Exams | Homework | Overall | Grade |
+{{range .Courses}}| {{.Code}} {{.Name}} | {{.Teacher}} | {{.Credits}} | {{pct .ExamPercent}} | {{pct .HomeworkPercent}} | {{pct .OverallPercent}} | {{.Letter}} |
+{{end}}
+Overall average: {{pct .OverallPercent}}
+
+## Attendance
+| Sessions | Present | Late | Absent | Attendance rate |
+| {{.Attendance.Sessions}} | {{.Attendance.Present}} | {{.Attendance.Late}} | {{.Attendance.Absent}} | {{pct .Attendance.Rate}} |
+
+## Teacher Comments
+{{range .Courses}}{{if .Comment}}{{.Code}} - {{.Teacher}}: {{.Comment}}
+{{end}}{{end}}`
+
+// ReportCardData is everything a template can show about a student's term
+type ReportCardData struct {
+	StudentID      uint
+	StudentName    string
+	Email          string
+	Term           string
+	IssuedOn       string
+	Courses        []CourseReport
+	Attendance     AttendanceSummary
+	OverallPercent *float64
+}
+
+// CourseReport is one course's results on a report card. Percentages are nil when
+// there is nothing published to average.
+type CourseReport struct {
+	Code            string
+	Name            string
+	Credits         int
+	Teacher         string
+	Exams           []ResultLine
+	Homework        []ResultLine
+	ExamPercent     *float64
+	HomeworkPercent *float64
+	OverallPercent  *float64
+	Letter          string
+	Attendance      AttendanceSummary
+	Comment         string
+}
+
+// AttendanceSummary counts attendance records for a term
+type AttendanceSummary struct {
+	Sessions int
+	Present  int
+	Late     int
+	Absent   int
+	Rate     *float64 // share of sessions attended, late counting as attended
+}
+
+// add counts attendance records of one status
+func (a *AttendanceSummary) add(status string, count int) {
+	a.Sessions += count
+	switch status {
+	case "present":
+		a.Present += count
+	case "late":
+		a.Late += count
+	case "absent":
+		a.Absent += count
+	}
+}
+
+// finish computes the attendance rate once all records are counted
+func (a *AttendanceSummary) finish() {
+	if a.Sessions == 0 {
+		return
+	}
+	rate := float64(a.Present+a.Late) * 100 / float64(a.Sessions)
+	a.Rate = &rate
+}
+
+var templateFuncs = template.FuncMap{
+	// pct formats an optional percentage, showing "-" when there is none
+	"pct": func(v *float64) string {
+		if v == nil {
+			return "-"
+		}
+		return fmt.Sprintf("%.1f%%", *v)
+	},
+	// score formats a result as "score / max"
+	"score": func(r ResultLine) string {
+		return fmt.Sprintf("%g / %g", r.Score, r.MaxScore)
+	},
+	// date formats a result's exam date or homework due date
+	"date": func(r ResultLine) string {
+		return r.Date.Format("2006-01-02")
+	},
+}
+
+// parseTemplate compiles a template body, reporting syntax errors
+func parseTemplate(body string) (*template.Template, error) {
+	tmpl, err := template.New("report_card").Funcs(templateFuncs).Option("missingkey=error").Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// render executes a template against the data and lays the output out as a PDF
+func render(tmpl *template.Template, data *ReportCardData) ([]byte, error) {
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return nil, fmt.Errorf("failed to render template: %w", err)
+	}
+
+	doc := pdf.New()
+	var table [][]string
+	flush := func() {
+		if len(table) > 0 {
+			doc.Table(table[0], table[1:])
+			table = nil
+		}
+	}
+
+	for _, line := range strings.Split(out.String(), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "|") {
+			if cells := tableCells(trimmed); cells != nil {
+				table = append(table, cells)
+			}
+			continue
+		}
+		flush()
+
+		switch {
+		case trimmed == "":
+			doc.Space(6)
+		case trimmed == "---":
+			doc.Rule()
+		case strings.HasPrefix(trimmed, "## "):
+			doc.Subheading(strings.TrimPrefix(trimmed, "## "))
+		case strings.HasPrefix(trimmed, "# "):
+			doc.Heading(strings.TrimPrefix(trimmed, "# "))
+		default:
+			doc.Paragraph(trimmed)
+		}
+	}
+	flush()
+
+	return doc.Bytes(), nil
+}
+
+// tableCells splits a "| a | b |" row into cells; separator rows return nil
+func tableCells(row string) []string {
+	row = strings.TrimSuffix(strings.TrimPrefix(row, "|"), "|")
+	if strings.Trim(row, "|-: ") == "" {
+		return nil
+	}
+	cells := strings.Split(row, "|")
+	for i := range cells {
+		cells[i] = strings.TrimSpace(cells[i])
+	}
+	return cells
+}
+
+// letterGrade maps a course percentage to a letter grade
+func letterGrade(percent *float64) string {
+	if percent == nil {
+		return "-"
+	}
+	switch p := *percent; {
+	case p >= 90:
+		return "A"
+	case p >= 80:
+		return "B"
+	case p >= 70:
+		return "C"
+	case p >= 60:
+		return "D"
+	default:
+		return "F"
+	}
+}
+
+// percentOf returns total score as a percentage of total max score, or nil if there is none
+func percentOf(results ...[]ResultLine) *float64 {
+	var score, possible float64
+	for _, rs := range results {
+		for _, r := range rs {
+			score += r.Score
+			possible += r.MaxScore
+		}
+	}
+	if possible == 0 {
+		return nil
+	}
+	p := score * 100 / possible
+	return &p
+}
@@ -0,0 +1,112 @@
+package report_card
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"school_management/internal/modules/course"
+	"school_management/internal/modules/student"
+	"school_management/internal/modules/teacher"
+)
+
+// ReportCardTemplate is a school-editable report card layout. The body is a Go text/template
+// whose output uses a small line markup; see report_card_layout.go.
+type ReportCardTemplate struct {
+	gorm.Model
+	Name        string `gorm:"uniqueIndex;not null;size:100" json:"name"`
+	Description string `gorm:"type:text" json:"description"`
+	Body        string `gorm:"type:text;not null" json:"body"`
+	IsDefault   bool   `gorm:"not null;default:false;comment:used when no template is requested" json:"is_default"`
+}
+
+// TableName specifies the table name for the ReportCardTemplate model
+func (ReportCardTemplate) TableName() string {
+	return "report_card_templates"
+}
+
+// ReportCardComment is a teacher's comment on a student's course for one term
+type ReportCardComment struct {
+	gorm.Model
+	StudentID uint   `gorm:"not null;uniqueIndex:idx_report_card_comment" json:"student_id"`
+	CourseID  uint   `gorm:"not null;uniqueIndex:idx_report_card_comment" json:"course_id"`
+	Term      string `gorm:"not null;size:10;uniqueIndex:idx_report_card_comment" json:"term"`
+	TeacherID uint   `gorm:"not null" json:"teacher_id"`
+	Comment   string `gorm:"type:text;not null" json:"comment"`
+
+	// Belongs To relationships
+	Student student.Student `gorm:"foreignKey:StudentID" json:"student,omitempty"`
+	Course  course.Course   `gorm:"foreignKey:CourseID" json:"course,omitempty"`
+	Teacher teacher.Teacher `gorm:"foreignKey:TeacherID" json:"teacher,omitempty"`
+}
+
+// TableName specifies the table name for the ReportCardComment model
+func (ReportCardComment) TableName() string {
+	return "report_card_comments"
+}
+
+// JobStatus represents the state of a bulk report card job
+type JobStatus string
+
+const (
+	JobPending   JobStatus = "pending"
+	JobRunning   JobStatus = "running"
+	JobCompleted JobStatus = "completed"
+	JobFailed    JobStatus = "failed"
+)
+
+// ReportCardJob renders the report cards of every student in a course into one ZIP archive
+type ReportCardJob struct {
+	gorm.Model
+	CourseID     uint       `gorm:"not null;index" json:"course_id"`
+	Term         string     `gorm:"not null;size:10" json:"term"`
+	TemplateID   *uint      `json:"template_id"`
+	Status       JobStatus  `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	StudentCount int        `gorm:"not null;default:0" json:"student_count"`
+	Archive      []byte     `gorm:"type:bytea" json:"-"`
+	StartedAt    *time.Time `gorm:"type:timestamp" json:"started_at"`
+	CompletedAt  *time.Time `gorm:"type:timestamp" json:"completed_at"`
+	Error        string     `gorm:"type:text" json:"error"`
+
+	// Belongs To relationship
+	Course course.Course `gorm:"foreignKey:CourseID" json:"course,omitempty"`
+}
+
+// TableName specifies the table name for the ReportCardJob model
+func (ReportCardJob) TableName() string {
+	return "report_card_jobs"
+}
+
+// EnrolledCourse is a course a student takes, with its teacher's name
+type EnrolledCourse struct {
+	CourseID         uint
+	Code             string
+	Name             string
+	Credits          int
+	TeacherID        uint
+	TeacherFirstName string
+	TeacherLastName  string
+}
+
+// ResultLine is one published exam or homework result
+type ResultLine struct {
+	CourseID uint
+	Title    string
+	Date     time.Time
+	Score    float64
+	MaxScore float64
+}
+
+// AttendanceCount is the number of attendance records with one status in a course
+type AttendanceCount struct {
+	CourseID uint
+	Status   string
+	Count    int
+}
+
+// CourseStudent is a student enrolled in a course
+type CourseStudent struct {
+	StudentID uint
+	FirstName string
+	LastName  string
+}
@@ -0,0 +1,271 @@
+package report_card
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"school_management/internal/modules/grade"
+	"school_management/internal/modules/students_homework"
+)
+
+// ReportCardRepository defines the interface for report card data access
+type ReportCardRepository interface {
+	// Templates
+	CreateTemplate(tmpl *ReportCardTemplate) error
+	GetTemplateByID(id uint) (*ReportCardTemplate, error)
+	GetDefaultTemplate() (*ReportCardTemplate, error)
+	GetAllTemplates() ([]ReportCardTemplate, error)
+	UpdateTemplate(tmpl *ReportCardTemplate) error
+	DeleteTemplate(id uint) error
+
+	// Comments
+	SaveComment(comment *ReportCardComment) error
+	GetComments(studentID uint, term string) ([]ReportCardComment, error)
+
+	// Report data
+	GetEnrolledCourses(studentID uint, academicYear string, start, end time.Time) ([]EnrolledCourse, error)
+	GetExamResults(studentID uint, start, end time.Time) ([]ResultLine, error)
+	GetHomeworkResults(studentID uint, start, end time.Time) ([]ResultLine, error)
+	GetAttendanceCounts(studentID uint, start, end time.Time) ([]AttendanceCount, error)
+	GetCourseStudents(courseID uint) ([]CourseStudent, error)
+
+	// Bulk jobs
+	CreateJob(job *ReportCardJob) error
+	GetJobByID(id uint) (*ReportCardJob, error)
+	GetLatestJob(courseID uint, term string) (*ReportCardJob, error)
+	UpdateJob(job *ReportCardJob) error
+}
+
+// reportCardRepository implements ReportCardRepository
+type reportCardRepository struct {
+	db *gorm.DB
+}
+
+// NewReportCardRepository creates a new report card repository with dependency injection
+func NewReportCardRepository(db *gorm.DB) ReportCardRepository {
+	return &reportCardRepository{db: db}
+}
+
+// CreateTemplate creates a template; a new default template replaces the previous one
+func (r *reportCardRepository) CreateTemplate(tmpl *ReportCardTemplate) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := clearDefault(tx, tmpl); err != nil {
+			return err
+		}
+		if err := tx.Create(tmpl).Error; err != nil {
+			return fmt.Errorf("failed to create report card template: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetTemplateByID retrieves a template by ID
+func (r *reportCardRepository) GetTemplateByID(id uint) (*ReportCardTemplate, error) {
+	var tmpl ReportCardTemplate
+	if err := r.db.First(&tmpl, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get report card template: %w", err)
+	}
+	return &tmpl, nil
+}
+
+// GetDefaultTemplate retrieves the school's default template, or nil if none is marked default
+func (r *reportCardRepository) GetDefaultTemplate() (*ReportCardTemplate, error) {
+	var tmpl ReportCardTemplate
+	err := r.db.Where("is_default = ?", true).First(&tmpl).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get default report card template: %w", err)
+	}
+	return &tmpl, nil
+}
+
+// GetAllTemplates retrieves all templates
+func (r *reportCardRepository) GetAllTemplates() ([]ReportCardTemplate, error) {
+	var templates []ReportCardTemplate
+	if err := r.db.Order("name ASC").Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("failed to get report card templates: %w", err)
+	}
+	return templates, nil
+}
+
+// UpdateTemplate updates a template; making it the default unsets the previous default
+func (r *reportCardRepository) UpdateTemplate(tmpl *ReportCardTemplate) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := clearDefault(tx, tmpl); err != nil {
+			return err
+		}
+		if err := tx.Save(tmpl).Error; err != nil {
+			return fmt.Errorf("failed to update report card template: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeleteTemplate soft deletes a template
+func (r *reportCardRepository) DeleteTemplate(id uint) error {
+	if err := r.db.Delete(&ReportCardTemplate{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete report card template: %w", err)
+	}
+	return nil
+}
+
+// clearDefault unsets the default flag on every other template when tmpl is the default
+func clearDefault(tx *gorm.DB, tmpl *ReportCardTemplate) error {
+	if !tmpl.IsDefault {
+		return nil
+	}
+	if err := tx.Model(&ReportCardTemplate{}).
+		Where("is_default = ? AND id <> ?", true, tmpl.ID).
+		Update("is_default", false).Error; err != nil {
+		return fmt.Errorf("failed to clear default report card template: %w", err)
+	}
+	return nil
+}
+
+// SaveComment creates or replaces a teacher's comment on a student's course for a term
+func (r *reportCardRepository) SaveComment(comment *ReportCardComment) error {
+	if err := r.db.Omit(clause.Associations).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "student_id"}, {Name: "course_id"}, {Name: "term"}},
+		DoUpdates: clause.AssignmentColumns([]string{"teacher_id", "comment", "updated_at", "deleted_at"}),
+	}).Create(comment).Error; err != nil {
+		return fmt.Errorf("failed to save report card comment: %w", err)
+	}
+	return nil
+}
+
+// GetComments retrieves a student's comments for a term
+func (r *reportCardRepository) GetComments(studentID uint, term string) ([]ReportCardComment, error) {
+	var comments []ReportCardComment
+	if err := r.db.Preload("Teacher").
+		Where("student_id = ? AND term = ?", studentID, term).
+		Find(&comments).Error; err != nil {
+		return nil, fmt.Errorf("failed to get report card comments: %w", err)
+	}
+	return comments, nil
+}
+
+// GetEnrolledCourses retrieves the courses a student was enrolled in before end, with teacher names.
+// Only courses of the academic year are kept; a course without one is kept when it had an exam,
+// homework or the student's attendance in [start, end).
+func (r *reportCardRepository) GetEnrolledCourses(studentID uint, academicYear string, start, end time.Time) ([]EnrolledCourse, error) {
+	var courses []EnrolledCourse
+	if err := r.db.Table("student_courses").
+		Select("courses.id AS course_id, courses.code, courses.name, courses.credits, "+
+			"teachers.id AS teacher_id, teachers.first_name AS teacher_first_name, teachers.last_name AS teacher_last_name").
+		Joins("JOIN courses ON courses.id = student_courses.course_id AND courses.deleted_at IS NULL").
+		Joins("LEFT JOIN teachers ON teachers.id = courses.teacher_id").
+		Where("student_courses.student_id = ? AND student_courses.deleted_at IS NULL", studentID).
+		Where("student_courses.enrollment_date < ?", end).
+		Where("courses.academic_year = ? OR (courses.academic_year = '' AND ("+
+			"EXISTS (SELECT 1 FROM exams WHERE exams.course_id = courses.id AND exams.deleted_at IS NULL AND exams.exam_date >= ? AND exams.exam_date < ?) OR "+
+			"EXISTS (SELECT 1 FROM homework WHERE homework.course_id = courses.id AND homework.deleted_at IS NULL AND homework.due_date >= ? AND homework.due_date < ?) OR "+
+			"EXISTS (SELECT 1 FROM attendances WHERE attendances.course_id = courses.id AND attendances.student_id = student_courses.student_id AND attendances.deleted_at IS NULL AND attendances.date >= ? AND attendances.date < ?)))",
+			academicYear, start, end, start, end, start, end).
+		Order("courses.code ASC").
+		Scan(&courses).Error; err != nil {
+		return nil, fmt.Errorf("failed to get enrolled courses: %w", err)
+	}
+	return courses, nil
+}
+
+// GetExamResults retrieves a student's published exam results for exams in [start, end)
+func (r *reportCardRepository) GetExamResults(studentID uint, start, end time.Time) ([]ResultLine, error) {
+	var results []ResultLine
+	if err := r.db.Model(&grade.Grade{}).
+		Select("exams.course_id, exams.title, exams.exam_date AS date, grades.score, exams.max_score").
+		Joins("JOIN exams ON exams.id = grades.exam_id AND exams.deleted_at IS NULL").
+		Where("grades.student_id = ? AND grades.status = ?", studentID, grade.GradePublished).
+		Where("exams.exam_date >= ? AND exams.exam_date < ?", start, end).
+		Order("exams.exam_date ASC").
+		Scan(&results).Error; err != nil {
+		return nil, fmt.Errorf("failed to get exam results: %w", err)
+	}
+	return results, nil
+}
+
+// GetHomeworkResults retrieves a student's published homework grades for homework due in [start, end)
+func (r *reportCardRepository) GetHomeworkResults(studentID uint, start, end time.Time) ([]ResultLine, error) {
+	var results []ResultLine
+	if err := r.db.Model(&students_homework.StudentHomework{}).
+		Select("homework.course_id, homework.title, homework.due_date AS date, "+
+			"students_homework.score, homework.max_score").
+		Joins("JOIN homework ON homework.id = students_homework.homework_id AND homework.deleted_at IS NULL").
+		Where("students_homework.student_id = ? AND students_homework.status = ? AND students_homework.grade_status = ?",
+			studentID, students_homework.HomeworkGraded, students_homework.GradePublished).
+		Where("students_homework.score IS NOT NULL").
+		Where("homework.due_date >= ? AND homework.due_date < ?", start, end).
+		Order("homework.due_date ASC").
+		Scan(&results).Error; err != nil {
+		return nil, fmt.Errorf("failed to get homework results: %w", err)
+	}
+	return results, nil
+}
+
+// GetAttendanceCounts counts a student's attendance records per course and status in [start, end)
+func (r *reportCardRepository) GetAttendanceCounts(studentID uint, start, end time.Time) ([]AttendanceCount, error) {
+	var counts []AttendanceCount
+	if err := r.db.Table("attendances").
+		Select("course_id, status, COUNT(*) AS count").
+		Where("student_id = ? AND date >= ? AND date < ? AND deleted_at IS NULL", studentID, start, end).
+		Group("course_id, status").
+		Scan(&counts).Error; err != nil {
+		return nil, fmt.Errorf("failed to get attendance counts: %w", err)
+	}
+	return counts, nil
+}
+
+// GetCourseStudents retrieves the students currently enrolled in a course
+func (r *reportCardRepository) GetCourseStudents(courseID uint) ([]CourseStudent, error) {
+	var students []CourseStudent
+	if err := r.db.Table("student_courses").
+		Select("students.id AS student_id, students.first_name, students.last_name").
+		Joins("JOIN students ON students.id = student_courses.student_id AND students.deleted_at IS NULL").
+		Where("student_courses.course_id = ? AND student_courses.deleted_at IS NULL", courseID).
+		Order("students.last_name ASC, students.first_name ASC").
+		Scan(&students).Error; err != nil {
+		return nil, fmt.Errorf("failed to get course students: %w", err)
+	}
+	return students, nil
+}
+
+// CreateJob creates a bulk report card job
+func (r *reportCardRepository) CreateJob(job *ReportCardJob) error {
+	if err := r.db.Create(job).Error; err != nil {
+		return fmt.Errorf("failed to create report card job: %w", err)
+	}
+	return nil
+}
+
+// GetLatestJob retrieves the most recent bulk report card job for a course and term
+func (r *reportCardRepository) GetLatestJob(courseID uint, term string) (*ReportCardJob, error) {
+	var job ReportCardJob
+	if err := r.db.Where("course_id = ? AND term = ?", courseID, term).
+		Order("created_at DESC").
+		First(&job).Error; err != nil {
+		return nil, fmt.Errorf("failed to get latest report card job: %w", err)
+	}
+	return &job, nil
+}
+
+// GetJobByID retrieves a bulk report card job, including its archive
+func (r *reportCardRepository) GetJobByID(id uint) (*ReportCardJob, error) {
+	var job ReportCardJob
+	if err := r.db.First(&job, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get report card job: %w", err)
+	}
+	return &job, nil
+}
+
+// UpdateJob updates a bulk report card job
+func (r *reportCardRepository) UpdateJob(job *ReportCardJob) error {
+	if err := r.db.Omit("Course").Save(job).Error; err != nil {
+		return fmt.Errorf("failed to update report card job: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,561 @@
+package report_card
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"school_management/internal/modules/course"
+	"school_management/internal/modules/standing"
+	"school_management/internal/modules/student"
+)
+
+// staleJobAfter is how long a queued or running job may go without an update before it is
+// treated as abandoned, e.g. because the server restarted while it ran
+const staleJobAfter = time.Hour
+
+// ReportCardService defines the business logic interface
+type ReportCardService interface {
+	Generate(studentID uint, term string, templateID *uint) ([]byte, string, error)
+	SaveComment(studentID uint, req *SaveCommentRequest) (*CommentResponse, error)
+	GetComments(studentID uint, term string) ([]CommentResponse, error)
+
+	CreateTemplate(req *CreateTemplateRequest) (*TemplateResponse, error)
+	GetTemplate(id uint) (*TemplateResponse, error)
+	GetAllTemplates() ([]TemplateResponse, error)
+	UpdateTemplate(id uint, req *UpdateTemplateRequest) (*TemplateResponse, error)
+	DeleteTemplate(id uint) error
+
+	StartCourseJob(courseID uint, term string, templateID *uint) (*JobResponse, error)
+	GetJob(id uint) (*JobResponse, error)
+	GetJobArchive(id uint) ([]byte, string, error)
+}
+
+// reportCardService implements ReportCardService
+type reportCardService struct {
+	repo        ReportCardRepository
+	studentRepo student.StudentRepository
+	courseRepo  course.CourseRepository
+}
+
+// NewReportCardService creates a new report card service with DI
+func NewReportCardService(repo ReportCardRepository, studentRepo student.StudentRepository, courseRepo course.CourseRepository) ReportCardService {
+	return &reportCardService{repo: repo, studentRepo: studentRepo, courseRepo: courseRepo}
+}
+
+// Generate renders a student's report card for a term as a PDF, returning the file name with it.
+// The term defaults to the current one and the template to the school's default.
+func (s *reportCardService) Generate(studentID uint, term string, templateID *uint) ([]byte, string, error) {
+	if term == "" {
+		term = standing.TermOf(time.Now())
+	}
+
+	stu, err := s.studentRepo.GetByID(studentID)
+	if err != nil {
+		return nil, "", fmt.Errorf("student not found: %w", err)
+	}
+
+	tmpl, err := s.resolveTemplate(templateID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	data, err := s.buildData(stu, term)
+	if err != nil {
+		return nil, "", err
+	}
+
+	doc, err := render(tmpl, data)
+	if err != nil {
+		return nil, "", err
+	}
+	return doc, fileName(term, stu.FirstName, stu.LastName, stu.ID), nil
+}
+
+// SaveComment records a teacher's comment on a student's course for a term
+func (s *reportCardService) SaveComment(studentID uint, req *SaveCommentRequest) (*CommentResponse, error) {
+	// Validate
+	if err := s.validateCommentRequest(req); err != nil {
+		return nil, err
+	}
+	if _, err := s.studentRepo.GetByID(studentID); err != nil {
+		return nil, fmt.Errorf("student not found: %w", err)
+	}
+	c, err := s.courseRepo.GetByID(req.CourseID)
+	if err != nil {
+		return nil, fmt.Errorf("course not found: %w", err)
+	}
+
+	// Map DTO to Model
+	comment := &ReportCardComment{
+		StudentID: studentID,
+		CourseID:  req.CourseID,
+		Term:      req.Term,
+		TeacherID: req.TeacherID,
+		Comment:   strings.TrimSpace(req.Comment),
+	}
+	if comment.TeacherID == 0 {
+		comment.TeacherID = c.TeacherID
+	}
+
+	// Save
+	if err := s.repo.SaveComment(comment); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.repo.GetComments(studentID, req.Term)
+	if err != nil {
+		return nil, err
+	}
+	for i := range comments {
+		if comments[i].CourseID == req.CourseID {
+			return s.toCommentResponseDTO(&comments[i]), nil
+		}
+	}
+	return s.toCommentResponseDTO(comment), nil
+}
+
+// GetComments retrieves a student's report card comments for a term
+func (s *reportCardService) GetComments(studentID uint, term string) ([]CommentResponse, error) {
+	if term == "" {
+		term = standing.TermOf(time.Now())
+	}
+
+	comments, err := s.repo.GetComments(studentID, term)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report card comments: %w", err)
+	}
+
+	responses := make([]CommentResponse, len(comments))
+	for i := range comments {
+		responses[i] = *s.toCommentResponseDTO(&comments[i])
+	}
+	return responses, nil
+}
+
+// CreateTemplate creates a report card template after checking that it renders
+func (s *reportCardService) CreateTemplate(req *CreateTemplateRequest) (*TemplateResponse, error) {
+	// Validate
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if err := s.validateTemplateBody(req.Body); err != nil {
+		return nil, err
+	}
+
+	// Map DTO to Model
+	tmpl := &ReportCardTemplate{
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		Body:        req.Body,
+		IsDefault:   req.IsDefault,
+	}
+
+	// Create via repository
+	if err := s.repo.CreateTemplate(tmpl); err != nil {
+		return nil, err
+	}
+
+	return s.toTemplateResponseDTO(tmpl), nil
+}
+
+// GetTemplate retrieves a report card template by ID
+func (s *reportCardService) GetTemplate(id uint) (*TemplateResponse, error) {
+	tmpl, err := s.repo.GetTemplateByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("report card template not found: %w", err)
+	}
+	return s.toTemplateResponseDTO(tmpl), nil
+}
+
+// GetAllTemplates retrieves all report card templates
+func (s *reportCardService) GetAllTemplates() ([]TemplateResponse, error) {
+	templates, err := s.repo.GetAllTemplates()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report card templates: %w", err)
+	}
+
+	responses := make([]TemplateResponse, len(templates))
+	for i := range templates {
+		responses[i] = *s.toTemplateResponseDTO(&templates[i])
+	}
+	return responses, nil
+}
+
+// UpdateTemplate updates a report card template
+func (s *reportCardService) UpdateTemplate(id uint, req *UpdateTemplateRequest) (*TemplateResponse, error) {
+	// Validate
+	if req.Body != "" {
+		if err := s.validateTemplateBody(req.Body); err != nil {
+			return nil, err
+		}
+	}
+
+	// Get existing
+	tmpl, err := s.repo.GetTemplateByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("report card template not found: %w", err)
+	}
+
+	// Update fields
+	if req.Name != "" {
+		tmpl.Name = strings.TrimSpace(req.Name)
+	}
+	if req.Description != "" {
+		tmpl.Description = req.Description
+	}
+	if req.Body != "" {
+		tmpl.Body = req.Body
+	}
+	if req.IsDefault != nil {
+		tmpl.IsDefault = *req.IsDefault
+	}
+
+	// Save
+	if err := s.repo.UpdateTemplate(tmpl); err != nil {
+		return nil, err
+	}
+
+	return s.toTemplateResponseDTO(tmpl), nil
+}
+
+// DeleteTemplate deletes a report card template
+func (s *reportCardService) DeleteTemplate(id uint) error {
+	if _, err := s.repo.GetTemplateByID(id); err != nil {
+		return fmt.Errorf("report card template not found: %w", err)
+	}
+	return s.repo.DeleteTemplate(id)
+}
+
+// StartCourseJob queues a ZIP of report cards for every student in a course and builds it in the background.
+// If a job for the course and term is already queued or running, that job is returned instead;
+// one that has not moved for staleJobAfter is marked failed and a new job is queued.
+func (s *reportCardService) StartCourseJob(courseID uint, term string, templateID *uint) (*JobResponse, error) {
+	if term == "" {
+		term = standing.TermOf(time.Now())
+	}
+
+	// Validate
+	if _, err := s.courseRepo.GetByID(courseID); err != nil {
+		return nil, fmt.Errorf("course not found: %w", err)
+	}
+	if _, _, err := standing.TermBounds(term); err != nil {
+		return nil, err
+	}
+	if _, err := s.resolveTemplate(templateID); err != nil {
+		return nil, err
+	}
+
+	if latest, err := s.repo.GetLatestJob(courseID, term); err == nil &&
+		(latest.Status == JobPending || latest.Status == JobRunning) && !s.abandoned(latest) {
+		return s.toJobResponseDTO(latest), nil
+	}
+
+	job := &ReportCardJob{
+		CourseID:   courseID,
+		Term:       term,
+		TemplateID: templateID,
+		Status:     JobPending,
+	}
+	if err := s.repo.CreateJob(job); err != nil {
+		return nil, fmt.Errorf("failed to queue report card job: %w", err)
+	}
+
+	go s.run(*job)
+
+	return s.toJobResponseDTO(job), nil
+}
+
+// GetJob retrieves a bulk report card job
+func (s *reportCardService) GetJob(id uint) (*JobResponse, error) {
+	job, err := s.repo.GetJobByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("report card job not found: %w", err)
+	}
+	s.abandoned(job)
+	return s.toJobResponseDTO(job), nil
+}
+
+// GetJobArchive retrieves a completed job's ZIP archive and its file name
+func (s *reportCardService) GetJobArchive(id uint) ([]byte, string, error) {
+	job, err := s.repo.GetJobByID(id)
+	if err != nil {
+		return nil, "", fmt.Errorf("report card job not found: %w", err)
+	}
+	if job.Status != JobCompleted {
+		return nil, "", fmt.Errorf("report card job is %s, not completed", job.Status)
+	}
+	return job.Archive, fmt.Sprintf("report-cards-course-%d-%s.zip", job.CourseID, job.Term), nil
+}
+
+// run renders every report card for a job into a ZIP archive and records the outcome
+func (s *reportCardService) run(job ReportCardJob) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.fail(&job, fmt.Errorf("panic: %v", r))
+		}
+	}()
+
+	started := time.Now()
+	job.Status = JobRunning
+	job.StartedAt = &started
+	if err := s.repo.UpdateJob(&job); err != nil {
+		log.Printf("❌ Report card job %d: %v", job.ID, err)
+		return
+	}
+
+	students, err := s.repo.GetCourseStudents(job.CourseID)
+	if err != nil {
+		s.fail(&job, err)
+		return
+	}
+
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	for _, st := range students {
+		doc, name, err := s.Generate(st.StudentID, job.Term, job.TemplateID)
+		if err != nil {
+			s.fail(&job, fmt.Errorf("student %d: %w", st.StudentID, err))
+			return
+		}
+		w, err := zw.Create(name)
+		if err != nil {
+			s.fail(&job, err)
+			return
+		}
+		if _, err := w.Write(doc); err != nil {
+			s.fail(&job, err)
+			return
+		}
+	}
+	if err := zw.Close(); err != nil {
+		s.fail(&job, err)
+		return
+	}
+
+	completed := time.Now()
+	job.Status = JobCompleted
+	job.StudentCount = len(students)
+	job.Archive = archive.Bytes()
+	job.CompletedAt = &completed
+	if err := s.repo.UpdateJob(&job); err != nil {
+		log.Printf("❌ Report card job %d: %v", job.ID, err)
+	}
+}
+
+// fail marks a job as failed
+func (s *reportCardService) fail(job *ReportCardJob, cause error) {
+	log.Printf("❌ Report card job %d failed: %v", job.ID, cause)
+	completed := time.Now()
+	job.Status = JobFailed
+	job.Error = cause.Error()
+	job.CompletedAt = &completed
+	if err := s.repo.UpdateJob(job); err != nil {
+		log.Printf("❌ Report card job %d: %v", job.ID, err)
+	}
+}
+
+// abandoned marks a queued or running job failed once it has not moved for staleJobAfter,
+// reporting whether it did
+func (s *reportCardService) abandoned(job *ReportCardJob) bool {
+	if (job.Status != JobPending && job.Status != JobRunning) || time.Since(job.UpdatedAt) < staleJobAfter {
+		return false
+	}
+	s.fail(job, fmt.Errorf("abandoned: no progress since %s", job.UpdatedAt.Format(time.RFC3339)))
+	return true
+}
+
+// resolveTemplate compiles the requested template, the school's default, or the built-in layout
+func (s *reportCardService) resolveTemplate(templateID *uint) (*template.Template, error) {
+	body := DefaultTemplateBody
+	if templateID != nil {
+		tmpl, err := s.repo.GetTemplateByID(*templateID)
+		if err != nil {
+			return nil, fmt.Errorf("report card template not found: %w", err)
+		}
+		body = tmpl.Body
+	} else {
+		tmpl, err := s.repo.GetDefaultTemplate()
+		if err != nil {
+			return nil, err
+		}
+		if tmpl != nil {
+			body = tmpl.Body
+		}
+	}
+	return parseTemplate(body)
+}
+
+// buildData gathers a student's courses, published results, attendance and comments for a term
+func (s *reportCardService) buildData(stu *student.Student, term string) (*ReportCardData, error) {
+	start, end, err := standing.TermBounds(term)
+	if err != nil {
+		return nil, err
+	}
+
+	courses, err := s.repo.GetEnrolledCourses(stu.ID, course.AcademicYearOf(start), start, end)
+	if err != nil {
+		return nil, err
+	}
+	exams, err := s.repo.GetExamResults(stu.ID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	homework, err := s.repo.GetHomeworkResults(stu.ID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	attendance, err := s.repo.GetAttendanceCounts(stu.ID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := s.repo.GetComments(stu.ID, term)
+	if err != nil {
+		return nil, err
+	}
+
+	examsByCourse := make(map[uint][]ResultLine)
+	for _, r := range exams {
+		examsByCourse[r.CourseID] = append(examsByCourse[r.CourseID], r)
+	}
+	homeworkByCourse := make(map[uint][]ResultLine)
+	for _, r := range homework {
+		homeworkByCourse[r.CourseID] = append(homeworkByCourse[r.CourseID], r)
+	}
+	attendanceByCourse := make(map[uint]*AttendanceSummary)
+	for _, a := range attendance {
+		if attendanceByCourse[a.CourseID] == nil {
+			attendanceByCourse[a.CourseID] = &AttendanceSummary{}
+		}
+		attendanceByCourse[a.CourseID].add(a.Status, a.Count)
+	}
+	commentByCourse := make(map[uint]string)
+	for _, c := range comments {
+		commentByCourse[c.CourseID] = c.Comment
+	}
+
+	data := &ReportCardData{
+		StudentID:   stu.ID,
+		StudentName: stu.FirstName + " " + stu.LastName,
+		Email:       stu.Email,
+		Term:        term,
+		IssuedOn:    time.Now().Format("2006-01-02"),
+		Courses:     make([]CourseReport, 0, len(courses)),
+	}
+	for _, c := range courses {
+		report := CourseReport{
+			Code:            c.Code,
+			Name:            c.Name,
+			Credits:         c.Credits,
+			Teacher:         strings.TrimSpace(c.TeacherFirstName + " " + c.TeacherLastName),
+			Exams:           examsByCourse[c.CourseID],
+			Homework:        homeworkByCourse[c.CourseID],
+			ExamPercent:     percentOf(examsByCourse[c.CourseID]),
+			HomeworkPercent: percentOf(homeworkByCourse[c.CourseID]),
+			OverallPercent:  percentOf(examsByCourse[c.CourseID], homeworkByCourse[c.CourseID]),
+			Comment:         commentByCourse[c.CourseID],
+		}
+		report.Letter = letterGrade(report.OverallPercent)
+		if a := attendanceByCourse[c.CourseID]; a != nil {
+			report.Attendance = *a
+			report.Attendance.finish()
+		}
+		data.Courses = append(data.Courses, report)
+	}
+
+	data.OverallPercent = percentOf(exams, homework)
+	for _, a := range attendanceByCourse {
+		data.Attendance.Sessions += a.Sessions
+		data.Attendance.Present += a.Present
+		data.Attendance.Late += a.Late
+		data.Attendance.Absent += a.Absent
+	}
+	data.Attendance.finish()
+
+	return data, nil
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// fileName builds a report card file name that is safe inside a ZIP archive
+func fileName(term, firstName, lastName string, studentID uint) string {
+	name := unsafeFileChars.ReplaceAllString(lastName+"-"+firstName, "_")
+	return fmt.Sprintf("report-card-%s-%s-%d.pdf", term, name, studentID)
+}
+
+// Validation methods
+func (s *reportCardService) validateCommentRequest(req *SaveCommentRequest) error {
+	if strings.TrimSpace(req.Comment) == "" {
+		return fmt.Errorf("comment is required")
+	}
+	if _, _, err := standing.TermBounds(req.Term); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateTemplateBody checks that a template compiles and renders against sample data
+func (s *reportCardService) validateTemplateBody(body string) error {
+	if strings.TrimSpace(body) == "" {
+		return fmt.Errorf("body is required")
+	}
+	tmpl, err := parseTemplate(body)
+	if err != nil {
+		return err
+	}
+	sample := &ReportCardData{
+		StudentName: "Sample Student",
+		Term:        standing.TermOf(time.Now()),
+		Courses:     []CourseReport{{Code: "SAMPLE", Exams: []ResultLine{{}}, Homework: []ResultLine{{}}}},
+	}
+	if _, err := render(tmpl, sample); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DTO mapping methods
+func (s *reportCardService) toTemplateResponseDTO(tmpl *ReportCardTemplate) *TemplateResponse {
+	return &TemplateResponse{
+		ID:          tmpl.ID,
+		Name:        tmpl.Name,
+		Description: tmpl.Description,
+		Body:        tmpl.Body,
+		IsDefault:   tmpl.IsDefault,
+		CreatedAt:   tmpl.CreatedAt,
+		UpdatedAt:   tmpl.UpdatedAt,
+	}
+}
+
+func (s *reportCardService) toCommentResponseDTO(comment *ReportCardComment) *CommentResponse {
+	return &CommentResponse{
+		ID:          comment.ID,
+		StudentID:   comment.StudentID,
+		CourseID:    comment.CourseID,
+		Term:        comment.Term,
+		TeacherID:   comment.TeacherID,
+		TeacherName: strings.TrimSpace(comment.Teacher.FirstName + " " + comment.Teacher.LastName),
+		Comment:     comment.Comment,
+		UpdatedAt:   comment.UpdatedAt,
+	}
+}
+
+func (s *reportCardService) toJobResponseDTO(job *ReportCardJob) *JobResponse {
+	return &JobResponse{
+		ID:           job.ID,
+		CourseID:     job.CourseID,
+		Term:         job.Term,
+		TemplateID:   job.TemplateID,
+		Status:       string(job.Status),
+		StudentCount: job.StudentCount,
+		StartedAt:    job.StartedAt,
+		CompletedAt:  job.CompletedAt,
+		Error:        job.Error,
+		CreatedAt:    job.CreatedAt,
+	}
+}
@@ -0,0 +1,140 @@
+package risk
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"school_management/internal/export"
+)
+
+// RiskController handles HTTP requests for at-risk students and risk alerts
+type RiskController struct {
+	service RiskService
+}
+
+// NewRiskController creates a new risk controller
+func NewRiskController(service RiskService) *RiskController {
+	return &RiskController{service: service}
+}
+
+// optionalID parses an optional numeric query parameter
+func optionalID(ctx *gin.Context, name string) (*uint, bool) {
+	raw := ctx.Query(name)
+	if raw == "" {
+		return nil, true
+	}
+	id, err := strconv.ParseUint(raw, 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name})
+		return nil, false
+	}
+	value := uint(id)
+	return &value, true
+}
+
+// GetAtRisk lists at-risk students, optionally filtered by course_id, department_id and min_level
+func (c *RiskController) GetAtRisk(ctx *gin.Context) {
+	courseID, ok := optionalID(ctx, "course_id")
+	if !ok {
+		return
+	}
+	departmentID, ok := optionalID(ctx, "department_id")
+	if !ok {
+		return
+	}
+
+	resp, err := c.service.GetAtRisk(courseID, departmentID, RiskLevel(ctx.Query("min_level")))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// Recompute rescores every student now instead of waiting for the nightly run
+func (c *RiskController) Recompute(ctx *gin.Context) {
+	resp, err := c.service.Recompute()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetByStudent retrieves a student's risk score
+func (c *RiskController) GetByStudent(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetByStudent(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetAlertsByTeacher retrieves a teacher's risk alerts; ?unacknowledged=true hides handled ones
+func (c *RiskController) GetAlertsByTeacher(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	unacknowledged, _ := strconv.ParseBool(ctx.DefaultQuery("unacknowledged", "false"))
+
+	resp, err := c.service.GetAlertsByTeacher(uint(id), unacknowledged)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// AcknowledgeAlert marks a risk alert as seen
+func (c *RiskController) AcknowledgeAlert(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.AcknowledgeAlert(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// RegisterRoutes registers at-risk student and risk alert routes
+func (c *RiskController) RegisterRoutes(rg *gin.RouterGroup) {
+	students := rg.Group("/students")
+	{
+		students.GET("/at-risk", c.GetAtRisk)
+		students.POST("/at-risk/recompute", c.Recompute)
+		students.GET("/:id/risk", c.GetByStudent)
+	}
+
+	rg.GET("/teachers/:id/risk-alerts", c.GetAlertsByTeacher)
+	rg.POST("/risk-alerts/:id/acknowledge", c.AcknowledgeAlert)
+}
@@ -0,0 +1,34 @@
+package risk
+
+import "time"
+
+// RiskScoreResponse represents a student's risk score with the factors behind it
+type RiskScoreResponse struct {
+	StudentID   uint         `json:"student_id"`
+	StudentName string       `json:"student_name"`
+	Score       float64      `json:"score"`
+	Level       RiskLevel    `json:"level"`
+	Factors     []RiskFactor `json:"factors"`
+	ComputedAt  time.Time    `json:"computed_at"`
+}
+
+// AlertResponse represents a risk alert sent to a teacher
+type AlertResponse struct {
+	ID             uint       `json:"id"`
+	StudentID      uint       `json:"student_id"`
+	StudentName    string     `json:"student_name"`
+	TeacherID      uint       `json:"teacher_id"`
+	Score          float64    `json:"score"`
+	Level          RiskLevel  `json:"level"`
+	Summary        string     `json:"summary"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// RecomputeResponse represents the outcome of a risk recompute
+type RecomputeResponse struct {
+	Scored        int `json:"scored"`
+	AlertsRaised  int `json:"alerts_raised"`
+	ModerateCount int `json:"moderate_count"`
+	HighCount     int `json:"high_count"`
+}
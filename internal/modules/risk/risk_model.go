@@ -0,0 +1,114 @@
+package risk
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"school_management/internal/modules/student"
+	"school_management/internal/modules/teacher"
+)
+
+// RiskLevel buckets a student's risk score
+type RiskLevel string
+
+const (
+	RiskLow      RiskLevel = "low"
+	RiskModerate RiskLevel = "moderate"
+	RiskHigh     RiskLevel = "high"
+)
+
+// rank orders levels so they can be compared
+func (l RiskLevel) rank() int {
+	switch l {
+	case RiskHigh:
+		return 2
+	case RiskModerate:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// RiskFactor explains how much one signal contributed to a risk score
+type RiskFactor struct {
+	Code      string  `json:"code"`
+	Points    float64 `json:"points"`
+	MaxPoints float64 `json:"max_points"`
+	Detail    string  `json:"detail"`
+}
+
+// RiskScore is a student's latest early-warning score. One row is kept per student
+// and replaced on every recompute.
+type RiskScore struct {
+	gorm.Model
+	StudentID  uint         `gorm:"not null;uniqueIndex" json:"student_id"`
+	Score      float64      `gorm:"not null;comment:0 (no risk) to 100" json:"score"`
+	Level      RiskLevel    `gorm:"type:varchar(20);not null;index" json:"level"`
+	Factors    []RiskFactor `gorm:"type:text;serializer:json" json:"factors"`
+	ComputedAt time.Time    `gorm:"type:timestamp;not null" json:"computed_at"`
+
+	// Belongs To relationship
+	Student student.Student `gorm:"foreignKey:StudentID" json:"student,omitempty"`
+}
+
+// TableName specifies the table name for the RiskScore model
+func (RiskScore) TableName() string {
+	return "student_risk_scores"
+}
+
+// RiskAlert notifies one of a student's teachers that the student's risk level has risen
+type RiskAlert struct {
+	gorm.Model
+	StudentID      uint       `gorm:"not null;index" json:"student_id"`
+	TeacherID      uint       `gorm:"not null;index" json:"teacher_id"`
+	Score          float64    `gorm:"not null" json:"score"`
+	Level          RiskLevel  `gorm:"type:varchar(20);not null" json:"level"`
+	Summary        string     `gorm:"type:text" json:"summary"`
+	AcknowledgedAt *time.Time `gorm:"type:timestamp" json:"acknowledged_at"`
+
+	// Belongs To relationships
+	Student student.Student `gorm:"foreignKey:StudentID" json:"student,omitempty"`
+	Teacher teacher.Teacher `gorm:"foreignKey:TeacherID" json:"teacher,omitempty"`
+}
+
+// TableName specifies the table name for the RiskAlert model
+func (RiskAlert) TableName() string {
+	return "risk_alerts"
+}
+
+// AttendanceSignal summarises a student's attendance records in the scoring window
+type AttendanceSignal struct {
+	StudentID uint
+	Total     int
+	Absent    int
+	Late      int
+}
+
+// HomeworkSignal summarises a student's homework that fell due in the scoring window
+type HomeworkSignal struct {
+	StudentID uint
+	Due       int
+	Missing   int
+	Late      int
+}
+
+// GradePoint is one published exam result as a percentage of the exam's max score
+type GradePoint struct {
+	StudentID uint
+	ExamDate  time.Time
+	Percent   float64
+}
+
+// EnrollmentSignal counts a student's enrollment changes in the scoring window
+type EnrollmentSignal struct {
+	StudentID uint
+	Added     int
+	Dropped   int
+}
+
+// StudentTeacher links a student to the teacher of one of their current courses
+type StudentTeacher struct {
+	StudentID uint
+	TeacherID uint
+}
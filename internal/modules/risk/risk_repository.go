@@ -0,0 +1,213 @@
+package risk
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"school_management/internal/modules/grade"
+)
+
+// RiskFilter narrows an at-risk listing to students currently enrolled in a course or department
+type RiskFilter struct {
+	CourseID     *uint
+	DepartmentID *uint
+	Levels       []RiskLevel
+}
+
+// RiskRepository defines the interface for early-warning data access
+type RiskRepository interface {
+	GetAttendanceSignals(since time.Time) ([]AttendanceSignal, error)
+	GetHomeworkSignals(since, until time.Time) ([]HomeworkSignal, error)
+	GetGradePoints(since time.Time) ([]GradePoint, error)
+	GetEnrollmentSignals(since time.Time) ([]EnrollmentSignal, error)
+	GetStudentTeachers(studentIDs []uint) ([]StudentTeacher, error)
+	GetAll() ([]RiskScore, error)
+	ReplaceScores(scores []RiskScore, alerts []RiskAlert) error
+	GetScores(filter RiskFilter) ([]RiskScore, error)
+	GetByStudent(studentID uint) (*RiskScore, error)
+	GetAlertsByTeacher(teacherID uint, unacknowledgedOnly bool) ([]RiskAlert, error)
+	GetAlertByID(id uint) (*RiskAlert, error)
+	UpdateAlert(alert *RiskAlert) error
+}
+
+// riskRepository implements RiskRepository
+type riskRepository struct {
+	db *gorm.DB
+}
+
+// NewRiskRepository creates a new risk repository with dependency injection
+func NewRiskRepository(db *gorm.DB) RiskRepository {
+	return &riskRepository{db: db}
+}
+
+// GetAttendanceSignals counts each student's attendance records, absences and late arrivals since a date
+func (r *riskRepository) GetAttendanceSignals(since time.Time) ([]AttendanceSignal, error) {
+	var result []AttendanceSignal
+	if err := r.db.Table("attendances").
+		Select("student_id, COUNT(*) AS total, "+
+			"SUM(CASE WHEN status = 'absent' THEN 1 ELSE 0 END) AS absent, "+
+			"SUM(CASE WHEN status = 'late' THEN 1 ELSE 0 END) AS late").
+		Where("date >= ? AND deleted_at IS NULL", since).
+		Group("student_id").
+		Scan(&result).Error; err != nil {
+		return nil, fmt.Errorf("failed to get attendance signals: %w", err)
+	}
+	return result, nil
+}
+
+// GetHomeworkSignals counts each student's homework due in [since, until), how much is
+// missing and how much was submitted after the due date
+func (r *riskRepository) GetHomeworkSignals(since, until time.Time) ([]HomeworkSignal, error) {
+	var result []HomeworkSignal
+	if err := r.db.Table("students_homework").
+		Select("students_homework.student_id, COUNT(*) AS due, "+
+			"SUM(CASE WHEN students_homework.status IN ('pending', 'missing') THEN 1 ELSE 0 END) AS missing, "+
+			"SUM(CASE WHEN students_homework.submission_date > homework.due_date THEN 1 ELSE 0 END) AS late").
+		Joins("JOIN homework ON homework.id = students_homework.homework_id AND homework.deleted_at IS NULL").
+		Where("homework.due_date >= ? AND homework.due_date < ?", since, until).
+		Where("students_homework.deleted_at IS NULL").
+		Group("students_homework.student_id").
+		Scan(&result).Error; err != nil {
+		return nil, fmt.Errorf("failed to get homework signals: %w", err)
+	}
+	return result, nil
+}
+
+// GetGradePoints retrieves published exam results since a date as percentages, oldest first
+func (r *riskRepository) GetGradePoints(since time.Time) ([]GradePoint, error) {
+	var result []GradePoint
+	if err := r.db.Model(&grade.Grade{}).
+		Select("grades.student_id, exams.exam_date, grades.score * 100.0 / exams.max_score AS percent").
+		Joins("JOIN exams ON exams.id = grades.exam_id AND exams.deleted_at IS NULL").
+		Where("grades.status = ? AND exams.exam_date >= ? AND exams.max_score > 0", grade.GradePublished, since).
+		Order("grades.student_id ASC, exams.exam_date ASC").
+		Scan(&result).Error; err != nil {
+		return nil, fmt.Errorf("failed to get grade points: %w", err)
+	}
+	return result, nil
+}
+
+// GetEnrollmentSignals counts each student's course enrollments and drops since a date
+func (r *riskRepository) GetEnrollmentSignals(since time.Time) ([]EnrollmentSignal, error) {
+	var result []EnrollmentSignal
+	if err := r.db.Table("student_courses").
+		Select("student_id, "+
+			"SUM(CASE WHEN created_at >= ? THEN 1 ELSE 0 END) AS added, "+
+			"SUM(CASE WHEN deleted_at >= ? THEN 1 ELSE 0 END) AS dropped", since, since).
+		Where("created_at >= ? OR deleted_at >= ?", since, since).
+		Group("student_id").
+		Scan(&result).Error; err != nil {
+		return nil, fmt.Errorf("failed to get enrollment signals: %w", err)
+	}
+	return result, nil
+}
+
+// GetStudentTeachers retrieves the teachers of the students' current courses
+func (r *riskRepository) GetStudentTeachers(studentIDs []uint) ([]StudentTeacher, error) {
+	var result []StudentTeacher
+	if len(studentIDs) == 0 {
+		return result, nil
+	}
+	if err := r.db.Table("student_courses").
+		Distinct("student_courses.student_id", "courses.teacher_id").
+		Joins("JOIN courses ON courses.id = student_courses.course_id AND courses.deleted_at IS NULL").
+		Where("student_courses.student_id IN ? AND student_courses.deleted_at IS NULL", studentIDs).
+		Scan(&result).Error; err != nil {
+		return nil, fmt.Errorf("failed to get student teachers: %w", err)
+	}
+	return result, nil
+}
+
+// GetAll retrieves every stored risk score
+func (r *riskRepository) GetAll() ([]RiskScore, error) {
+	var scores []RiskScore
+	if err := r.db.Find(&scores).Error; err != nil {
+		return nil, fmt.Errorf("failed to get risk scores: %w", err)
+	}
+	return scores, nil
+}
+
+// ReplaceScores swaps the stored risk scores for a fresh set and records new alerts in one transaction
+func (r *riskRepository) ReplaceScores(scores []RiskScore, alerts []RiskAlert) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("1 = 1").Delete(&RiskScore{}).Error; err != nil {
+			return fmt.Errorf("failed to clear risk scores: %w", err)
+		}
+		if len(scores) > 0 {
+			if err := tx.Omit("Student").CreateInBatches(scores, 200).Error; err != nil {
+				return fmt.Errorf("failed to save risk scores: %w", err)
+			}
+		}
+		if len(alerts) > 0 {
+			if err := tx.Omit("Student", "Teacher").CreateInBatches(alerts, 200).Error; err != nil {
+				return fmt.Errorf("failed to save risk alerts: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// GetScores retrieves risk scores matching a filter with students preloaded, highest score first
+func (r *riskRepository) GetScores(filter RiskFilter) ([]RiskScore, error) {
+	var scores []RiskScore
+	query := r.db.Preload("Student")
+	if len(filter.Levels) > 0 {
+		query = query.Where("level IN ?", filter.Levels)
+	}
+	if filter.CourseID != nil {
+		query = query.Where("student_id IN (?)", r.db.Table("student_courses").
+			Select("student_id").
+			Where("course_id = ? AND deleted_at IS NULL", *filter.CourseID))
+	}
+	if filter.DepartmentID != nil {
+		query = query.Where("student_id IN (?)", r.db.Table("student_courses").
+			Select("student_courses.student_id").
+			Joins("JOIN courses ON courses.id = student_courses.course_id AND courses.deleted_at IS NULL").
+			Where("courses.department_id = ? AND student_courses.deleted_at IS NULL", *filter.DepartmentID))
+	}
+	if err := query.Order("score DESC, student_id ASC").Find(&scores).Error; err != nil {
+		return nil, fmt.Errorf("failed to get risk scores: %w", err)
+	}
+	return scores, nil
+}
+
+// GetByStudent retrieves a student's risk score
+func (r *riskRepository) GetByStudent(studentID uint) (*RiskScore, error) {
+	var score RiskScore
+	if err := r.db.Preload("Student").Where("student_id = ?", studentID).First(&score).Error; err != nil {
+		return nil, fmt.Errorf("failed to get risk score: %w", err)
+	}
+	return &score, nil
+}
+
+// GetAlertsByTeacher retrieves a teacher's risk alerts, newest first
+func (r *riskRepository) GetAlertsByTeacher(teacherID uint, unacknowledgedOnly bool) ([]RiskAlert, error) {
+	var alerts []RiskAlert
+	query := r.db.Preload("Student").Where("teacher_id = ?", teacherID)
+	if unacknowledgedOnly {
+		query = query.Where("acknowledged_at IS NULL")
+	}
+	if err := query.Order("created_at DESC").Find(&alerts).Error; err != nil {
+		return nil, fmt.Errorf("failed to get risk alerts: %w", err)
+	}
+	return alerts, nil
+}
+
+// GetAlertByID retrieves a risk alert by ID
+func (r *riskRepository) GetAlertByID(id uint) (*RiskAlert, error) {
+	var alert RiskAlert
+	if err := r.db.Preload("Student").First(&alert, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get risk alert: %w", err)
+	}
+	return &alert, nil
+}
+
+// UpdateAlert updates a risk alert
+func (r *riskRepository) UpdateAlert(alert *RiskAlert) error {
+	if err := r.db.Omit("Student", "Teacher").Save(alert).Error; err != nil {
+		return fmt.Errorf("failed to update risk alert: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package risk
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// WindowDays is how far back signals are read when scoring
+const WindowDays = 60
+
+// Maximum points each signal can contribute; together they make up the 0-100 scale
+const (
+	attendanceMaxPoints = 35.0
+	homeworkMaxPoints   = 30.0
+	trendMaxPoints      = 15.0
+	averageMaxPoints    = 10.0
+	enrollmentMaxPoints = 10.0
+)
+
+// Score thresholds for the risk levels
+const (
+	moderateThreshold = 30.0
+	highThreshold     = 60.0
+)
+
+// signals gathers everything known about one student for scoring
+type signals struct {
+	attendance *AttendanceSignal
+	homework   *HomeworkSignal
+	grades     []GradePoint // oldest first
+	enrollment *EnrollmentSignal
+}
+
+// score combines a student's signals into a 0-100 risk score, returning the factors
+// that contributed points
+func score(sig signals) (float64, RiskLevel, []RiskFactor) {
+	factors := make([]RiskFactor, 0, 5)
+	add := func(code string, points, maxPoints float64, detail string) {
+		if points <= 0 {
+			return
+		}
+		factors = append(factors, RiskFactor{Code: code, Points: round1(points), MaxPoints: maxPoints, Detail: detail})
+	}
+
+	// Attendance: nothing at 95% or better, full points at 70% or worse; a late arrival counts as half an absence
+	if a := sig.attendance; a != nil && a.Total > 0 {
+		rate := 1 - (float64(a.Absent)+0.5*float64(a.Late))/float64(a.Total)
+		add("attendance", attendanceMaxPoints*clamp((0.95-rate)/0.25),
+			attendanceMaxPoints,
+			fmt.Sprintf("attended %.0f%% of %d sessions (%d absent, %d late)", rate*100, a.Total, a.Absent, a.Late))
+	}
+
+	// Homework: a late submission counts as half a missing one; full points when 40% is missing
+	if h := sig.homework; h != nil && h.Due > 0 {
+		ratio := (float64(h.Missing) + 0.5*float64(h.Late)) / float64(h.Due)
+		add("homework", homeworkMaxPoints*clamp(ratio/0.4),
+			homeworkMaxPoints,
+			fmt.Sprintf("%d of %d assignments missing, %d submitted late", h.Missing, h.Due, h.Late))
+	}
+
+	// Grade trend: full points for a drop of 15 percentage points per 30 days
+	if len(sig.grades) >= 2 {
+		slope := trendPer30Days(sig.grades)
+		if slope < 0 {
+			add("grade_trend", trendMaxPoints*clamp(-slope/15),
+				trendMaxPoints,
+				fmt.Sprintf("exam results falling %.1f points per month over %d exams", -slope, len(sig.grades)))
+		}
+	}
+
+	// Low results: nothing at 60% or better, full points at 40% or worse
+	if len(sig.grades) > 0 {
+		avg := 0.0
+		for _, g := range sig.grades {
+			avg += g.Percent
+		}
+		avg /= float64(len(sig.grades))
+		add("grade_average", averageMaxPoints*clamp((60-avg)/20),
+			averageMaxPoints,
+			fmt.Sprintf("averaging %.1f%% over %d exams", avg, len(sig.grades)))
+	}
+
+	// Enrollment changes: half the points per dropped course
+	if e := sig.enrollment; e != nil && e.Dropped > 0 {
+		add("enrollment", math.Min(enrollmentMaxPoints, float64(e.Dropped)*enrollmentMaxPoints/2),
+			enrollmentMaxPoints,
+			fmt.Sprintf("dropped %d course(s), added %d", e.Dropped, e.Added))
+	}
+
+	total := 0.0
+	for _, f := range factors {
+		total += f.Points
+	}
+	total = round1(total)
+	return total, levelFor(total), factors
+}
+
+// levelFor buckets a score into a risk level
+func levelFor(score float64) RiskLevel {
+	switch {
+	case score >= highThreshold:
+		return RiskHigh
+	case score >= moderateThreshold:
+		return RiskModerate
+	default:
+		return RiskLow
+	}
+}
+
+// trendPer30Days fits a least-squares line through the grade points and returns its slope
+// in percentage points per 30 days
+func trendPer30Days(points []GradePoint) float64 {
+	origin := points[0].ExamDate
+	n := float64(len(points))
+	var sumX, sumY, sumXY, sumXX float64
+	for _, p := range points {
+		x := p.ExamDate.Sub(origin).Hours() / (24 * 30)
+		sumX += x
+		sumY += p.Percent
+		sumXY += x * p.Percent
+		sumXX += x * x
+	}
+	denom := n*sumXX - sumX*sumX
+	if denom == 0 {
+		// All exams on the same day: no trend
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denom
+}
+
+// windowStart returns the start of the scoring window ending at now
+func windowStart(now time.Time) time.Time {
+	return now.AddDate(0, 0, -WindowDays)
+}
+
+func clamp(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
@@ -0,0 +1,282 @@
+package risk
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// RiskService defines the business logic interface
+type RiskService interface {
+	Recompute() (*RecomputeResponse, error)
+	GetAtRisk(courseID, departmentID *uint, minLevel RiskLevel) ([]RiskScoreResponse, error)
+	GetByStudent(studentID uint) (*RiskScoreResponse, error)
+	GetAlertsByTeacher(teacherID uint, unacknowledgedOnly bool) ([]AlertResponse, error)
+	AcknowledgeAlert(id uint) (*AlertResponse, error)
+}
+
+// riskService implements RiskService
+type riskService struct {
+	repo RiskRepository
+}
+
+// NewRiskService creates a new risk service with DI
+func NewRiskService(repo RiskRepository) RiskService {
+	return &riskService{repo: repo}
+}
+
+// Recompute scores every student with activity in the scoring window and replaces the
+// stored scores. Students whose level rose to moderate or high raise an alert for each
+// teacher of their current courses.
+func (s *riskService) Recompute() (*RecomputeResponse, error) {
+	now := time.Now()
+	since := windowStart(now)
+
+	byStudent, err := s.collectSignals(since, now)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	previousLevel := make(map[uint]RiskLevel, len(previous))
+	for _, p := range previous {
+		previousLevel[p.StudentID] = p.Level
+	}
+
+	// Score
+	resp := &RecomputeResponse{}
+	scores := make([]RiskScore, 0, len(byStudent))
+	var risen []uint
+	for studentID, sig := range byStudent {
+		total, level, factors := score(*sig)
+		scores = append(scores, RiskScore{
+			StudentID:  studentID,
+			Score:      total,
+			Level:      level,
+			Factors:    factors,
+			ComputedAt: now,
+		})
+		switch level {
+		case RiskHigh:
+			resp.HighCount++
+		case RiskModerate:
+			resp.ModerateCount++
+		}
+		if level.rank() > previousLevel[studentID].rank() {
+			risen = append(risen, studentID)
+		}
+	}
+	resp.Scored = len(scores)
+
+	// Alert the teachers of students whose level rose
+	alerts, err := s.buildAlerts(scores, risen)
+	if err != nil {
+		return nil, err
+	}
+	resp.AlertsRaised = len(alerts)
+
+	// Save
+	if err := s.repo.ReplaceScores(scores, alerts); err != nil {
+		return nil, err
+	}
+
+	log.Printf("✅ Scored %d student(s) for risk: %d high, %d moderate, %d alert(s) raised",
+		resp.Scored, resp.HighCount, resp.ModerateCount, resp.AlertsRaised)
+	return resp, nil
+}
+
+// GetAtRisk lists students at or above a risk level, optionally limited to a course or department
+func (s *riskService) GetAtRisk(courseID, departmentID *uint, minLevel RiskLevel) ([]RiskScoreResponse, error) {
+	if minLevel == "" {
+		minLevel = RiskModerate
+	}
+	if err := s.validateLevel(minLevel); err != nil {
+		return nil, err
+	}
+
+	var levels []RiskLevel
+	for _, l := range []RiskLevel{RiskLow, RiskModerate, RiskHigh} {
+		if l.rank() >= minLevel.rank() {
+			levels = append(levels, l)
+		}
+	}
+
+	scores, err := s.repo.GetScores(RiskFilter{CourseID: courseID, DepartmentID: departmentID, Levels: levels})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get at-risk students: %w", err)
+	}
+
+	responses := make([]RiskScoreResponse, len(scores))
+	for i := range scores {
+		responses[i] = *s.toScoreResponseDTO(&scores[i])
+	}
+	return responses, nil
+}
+
+// GetByStudent retrieves a student's latest risk score
+func (s *riskService) GetByStudent(studentID uint) (*RiskScoreResponse, error) {
+	score, err := s.repo.GetByStudent(studentID)
+	if err != nil {
+		return nil, fmt.Errorf("risk score not found: %w", err)
+	}
+	return s.toScoreResponseDTO(score), nil
+}
+
+// GetAlertsByTeacher retrieves a teacher's risk alerts
+func (s *riskService) GetAlertsByTeacher(teacherID uint, unacknowledgedOnly bool) ([]AlertResponse, error) {
+	alerts, err := s.repo.GetAlertsByTeacher(teacherID, unacknowledgedOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get risk alerts: %w", err)
+	}
+
+	responses := make([]AlertResponse, len(alerts))
+	for i := range alerts {
+		responses[i] = *s.toAlertResponseDTO(&alerts[i])
+	}
+	return responses, nil
+}
+
+// AcknowledgeAlert marks a risk alert as seen by its teacher
+func (s *riskService) AcknowledgeAlert(id uint) (*AlertResponse, error) {
+	// Get existing
+	alert, err := s.repo.GetAlertByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("risk alert not found: %w", err)
+	}
+
+	if alert.AcknowledgedAt == nil {
+		now := time.Now()
+		alert.AcknowledgedAt = &now
+
+		// Save
+		if err := s.repo.UpdateAlert(alert); err != nil {
+			return nil, fmt.Errorf("failed to acknowledge risk alert: %w", err)
+		}
+	}
+
+	return s.toAlertResponseDTO(alert), nil
+}
+
+// collectSignals reads every signal in the window and groups it by student
+func (s *riskService) collectSignals(since, now time.Time) (map[uint]*signals, error) {
+	byStudent := make(map[uint]*signals)
+	get := func(studentID uint) *signals {
+		if byStudent[studentID] == nil {
+			byStudent[studentID] = &signals{}
+		}
+		return byStudent[studentID]
+	}
+
+	attendance, err := s.repo.GetAttendanceSignals(since)
+	if err != nil {
+		return nil, err
+	}
+	for i := range attendance {
+		get(attendance[i].StudentID).attendance = &attendance[i]
+	}
+
+	homework, err := s.repo.GetHomeworkSignals(since, now)
+	if err != nil {
+		return nil, err
+	}
+	for i := range homework {
+		get(homework[i].StudentID).homework = &homework[i]
+	}
+
+	grades, err := s.repo.GetGradePoints(since)
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range grades {
+		sig := get(g.StudentID)
+		sig.grades = append(sig.grades, g)
+	}
+
+	enrollments, err := s.repo.GetEnrollmentSignals(since)
+	if err != nil {
+		return nil, err
+	}
+	for i := range enrollments {
+		get(enrollments[i].StudentID).enrollment = &enrollments[i]
+	}
+
+	return byStudent, nil
+}
+
+// buildAlerts creates one alert per teacher of each student whose risk level rose
+func (s *riskService) buildAlerts(scores []RiskScore, risen []uint) ([]RiskAlert, error) {
+	if len(risen) == 0 {
+		return nil, nil
+	}
+
+	byStudent := make(map[uint]*RiskScore, len(scores))
+	for i := range scores {
+		byStudent[scores[i].StudentID] = &scores[i]
+	}
+
+	teachers, err := s.repo.GetStudentTeachers(risen)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := make([]RiskAlert, 0, len(teachers))
+	for _, st := range teachers {
+		score := byStudent[st.StudentID]
+		alerts = append(alerts, RiskAlert{
+			StudentID: st.StudentID,
+			TeacherID: st.TeacherID,
+			Score:     score.Score,
+			Level:     score.Level,
+			Summary:   summarize(score.Factors),
+		})
+	}
+	return alerts, nil
+}
+
+// summarize joins the factor details into one line for an alert
+func summarize(factors []RiskFactor) string {
+	details := make([]string, len(factors))
+	for i, f := range factors {
+		details[i] = f.Detail
+	}
+	return strings.Join(details, "; ")
+}
+
+// Validation methods
+func (s *riskService) validateLevel(level RiskLevel) error {
+	switch level {
+	case RiskLow, RiskModerate, RiskHigh:
+		return nil
+	}
+	return fmt.Errorf("invalid risk level %q (use low, moderate or high)", level)
+}
+
+// DTO mapping methods
+func (s *riskService) toScoreResponseDTO(score *RiskScore) *RiskScoreResponse {
+	return &RiskScoreResponse{
+		StudentID:   score.StudentID,
+		StudentName: score.Student.FirstName + " " + score.Student.LastName,
+		Score:       score.Score,
+		Level:       score.Level,
+		Factors:     score.Factors,
+		ComputedAt:  score.ComputedAt,
+	}
+}
+
+func (s *riskService) toAlertResponseDTO(alert *RiskAlert) *AlertResponse {
+	return &AlertResponse{
+		ID:             alert.ID,
+		StudentID:      alert.StudentID,
+		StudentName:    alert.Student.FirstName + " " + alert.Student.LastName,
+		TeacherID:      alert.TeacherID,
+		Score:          alert.Score,
+		Level:          alert.Level,
+		Summary:        alert.Summary,
+		AcknowledgedAt: alert.AcknowledgedAt,
+		CreatedAt:      alert.CreatedAt,
+	}
+}
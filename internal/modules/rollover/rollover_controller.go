@@ -0,0 +1,94 @@
+package rollover
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"school_management/internal/export"
+)
+
+// RolloverController handles HTTP requests for year-end rollover
+type RolloverController struct {
+	service RolloverService
+}
+
+// NewRolloverController creates a new rollover controller
+func NewRolloverController(service RolloverService) *RolloverController {
+	return &RolloverController{service: service}
+}
+
+// Run previews a rollover with dry_run, or commits it
+func (c *RolloverController) Run(ctx *gin.Context) {
+	var req RolloverRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.Run(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.DryRun {
+		ctx.JSON(http.StatusOK, resp)
+		return
+	}
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// GetAll retrieves every completed rollover
+func (c *RolloverController) GetAll(ctx *gin.Context) {
+	resp, err := c.service.GetAll()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// GetPolicy retrieves the promotion rules
+func (c *RolloverController) GetPolicy(ctx *gin.Context) {
+	resp, err := c.service.GetPolicy()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UpdatePolicy changes the promotion rules
+func (c *RolloverController) UpdatePolicy(ctx *gin.Context) {
+	var req UpdatePolicyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.UpdatePolicy(&req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// RegisterRoutes registers rollover routes
+func (c *RolloverController) RegisterRoutes(rg *gin.RouterGroup) {
+	rollovers := rg.Group("/rollovers")
+	{
+		rollovers.POST("", c.Run)
+		rollovers.GET("", c.GetAll)
+		rollovers.GET("/policy", c.GetPolicy)
+		rollovers.PUT("/policy", c.UpdatePolicy)
+	}
+}
@@ -0,0 +1,97 @@
+package rollover
+
+import "time"
+
+// RolloverRequest represents the request body for rolling an academic year over into the next
+type RolloverRequest struct {
+	FromYear  string `json:"from_year" binding:"required,len=9"` // e.g. 2025-2026
+	ToYear    string `json:"to_year" binding:"omitempty,len=9"`  // defaults to the year after from_year
+	StartDate string `json:"start_date" binding:"omitempty"`     // YYYY-MM-DD; defaults to July 1 of to_year
+	DryRun    bool   `json:"dry_run"`
+}
+
+// UpdatePolicyRequest represents the request body for changing the promotion rules
+type UpdatePolicyRequest struct {
+	PassPercent      *float64 `json:"pass_percent" binding:"omitempty,min=0,max=100"`
+	MinGPA           *float64 `json:"min_gpa" binding:"omitempty,min=0,max=4"`
+	MaxFailedCourses *int     `json:"max_failed_courses" binding:"omitempty,min=0"`
+}
+
+// PolicyResponse represents the promotion rules
+type PolicyResponse struct {
+	PassPercent      float64 `json:"pass_percent"`
+	MinGPA           float64 `json:"min_gpa"`
+	MaxFailedCourses int     `json:"max_failed_courses"`
+}
+
+// CourseCloneResponse represents one course carried into the new year
+type CourseCloneResponse struct {
+	SourceID uint   `json:"source_id"`
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	Exists   bool   `json:"exists"`           // already in the new year, so not cloned
+	NewID    uint   `json:"new_id,omitempty"` // set once committed
+}
+
+// HomeroomCloneResponse represents one homeroom carried into the new year
+type HomeroomCloneResponse struct {
+	SourceID   uint   `json:"source_id"`
+	Name       string `json:"name"`
+	GradeLevel string `json:"grade_level"`
+	Exists     bool   `json:"exists"`
+	NewID      uint   `json:"new_id,omitempty"`
+}
+
+// StudentOutcomeResponse represents what happens to one student
+type StudentOutcomeResponse struct {
+	StudentID      uint    `json:"student_id"`
+	StudentName    string  `json:"student_name"`
+	FromHomeroom   string  `json:"from_homeroom"`
+	FromGradeLevel string  `json:"from_grade_level"`
+	GPA            float64 `json:"gpa"`
+	Credits        int     `json:"credits"`
+	FailedCourses  int     `json:"failed_courses"`
+	Outcome        Outcome `json:"outcome"`
+	ToGradeLevel   string  `json:"to_grade_level,omitempty"`
+	ToHomeroom     string  `json:"to_homeroom,omitempty"` // empty when no homeroom had room
+	Reason         string  `json:"reason,omitempty"`
+}
+
+// SummaryResponse counts a rollover's changes
+type SummaryResponse struct {
+	CoursesCloned   int `json:"courses_cloned"`
+	HomeroomsCloned int `json:"homerooms_cloned"`
+	Promoted        int `json:"promoted"`
+	Retained        int `json:"retained"`
+	Graduated       int `json:"graduated"`
+	Skipped         int `json:"skipped"`
+	Unplaced        int `json:"unplaced"`
+}
+
+// RolloverPlanResponse represents the full plan of a rollover, previewed or committed
+type RolloverPlanResponse struct {
+	FromYear  string                   `json:"from_year"`
+	ToYear    string                   `json:"to_year"`
+	StartDate time.Time                `json:"start_date"`
+	DryRun    bool                     `json:"dry_run"`
+	Policy    PolicyResponse           `json:"policy"`
+	Summary   SummaryResponse          `json:"summary"`
+	Courses   []CourseCloneResponse    `json:"courses"`
+	Homerooms []HomeroomCloneResponse  `json:"homerooms"`
+	Students  []StudentOutcomeResponse `json:"students"`
+}
+
+// RolloverResponse represents a completed rollover
+type RolloverResponse struct {
+	ID              uint      `json:"id"`
+	FromYear        string    `json:"from_year"`
+	ToYear          string    `json:"to_year"`
+	StartDate       time.Time `json:"start_date"`
+	CoursesCloned   int       `json:"courses_cloned"`
+	HomeroomsCloned int       `json:"homerooms_cloned"`
+	Promoted        int       `json:"promoted"`
+	Retained        int       `json:"retained"`
+	Graduated       int       `json:"graduated"`
+	Unplaced        int       `json:"unplaced"`
+	CreatedAt       time.Time `json:"created_at"`
+}
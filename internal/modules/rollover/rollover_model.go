@@ -0,0 +1,54 @@
+package rollover
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Outcome is what a rollover does with one student
+type Outcome string
+
+const (
+	OutcomePromoted  Outcome = "promoted"  // moves up to the next grade level
+	OutcomeRetained  Outcome = "retained"  // repeats the same grade level
+	OutcomeGraduated Outcome = "graduated" // passed the final grade level and leaves the school
+	OutcomeSkipped   Outcome = "skipped"   // no longer studying; the homeroom membership just ends
+)
+
+// PromotionPolicy holds the pass rules applied to a student's results at rollover. Only one row is kept.
+type PromotionPolicy struct {
+	gorm.Model
+	PassPercent      float64 `gorm:"not null;default:60;comment:course percentage needed to pass a course" json:"pass_percent"`
+	MinGPA           float64 `gorm:"not null;default:2.0" json:"min_gpa"`
+	MaxFailedCourses int     `gorm:"not null;default:1" json:"max_failed_courses"`
+}
+
+// TableName specifies the table name for the PromotionPolicy model
+func (PromotionPolicy) TableName() string {
+	return "promotion_policies"
+}
+
+// Passes reports whether results with a GPA and failed course count meet the policy
+func (p *PromotionPolicy) Passes(gpa float64, failed int) bool {
+	return gpa >= p.MinGPA && failed <= p.MaxFailedCourses
+}
+
+// Rollover records a completed move from one academic year to the next. A year is rolled over once.
+type Rollover struct {
+	gorm.Model
+	FromYear        string    `gorm:"not null;size:9;uniqueIndex:idx_rollover_years" json:"from_year"`
+	ToYear          string    `gorm:"not null;size:9;uniqueIndex:idx_rollover_years" json:"to_year"`
+	StartDate       time.Time `gorm:"type:date;not null" json:"start_date"`
+	CoursesCloned   int       `gorm:"not null;default:0" json:"courses_cloned"`
+	HomeroomsCloned int       `gorm:"not null;default:0" json:"homerooms_cloned"`
+	Promoted        int       `gorm:"not null;default:0" json:"promoted"`
+	Retained        int       `gorm:"not null;default:0" json:"retained"`
+	Graduated       int       `gorm:"not null;default:0" json:"graduated"`
+	Unplaced        int       `gorm:"not null;default:0;comment:students left without a homeroom" json:"unplaced"`
+}
+
+// TableName specifies the table name for the Rollover model
+func (Rollover) TableName() string {
+	return "rollovers"
+}
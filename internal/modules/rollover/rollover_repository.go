@@ -0,0 +1,160 @@
+package rollover
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"school_management/internal/modules/course"
+	"school_management/internal/modules/homeroom"
+	"school_management/internal/modules/student"
+)
+
+// Placement puts a student in a new-year homeroom, which may be a clone not yet saved
+type Placement struct {
+	StudentID uint
+	Homeroom  *homeroom.Homeroom
+}
+
+// Changes are every write of a committed rollover
+type Changes struct {
+	Run         *Rollover
+	Courses     []*course.Course
+	Homerooms   []*homeroom.Homeroom
+	Ended       []uint // memberships ending the day before the new year starts
+	Placements  []Placement
+	Graduations []*student.StudentStatusChange // applied now when AppliedAt is set, otherwise left pending
+}
+
+// RolloverRepository defines the interface for rollover data access
+type RolloverRepository interface {
+	GetPolicy() (*PromotionPolicy, error)
+	SavePolicy(policy *PromotionPolicy) error
+	GetByYears(fromYear, toYear string) (*Rollover, error)
+	GetAll() ([]Rollover, error)
+	GetOpenMemberships(academicYear string) ([]homeroom.Membership, error)
+	Commit(changes *Changes) error
+}
+
+// rolloverRepository implements RolloverRepository
+type rolloverRepository struct {
+	db *gorm.DB
+}
+
+// NewRolloverRepository creates a new rollover repository with dependency injection
+func NewRolloverRepository(db *gorm.DB) RolloverRepository {
+	return &rolloverRepository{db: db}
+}
+
+// GetPolicy retrieves the promotion policy, falling back to the column defaults when none is saved
+func (r *rolloverRepository) GetPolicy() (*PromotionPolicy, error) {
+	var policy PromotionPolicy
+	err := r.db.Order("id ASC").First(&policy).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &PromotionPolicy{PassPercent: 60, MinGPA: 2.0, MaxFailedCourses: 1}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promotion policy: %w", err)
+	}
+	return &policy, nil
+}
+
+// SavePolicy creates or updates the promotion policy
+func (r *rolloverRepository) SavePolicy(policy *PromotionPolicy) error {
+	if err := r.db.Save(policy).Error; err != nil {
+		return fmt.Errorf("failed to save promotion policy: %w", err)
+	}
+	return nil
+}
+
+// GetByYears retrieves the rollover between two academic years
+func (r *rolloverRepository) GetByYears(fromYear, toYear string) (*Rollover, error) {
+	var rollover Rollover
+	if err := r.db.Where("from_year = ? AND to_year = ?", fromYear, toYear).First(&rollover).Error; err != nil {
+		return nil, fmt.Errorf("failed to get rollover: %w", err)
+	}
+	return &rollover, nil
+}
+
+// GetAll retrieves every completed rollover, newest first
+func (r *rolloverRepository) GetAll() ([]Rollover, error) {
+	var rollovers []Rollover
+	if err := r.db.Order("from_year DESC").Find(&rollovers).Error; err != nil {
+		return nil, fmt.Errorf("failed to get rollovers: %w", err)
+	}
+	return rollovers, nil
+}
+
+// GetOpenMemberships retrieves the open memberships of an academic year's homerooms,
+// with the students and the homerooms' grade levels preloaded
+func (r *rolloverRepository) GetOpenMemberships(academicYear string) ([]homeroom.Membership, error) {
+	var memberships []homeroom.Membership
+	if err := r.db.Preload("Student").Preload("Homeroom.GradeLevel").
+		Joins("JOIN homerooms ON homerooms.id = homeroom_memberships.homeroom_id AND homerooms.deleted_at IS NULL").
+		Where("homerooms.academic_year = ? AND homeroom_memberships.end_date IS NULL", academicYear).
+		Order("homeroom_memberships.student_id ASC").
+		Find(&memberships).Error; err != nil {
+		return nil, fmt.Errorf("failed to get homeroom memberships: %w", err)
+	}
+	return memberships, nil
+}
+
+// Commit writes a rollover in one transaction: the run record, cloned courses and homerooms,
+// ended and new homeroom memberships, and graduations
+func (r *rolloverRepository) Commit(changes *Changes) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(changes.Run).Error; err != nil {
+			return fmt.Errorf("failed to record rollover: %w", err)
+		}
+
+		if len(changes.Courses) > 0 {
+			if err := tx.Omit("Department", "Teacher").Create(&changes.Courses).Error; err != nil {
+				return fmt.Errorf("failed to clone courses: %w", err)
+			}
+		}
+		if len(changes.Homerooms) > 0 {
+			if err := tx.Omit("GradeLevel", "Teacher").Create(&changes.Homerooms).Error; err != nil {
+				return fmt.Errorf("failed to clone homerooms: %w", err)
+			}
+		}
+
+		endDate := changes.Run.StartDate.AddDate(0, 0, -1)
+		if len(changes.Ended) > 0 {
+			if err := tx.Model(&homeroom.Membership{}).
+				Where("id IN ?", changes.Ended).
+				Update("end_date", endDate).Error; err != nil {
+				return fmt.Errorf("failed to end homeroom memberships: %w", err)
+			}
+		}
+
+		if len(changes.Placements) > 0 {
+			memberships := make([]homeroom.Membership, len(changes.Placements))
+			for i, p := range changes.Placements {
+				memberships[i] = homeroom.Membership{
+					StudentID:  p.StudentID,
+					HomeroomID: p.Homeroom.ID, // set by the clone insert above for new homerooms
+					StartDate:  changes.Run.StartDate,
+				}
+			}
+			if err := tx.Omit("Student", "Homeroom").CreateInBatches(&memberships, 500).Error; err != nil {
+				return fmt.Errorf("failed to place students in homerooms: %w", err)
+			}
+		}
+
+		for _, change := range changes.Graduations {
+			if change.AppliedAt != nil {
+				if err := tx.Model(&student.Student{}).Where("id = ?", change.StudentID).Updates(map[string]interface{}{
+					"status":                change.ToStatus,
+					"status_effective_date": change.EffectiveDate,
+				}).Error; err != nil {
+					return fmt.Errorf("failed to graduate student %d: %w", change.StudentID, err)
+				}
+			}
+			if err := tx.Create(change).Error; err != nil {
+				return fmt.Errorf("failed to record graduation of student %d: %w", change.StudentID, err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,497 @@
+package rollover
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"school_management/internal/modules/course"
+	"school_management/internal/modules/homeroom"
+	"school_management/internal/modules/standing"
+	"school_management/internal/modules/student"
+)
+
+// RolloverService defines the business logic interface for year-end rollover
+type RolloverService interface {
+	GetPolicy() (*PolicyResponse, error)
+	UpdatePolicy(req *UpdatePolicyRequest) (*PolicyResponse, error)
+	Run(req *RolloverRequest) (*RolloverPlanResponse, error)
+	GetAll() ([]RolloverResponse, error)
+}
+
+// rolloverService implements RolloverService
+type rolloverService struct {
+	repo         RolloverRepository
+	homeroomRepo homeroom.HomeroomRepository
+	courseRepo   course.CourseRepository
+	studentRepo  student.StudentRepository
+	standingRepo standing.StandingRepository
+}
+
+// NewRolloverService creates a new rollover service with DI
+func NewRolloverService(repo RolloverRepository, homeroomRepo homeroom.HomeroomRepository, courseRepo course.CourseRepository,
+	studentRepo student.StudentRepository, standingRepo standing.StandingRepository) RolloverService {
+	return &rolloverService{
+		repo:         repo,
+		homeroomRepo: homeroomRepo,
+		courseRepo:   courseRepo,
+		studentRepo:  studentRepo,
+		standingRepo: standingRepo,
+	}
+}
+
+// plan is a rollover being worked out. courses and homerooms run parallel to the
+// response's lists and hold the clones to save, or nil where the new year already has one.
+type plan struct {
+	resp      *RolloverPlanResponse
+	changes   *Changes
+	courses   []*course.Course
+	homerooms []*homeroom.Homeroom
+	targets   map[uint][]*slot // new-year homerooms by grade level ID
+}
+
+// slot is a new-year homeroom students can be placed in
+type slot struct {
+	homeroom *homeroom.Homeroom
+	members  int64
+}
+
+// GetPolicy retrieves the promotion rules
+func (s *rolloverService) GetPolicy() (*PolicyResponse, error) {
+	policy, err := s.repo.GetPolicy()
+	if err != nil {
+		return nil, err
+	}
+	return s.toPolicyResponseDTO(policy), nil
+}
+
+// UpdatePolicy changes the promotion rules
+func (s *rolloverService) UpdatePolicy(req *UpdatePolicyRequest) (*PolicyResponse, error) {
+	// Get existing
+	policy, err := s.repo.GetPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	if req.PassPercent != nil {
+		policy.PassPercent = *req.PassPercent
+	}
+	if req.MinGPA != nil {
+		policy.MinGPA = *req.MinGPA
+	}
+	if req.MaxFailedCourses != nil {
+		policy.MaxFailedCourses = *req.MaxFailedCourses
+	}
+
+	// Save
+	if err := s.repo.SavePolicy(policy); err != nil {
+		return nil, err
+	}
+	return s.toPolicyResponseDTO(policy), nil
+}
+
+// Run works out a rollover from one academic year into the next: courses and homerooms are
+// cloned into the new year and every student in a homeroom is promoted, retained or graduated
+// by the promotion rules. A dry run only returns the plan; otherwise it is saved in one transaction.
+func (s *rolloverService) Run(req *RolloverRequest) (*RolloverPlanResponse, error) {
+	// Validate
+	toYear, startDate, err := s.validateRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if done, _ := s.repo.GetByYears(req.FromYear, toYear); done != nil {
+		return nil, fmt.Errorf("%s was already rolled over into %s on %s",
+			req.FromYear, toYear, done.CreatedAt.Format("2006-01-02"))
+	}
+
+	policy, err := s.repo.GetPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	p := &plan{
+		resp: &RolloverPlanResponse{
+			FromYear:  req.FromYear,
+			ToYear:    toYear,
+			StartDate: startDate,
+			DryRun:    req.DryRun,
+			Policy:    *s.toPolicyResponseDTO(policy),
+			Courses:   []CourseCloneResponse{},
+			Homerooms: []HomeroomCloneResponse{},
+			Students:  []StudentOutcomeResponse{},
+		},
+		changes: &Changes{},
+	}
+	if err := s.planCourses(p); err != nil {
+		return nil, err
+	}
+	if err := s.planHomerooms(p); err != nil {
+		return nil, err
+	}
+	if err := s.planStudents(p, policy); err != nil {
+		return nil, err
+	}
+	if len(p.resp.Courses) == 0 && len(p.resp.Homerooms) == 0 && len(p.resp.Students) == 0 {
+		return nil, fmt.Errorf("nothing to roll over: %s has no courses, homerooms or students", req.FromYear)
+	}
+
+	summary := &p.resp.Summary
+	p.changes.Run = &Rollover{
+		FromYear:        req.FromYear,
+		ToYear:          toYear,
+		StartDate:       startDate,
+		CoursesCloned:   summary.CoursesCloned,
+		HomeroomsCloned: summary.HomeroomsCloned,
+		Promoted:        summary.Promoted,
+		Retained:        summary.Retained,
+		Graduated:       summary.Graduated,
+		Unplaced:        summary.Unplaced,
+	}
+	if req.DryRun {
+		return p.resp, nil
+	}
+
+	// Save
+	if err := s.repo.Commit(p.changes); err != nil {
+		return nil, fmt.Errorf("failed to roll over %s: %w", req.FromYear, err)
+	}
+	for i, c := range p.courses {
+		if c != nil {
+			p.resp.Courses[i].NewID = c.ID
+		}
+	}
+	for i, h := range p.homerooms {
+		if h != nil {
+			p.resp.Homerooms[i].NewID = h.ID
+		}
+	}
+	return p.resp, nil
+}
+
+// GetAll retrieves every completed rollover
+func (s *rolloverService) GetAll() ([]RolloverResponse, error) {
+	rollovers, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]RolloverResponse, len(rollovers))
+	for i := range rollovers {
+		responses[i] = *s.toResponseDTO(&rollovers[i])
+	}
+	return responses, nil
+}
+
+// planCourses clones the old year's courses, skipping codes the new year already has
+func (s *rolloverService) planCourses(p *plan) error {
+	courses, err := s.courseRepo.GetByAcademicYear(p.resp.FromYear)
+	if err != nil {
+		return err
+	}
+	existing, err := s.courseRepo.GetByAcademicYear(p.resp.ToYear)
+	if err != nil {
+		return err
+	}
+	existingByCode := make(map[string]uint, len(existing))
+	for _, c := range existing {
+		existingByCode[c.Code] = c.ID
+	}
+
+	for _, c := range courses {
+		entry := CourseCloneResponse{SourceID: c.ID, Code: c.Code, Name: c.Name}
+		var clone *course.Course
+		if id, ok := existingByCode[c.Code]; ok {
+			entry.Exists = true
+			entry.NewID = id
+		} else {
+			clone = &course.Course{
+				Name:          c.Name,
+				Code:          c.Code,
+				Description:   c.Description,
+				Credits:       c.Credits,
+				DepartmentID:  c.DepartmentID,
+				TeacherID:     c.TeacherID,
+				AcademicYear:  p.resp.ToYear,
+				MinGradeLevel: c.MinGradeLevel,
+				MaxGradeLevel: c.MaxGradeLevel,
+			}
+			p.changes.Courses = append(p.changes.Courses, clone)
+			p.resp.Summary.CoursesCloned++
+		}
+		p.resp.Courses = append(p.resp.Courses, entry)
+		p.courses = append(p.courses, clone)
+	}
+	return nil
+}
+
+// planHomerooms clones the old year's homerooms, keeping names the new year already has,
+// and collects the new year's homerooms as placement targets
+func (s *rolloverService) planHomerooms(p *plan) error {
+	homerooms, err := s.homeroomRepo.GetAll(homeroom.HomeroomFilter{AcademicYear: p.resp.FromYear})
+	if err != nil {
+		return err
+	}
+	existing, err := s.homeroomRepo.GetAll(homeroom.HomeroomFilter{AcademicYear: p.resp.ToYear})
+	if err != nil {
+		return err
+	}
+	ids := make([]uint, len(existing))
+	for i := range existing {
+		ids[i] = existing[i].ID
+	}
+	counts, err := s.homeroomRepo.GetMemberCounts(ids)
+	if err != nil {
+		return err
+	}
+
+	p.targets = make(map[uint][]*slot)
+	existingByName := make(map[string]*homeroom.Homeroom, len(existing))
+	for i := range existing {
+		h := &existing[i]
+		existingByName[h.Name] = h
+		p.targets[h.GradeLevelID] = append(p.targets[h.GradeLevelID], &slot{homeroom: h, members: counts[h.ID]})
+	}
+
+	for _, h := range homerooms {
+		entry := HomeroomCloneResponse{SourceID: h.ID, Name: h.Name, GradeLevel: h.GradeLevel.Name}
+		var clone *homeroom.Homeroom
+		if found, ok := existingByName[h.Name]; ok {
+			entry.Exists = true
+			entry.NewID = found.ID
+		} else {
+			clone = &homeroom.Homeroom{
+				Name:         h.Name,
+				AcademicYear: p.resp.ToYear,
+				GradeLevelID: h.GradeLevelID,
+				TeacherID:    h.TeacherID,
+				Room:         h.Room,
+				Capacity:     h.Capacity,
+			}
+			p.changes.Homerooms = append(p.changes.Homerooms, clone)
+			p.targets[h.GradeLevelID] = append(p.targets[h.GradeLevelID], &slot{homeroom: clone})
+			p.resp.Summary.HomeroomsCloned++
+		}
+		p.resp.Homerooms = append(p.resp.Homerooms, entry)
+		p.homerooms = append(p.homerooms, clone)
+	}
+	return nil
+}
+
+// planStudents decides each old-year homeroom member's outcome and new homeroom
+func (s *rolloverService) planStudents(p *plan, policy *PromotionPolicy) error {
+	levels, err := s.homeroomRepo.GetGradeLevels()
+	if err != nil {
+		return err
+	}
+	next := make(map[uint]*homeroom.GradeLevel, len(levels))
+	for i := range levels {
+		if i+1 < len(levels) {
+			next[levels[i].ID] = &levels[i+1]
+		}
+	}
+
+	memberships, err := s.repo.GetOpenMemberships(p.resp.FromYear)
+	if err != nil {
+		return err
+	}
+	yearStart, yearEnd, err := course.AcademicYearBounds(p.resp.FromYear)
+	if err != nil {
+		return err
+	}
+	graduationDate := p.resp.StartDate.AddDate(0, 0, -1)
+	today := time.Now().Truncate(24 * time.Hour)
+	now := time.Now()
+
+	for _, m := range memberships {
+		st := m.Student
+		level := m.Homeroom.GradeLevel
+		out := StudentOutcomeResponse{
+			StudentID:      st.ID,
+			StudentName:    st.FirstName + " " + st.LastName,
+			FromHomeroom:   m.Homeroom.Name,
+			FromGradeLevel: level.Name,
+		}
+		p.changes.Ended = append(p.changes.Ended, m.ID)
+
+		target := &level
+		switch st.Status {
+		case student.StatusWithdrawn, student.StatusGraduated:
+			out.Outcome = OutcomeSkipped
+			out.Reason = fmt.Sprintf("student is %s", st.Status)
+			target = nil
+		case student.StatusApplicant:
+			out.Outcome = OutcomeRetained
+			out.Reason = "applicant has not started yet"
+		default:
+			results, err := s.standingRepo.GetCourseResults(st.ID, yearStart, yearEnd)
+			if err != nil {
+				return err
+			}
+			out.GPA, out.Credits = standing.GPA(results)
+			out.FailedCourses = failedCourses(results, policy.PassPercent)
+
+			// Without graded results there is nothing to judge, so the student is held back for review
+			switch {
+			case out.Credits == 0:
+				out.Outcome = OutcomeRetained
+				out.Reason = "no graded results; held at the same grade level for review"
+			case !policy.Passes(out.GPA, out.FailedCourses):
+				out.Outcome = OutcomeRetained
+				out.Reason = failReason(policy, out.GPA, out.FailedCourses)
+			case next[level.ID] != nil:
+				out.Outcome = OutcomePromoted
+				target = next[level.ID]
+			case st.Status != student.StatusActive:
+				out.Outcome = OutcomeRetained
+				out.Reason = fmt.Sprintf("%s students cannot graduate", st.Status)
+			default:
+				if pending, _ := s.studentRepo.GetPendingStatusChange(st.ID); pending != nil {
+					out.Outcome = OutcomeRetained
+					out.Reason = fmt.Sprintf("pending change to %s on %s; not graduated",
+						pending.ToStatus, pending.EffectiveDate.Format("2006-01-02"))
+					break
+				}
+				out.Outcome = OutcomeGraduated
+				target = nil
+				change := &student.StudentStatusChange{
+					StudentID:     st.ID,
+					FromStatus:    st.Status,
+					ToStatus:      student.StatusGraduated,
+					EffectiveDate: graduationDate,
+					Reason:        fmt.Sprintf("Completed %s in %s", level.Name, p.resp.FromYear),
+				}
+				if !graduationDate.After(today) {
+					change.AppliedAt = &now
+				}
+				p.changes.Graduations = append(p.changes.Graduations, change)
+			}
+		}
+
+		if target != nil {
+			out.ToGradeLevel = target.Name
+			if sl := pickSlot(p.targets[target.ID]); sl != nil {
+				sl.members++
+				out.ToHomeroom = sl.homeroom.Name
+				p.changes.Placements = append(p.changes.Placements, Placement{StudentID: st.ID, Homeroom: sl.homeroom})
+			} else {
+				p.resp.Summary.Unplaced++
+				out.Reason = joinReasons(out.Reason, fmt.Sprintf("no %s homeroom with room in %s", target.Name, p.resp.ToYear))
+			}
+		}
+
+		switch out.Outcome {
+		case OutcomePromoted:
+			p.resp.Summary.Promoted++
+		case OutcomeRetained:
+			p.resp.Summary.Retained++
+		case OutcomeGraduated:
+			p.resp.Summary.Graduated++
+		case OutcomeSkipped:
+			p.resp.Summary.Skipped++
+		}
+		p.resp.Students = append(p.resp.Students, out)
+	}
+	return nil
+}
+
+// pickSlot returns the homeroom with the fewest members that still has room, by name on ties
+func pickSlot(slots []*slot) *slot {
+	sort.SliceStable(slots, func(a, b int) bool {
+		if slots[a].members != slots[b].members {
+			return slots[a].members < slots[b].members
+		}
+		return slots[a].homeroom.Name < slots[b].homeroom.Name
+	})
+	for _, sl := range slots {
+		if sl.homeroom.Capacity == 0 || sl.members < int64(sl.homeroom.Capacity) {
+			return sl
+		}
+	}
+	return nil
+}
+
+// failedCourses counts the courses scored below the pass percentage
+func failedCourses(results []standing.CourseResult, passPercent float64) int {
+	failed := 0
+	for _, r := range results {
+		if r.MaxScore > 0 && r.Score/r.MaxScore*100 < passPercent {
+			failed++
+		}
+	}
+	return failed
+}
+
+// failReason explains which promotion rules a student missed
+func failReason(policy *PromotionPolicy, gpa float64, failed int) string {
+	var reasons []string
+	if gpa < policy.MinGPA {
+		reasons = append(reasons, fmt.Sprintf("GPA %.2f below %.2f", gpa, policy.MinGPA))
+	}
+	if failed > policy.MaxFailedCourses {
+		reasons = append(reasons, fmt.Sprintf("%d failed courses (at most %d allowed)", failed, policy.MaxFailedCourses))
+	}
+	return strings.Join(reasons, "; ")
+}
+
+func joinReasons(a, b string) string {
+	if a == "" {
+		return b
+	}
+	return a + "; " + b
+}
+
+// Validation methods
+func (s *rolloverService) validateRequest(req *RolloverRequest) (string, time.Time, error) {
+	if err := course.ValidateAcademicYear(req.FromYear); err != nil {
+		return "", time.Time{}, err
+	}
+	toYear, err := course.NextAcademicYear(req.FromYear)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if req.ToYear != "" && req.ToYear != toYear {
+		return "", time.Time{}, fmt.Errorf("to_year must be the year after %s (%s)", req.FromYear, toYear)
+	}
+
+	yearStart, yearEnd, err := course.AcademicYearBounds(toYear)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if req.StartDate == "" {
+		return toYear, yearStart, nil
+	}
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("invalid start date format (use YYYY-MM-DD): %w", err)
+	}
+	if startDate.Before(yearStart) || !startDate.Before(yearEnd) {
+		return "", time.Time{}, fmt.Errorf("start date must fall in %s", toYear)
+	}
+	return toYear, startDate, nil
+}
+
+// DTO mapping methods
+func (s *rolloverService) toPolicyResponseDTO(policy *PromotionPolicy) *PolicyResponse {
+	return &PolicyResponse{
+		PassPercent:      policy.PassPercent,
+		MinGPA:           policy.MinGPA,
+		MaxFailedCourses: policy.MaxFailedCourses,
+	}
+}
+
+func (s *rolloverService) toResponseDTO(rollover *Rollover) *RolloverResponse {
+	return &RolloverResponse{
+		ID:              rollover.ID,
+		FromYear:        rollover.FromYear,
+		ToYear:          rollover.ToYear,
+		StartDate:       rollover.StartDate,
+		CoursesCloned:   rollover.CoursesCloned,
+		HomeroomsCloned: rollover.HomeroomsCloned,
+		Promoted:        rollover.Promoted,
+		Retained:        rollover.Retained,
+		Graduated:       rollover.Graduated,
+		Unplaced:        rollover.Unplaced,
+		CreatedAt:       rollover.CreatedAt,
+	}
+}
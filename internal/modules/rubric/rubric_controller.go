@@ -0,0 +1,138 @@
+package rubric
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"school_management/internal/export"
+)
+
+// RubricController handles HTTP requests for rubrics
+type RubricController struct {
+	service RubricService
+}
+
+// NewRubricController creates a new rubric controller
+func NewRubricController(service RubricService) *RubricController {
+	return &RubricController{service: service}
+}
+
+// Create creates a new rubric
+func (c *RubricController) Create(ctx *gin.Context) {
+	var req CreateRubricRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.Create(&req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// GetByID retrieves a rubric by ID
+func (c *RubricController) GetByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetByID(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetAll retrieves all rubrics with pagination
+func (c *RubricController) GetAll(ctx *gin.Context) {
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	offset, _ := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+
+	if export.Requested(ctx) {
+		export.StreamPages(ctx, offset, func(limit, offset int) (interface{}, error) {
+			return c.service.GetAll(limit, offset)
+		})
+		return
+	}
+
+	resp, err := c.service.GetAll(limit, offset)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data":   resp,
+		"limit":  limit,
+		"offset": offset,
+		"count":  len(resp),
+	})
+}
+
+// Update updates a rubric
+func (c *RubricController) Update(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	var req UpdateRubricRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.Update(uint(id), &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Delete deletes a rubric
+func (c *RubricController) Delete(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	err = c.service.Delete(uint(id))
+	if errors.Is(err, ErrInUse) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "rubric deleted successfully"})
+}
+
+// RegisterRoutes registers rubric routes
+func (c *RubricController) RegisterRoutes(rg *gin.RouterGroup) {
+	rubrics := rg.Group("/rubrics")
+	{
+		rubrics.POST("", c.Create)
+		rubrics.GET("/:id", c.GetByID)
+		rubrics.GET("", c.GetAll)
+		rubrics.PUT("/:id", c.Update)
+		rubrics.DELETE("/:id", c.Delete)
+	}
+}
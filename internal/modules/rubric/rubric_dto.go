@@ -0,0 +1,60 @@
+package rubric
+
+import "time"
+
+// CreateRubricRequest represents the request body for creating a rubric
+type CreateRubricRequest struct {
+	Name        string             `json:"name" binding:"required,min=2,max=200"`
+	Description string             `json:"description" binding:"omitempty,max=1000"`
+	Criteria    []CriterionRequest `json:"criteria" binding:"required,min=1,dive"`
+}
+
+// CriterionRequest represents a criterion in a rubric request
+type CriterionRequest struct {
+	Name        string         `json:"name" binding:"required,min=2,max=200"`
+	Description string         `json:"description" binding:"omitempty,max=1000"`
+	Levels      []LevelRequest `json:"levels" binding:"required,min=1,dive"`
+}
+
+// LevelRequest represents a performance level in a rubric request
+type LevelRequest struct {
+	Label       string  `json:"label" binding:"required,max=100"`
+	Description string  `json:"description" binding:"omitempty,max=1000"`
+	Points      float64 `json:"points" binding:"min=0"`
+}
+
+// UpdateRubricRequest represents the request body for updating a rubric.
+// Criteria are fixed once created so that existing grades stay meaningful.
+type UpdateRubricRequest struct {
+	Name        string `json:"name" binding:"omitempty,min=2,max=200"`
+	Description string `json:"description" binding:"omitempty,max=1000"`
+}
+
+// LevelResponse represents the response body for a performance level
+type LevelResponse struct {
+	ID          uint    `json:"id"`
+	Label       string  `json:"label"`
+	Description string  `json:"description"`
+	Points      float64 `json:"points"`
+}
+
+// CriterionResponse represents the response body for a criterion
+type CriterionResponse struct {
+	ID          uint            `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Position    int             `json:"position"`
+	MaxPoints   float64         `json:"max_points"`
+	Levels      []LevelResponse `json:"levels"`
+}
+
+// RubricResponse represents the response body for rubric data
+type RubricResponse struct {
+	ID          uint                `json:"id"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	MaxPoints   float64             `json:"max_points"`
+	Criteria    []CriterionResponse `json:"criteria"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
@@ -0,0 +1,71 @@
+package rubric
+
+import (
+	"gorm.io/gorm"
+)
+
+// Rubric is a reusable grading scheme made of criteria, each with performance levels
+type Rubric struct {
+	gorm.Model
+	Name        string `gorm:"not null;size:200" json:"name"`
+	Description string `gorm:"type:text" json:"description"`
+
+	// Has Many relationship
+	Criteria []RubricCriterion `gorm:"foreignKey:RubricID" json:"criteria,omitempty"`
+}
+
+// TableName specifies the table name for the Rubric model
+func (Rubric) TableName() string {
+	return "rubrics"
+}
+
+// MaxPoints returns the highest total achievable with this rubric
+func (r *Rubric) MaxPoints() float64 {
+	var total float64
+	for i := range r.Criteria {
+		total += r.Criteria[i].MaxPoints()
+	}
+	return total
+}
+
+// RubricCriterion is a single dimension a submission is graded on
+type RubricCriterion struct {
+	gorm.Model
+	RubricID    uint   `gorm:"not null;index" json:"rubric_id"`
+	Name        string `gorm:"not null;size:200" json:"name"`
+	Description string `gorm:"type:text" json:"description"`
+	Position    int    `gorm:"not null;default:0" json:"position"`
+
+	// Has Many relationship
+	Levels []RubricLevel `gorm:"foreignKey:CriterionID" json:"levels,omitempty"`
+}
+
+// TableName specifies the table name for the RubricCriterion model
+func (RubricCriterion) TableName() string {
+	return "rubric_criteria"
+}
+
+// MaxPoints returns the points of the criterion's best performance level
+func (c *RubricCriterion) MaxPoints() float64 {
+	var best float64
+	for _, level := range c.Levels {
+		if level.Points > best {
+			best = level.Points
+		}
+	}
+	return best
+}
+
+// RubricLevel is a performance level of a criterion, worth a fixed number of points
+type RubricLevel struct {
+	gorm.Model
+	CriterionID uint    `gorm:"not null;index" json:"criterion_id"`
+	Label       string  `gorm:"not null;size:100" json:"label"`
+	Description string  `gorm:"type:text" json:"description"`
+	Points      float64 `gorm:"not null" json:"points"`
+}
+
+// TableName specifies the table name for the RubricLevel model
+func (RubricLevel) TableName() string {
+	return "rubric_levels"
+}
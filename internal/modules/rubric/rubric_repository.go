@@ -0,0 +1,91 @@
+package rubric
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// RubricRepository defines the interface for rubric data access
+type RubricRepository interface {
+	Create(rubric *Rubric) error
+	GetByID(id uint) (*Rubric, error)
+	GetAll(limit, offset int) ([]Rubric, error)
+	Update(rubric *Rubric) error
+	Delete(id uint) error
+	CountHomework(id uint) (int64, error)
+}
+
+// rubricRepository implements RubricRepository
+type rubricRepository struct {
+	db *gorm.DB
+}
+
+// NewRubricRepository creates a new rubric repository with dependency injection
+func NewRubricRepository(db *gorm.DB) RubricRepository {
+	return &rubricRepository{db: db}
+}
+
+// withStructure preloads criteria and levels in display order
+func (r *rubricRepository) withStructure() *gorm.DB {
+	return r.db.
+		Preload("Criteria", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Preload("Criteria.Levels", func(db *gorm.DB) *gorm.DB {
+			return db.Order("points DESC")
+		})
+}
+
+// Create creates a new rubric together with its criteria and levels
+func (r *rubricRepository) Create(rubric *Rubric) error {
+	if err := r.db.Create(rubric).Error; err != nil {
+		return fmt.Errorf("failed to create rubric: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a rubric by ID with criteria and levels preloaded
+func (r *rubricRepository) GetByID(id uint) (*Rubric, error) {
+	var rubric Rubric
+	if err := r.withStructure().First(&rubric, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get rubric: %w", err)
+	}
+	return &rubric, nil
+}
+
+// GetAll retrieves all rubrics with pagination
+func (r *rubricRepository) GetAll(limit, offset int) ([]Rubric, error) {
+	var rubrics []Rubric
+	if err := r.withStructure().Order("id").Limit(limit).Offset(offset).Find(&rubrics).Error; err != nil {
+		return nil, fmt.Errorf("failed to get rubrics: %w", err)
+	}
+	return rubrics, nil
+}
+
+// Update updates a rubric's own fields (criteria are left untouched)
+func (r *rubricRepository) Update(rubric *Rubric) error {
+	if err := r.db.Omit("Criteria").Save(rubric).Error; err != nil {
+		return fmt.Errorf("failed to update rubric: %w", err)
+	}
+	return nil
+}
+
+// Delete soft deletes a rubric
+func (r *rubricRepository) Delete(id uint) error {
+	if err := r.db.Delete(&Rubric{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete rubric: %w", err)
+	}
+	return nil
+}
+
+// CountHomework counts the homework assignments graded with a rubric
+func (r *rubricRepository) CountHomework(id uint) (int64, error) {
+	var count int64
+	if err := r.db.Table("homework").
+		Where("rubric_id = ? AND deleted_at IS NULL", id).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count homework using rubric: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,204 @@
+package rubric
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInUse is returned when deleting a rubric that homework is still graded with
+var ErrInUse = errors.New("rubric is still used by homework")
+
+// RubricService defines the business logic interface
+type RubricService interface {
+	Create(req *CreateRubricRequest) (*RubricResponse, error)
+	GetByID(id uint) (*RubricResponse, error)
+	GetAll(limit, offset int) ([]RubricResponse, error)
+	Update(id uint, req *UpdateRubricRequest) (*RubricResponse, error)
+	Delete(id uint) error
+}
+
+// rubricService implements RubricService
+type rubricService struct {
+	repo RubricRepository
+}
+
+// NewRubricService creates a new rubric service with DI
+func NewRubricService(repo RubricRepository) RubricService {
+	return &rubricService{repo: repo}
+}
+
+// Create creates a new rubric
+func (s *rubricService) Create(req *CreateRubricRequest) (*RubricResponse, error) {
+	// Validate
+	if err := s.validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+
+	// Map DTO to Model
+	rb := &Rubric{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+	for i, c := range req.Criteria {
+		criterion := RubricCriterion{
+			Name:        c.Name,
+			Description: c.Description,
+			Position:    i,
+		}
+		for _, l := range c.Levels {
+			criterion.Levels = append(criterion.Levels, RubricLevel{
+				Label:       l.Label,
+				Description: l.Description,
+				Points:      l.Points,
+			})
+		}
+		rb.Criteria = append(rb.Criteria, criterion)
+	}
+
+	// Create via repository
+	if err := s.repo.Create(rb); err != nil {
+		return nil, fmt.Errorf("failed to create rubric: %w", err)
+	}
+
+	// Map Model to Response DTO
+	return s.toResponseDTO(rb), nil
+}
+
+// GetByID retrieves a rubric by ID
+func (s *rubricService) GetByID(id uint) (*RubricResponse, error) {
+	rb, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("rubric not found: %w", err)
+	}
+	return s.toResponseDTO(rb), nil
+}
+
+// GetAll retrieves all rubrics with pagination
+func (s *rubricService) GetAll(limit, offset int) ([]RubricResponse, error) {
+	// Validate pagination
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	rubrics, err := s.repo.GetAll(limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rubrics: %w", err)
+	}
+	return s.toResponseDTOList(rubrics), nil
+}
+
+// Update updates a rubric's name and description
+func (s *rubricService) Update(id uint, req *UpdateRubricRequest) (*RubricResponse, error) {
+	// Get existing
+	rb, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("rubric not found: %w", err)
+	}
+
+	// Update fields
+	if req.Name != "" {
+		rb.Name = req.Name
+	}
+	if req.Description != "" {
+		rb.Description = req.Description
+	}
+
+	// Save
+	if err := s.repo.Update(rb); err != nil {
+		return nil, fmt.Errorf("failed to update rubric: %w", err)
+	}
+
+	return s.toResponseDTO(rb), nil
+}
+
+// Delete deletes a rubric. A rubric that homework is still graded with cannot be deleted,
+// since its criteria are needed to show those submissions' breakdowns.
+func (s *rubricService) Delete(id uint) error {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return fmt.Errorf("rubric not found: %w", err)
+	}
+	used, err := s.repo.CountHomework(id)
+	if err != nil {
+		return err
+	}
+	if used > 0 {
+		return fmt.Errorf("%w: %d homework assignment(s); detach it from them first", ErrInUse, used)
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete rubric: %w", err)
+	}
+
+	return nil
+}
+
+// Validation methods
+func (s *rubricService) validateCreateRequest(req *CreateRubricRequest) error {
+	if strings.TrimSpace(req.Name) == "" {
+		return fmt.Errorf("rubric name is required")
+	}
+	if len(req.Criteria) == 0 {
+		return fmt.Errorf("at least one criterion is required")
+	}
+	for _, c := range req.Criteria {
+		if strings.TrimSpace(c.Name) == "" {
+			return fmt.Errorf("criterion name is required")
+		}
+		if len(c.Levels) == 0 {
+			return fmt.Errorf("criterion %q needs at least one level", c.Name)
+		}
+		for _, l := range c.Levels {
+			if l.Points < 0 {
+				return fmt.Errorf("level points cannot be negative")
+			}
+		}
+	}
+	return nil
+}
+
+// DTO mapping methods
+func (s *rubricService) toResponseDTO(rb *Rubric) *RubricResponse {
+	resp := &RubricResponse{
+		ID:          rb.ID,
+		Name:        rb.Name,
+		Description: rb.Description,
+		MaxPoints:   rb.MaxPoints(),
+		Criteria:    make([]CriterionResponse, len(rb.Criteria)),
+		CreatedAt:   rb.CreatedAt,
+		UpdatedAt:   rb.UpdatedAt,
+	}
+
+	for i, c := range rb.Criteria {
+		criterion := CriterionResponse{
+			ID:          c.ID,
+			Name:        c.Name,
+			Description: c.Description,
+			Position:    c.Position,
+			MaxPoints:   c.MaxPoints(),
+			Levels:      make([]LevelResponse, len(c.Levels)),
+		}
+		for j, l := range c.Levels {
+			criterion.Levels[j] = LevelResponse{
+				ID:          l.ID,
+				Label:       l.Label,
+				Description: l.Description,
+				Points:      l.Points,
+			}
+		}
+		resp.Criteria[i] = criterion
+	}
+
+	return resp
+}
+
+func (s *rubricService) toResponseDTOList(rubrics []Rubric) []RubricResponse {
+	responses := make([]RubricResponse, len(rubrics))
+	for i, rb := range rubrics {
+		responses[i] = *s.toResponseDTO(&rb)
+	}
+	return responses
+}
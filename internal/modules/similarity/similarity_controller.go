@@ -0,0 +1,91 @@
+package similarity
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SimilarityController handles HTTP requests for submission similarity checks
+type SimilarityController struct {
+	service SimilarityService
+}
+
+// NewSimilarityController creates a new similarity controller
+func NewSimilarityController(service SimilarityService) *SimilarityController {
+	return &SimilarityController{service: service}
+}
+
+// RunCheck starts a similarity check for a homework's submissions
+func (c *SimilarityController) RunCheck(ctx *gin.Context) {
+	homeworkID, err := strconv.ParseUint(ctx.Param("homeworkId"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid homework ID"})
+		return
+	}
+
+	var req RunCheckRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	resp, err := c.service.RunCheck(uint(homeworkID), &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, resp)
+}
+
+// GetReport retrieves a similarity report by ID
+func (c *SimilarityController) GetReport(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	minScore, _ := strconv.ParseFloat(ctx.DefaultQuery("min_score", "0"), 64)
+
+	resp, err := c.service.GetReport(uint(id), minScore)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetLatestByHomework retrieves the latest similarity report for a homework
+func (c *SimilarityController) GetLatestByHomework(ctx *gin.Context) {
+	homeworkID, err := strconv.ParseUint(ctx.Param("homeworkId"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid homework ID"})
+		return
+	}
+
+	minScore, _ := strconv.ParseFloat(ctx.DefaultQuery("min_score", "0"), 64)
+
+	resp, err := c.service.GetLatestByHomework(uint(homeworkID), minScore)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// RegisterRoutes registers similarity routes
+func (c *SimilarityController) RegisterRoutes(rg *gin.RouterGroup) {
+	similarity := rg.Group("/similarity")
+	{
+		similarity.GET("/:id", c.GetReport)
+		similarity.POST("/homework/:homeworkId", c.RunCheck)
+		similarity.GET("/homework/:homeworkId", c.GetLatestByHomework)
+	}
+}
@@ -0,0 +1,33 @@
+package similarity
+
+import "time"
+
+// RunCheckRequest represents the request body for starting a similarity check
+type RunCheckRequest struct {
+	ShingleSize int `json:"shingle_size" binding:"omitempty,min=2,max=20"` // Words per shingle, default 5
+}
+
+// MatchResponse represents the response body for a pair of similar submissions
+type MatchResponse struct {
+	SubmissionAID uint      `json:"submission_a_id"`
+	SubmissionBID uint      `json:"submission_b_id"`
+	StudentAID    uint      `json:"student_a_id"`
+	StudentBID    uint      `json:"student_b_id"`
+	Score         float64   `json:"score"`
+	Excerpts      []Excerpt `json:"excerpts"`
+}
+
+// ReportResponse represents the response body for a similarity report
+type ReportResponse struct {
+	ID              uint            `json:"id"`
+	HomeworkID      uint            `json:"homework_id"`
+	Status          string          `json:"status"`
+	ShingleSize     int             `json:"shingle_size"`
+	SubmissionCount int             `json:"submission_count"`
+	StartedAt       *time.Time      `json:"started_at"`
+	CompletedAt     *time.Time      `json:"completed_at"`
+	Error           string          `json:"error,omitempty"`
+	Matches         []MatchResponse `json:"matches"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
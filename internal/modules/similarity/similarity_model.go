@@ -0,0 +1,72 @@
+package similarity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"school_management/internal/modules/homework"
+)
+
+// ReportStatus represents the state of a similarity check job
+type ReportStatus string
+
+const (
+	ReportPending   ReportStatus = "pending"
+	ReportRunning   ReportStatus = "running"
+	ReportCompleted ReportStatus = "completed"
+	ReportFailed    ReportStatus = "failed"
+)
+
+// SimilarityReport is one run of the pairwise similarity check over a homework's submissions
+type SimilarityReport struct {
+	gorm.Model
+	HomeworkID      uint         `gorm:"not null;index" json:"homework_id"`
+	Status          ReportStatus `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	ShingleSize     int          `gorm:"not null;default:5" json:"shingle_size"`
+	SubmissionCount int          `gorm:"not null;default:0" json:"submission_count"`
+	StartedAt       *time.Time   `gorm:"type:timestamp" json:"started_at"`
+	CompletedAt     *time.Time   `gorm:"type:timestamp" json:"completed_at"`
+	Error           string       `gorm:"type:text" json:"error"`
+
+	// Belongs To relationship
+	Homework homework.Homework `gorm:"foreignKey:HomeworkID" json:"homework,omitempty"`
+
+	// Has Many relationship
+	Matches []SimilarityMatch `gorm:"foreignKey:ReportID" json:"matches,omitempty"`
+}
+
+// TableName specifies the table name for the SimilarityReport model
+func (SimilarityReport) TableName() string {
+	return "similarity_reports"
+}
+
+// SimilarityMatch is the similarity score between two submissions of the same homework
+type SimilarityMatch struct {
+	gorm.Model
+	ReportID      uint      `gorm:"not null;index" json:"report_id"`
+	SubmissionAID uint      `gorm:"not null" json:"submission_a_id"`
+	SubmissionBID uint      `gorm:"not null" json:"submission_b_id"`
+	StudentAID    uint      `gorm:"not null" json:"student_a_id"`
+	StudentBID    uint      `gorm:"not null" json:"student_b_id"`
+	Score         float64   `gorm:"not null;index" json:"score"` // Jaccard similarity in [0, 1]
+	Excerpts      []Excerpt `gorm:"type:text;serializer:json" json:"excerpts"`
+}
+
+// TableName specifies the table name for the SimilarityMatch model
+func (SimilarityMatch) TableName() string {
+	return "similarity_matches"
+}
+
+// Excerpt is a passage shared by two submissions, with surrounding context on each side
+type Excerpt struct {
+	A ExcerptSide `json:"a"`
+	B ExcerptSide `json:"b"`
+}
+
+// ExcerptSide splits a passage into the matching text and its context so clients can highlight it
+type ExcerptSide struct {
+	Before string `json:"before"`
+	Match  string `json:"match"`
+	After  string `json:"after"`
+}
@@ -0,0 +1,81 @@
+package similarity
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// SimilarityRepository defines the interface for similarity report data access
+type SimilarityRepository interface {
+	CreateReport(report *SimilarityReport) error
+	GetReportByID(id uint, minScore float64) (*SimilarityReport, error)
+	GetLatestByHomework(homeworkID uint, minScore float64) (*SimilarityReport, error)
+	UpdateReport(report *SimilarityReport) error
+	CreateMatches(matches []SimilarityMatch) error
+}
+
+// similarityRepository implements SimilarityRepository
+type similarityRepository struct {
+	db *gorm.DB
+}
+
+// NewSimilarityRepository creates a new similarity repository with dependency injection
+func NewSimilarityRepository(db *gorm.DB) SimilarityRepository {
+	return &similarityRepository{db: db}
+}
+
+// withMatches preloads matches at or above minScore, most similar first
+func (r *similarityRepository) withMatches(minScore float64) *gorm.DB {
+	return r.db.Preload("Matches", func(db *gorm.DB) *gorm.DB {
+		return db.Where("score >= ?", minScore).Order("score DESC")
+	})
+}
+
+// CreateReport creates a new similarity report
+func (r *similarityRepository) CreateReport(report *SimilarityReport) error {
+	if err := r.db.Create(report).Error; err != nil {
+		return fmt.Errorf("failed to create similarity report: %w", err)
+	}
+	return nil
+}
+
+// GetReportByID retrieves a report with its matches
+func (r *similarityRepository) GetReportByID(id uint, minScore float64) (*SimilarityReport, error) {
+	var report SimilarityReport
+	if err := r.withMatches(minScore).First(&report, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get similarity report: %w", err)
+	}
+	return &report, nil
+}
+
+// GetLatestByHomework retrieves the most recent report for a homework with its matches
+func (r *similarityRepository) GetLatestByHomework(homeworkID uint, minScore float64) (*SimilarityReport, error) {
+	var report SimilarityReport
+	if err := r.withMatches(minScore).
+		Where("homework_id = ?", homeworkID).
+		Order("created_at DESC").
+		First(&report).Error; err != nil {
+		return nil, fmt.Errorf("failed to get similarity report by homework: %w", err)
+	}
+	return &report, nil
+}
+
+// UpdateReport updates a similarity report
+func (r *similarityRepository) UpdateReport(report *SimilarityReport) error {
+	if err := r.db.Omit("Matches", "Homework").Save(report).Error; err != nil {
+		return fmt.Errorf("failed to update similarity report: %w", err)
+	}
+	return nil
+}
+
+// CreateMatches stores the pairwise matches of a report in batches
+func (r *similarityRepository) CreateMatches(matches []SimilarityMatch) error {
+	if len(matches) == 0 {
+		return nil
+	}
+	if err := r.db.CreateInBatches(matches, 200).Error; err != nil {
+		return fmt.Errorf("failed to create similarity matches: %w", err)
+	}
+	return nil
+}
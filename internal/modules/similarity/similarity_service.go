@@ -0,0 +1,212 @@
+package similarity
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"school_management/internal/modules/homework"
+	"school_management/internal/modules/students_homework"
+)
+
+const defaultShingleSize = 5
+
+// staleReportAfter is how long a queued or running check may go without an update before it
+// is treated as abandoned, e.g. because the server restarted while it ran
+const staleReportAfter = time.Hour
+
+// SimilarityService defines the business logic interface
+type SimilarityService interface {
+	RunCheck(homeworkID uint, req *RunCheckRequest) (*ReportResponse, error)
+	GetReport(id uint, minScore float64) (*ReportResponse, error)
+	GetLatestByHomework(homeworkID uint, minScore float64) (*ReportResponse, error)
+}
+
+// similarityService implements SimilarityService
+type similarityService struct {
+	repo           SimilarityRepository
+	homeworkRepo   homework.HomeworkRepository
+	submissionRepo students_homework.StudentHomeworkRepository
+}
+
+// NewSimilarityService creates a new similarity service with DI
+func NewSimilarityService(repo SimilarityRepository, homeworkRepo homework.HomeworkRepository, submissionRepo students_homework.StudentHomeworkRepository) SimilarityService {
+	return &similarityService{repo: repo, homeworkRepo: homeworkRepo, submissionRepo: submissionRepo}
+}
+
+// RunCheck queues a similarity check for a homework and runs it in the background.
+// If a check for the homework is already queued or running, that report is returned instead;
+// one that has not moved for staleReportAfter is marked failed and a new check is queued.
+func (s *similarityService) RunCheck(homeworkID uint, req *RunCheckRequest) (*ReportResponse, error) {
+	if _, err := s.homeworkRepo.GetByID(homeworkID); err != nil {
+		return nil, fmt.Errorf("homework not found: %w", err)
+	}
+
+	if latest, err := s.repo.GetLatestByHomework(homeworkID, 0); err == nil &&
+		(latest.Status == ReportPending || latest.Status == ReportRunning) {
+		if time.Since(latest.UpdatedAt) < staleReportAfter {
+			return s.toResponseDTO(latest), nil
+		}
+		s.fail(latest, fmt.Errorf("abandoned: no progress since %s", latest.UpdatedAt.Format(time.RFC3339)))
+	}
+
+	shingleSize := req.ShingleSize
+	if shingleSize == 0 {
+		shingleSize = defaultShingleSize
+	}
+
+	report := &SimilarityReport{
+		HomeworkID:  homeworkID,
+		Status:      ReportPending,
+		ShingleSize: shingleSize,
+	}
+	if err := s.repo.CreateReport(report); err != nil {
+		return nil, fmt.Errorf("failed to queue similarity check: %w", err)
+	}
+
+	go s.run(*report)
+
+	return s.toResponseDTO(report), nil
+}
+
+// GetReport retrieves a similarity report by ID
+func (s *similarityService) GetReport(id uint, minScore float64) (*ReportResponse, error) {
+	report, err := s.repo.GetReportByID(id, minScore)
+	if err != nil {
+		return nil, fmt.Errorf("similarity report not found: %w", err)
+	}
+	return s.toResponseDTO(report), nil
+}
+
+// GetLatestByHomework retrieves the most recent similarity report for a homework
+func (s *similarityService) GetLatestByHomework(homeworkID uint, minScore float64) (*ReportResponse, error) {
+	report, err := s.repo.GetLatestByHomework(homeworkID, minScore)
+	if err != nil {
+		return nil, fmt.Errorf("similarity report not found: %w", err)
+	}
+	return s.toResponseDTO(report), nil
+}
+
+// run executes the similarity check for a report and records its outcome
+func (s *similarityService) run(report SimilarityReport) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.fail(&report, fmt.Errorf("panic: %v", r))
+		}
+	}()
+
+	started := time.Now()
+	report.Status = ReportRunning
+	report.StartedAt = &started
+	if err := s.repo.UpdateReport(&report); err != nil {
+		log.Printf("❌ Similarity check %d: %v", report.ID, err)
+		return
+	}
+
+	matches, count, err := s.compare(report.HomeworkID, report.ShingleSize)
+	if err != nil {
+		s.fail(&report, err)
+		return
+	}
+	for i := range matches {
+		matches[i].ReportID = report.ID
+	}
+	if err := s.repo.CreateMatches(matches); err != nil {
+		s.fail(&report, err)
+		return
+	}
+
+	completed := time.Now()
+	report.Status = ReportCompleted
+	report.SubmissionCount = count
+	report.CompletedAt = &completed
+	if err := s.repo.UpdateReport(&report); err != nil {
+		log.Printf("❌ Similarity check %d: %v", report.ID, err)
+	}
+}
+
+// compare scores every pair of submissions that have text content.
+// It returns the pairs with any overlap and the number of submissions compared.
+func (s *similarityService) compare(homeworkID uint, shingleSize int) ([]SimilarityMatch, int, error) {
+	submissions, err := s.submissionRepo.GetByHomework(homeworkID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	type entry struct {
+		submission students_homework.StudentHomework
+		doc        *document
+	}
+	var entries []entry
+	for _, sub := range submissions {
+		version, err := s.submissionRepo.GetLatestVersion(sub.ID)
+		if err != nil || strings.TrimSpace(version.Content) == "" {
+			continue
+		}
+		entries = append(entries, entry{submission: sub, doc: newDocument(version.Content, shingleSize)})
+	}
+
+	var matches []SimilarityMatch
+	for i := 0; i < len(entries); i++ {
+		for j := i + 1; j < len(entries); j++ {
+			a, b := entries[i], entries[j]
+			score := jaccard(a.doc, b.doc)
+			if score == 0 {
+				continue
+			}
+			matches = append(matches, SimilarityMatch{
+				SubmissionAID: a.submission.ID,
+				SubmissionBID: b.submission.ID,
+				StudentAID:    a.submission.StudentID,
+				StudentBID:    b.submission.StudentID,
+				Score:         score,
+				Excerpts:      excerpts(a.doc, b.doc, shingleSize),
+			})
+		}
+	}
+
+	return matches, len(entries), nil
+}
+
+// fail marks a report as failed
+func (s *similarityService) fail(report *SimilarityReport, cause error) {
+	log.Printf("❌ Similarity check %d failed: %v", report.ID, cause)
+	completed := time.Now()
+	report.Status = ReportFailed
+	report.Error = cause.Error()
+	report.CompletedAt = &completed
+	if err := s.repo.UpdateReport(report); err != nil {
+		log.Printf("❌ Similarity check %d: %v", report.ID, err)
+	}
+}
+
+// DTO mapping methods
+func (s *similarityService) toResponseDTO(report *SimilarityReport) *ReportResponse {
+	resp := &ReportResponse{
+		ID:              report.ID,
+		HomeworkID:      report.HomeworkID,
+		Status:          string(report.Status),
+		ShingleSize:     report.ShingleSize,
+		SubmissionCount: report.SubmissionCount,
+		StartedAt:       report.StartedAt,
+		CompletedAt:     report.CompletedAt,
+		Error:           report.Error,
+		Matches:         make([]MatchResponse, len(report.Matches)),
+		CreatedAt:       report.CreatedAt,
+		UpdatedAt:       report.UpdatedAt,
+	}
+
+	for i, m := range report.Matches {
+		resp.Matches[i] = MatchResponse{
+			SubmissionAID: m.SubmissionAID,
+			SubmissionBID: m.SubmissionBID,
+			StudentAID:    m.StudentAID,
+			StudentBID:    m.StudentBID,
+			Score:         m.Score,
+			Excerpts:      m.Excerpts,
+		}
+	}
+
+	return resp
+}
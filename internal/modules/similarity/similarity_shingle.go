@@ -0,0 +1,122 @@
+package similarity
+
+import (
+	"hash/fnv"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	excerptContextWords = 8
+	maxExcerptsPerMatch = 5
+)
+
+var wordPattern = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// document is a tokenized submission text and its set of word n-gram shingles
+type document struct {
+	words    []string       // original words, used for excerpts
+	shingles map[uint64]int // shingle hash -> first word position
+	order    []uint64       // shingle hash at each word position
+}
+
+// newDocument tokenizes text into words and builds its n-word shingles
+func newDocument(text string, n int) *document {
+	words := wordPattern.FindAllString(text, -1)
+	doc := &document{words: words, shingles: make(map[uint64]int)}
+	if len(words) < n {
+		return doc
+	}
+
+	doc.order = make([]uint64, len(words)-n+1)
+	for i := range doc.order {
+		h := fnv.New64a()
+		for _, w := range words[i : i+n] {
+			h.Write([]byte(strings.ToLower(w)))
+			h.Write([]byte{0})
+		}
+		sum := h.Sum64()
+		doc.order[i] = sum
+		if _, seen := doc.shingles[sum]; !seen {
+			doc.shingles[sum] = i
+		}
+	}
+	return doc
+}
+
+// jaccard returns |A ∩ B| / |A ∪ B| over the two documents' shingle sets
+func jaccard(a, b *document) float64 {
+	if len(a.shingles) == 0 || len(b.shingles) == 0 {
+		return 0
+	}
+	small, large := a, b
+	if len(small.shingles) > len(large.shingles) {
+		small, large = large, small
+	}
+
+	shared := 0
+	for h := range small.shingles {
+		if _, ok := large.shingles[h]; ok {
+			shared++
+		}
+	}
+	union := len(a.shingles) + len(b.shingles) - shared
+	return float64(shared) / float64(union)
+}
+
+// excerpts finds the longest passages of a that also appear in b
+func excerpts(a, b *document, n int) []Excerpt {
+	type span struct{ startA, startB, length int }
+
+	var spans []span
+	for i := 0; i < len(a.order); {
+		j, ok := b.shingles[a.order[i]]
+		if !ok {
+			i++
+			continue
+		}
+		// Extend the run while consecutive shingles keep matching in b
+		k := 1
+		for i+k < len(a.order) && j+k < len(b.order) && a.order[i+k] == b.order[j+k] {
+			k++
+		}
+		spans = append(spans, span{startA: i, startB: j, length: k + n - 1})
+		i += k
+	}
+
+	sort.SliceStable(spans, func(x, y int) bool { return spans[x].length > spans[y].length })
+	if len(spans) > maxExcerptsPerMatch {
+		spans = spans[:maxExcerptsPerMatch]
+	}
+
+	result := make([]Excerpt, len(spans))
+	for i, s := range spans {
+		result[i] = Excerpt{
+			A: a.side(s.startA, s.length),
+			B: b.side(s.startB, s.length),
+		}
+	}
+	return result
+}
+
+// side renders the words [start, start+length) with a little context on either side
+func (d *document) side(start, length int) ExcerptSide {
+	end := start + length
+	if end > len(d.words) {
+		end = len(d.words)
+	}
+	from := start - excerptContextWords
+	if from < 0 {
+		from = 0
+	}
+	to := end + excerptContextWords
+	if to > len(d.words) {
+		to = len(d.words)
+	}
+	return ExcerptSide{
+		Before: strings.Join(d.words[from:start], " "),
+		Match:  strings.Join(d.words[start:end], " "),
+		After:  strings.Join(d.words[end:to], " "),
+	}
+}
@@ -0,0 +1,159 @@
+package standing
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"school_management/internal/export"
+)
+
+// StandingController handles HTTP requests for academic standing reports
+type StandingController struct {
+	service StandingService
+}
+
+// NewStandingController creates a new standing controller
+func NewStandingController(service StandingService) *StandingController {
+	return &StandingController{service: service}
+}
+
+// reportFilter reads the term (defaulting to the current one) and optional department_id query parameters
+func reportFilter(ctx *gin.Context) (string, *uint, bool) {
+	term := ctx.DefaultQuery("term", TermOf(time.Now()))
+
+	raw := ctx.Query("department_id")
+	if raw == "" {
+		return term, nil, true
+	}
+	id, err := strconv.ParseUint(raw, 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid department ID"})
+		return "", nil, false
+	}
+	departmentID := uint(id)
+	return term, &departmentID, true
+}
+
+// GetHonorRoll retrieves a term's dean's list and honor roll
+func (c *StandingController) GetHonorRoll(ctx *gin.Context) {
+	term, departmentID, ok := reportFilter(ctx)
+	if !ok {
+		return
+	}
+
+	resp, err := c.service.GetHonorRoll(term, departmentID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetProbation retrieves the students on academic probation for a term
+func (c *StandingController) GetProbation(ctx *gin.Context) {
+	term, departmentID, ok := reportFilter(ctx)
+	if !ok {
+		return
+	}
+
+	resp, err := c.service.GetProbation(term, departmentID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetClassRank retrieves a term's class rank, school-wide, within a department or within the
+// cohort at ?grade_level_id
+func (c *StandingController) GetClassRank(ctx *gin.Context) {
+	term, departmentID, ok := reportFilter(ctx)
+	if !ok {
+		return
+	}
+	var gradeLevelID *uint
+	if raw := ctx.Query("grade_level_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid grade level ID"})
+			return
+		}
+		level := uint(id)
+		gradeLevelID = &level
+	}
+
+	resp, err := c.service.GetClassRank(term, departmentID, gradeLevelID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetByStudent retrieves a student's standing for every term
+func (c *StandingController) GetByStudent(ctx *gin.Context) {
+	studentID, err := strconv.ParseUint(ctx.Param("studentId"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid student ID"})
+		return
+	}
+
+	resp, err := c.service.GetByStudent(uint(studentID))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// GetPolicy retrieves the standing thresholds
+func (c *StandingController) GetPolicy(ctx *gin.Context) {
+	resp, err := c.service.GetPolicy()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UpdatePolicy changes the standing thresholds
+func (c *StandingController) UpdatePolicy(ctx *gin.Context) {
+	var req UpdatePolicyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.UpdatePolicy(&req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// RegisterRoutes registers academic standing report routes
+func (c *StandingController) RegisterRoutes(rg *gin.RouterGroup) {
+	reports := rg.Group("/reports")
+	{
+		reports.GET("/honor-roll", c.GetHonorRoll)
+		reports.GET("/probation", c.GetProbation)
+		reports.GET("/class-rank", c.GetClassRank)
+		reports.GET("/standing/student/:studentId", c.GetByStudent)
+		reports.GET("/standing-policy", c.GetPolicy)
+		reports.PUT("/standing-policy", c.UpdatePolicy)
+	}
+}
@@ -0,0 +1,73 @@
+package standing
+
+import "time"
+
+// UpdatePolicyRequest represents the request body for changing the standing thresholds
+type UpdatePolicyRequest struct {
+	DeansListGPA *float64 `json:"deans_list_gpa" binding:"omitempty,min=0,max=4"`
+	HonorRollGPA *float64 `json:"honor_roll_gpa" binding:"omitempty,min=0,max=4"`
+	ProbationGPA *float64 `json:"probation_gpa" binding:"omitempty,min=0,max=4"`
+	MinCredits   *int     `json:"min_credits" binding:"omitempty,min=0"`
+}
+
+// PolicyResponse represents the standing thresholds
+type PolicyResponse struct {
+	DeansListGPA float64 `json:"deans_list_gpa"`
+	HonorRollGPA float64 `json:"honor_roll_gpa"`
+	ProbationGPA float64 `json:"probation_gpa"`
+	MinCredits   int     `json:"min_credits"`
+}
+
+// EntryResponse represents one student's standing in a report
+type EntryResponse struct {
+	StudentID   uint    `json:"student_id"`
+	StudentName string  `json:"student_name"`
+	GPA         float64 `json:"gpa"`
+	Credits     int     `json:"credits"`
+	Standing    string  `json:"standing,omitempty"`
+	Rank        int     `json:"rank"`
+	RankedAmong int     `json:"ranked_among"`
+}
+
+// HonorRollResponse represents a term's dean's list and honor roll
+type HonorRollResponse struct {
+	Term         string          `json:"term"`
+	DepartmentID *uint           `json:"department_id,omitempty"`
+	Policy       PolicyResponse  `json:"policy"`
+	DeansList    []EntryResponse `json:"deans_list"`
+	HonorRoll    []EntryResponse `json:"honor_roll"`
+}
+
+// RankingResponse represents a ranked list of students for a term, school-wide, within a
+// department or within a grade level cohort
+type RankingResponse struct {
+	Term         string          `json:"term"`
+	DepartmentID *uint           `json:"department_id,omitempty"`
+	GradeLevelID *uint           `json:"grade_level_id,omitempty"`
+	Entries      []EntryResponse `json:"entries"`
+}
+
+// TermStandingResponse represents a student's standing for one term
+type TermStandingResponse struct {
+	Term        string                   `json:"term"`
+	GPA         float64                  `json:"gpa"`
+	Credits     int                      `json:"credits"`
+	Standing    string                   `json:"standing"`
+	Rank        int                      `json:"rank"`
+	RankedAmong int                      `json:"ranked_among"`
+	ComputedAt  time.Time                `json:"computed_at"`
+	Departments []DepartmentRankResponse `json:"departments"`
+
+	GradeLevelID      *uint `json:"grade_level_id"`
+	CohortRank        int   `json:"cohort_rank"` // rank among students at the same grade level; 0 when in no homeroom
+	CohortRankedAmong int   `json:"cohort_ranked_among"`
+}
+
+// DepartmentRankResponse represents a student's rank within one department for a term
+type DepartmentRankResponse struct {
+	DepartmentID uint    `json:"department_id"`
+	GPA          float64 `json:"gpa"`
+	Credits      int     `json:"credits"`
+	Rank         int     `json:"rank"`
+	RankedAmong  int     `json:"ranked_among"`
+}
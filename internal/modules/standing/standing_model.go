@@ -0,0 +1,127 @@
+package standing
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"school_management/internal/modules/department"
+	"school_management/internal/modules/student"
+)
+
+// Level represents a student's academic standing for a term
+type Level string
+
+const (
+	LevelDeansList Level = "deans_list"
+	LevelHonorRoll Level = "honor_roll"
+	LevelGood      Level = "good_standing"
+	LevelProbation Level = "probation"
+)
+
+// StandingPolicy holds the GPA thresholds used to classify standings. Only one row is kept.
+type StandingPolicy struct {
+	gorm.Model
+	DeansListGPA float64 `gorm:"not null;default:3.7" json:"deans_list_gpa"`
+	HonorRollGPA float64 `gorm:"not null;default:3.3" json:"honor_roll_gpa"`
+	ProbationGPA float64 `gorm:"not null;default:2.0;comment:GPA below this is probation" json:"probation_gpa"`
+	MinCredits   int     `gorm:"not null;default:1;comment:credits required for dean's list or honor roll" json:"min_credits"`
+}
+
+// TableName specifies the table name for the StandingPolicy model
+func (StandingPolicy) TableName() string {
+	return "standing_policies"
+}
+
+// Classify returns the standing level for a GPA earned over the given credits
+func (p *StandingPolicy) Classify(gpa float64, credits int) Level {
+	switch {
+	case gpa < p.ProbationGPA:
+		return LevelProbation
+	case credits >= p.MinCredits && gpa >= p.DeansListGPA:
+		return LevelDeansList
+	case credits >= p.MinCredits && gpa >= p.HonorRollGPA:
+		return LevelHonorRoll
+	default:
+		return LevelGood
+	}
+}
+
+// AcademicStanding is a student's GPA, standing and class rank for one term, school-wide and
+// within the cohort of students at their grade level
+type AcademicStanding struct {
+	gorm.Model
+	StudentID   uint      `gorm:"not null;uniqueIndex:idx_student_term" json:"student_id"`
+	Term        string    `gorm:"not null;size:10;uniqueIndex:idx_student_term;index" json:"term"`
+	GPA         float64   `gorm:"not null" json:"gpa"`
+	Credits     int       `gorm:"not null" json:"credits"`
+	Standing    Level     `gorm:"type:varchar(20);not null" json:"standing"`
+	Rank        int       `gorm:"not null;default:0" json:"rank"`
+	RankedAmong int       `gorm:"not null;default:0" json:"ranked_among"`
+	ComputedAt  time.Time `gorm:"type:timestamp;not null" json:"computed_at"`
+
+	GradeLevelID      *uint `gorm:"index;comment:grade level of the student's homeroom in the term" json:"grade_level_id"`
+	CohortRank        int   `gorm:"not null;default:0" json:"cohort_rank"`
+	CohortRankedAmong int   `gorm:"not null;default:0" json:"cohort_ranked_among"`
+
+	// Belongs To relationship
+	Student student.Student `gorm:"foreignKey:StudentID" json:"student,omitempty"`
+}
+
+// TableName specifies the table name for the AcademicStanding model
+func (AcademicStanding) TableName() string {
+	return "academic_standings"
+}
+
+// DepartmentStanding is a student's GPA and rank over one department's courses for a term
+type DepartmentStanding struct {
+	gorm.Model
+	StudentID    uint    `gorm:"not null;uniqueIndex:idx_student_term_department" json:"student_id"`
+	Term         string  `gorm:"not null;size:10;uniqueIndex:idx_student_term_department;index:idx_term_department" json:"term"`
+	DepartmentID uint    `gorm:"not null;uniqueIndex:idx_student_term_department;index:idx_term_department" json:"department_id"`
+	GPA          float64 `gorm:"not null" json:"gpa"`
+	Credits      int     `gorm:"not null" json:"credits"`
+	Rank         int     `gorm:"not null;default:0" json:"rank"`
+	RankedAmong  int     `gorm:"not null;default:0" json:"ranked_among"`
+
+	// Belongs To relationships
+	Student    student.Student       `gorm:"foreignKey:StudentID" json:"student,omitempty"`
+	Department department.Department `gorm:"foreignKey:DepartmentID" json:"department,omitempty"`
+}
+
+// TableName specifies the table name for the DepartmentStanding model
+func (DepartmentStanding) TableName() string {
+	return "department_standings"
+}
+
+// StandingSync records how far grade changes have been folded into standings. Only one row is kept.
+type StandingSync struct {
+	gorm.Model
+	LastSyncedAt time.Time `gorm:"type:timestamp;not null" json:"last_synced_at"`
+}
+
+// TableName specifies the table name for the StandingSync model
+func (StandingSync) TableName() string {
+	return "standing_syncs"
+}
+
+// CourseResult is a student's published exam results in one course over a term
+type CourseResult struct {
+	CourseID     uint
+	DepartmentID uint
+	Credits      int
+	Score        float64 // sum of exam scores
+	MaxScore     float64 // sum of exam maximums
+}
+
+// GradeChange is a student whose grade on an exam taken on ExamDate changed
+type GradeChange struct {
+	StudentID uint
+	ExamDate  time.Time
+}
+
+// StudentTerm is a term a student has a stored standing for
+type StudentTerm struct {
+	StudentID uint
+	Term      string
+}
@@ -0,0 +1,255 @@
+package standing
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"school_management/internal/modules/grade"
+)
+
+// StandingRepository defines the interface for academic standing data access
+type StandingRepository interface {
+	GetPolicy() (*StandingPolicy, error)
+	SavePolicy(policy *StandingPolicy) error
+	GetLastSync() (time.Time, error)
+	SaveLastSync(at time.Time) error
+	GetGradeChanges(since time.Time) ([]GradeChange, error)
+	GetStandingsOnChangedExams(since time.Time) ([]StudentTerm, error)
+	GetGradeLevel(studentID uint, start, end time.Time) (*uint, error)
+	GetCourseResults(studentID uint, start, end time.Time) ([]CourseResult, error)
+	ReplaceStudentTerm(studentID uint, term string, overall *AcademicStanding, departments []DepartmentStanding) error
+	GetByTerm(term string) ([]AcademicStanding, error)
+	GetAll() ([]AcademicStanding, error)
+	GetDepartmentStandingsByTerm(term string) ([]DepartmentStanding, error)
+	GetByStudent(studentID uint) ([]AcademicStanding, error)
+	GetDepartmentStandingsByStudent(studentID uint) ([]DepartmentStanding, error)
+	SaveClassification(standings []AcademicStanding, departments []DepartmentStanding) error
+}
+
+// standingRepository implements StandingRepository
+type standingRepository struct {
+	db *gorm.DB
+}
+
+// NewStandingRepository creates a new standing repository with dependency injection
+func NewStandingRepository(db *gorm.DB) StandingRepository {
+	return &standingRepository{db: db}
+}
+
+// GetPolicy retrieves the standing policy, falling back to the column defaults when none is saved
+func (r *standingRepository) GetPolicy() (*StandingPolicy, error) {
+	var policy StandingPolicy
+	err := r.db.Order("id ASC").First(&policy).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &StandingPolicy{DeansListGPA: 3.7, HonorRollGPA: 3.3, ProbationGPA: 2.0, MinCredits: 1}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get standing policy: %w", err)
+	}
+	return &policy, nil
+}
+
+// SavePolicy creates or updates the standing policy
+func (r *standingRepository) SavePolicy(policy *StandingPolicy) error {
+	if err := r.db.Save(policy).Error; err != nil {
+		return fmt.Errorf("failed to save standing policy: %w", err)
+	}
+	return nil
+}
+
+// GetLastSync retrieves when grade changes were last folded into standings; zero if never
+func (r *standingRepository) GetLastSync() (time.Time, error) {
+	var sync StandingSync
+	err := r.db.Order("id ASC").First(&sync).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get standing sync: %w", err)
+	}
+	return sync.LastSyncedAt, nil
+}
+
+// SaveLastSync records when grade changes were last folded into standings
+func (r *standingRepository) SaveLastSync(at time.Time) error {
+	var sync StandingSync
+	if err := r.db.Order("id ASC").FirstOrInit(&sync).Error; err != nil {
+		return fmt.Errorf("failed to get standing sync: %w", err)
+	}
+	sync.LastSyncedAt = at
+	if err := r.db.Save(&sync).Error; err != nil {
+		return fmt.Errorf("failed to save standing sync: %w", err)
+	}
+	return nil
+}
+
+// GetGradeChanges retrieves students whose exam grades were created, changed or deleted
+// since the given time, including grades on exams that were themselves changed
+func (r *standingRepository) GetGradeChanges(since time.Time) ([]GradeChange, error) {
+	var changes []GradeChange
+	if err := r.db.Unscoped().Model(&grade.Grade{}).
+		Distinct("grades.student_id", "exams.exam_date").
+		Joins("JOIN exams ON exams.id = grades.exam_id").
+		Where("grades.updated_at > ? OR grades.deleted_at > ? OR exams.updated_at > ? OR exams.deleted_at > ?",
+			since, since, since, since).
+		Scan(&changes).Error; err != nil {
+		return nil, fmt.Errorf("failed to get grade changes: %w", err)
+	}
+	return changes, nil
+}
+
+// GetStandingsOnChangedExams retrieves the stored standing terms of students graded on exams
+// changed since the given time. An exam moved to another date leaves the term it moved out of
+// among them, so that term is recomputed too.
+func (r *standingRepository) GetStandingsOnChangedExams(since time.Time) ([]StudentTerm, error) {
+	var terms []StudentTerm
+	if err := r.db.Model(&AcademicStanding{}).
+		Distinct("academic_standings.student_id", "academic_standings.term").
+		Joins("JOIN grades ON grades.student_id = academic_standings.student_id").
+		Joins("JOIN exams ON exams.id = grades.exam_id").
+		Where("exams.updated_at > ? OR exams.deleted_at > ?", since, since).
+		Scan(&terms).Error; err != nil {
+		return nil, fmt.Errorf("failed to get standings on changed exams: %w", err)
+	}
+	return terms, nil
+}
+
+// GetGradeLevel retrieves the grade level of the homeroom a student was last in during
+// [start, end), or nil when they were in none
+func (r *standingRepository) GetGradeLevel(studentID uint, start, end time.Time) (*uint, error) {
+	var levels []uint
+	if err := r.db.Table("homeroom_memberships").
+		Joins("JOIN homerooms ON homerooms.id = homeroom_memberships.homeroom_id AND homerooms.deleted_at IS NULL").
+		Where("homeroom_memberships.student_id = ? AND homeroom_memberships.deleted_at IS NULL", studentID).
+		Where("homeroom_memberships.start_date < ? AND (homeroom_memberships.end_date IS NULL OR homeroom_memberships.end_date >= ?)", end, start).
+		Order("homeroom_memberships.start_date DESC").
+		Limit(1).
+		Pluck("homerooms.grade_level_id", &levels).Error; err != nil {
+		return nil, fmt.Errorf("failed to get grade level: %w", err)
+	}
+	if len(levels) == 0 {
+		return nil, nil
+	}
+	return &levels[0], nil
+}
+
+// GetCourseResults sums a student's published exam results per course for exams in [start, end)
+func (r *standingRepository) GetCourseResults(studentID uint, start, end time.Time) ([]CourseResult, error) {
+	var results []CourseResult
+	if err := r.db.Model(&grade.Grade{}).
+		Select("courses.id AS course_id, courses.department_id, courses.credits, "+
+			"SUM(grades.score) AS score, SUM(exams.max_score) AS max_score").
+		Joins("JOIN exams ON exams.id = grades.exam_id AND exams.deleted_at IS NULL").
+		Joins("JOIN courses ON courses.id = exams.course_id AND courses.deleted_at IS NULL").
+		Where("grades.student_id = ? AND grades.status = ?", studentID, grade.GradePublished).
+		Where("exams.exam_date >= ? AND exams.exam_date < ?", start, end).
+		Group("courses.id, courses.department_id, courses.credits").
+		Scan(&results).Error; err != nil {
+		return nil, fmt.Errorf("failed to get course results: %w", err)
+	}
+	return results, nil
+}
+
+// ReplaceStudentTerm replaces a student's standings for a term in one transaction;
+// a nil overall standing just clears the term
+func (r *standingRepository) ReplaceStudentTerm(studentID uint, term string, overall *AcademicStanding, departments []DepartmentStanding) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("student_id = ? AND term = ?", studentID, term).
+			Delete(&AcademicStanding{}).Error; err != nil {
+			return fmt.Errorf("failed to clear academic standing: %w", err)
+		}
+		if err := tx.Unscoped().Where("student_id = ? AND term = ?", studentID, term).
+			Delete(&DepartmentStanding{}).Error; err != nil {
+			return fmt.Errorf("failed to clear department standings: %w", err)
+		}
+		if overall == nil {
+			return nil
+		}
+		if err := tx.Omit("Student").Create(overall).Error; err != nil {
+			return fmt.Errorf("failed to save academic standing: %w", err)
+		}
+		if len(departments) == 0 {
+			return nil
+		}
+		if err := tx.Omit("Student", "Department").Create(&departments).Error; err != nil {
+			return fmt.Errorf("failed to save department standings: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetByTerm retrieves all standings for a term with students preloaded, best rank first
+func (r *standingRepository) GetByTerm(term string) ([]AcademicStanding, error) {
+	var standings []AcademicStanding
+	if err := r.db.Preload("Student").Where("term = ?", term).
+		Order("rank ASC, student_id ASC").
+		Find(&standings).Error; err != nil {
+		return nil, fmt.Errorf("failed to get standings by term: %w", err)
+	}
+	return standings, nil
+}
+
+// GetAll retrieves every stored standing
+func (r *standingRepository) GetAll() ([]AcademicStanding, error) {
+	var standings []AcademicStanding
+	if err := r.db.Find(&standings).Error; err != nil {
+		return nil, fmt.Errorf("failed to get standings: %w", err)
+	}
+	return standings, nil
+}
+
+// GetDepartmentStandingsByTerm retrieves all department standings for a term, best rank first
+func (r *standingRepository) GetDepartmentStandingsByTerm(term string) ([]DepartmentStanding, error) {
+	var standings []DepartmentStanding
+	if err := r.db.Preload("Student").Where("term = ?", term).
+		Order("department_id ASC, rank ASC, student_id ASC").
+		Find(&standings).Error; err != nil {
+		return nil, fmt.Errorf("failed to get department standings by term: %w", err)
+	}
+	return standings, nil
+}
+
+// GetByStudent retrieves a student's standings, latest term first
+func (r *standingRepository) GetByStudent(studentID uint) ([]AcademicStanding, error) {
+	var standings []AcademicStanding
+	if err := r.db.Where("student_id = ?", studentID).Order("term DESC").Find(&standings).Error; err != nil {
+		return nil, fmt.Errorf("failed to get standings by student: %w", err)
+	}
+	return standings, nil
+}
+
+// GetDepartmentStandingsByStudent retrieves a student's department standings, latest term first
+func (r *standingRepository) GetDepartmentStandingsByStudent(studentID uint) ([]DepartmentStanding, error) {
+	var standings []DepartmentStanding
+	if err := r.db.Where("student_id = ?", studentID).
+		Order("term DESC, department_id ASC").
+		Find(&standings).Error; err != nil {
+		return nil, fmt.Errorf("failed to get department standings by student: %w", err)
+	}
+	return standings, nil
+}
+
+// SaveClassification writes standing levels and ranks in one transaction
+func (r *standingRepository) SaveClassification(standings []AcademicStanding, departments []DepartmentStanding) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, s := range standings {
+			if err := tx.Model(&AcademicStanding{}).Where("id = ?", s.ID).
+				Updates(map[string]interface{}{
+					"standing": s.Standing, "rank": s.Rank, "ranked_among": s.RankedAmong,
+					"cohort_rank": s.CohortRank, "cohort_ranked_among": s.CohortRankedAmong,
+				}).Error; err != nil {
+				return fmt.Errorf("failed to save academic standing: %w", err)
+			}
+		}
+		for _, d := range departments {
+			if err := tx.Model(&DepartmentStanding{}).Where("id = ?", d.ID).
+				Updates(map[string]interface{}{"rank": d.Rank, "ranked_among": d.RankedAmong}).Error; err != nil {
+				return fmt.Errorf("failed to save department standing: %w", err)
+			}
+		}
+		return nil
+	})
+}
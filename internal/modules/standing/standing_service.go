@@ -0,0 +1,493 @@
+package standing
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"school_management/internal/modules/student"
+)
+
+// StandingService defines the business logic interface
+type StandingService interface {
+	Recompute() (int, error)
+	GetHonorRoll(term string, departmentID *uint) (*HonorRollResponse, error)
+	GetProbation(term string, departmentID *uint) (*RankingResponse, error)
+	GetClassRank(term string, departmentID, gradeLevelID *uint) (*RankingResponse, error)
+	GetByStudent(studentID uint) ([]TermStandingResponse, error)
+	GetStandingSummaries(studentID uint) ([]student.StandingSummary, error)
+	GetPolicy() (*PolicyResponse, error)
+	UpdatePolicy(req *UpdatePolicyRequest) (*PolicyResponse, error)
+}
+
+// standingService implements StandingService
+type standingService struct {
+	repo StandingRepository
+}
+
+// NewStandingService creates a new standing service with DI
+func NewStandingService(repo StandingRepository) StandingService {
+	return &standingService{repo: repo}
+}
+
+// Recompute folds grade changes since the last run into the stored standings: only the
+// affected student terms are recomputed, then those terms are re-ranked. It returns
+// the number of student terms recomputed.
+func (s *standingService) Recompute() (int, error) {
+	since, err := s.repo.GetLastSync()
+	if err != nil {
+		return 0, err
+	}
+	// Taken before reading changes so grades written during the run are picked up next time
+	now := time.Now()
+
+	changes, err := s.repo.GetGradeChanges(since)
+	if err != nil {
+		return 0, err
+	}
+
+	moved, err := s.repo.GetStandingsOnChangedExams(since)
+	if err != nil {
+		return 0, err
+	}
+
+	dirty := make(map[string]map[uint]bool)
+	mark := func(studentID uint, term string) {
+		if dirty[term] == nil {
+			dirty[term] = make(map[uint]bool)
+		}
+		dirty[term][studentID] = true
+	}
+	for _, c := range changes {
+		mark(c.StudentID, TermOf(c.ExamDate))
+	}
+	for _, t := range moved {
+		mark(t.StudentID, t.Term)
+	}
+
+	policy, err := s.repo.GetPolicy()
+	if err != nil {
+		return 0, err
+	}
+
+	recomputed := 0
+	for term, students := range dirty {
+		for studentID := range students {
+			if err := s.recomputeStudentTerm(studentID, term, policy, now); err != nil {
+				return recomputed, err
+			}
+			recomputed++
+		}
+		if err := s.rankTerm(term, policy); err != nil {
+			return recomputed, err
+		}
+	}
+
+	if err := s.repo.SaveLastSync(now); err != nil {
+		return recomputed, err
+	}
+	if recomputed > 0 {
+		log.Printf("📊 Recomputed academic standing for %d student term(s)", recomputed)
+	}
+	return recomputed, nil
+}
+
+// GetHonorRoll lists the term's dean's list and honor roll, optionally limited to students
+// who took courses in a department
+func (s *standingService) GetHonorRoll(term string, departmentID *uint) (*HonorRollResponse, error) {
+	policy, err := s.repo.GetPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	standings, err := s.termStandings(term, departmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &HonorRollResponse{
+		Term:         term,
+		DepartmentID: departmentID,
+		Policy:       *s.toPolicyResponseDTO(policy),
+		DeansList:    []EntryResponse{},
+		HonorRoll:    []EntryResponse{},
+	}
+	for _, st := range standings {
+		switch st.Standing {
+		case LevelDeansList:
+			resp.DeansList = append(resp.DeansList, s.toEntryResponseDTO(&st))
+		case LevelHonorRoll:
+			resp.HonorRoll = append(resp.HonorRoll, s.toEntryResponseDTO(&st))
+		}
+	}
+	return resp, nil
+}
+
+// GetProbation lists the students on academic probation for a term
+func (s *standingService) GetProbation(term string, departmentID *uint) (*RankingResponse, error) {
+	standings, err := s.termStandings(term, departmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &RankingResponse{Term: term, DepartmentID: departmentID, Entries: []EntryResponse{}}
+	for _, st := range standings {
+		if st.Standing == LevelProbation {
+			resp.Entries = append(resp.Entries, s.toEntryResponseDTO(&st))
+		}
+	}
+	return resp, nil
+}
+
+// GetClassRank lists a term's class rank, school-wide, within a department or within the
+// cohort at a grade level
+func (s *standingService) GetClassRank(term string, departmentID, gradeLevelID *uint) (*RankingResponse, error) {
+	if _, _, err := TermBounds(term); err != nil {
+		return nil, err
+	}
+	if departmentID != nil && gradeLevelID != nil {
+		return nil, fmt.Errorf("rank within a department or a grade level, not both")
+	}
+
+	resp := &RankingResponse{Term: term, DepartmentID: departmentID, GradeLevelID: gradeLevelID, Entries: []EntryResponse{}}
+
+	if gradeLevelID != nil {
+		standings, err := s.repo.GetByTerm(term)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get class rank: %w", err)
+		}
+		cohort := make([]AcademicStanding, 0, len(standings))
+		for _, st := range standings {
+			if st.GradeLevelID != nil && *st.GradeLevelID == *gradeLevelID {
+				cohort = append(cohort, st)
+			}
+		}
+		sort.SliceStable(cohort, func(i, j int) bool { return cohort[i].CohortRank < cohort[j].CohortRank })
+		for _, st := range cohort {
+			entry := s.toEntryResponseDTO(&st)
+			entry.Rank = st.CohortRank
+			entry.RankedAmong = st.CohortRankedAmong
+			resp.Entries = append(resp.Entries, entry)
+		}
+		return resp, nil
+	}
+
+	if departmentID == nil {
+		standings, err := s.repo.GetByTerm(term)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get class rank: %w", err)
+		}
+		for _, st := range standings {
+			resp.Entries = append(resp.Entries, s.toEntryResponseDTO(&st))
+		}
+		return resp, nil
+	}
+
+	departments, err := s.repo.GetDepartmentStandingsByTerm(term)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get class rank: %w", err)
+	}
+	for _, d := range departments {
+		if d.DepartmentID != *departmentID {
+			continue
+		}
+		resp.Entries = append(resp.Entries, EntryResponse{
+			StudentID:   d.StudentID,
+			StudentName: d.Student.FirstName + " " + d.Student.LastName,
+			GPA:         d.GPA,
+			Credits:     d.Credits,
+			Rank:        d.Rank,
+			RankedAmong: d.RankedAmong,
+		})
+	}
+	return resp, nil
+}
+
+// GetByStudent retrieves a student's standing for every term, with department ranks
+func (s *standingService) GetByStudent(studentID uint) ([]TermStandingResponse, error) {
+	standings, err := s.repo.GetByStudent(studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get academic standing: %w", err)
+	}
+	departments, err := s.repo.GetDepartmentStandingsByStudent(studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get academic standing: %w", err)
+	}
+
+	byTerm := make(map[string][]DepartmentRankResponse)
+	for _, d := range departments {
+		byTerm[d.Term] = append(byTerm[d.Term], DepartmentRankResponse{
+			DepartmentID: d.DepartmentID,
+			GPA:          d.GPA,
+			Credits:      d.Credits,
+			Rank:         d.Rank,
+			RankedAmong:  d.RankedAmong,
+		})
+	}
+
+	responses := make([]TermStandingResponse, len(standings))
+	for i, st := range standings {
+		responses[i] = TermStandingResponse{
+			Term:        st.Term,
+			GPA:         st.GPA,
+			Credits:     st.Credits,
+			Standing:    string(st.Standing),
+			Rank:        st.Rank,
+			RankedAmong: st.RankedAmong,
+			ComputedAt:  st.ComputedAt,
+			Departments: byTerm[st.Term],
+
+			GradeLevelID:      st.GradeLevelID,
+			CohortRank:        st.CohortRank,
+			CohortRankedAmong: st.CohortRankedAmong,
+		}
+	}
+	return responses, nil
+}
+
+// GetStandingSummaries retrieves a student's per-term standing for their profile
+func (s *standingService) GetStandingSummaries(studentID uint) ([]student.StandingSummary, error) {
+	standings, err := s.repo.GetByStudent(studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]student.StandingSummary, len(standings))
+	for i, st := range standings {
+		summaries[i] = student.StandingSummary{
+			Term:        st.Term,
+			GPA:         st.GPA,
+			Credits:     st.Credits,
+			Standing:    string(st.Standing),
+			Rank:        st.Rank,
+			RankedAmong: st.RankedAmong,
+
+			CohortRank:        st.CohortRank,
+			CohortRankedAmong: st.CohortRankedAmong,
+		}
+	}
+	return summaries, nil
+}
+
+// GetPolicy retrieves the standing thresholds
+func (s *standingService) GetPolicy() (*PolicyResponse, error) {
+	policy, err := s.repo.GetPolicy()
+	if err != nil {
+		return nil, err
+	}
+	return s.toPolicyResponseDTO(policy), nil
+}
+
+// UpdatePolicy changes the standing thresholds and reclassifies every stored standing
+func (s *standingService) UpdatePolicy(req *UpdatePolicyRequest) (*PolicyResponse, error) {
+	// Get existing
+	policy, err := s.repo.GetPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	if req.DeansListGPA != nil {
+		policy.DeansListGPA = *req.DeansListGPA
+	}
+	if req.HonorRollGPA != nil {
+		policy.HonorRollGPA = *req.HonorRollGPA
+	}
+	if req.ProbationGPA != nil {
+		policy.ProbationGPA = *req.ProbationGPA
+	}
+	if req.MinCredits != nil {
+		policy.MinCredits = *req.MinCredits
+	}
+
+	// Validate
+	if !(policy.ProbationGPA <= policy.HonorRollGPA && policy.HonorRollGPA <= policy.DeansListGPA) {
+		return nil, fmt.Errorf("thresholds must satisfy probation <= honor roll <= dean's list")
+	}
+
+	// Save
+	if err := s.repo.SavePolicy(policy); err != nil {
+		return nil, err
+	}
+
+	standings, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for i := range standings {
+		standings[i].Standing = policy.Classify(standings[i].GPA, standings[i].Credits)
+	}
+	if err := s.repo.SaveClassification(standings, nil); err != nil {
+		return nil, fmt.Errorf("failed to reclassify standings: %w", err)
+	}
+
+	return s.toPolicyResponseDTO(policy), nil
+}
+
+// recomputeStudentTerm rebuilds one student's overall and department standings for a term
+func (s *standingService) recomputeStudentTerm(studentID uint, term string, policy *StandingPolicy, now time.Time) error {
+	start, end, err := TermBounds(term)
+	if err != nil {
+		return err
+	}
+
+	results, err := s.repo.GetCourseResults(studentID, start, end)
+	if err != nil {
+		return err
+	}
+
+	overallGPA, credits := GPA(results)
+	if credits == 0 {
+		return s.repo.ReplaceStudentTerm(studentID, term, nil, nil)
+	}
+	gradeLevelID, err := s.repo.GetGradeLevel(studentID, start, end)
+	if err != nil {
+		return err
+	}
+
+	overall := &AcademicStanding{
+		StudentID:  studentID,
+		Term:       term,
+		GPA:        overallGPA,
+		Credits:    credits,
+		Standing:   policy.Classify(overallGPA, credits),
+		ComputedAt: now,
+
+		GradeLevelID: gradeLevelID,
+	}
+
+	byDepartment := make(map[uint][]CourseResult)
+	for _, r := range results {
+		byDepartment[r.DepartmentID] = append(byDepartment[r.DepartmentID], r)
+	}
+	departments := make([]DepartmentStanding, 0, len(byDepartment))
+	for departmentID, deptResults := range byDepartment {
+		deptGPA, deptCredits := GPA(deptResults)
+		if deptCredits == 0 {
+			continue
+		}
+		departments = append(departments, DepartmentStanding{
+			StudentID:    studentID,
+			Term:         term,
+			DepartmentID: departmentID,
+			GPA:          deptGPA,
+			Credits:      deptCredits,
+		})
+	}
+
+	return s.repo.ReplaceStudentTerm(studentID, term, overall, departments)
+}
+
+// rankTerm re-ranks every student in a term, school-wide, per grade level cohort and per department
+func (s *standingService) rankTerm(term string, policy *StandingPolicy) error {
+	standings, err := s.repo.GetByTerm(term)
+	if err != nil {
+		return err
+	}
+	gpas := make([]float64, len(standings))
+	for i, st := range standings {
+		gpas[i] = st.GPA
+	}
+	for i, rank := range competitionRanks(gpas) {
+		standings[i].Rank = rank
+		standings[i].RankedAmong = len(standings)
+		standings[i].Standing = policy.Classify(standings[i].GPA, standings[i].Credits)
+		standings[i].CohortRank = 0
+		standings[i].CohortRankedAmong = 0
+	}
+
+	cohorts := make(map[uint][]int)
+	for i, st := range standings {
+		if st.GradeLevelID != nil {
+			cohorts[*st.GradeLevelID] = append(cohorts[*st.GradeLevelID], i)
+		}
+	}
+	for _, members := range cohorts {
+		cohortGPAs := make([]float64, len(members))
+		for j, i := range members {
+			cohortGPAs[j] = standings[i].GPA
+		}
+		for j, rank := range competitionRanks(cohortGPAs) {
+			standings[members[j]].CohortRank = rank
+			standings[members[j]].CohortRankedAmong = len(members)
+		}
+	}
+
+	departments, err := s.repo.GetDepartmentStandingsByTerm(term)
+	if err != nil {
+		return err
+	}
+	groups := make(map[uint][]int)
+	for i, d := range departments {
+		groups[d.DepartmentID] = append(groups[d.DepartmentID], i)
+	}
+	for _, members := range groups {
+		deptGPAs := make([]float64, len(members))
+		for j, i := range members {
+			deptGPAs[j] = departments[i].GPA
+		}
+		for j, rank := range competitionRanks(deptGPAs) {
+			departments[members[j]].Rank = rank
+			departments[members[j]].RankedAmong = len(members)
+		}
+	}
+
+	return s.repo.SaveClassification(standings, departments)
+}
+
+// termStandings retrieves a term's standings, limited to students ranked in the department if one is given
+func (s *standingService) termStandings(term string, departmentID *uint) ([]AcademicStanding, error) {
+	if _, _, err := TermBounds(term); err != nil {
+		return nil, err
+	}
+
+	standings, err := s.repo.GetByTerm(term)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get standings: %w", err)
+	}
+	if departmentID == nil {
+		return standings, nil
+	}
+
+	departments, err := s.repo.GetDepartmentStandingsByTerm(term)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get standings: %w", err)
+	}
+	inDepartment := make(map[uint]bool)
+	for _, d := range departments {
+		if d.DepartmentID == *departmentID {
+			inDepartment[d.StudentID] = true
+		}
+	}
+
+	filtered := make([]AcademicStanding, 0, len(inDepartment))
+	for _, st := range standings {
+		if inDepartment[st.StudentID] {
+			filtered = append(filtered, st)
+		}
+	}
+	return filtered, nil
+}
+
+// DTO mapping methods
+func (s *standingService) toEntryResponseDTO(st *AcademicStanding) EntryResponse {
+	return EntryResponse{
+		StudentID:   st.StudentID,
+		StudentName: st.Student.FirstName + " " + st.Student.LastName,
+		GPA:         st.GPA,
+		Credits:     st.Credits,
+		Standing:    string(st.Standing),
+		Rank:        st.Rank,
+		RankedAmong: st.RankedAmong,
+	}
+}
+
+func (s *standingService) toPolicyResponseDTO(policy *StandingPolicy) *PolicyResponse {
+	return &PolicyResponse{
+		DeansListGPA: policy.DeansListGPA,
+		HonorRollGPA: policy.HonorRollGPA,
+		ProbationGPA: policy.ProbationGPA,
+		MinCredits:   policy.MinCredits,
+	}
+}
@@ -0,0 +1,82 @@
+package standing
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Terms are calendar half-years: "2026-1" runs January to June, "2026-2" July to December.
+
+// TermOf returns the term an exam date falls in
+func TermOf(t time.Time) string {
+	half := 1
+	if t.Month() >= time.July {
+		half = 2
+	}
+	return fmt.Sprintf("%d-%d", t.Year(), half)
+}
+
+// TermBounds returns the [start, end) range of a term
+func TermBounds(term string) (time.Time, time.Time, error) {
+	var year, half int
+	if _, err := fmt.Sscanf(term, "%d-%d", &year, &half); err != nil || half < 1 || half > 2 {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid term %q (use YYYY-1 or YYYY-2)", term)
+	}
+	start := time.Date(year, time.Month(1+(half-1)*6), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 6, 0), nil
+}
+
+// gradePoints maps a course percentage to points on a 4.0 scale
+func gradePoints(percent float64) float64 {
+	switch {
+	case percent >= 90:
+		return 4.0
+	case percent >= 80:
+		return 3.0
+	case percent >= 70:
+		return 2.0
+	case percent >= 60:
+		return 1.0
+	default:
+		return 0
+	}
+}
+
+// GPA returns the credit-weighted GPA and total credits over course results
+func GPA(results []CourseResult) (float64, int) {
+	var points float64
+	credits := 0
+	for _, r := range results {
+		if r.MaxScore <= 0 {
+			continue
+		}
+		points += gradePoints(r.Score/r.MaxScore*100) * float64(r.Credits)
+		credits += r.Credits
+	}
+	if credits == 0 {
+		return 0, 0
+	}
+	return math.Round(points/float64(credits)*100) / 100, credits
+}
+
+// competitionRanks ranks GPAs highest first; equal GPAs share a rank and the
+// next rank skips accordingly ("1224" ranking). The result is indexed like gpas.
+func competitionRanks(gpas []float64) []int {
+	order := make([]int, len(gpas))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return gpas[order[a]] > gpas[order[b]] })
+
+	ranks := make([]int, len(gpas))
+	for pos, i := range order {
+		if pos > 0 && gpas[i] == gpas[order[pos-1]] {
+			ranks[i] = ranks[order[pos-1]]
+		} else {
+			ranks[i] = pos + 1
+		}
+	}
+	return ranks
+}
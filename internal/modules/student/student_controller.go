@@ -5,6 +5,8 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+
+	"school_management/internal/export"
 )
 
 // StudentController handles HTTP requests for students
@@ -57,7 +59,25 @@ func (c *StudentController) GetAll(ctx *gin.Context) {
 	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
 	offset, _ := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
 
-	resp, err := c.service.GetAll(limit, offset)
+	list := c.service.GetAll
+	if status := ctx.Query("status"); status != "" {
+		if !IsValidStatus(status) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid status"})
+			return
+		}
+		list = func(limit, offset int) ([]StudentResponse, error) {
+			return c.service.GetByStatus(status, limit, offset)
+		}
+	}
+
+	if export.Requested(ctx) {
+		export.StreamPages(ctx, offset, func(limit, offset int) (interface{}, error) {
+			return list(limit, offset)
+		})
+		return
+	}
+
+	resp, err := list(limit, offset)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -126,9 +146,81 @@ func (c *StudentController) Search(ctx *gin.Context) {
 		return
 	}
 
+	if export.Send(ctx, resp) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// ChangeStatus moves a student to another lifecycle status, now or on a future effective date
+func (c *StudentController) ChangeStatus(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	var req ChangeStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.ChangeStatus(uint(id), &req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	status := http.StatusOK
+	if resp.Pending {
+		status = http.StatusAccepted
+	}
+	ctx.JSON(status, resp)
+}
+
+// GetStatusHistory retrieves a student's status changes
+func (c *StudentController) GetStatusHistory(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetStatusHistory(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
 	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
 }
 
+// CancelStatusChange cancels a pending status change
+func (c *StudentController) CancelStatusChange(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+	changeID, err := strconv.ParseUint(ctx.Param("changeId"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid status change ID"})
+		return
+	}
+
+	if err := c.service.CancelStatusChange(uint(id), uint(changeID)); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "status change cancelled successfully"})
+}
+
 // RegisterRoutes registers student routes
 func (c *StudentController) RegisterRoutes(rg *gin.RouterGroup) {
 	students := rg.Group("/students")
@@ -139,5 +231,10 @@ func (c *StudentController) RegisterRoutes(rg *gin.RouterGroup) {
 		students.PUT("/:id", c.Update)
 		students.DELETE("/:id", c.Delete)
 		students.GET("/search", c.Search)
+
+		// Lifecycle status
+		students.POST("/:id/status", c.ChangeStatus)
+		students.GET("/:id/status-history", c.GetStatusHistory)
+		students.DELETE("/:id/status-changes/:changeId", c.CancelStatusChange)
 	}
 }
@@ -8,8 +8,9 @@ type CreateStudentRequest struct {
 	LastName       string `json:"last_name" binding:"required,min=2,max=50"`
 	Email          string `json:"email" binding:"required,email,max=100"`
 	Phone          string `json:"phone" binding:"omitempty,max=20"`
-	DateOfBirth    string `json:"date_of_birth" binding:"required"`   // Format: YYYY-MM-DD
-	EnrollmentDate string `json:"enrollment_date" binding:"required"` // Format: YYYY-MM-DD
+	DateOfBirth    string `json:"date_of_birth" binding:"required"`                  // Format: YYYY-MM-DD
+	EnrollmentDate string `json:"enrollment_date" binding:"required"`                // Format: YYYY-MM-DD
+	Status         string `json:"status" binding:"omitempty,oneof=applicant active"` // Defaults to active
 }
 
 // UpdateStudentRequest represents the request body for updating a student
@@ -33,4 +34,49 @@ type StudentResponse struct {
 	EnrollmentDate time.Time `json:"enrollment_date"`
 	CreatedAt      time.Time `json:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at"`
+
+	Status              string     `json:"status"`
+	StatusEffectiveDate *time.Time `json:"status_effective_date"`
+
+	Standing []StandingSummary `json:"standing,omitempty"`
+}
+
+// StandingSummary represents a student's academic standing for one term
+type StandingSummary struct {
+	Term        string  `json:"term"`
+	GPA         float64 `json:"gpa"`
+	Credits     int     `json:"credits"`
+	Standing    string  `json:"standing"`
+	Rank        int     `json:"rank"`
+	RankedAmong int     `json:"ranked_among"`
+
+	CohortRank        int `json:"cohort_rank"` // rank among students at the same grade level
+	CohortRankedAmong int `json:"cohort_ranked_among"`
+}
+
+// ChangeStatusRequest represents the request body for moving a student to another lifecycle status
+type ChangeStatusRequest struct {
+	Status        string `json:"status" binding:"required,oneof=applicant active suspended graduated withdrawn"`
+	EffectiveDate string `json:"effective_date" binding:"omitempty"` // Format: YYYY-MM-DD, defaults to today
+	Reason        string `json:"reason" binding:"omitempty,max=1000"`
+}
+
+// StatusChangeResponse represents one entry of a student's status history
+type StatusChangeResponse struct {
+	ID            uint       `json:"id"`
+	StudentID     uint       `json:"student_id"`
+	FromStatus    string     `json:"from_status"`
+	ToStatus      string     `json:"to_status"`
+	EffectiveDate time.Time  `json:"effective_date"`
+	Reason        string     `json:"reason"`
+	Pending       bool       `json:"pending"`
+	AppliedAt     *time.Time `json:"applied_at"`
+	CancelledAt   *time.Time `json:"cancelled_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// ApplyStatusChangesResponse represents the result of applying due status changes
+type ApplyStatusChangesResponse struct {
+	Applied int `json:"applied"`
+	Skipped int `json:"skipped"`
 }
@@ -0,0 +1,27 @@
+package student
+
+// transitions lists the statuses a student may move to from each status.
+// Graduation is final; a withdrawn student may be readmitted.
+var transitions = map[StudentStatus][]StudentStatus{
+	StatusApplicant: {StatusActive, StatusWithdrawn},
+	StatusActive:    {StatusSuspended, StatusGraduated, StatusWithdrawn},
+	StatusSuspended: {StatusActive, StatusWithdrawn},
+	StatusGraduated: {},
+	StatusWithdrawn: {StatusActive},
+}
+
+// CanTransition reports whether a student may move from one status to another
+func CanTransition(from, to StudentStatus) bool {
+	for _, allowed := range transitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidStatus reports whether status is a known lifecycle status
+func IsValidStatus(status string) bool {
+	_, ok := transitions[StudentStatus(status)]
+	return ok
+}
@@ -6,17 +6,59 @@ import (
 	"gorm.io/gorm"
 )
 
+// StudentStatus represents where a student is in their lifecycle
+type StudentStatus string
+
+const (
+	StatusApplicant StudentStatus = "applicant" // admitted but not yet started
+	StatusActive    StudentStatus = "active"
+	StatusSuspended StudentStatus = "suspended"
+	StatusGraduated StudentStatus = "graduated"
+	StatusWithdrawn StudentStatus = "withdrawn"
+)
+
 type Student struct {
 	gorm.Model
 	FirstName      string    `gorm:"not null;size:50" json:"first_name"`
 	LastName       string    `gorm:"not null;size:50" json:"last_name"`
-	Email          string    `gorm:"uniqueIndex;not null;size:100" json:"email"`
+	Email          string    `gorm:"uniqueIndex:idx_students_active_email,where:deleted_at IS NULL;not null;size:100" json:"email"`
 	Phone          string    `gorm:"size:20" json:"phone"`
 	DateOfBirth    time.Time `gorm:"type:date" json:"date_of_birth"`
 	EnrollmentDate time.Time `gorm:"type:date;not null" json:"enrollment_date"`
+
+	Status              StudentStatus `gorm:"type:varchar(20);not null;default:'active';index;comment:students that predate lifecycle status are active" json:"status"`
+	StatusEffectiveDate *time.Time    `gorm:"type:date" json:"status_effective_date"`
 }
 
 // TableName specifies the table name for the Student model
 func (Student) TableName() string {
 	return "students"
 }
+
+// IsActive reports whether the student may be enrolled in courses and marked for attendance
+func (s *Student) IsActive() bool {
+	return s.Status == StatusActive
+}
+
+// StudentStatusChange records one lifecycle transition. A change dated in the future
+// stays pending until the scheduler applies it on its effective date.
+type StudentStatusChange struct {
+	gorm.Model
+	StudentID     uint          `gorm:"not null;index" json:"student_id"`
+	FromStatus    StudentStatus `gorm:"type:varchar(20);not null" json:"from_status"`
+	ToStatus      StudentStatus `gorm:"type:varchar(20);not null" json:"to_status"`
+	EffectiveDate time.Time     `gorm:"type:date;not null" json:"effective_date"`
+	Reason        string        `gorm:"type:text" json:"reason"`
+	AppliedAt     *time.Time    `gorm:"type:timestamp" json:"applied_at"`
+	CancelledAt   *time.Time    `gorm:"type:timestamp" json:"cancelled_at"`
+}
+
+// TableName specifies the table name for the StudentStatusChange model
+func (StudentStatusChange) TableName() string {
+	return "student_status_changes"
+}
+
+// Pending reports whether the change is waiting for its effective date
+func (c *StudentStatusChange) Pending() bool {
+	return c.AppliedAt == nil && c.CancelledAt == nil
+}
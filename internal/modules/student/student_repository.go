@@ -15,8 +15,18 @@ type StudentRepository interface {
 	GetByEmail(email string) (*Student, error)
 	Search(query string, limit int) ([]Student, error)
 	GetEnrolledBefore(date time.Time) ([]Student, error)
+	GetByStatus(status StudentStatus, limit, offset int) ([]Student, error)
 	Update(student *Student) error
 	Delete(id uint) error
+
+	// Lifecycle status
+	ApplyStatusChange(change *StudentStatusChange, at time.Time) error
+	CreateStatusChange(change *StudentStatusChange) error
+	UpdateStatusChange(change *StudentStatusChange) error
+	GetStatusChanges(studentID uint) ([]StudentStatusChange, error)
+	GetStatusChangeByID(id uint) (*StudentStatusChange, error)
+	GetPendingStatusChange(studentID uint) (*StudentStatusChange, error)
+	GetDueStatusChanges(asOf time.Time) ([]StudentStatusChange, error)
 }
 
 // studentRepository implements StudentRepository
@@ -49,7 +59,7 @@ func (r *studentRepository) GetByID(id uint) (*Student, error) {
 // GetAll retrieves all students with pagination
 func (r *studentRepository) GetAll(limit, offset int) ([]Student, error) {
 	var students []Student
-	if err := r.db.Limit(limit).Offset(offset).Find(&students).Error; err != nil {
+	if err := r.db.Order("id").Limit(limit).Offset(offset).Find(&students).Error; err != nil {
 		return nil, fmt.Errorf("failed to get students: %w", err)
 	}
 	return students, nil
@@ -86,6 +96,15 @@ func (r *studentRepository) GetEnrolledBefore(date time.Time) ([]Student, error)
 	return students, nil
 }
 
+// GetByStatus retrieves students with a lifecycle status with pagination
+func (r *studentRepository) GetByStatus(status StudentStatus, limit, offset int) ([]Student, error) {
+	var students []Student
+	if err := r.db.Where("status = ?", status).Order("id").Limit(limit).Offset(offset).Find(&students).Error; err != nil {
+		return nil, fmt.Errorf("failed to get students by status: %w", err)
+	}
+	return students, nil
+}
+
 // Update updates a student
 func (r *studentRepository) Update(student *Student) error {
 	if err := r.db.Save(student).Error; err != nil {
@@ -101,3 +120,77 @@ func (r *studentRepository) Delete(id uint) error {
 	}
 	return nil
 }
+
+// ApplyStatusChange moves a student to the change's status and records the change as applied, in one transaction.
+// A change that has not been saved yet is created; a pending one is marked applied.
+func (r *studentRepository) ApplyStatusChange(change *StudentStatusChange, at time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Student{}).Where("id = ?", change.StudentID).Updates(map[string]interface{}{
+			"status":                change.ToStatus,
+			"status_effective_date": change.EffectiveDate,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to update student status: %w", err)
+		}
+
+		change.AppliedAt = &at
+		if err := tx.Save(change).Error; err != nil {
+			return fmt.Errorf("failed to record status change: %w", err)
+		}
+		return nil
+	})
+}
+
+// CreateStatusChange saves a pending status change
+func (r *studentRepository) CreateStatusChange(change *StudentStatusChange) error {
+	if err := r.db.Create(change).Error; err != nil {
+		return fmt.Errorf("failed to create status change: %w", err)
+	}
+	return nil
+}
+
+// UpdateStatusChange updates a status change
+func (r *studentRepository) UpdateStatusChange(change *StudentStatusChange) error {
+	if err := r.db.Save(change).Error; err != nil {
+		return fmt.Errorf("failed to update status change: %w", err)
+	}
+	return nil
+}
+
+// GetStatusChanges retrieves a student's status history, newest first
+func (r *studentRepository) GetStatusChanges(studentID uint) ([]StudentStatusChange, error) {
+	var changes []StudentStatusChange
+	if err := r.db.Where("student_id = ?", studentID).
+		Order("effective_date DESC, id DESC").Find(&changes).Error; err != nil {
+		return nil, fmt.Errorf("failed to get status changes: %w", err)
+	}
+	return changes, nil
+}
+
+// GetStatusChangeByID retrieves a status change by ID
+func (r *studentRepository) GetStatusChangeByID(id uint) (*StudentStatusChange, error) {
+	var change StudentStatusChange
+	if err := r.db.First(&change, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get status change: %w", err)
+	}
+	return &change, nil
+}
+
+// GetPendingStatusChange retrieves a student's status change that is waiting for its effective date
+func (r *studentRepository) GetPendingStatusChange(studentID uint) (*StudentStatusChange, error) {
+	var change StudentStatusChange
+	if err := r.db.Where("student_id = ? AND applied_at IS NULL AND cancelled_at IS NULL", studentID).
+		First(&change).Error; err != nil {
+		return nil, fmt.Errorf("failed to get pending status change: %w", err)
+	}
+	return &change, nil
+}
+
+// GetDueStatusChanges retrieves pending status changes effective on or before a date, oldest first
+func (r *studentRepository) GetDueStatusChanges(asOf time.Time) ([]StudentStatusChange, error) {
+	var changes []StudentStatusChange
+	if err := r.db.Where("applied_at IS NULL AND cancelled_at IS NULL AND effective_date <= ?", asOf).
+		Order("effective_date ASC, id ASC").Find(&changes).Error; err != nil {
+		return nil, fmt.Errorf("failed to get due status changes: %w", err)
+	}
+	return changes, nil
+}
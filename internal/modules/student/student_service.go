@@ -1,34 +1,69 @@
 package student
 
 import (
+	"errors"
 	"fmt"
+	"log"
 	"regexp"
 	"strings"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // StudentService defines the business logic interface
 type StudentService interface {
 	Create(req *CreateStudentRequest) (*StudentResponse, error)
+	Prepare(req *CreateStudentRequest) (*Student, error)
 	GetByID(id uint) (*StudentResponse, error)
 	GetAll(limit, offset int) ([]StudentResponse, error)
 	Update(id uint, req *UpdateStudentRequest) (*StudentResponse, error)
 	Delete(id uint) error
 	Search(query string, limit int) ([]StudentResponse, error)
+	GetByStatus(status string, limit, offset int) ([]StudentResponse, error)
+
+	// Lifecycle status
+	ChangeStatus(id uint, req *ChangeStatusRequest) (*StatusChangeResponse, error)
+	CancelStatusChange(id, changeID uint) error
+	GetStatusHistory(id uint) ([]StatusChangeResponse, error)
+	ApplyDueStatusChanges() (*ApplyStatusChangesResponse, error)
+}
+
+// StandingProvider supplies a student's computed academic standing for their profile
+type StandingProvider interface {
+	GetStandingSummaries(studentID uint) ([]StandingSummary, error)
 }
 
 // studentService implements StudentService
 type studentService struct {
-	repo StudentRepository
+	repo      StudentRepository
+	standings StandingProvider
 }
 
 // NewStudentService creates a new student service with DI
-func NewStudentService(repo StudentRepository) StudentService {
-	return &studentService{repo: repo}
+func NewStudentService(repo StudentRepository, standings StandingProvider) StudentService {
+	return &studentService{repo: repo, standings: standings}
 }
 
 // Create creates a new student
 func (s *studentService) Create(req *CreateStudentRequest) (*StudentResponse, error) {
+	// Validate and map DTO to Model
+	student, err := s.Prepare(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create via repository
+	if err := s.repo.Create(student); err != nil {
+		return nil, fmt.Errorf("failed to create student: %w", err)
+	}
+
+	// Map Model to Response DTO
+	return s.toResponseDTO(student), nil
+}
+
+// Prepare validates a create request and maps it to an unsaved student
+func (s *studentService) Prepare(req *CreateStudentRequest) (*Student, error) {
 	// Validate
 	if err := s.validateCreateRequest(req); err != nil {
 		return nil, err
@@ -45,23 +80,22 @@ func (s *studentService) Create(req *CreateStudentRequest) (*StudentResponse, er
 		return nil, fmt.Errorf("invalid enrollment date format (use YYYY-MM-DD): %w", err)
 	}
 
-	// Map DTO to Model
-	student := &Student{
-		FirstName:      req.FirstName,
-		LastName:       req.LastName,
-		Email:          req.Email,
-		Phone:          req.Phone,
-		DateOfBirth:    dob,
-		EnrollmentDate: enrollDate,
+	status := StatusActive
+	if req.Status != "" {
+		status = StudentStatus(req.Status)
 	}
 
-	// Create via repository
-	if err := s.repo.Create(student); err != nil {
-		return nil, fmt.Errorf("failed to create student: %w", err)
-	}
-
-	// Map Model to Response DTO
-	return s.toResponseDTO(student), nil
+	// Map DTO to Model
+	return &Student{
+		FirstName:           req.FirstName,
+		LastName:            req.LastName,
+		Email:               req.Email,
+		Phone:               req.Phone,
+		DateOfBirth:         dob,
+		EnrollmentDate:      enrollDate,
+		Status:              status,
+		StatusEffectiveDate: &enrollDate,
+	}, nil
 }
 
 // GetByID retrieves a student by ID
@@ -70,7 +104,15 @@ func (s *studentService) GetByID(id uint) (*StudentResponse, error) {
 	if err != nil {
 		return nil, fmt.Errorf("student not found: %w", err)
 	}
-	return s.toResponseDTO(student), nil
+
+	resp := s.toResponseDTO(student)
+	standings, err := s.standings.GetStandingSummaries(student.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get academic standing: %w", err)
+	}
+	resp.Standing = standings
+
+	return resp, nil
 }
 
 // GetAll retrieves all students with pagination
@@ -162,6 +204,152 @@ func (s *studentService) Search(query string, limit int) ([]StudentResponse, err
 	return s.toResponseDTOList(students), nil
 }
 
+// GetByStatus retrieves students with a lifecycle status with pagination.
+// Graduated and withdrawn students stay queryable here with all their records.
+func (s *studentService) GetByStatus(status string, limit, offset int) ([]StudentResponse, error) {
+	if !IsValidStatus(status) {
+		return nil, fmt.Errorf("invalid status (use applicant, active, suspended, graduated or withdrawn)")
+	}
+
+	// Validate pagination
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	students, err := s.repo.GetByStatus(StudentStatus(status), limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get students: %w", err)
+	}
+
+	return s.toResponseDTOList(students), nil
+}
+
+// ChangeStatus moves a student to another lifecycle status. A change effective today or
+// earlier is applied immediately; a future-dated change stays pending until that date.
+func (s *studentService) ChangeStatus(id uint, req *ChangeStatusRequest) (*StatusChangeResponse, error) {
+	// Validate
+	today := time.Now().Truncate(24 * time.Hour)
+	effective := today
+	if req.EffectiveDate != "" {
+		date, err := time.Parse("2006-01-02", req.EffectiveDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid effective date format (use YYYY-MM-DD): %w", err)
+		}
+		effective = date
+	}
+
+	// Get existing
+	student, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("student not found: %w", err)
+	}
+	pending, err := s.repo.GetPendingStatusChange(id)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if pending != nil {
+		return nil, fmt.Errorf("student already has a pending change to %s on %s; cancel it first",
+			pending.ToStatus, pending.EffectiveDate.Format("2006-01-02"))
+	}
+
+	to := StudentStatus(req.Status)
+	if !CanTransition(student.Status, to) {
+		return nil, fmt.Errorf("cannot change status from %s to %s", student.Status, to)
+	}
+
+	change := &StudentStatusChange{
+		StudentID:     id,
+		FromStatus:    student.Status,
+		ToStatus:      to,
+		EffectiveDate: effective,
+		Reason:        req.Reason,
+	}
+
+	// Save
+	if effective.After(today) {
+		if err := s.repo.CreateStatusChange(change); err != nil {
+			return nil, err
+		}
+	} else if err := s.repo.ApplyStatusChange(change, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return s.toStatusChangeResponse(change), nil
+}
+
+// CancelStatusChange cancels a student's pending status change
+func (s *studentService) CancelStatusChange(id, changeID uint) error {
+	change, err := s.repo.GetStatusChangeByID(changeID)
+	if err != nil || change.StudentID != id {
+		return fmt.Errorf("status change not found")
+	}
+	if !change.Pending() {
+		return fmt.Errorf("status change has already been applied or cancelled")
+	}
+
+	now := time.Now()
+	change.CancelledAt = &now
+	return s.repo.UpdateStatusChange(change)
+}
+
+// GetStatusHistory retrieves a student's status changes, including pending ones, newest first
+func (s *studentService) GetStatusHistory(id uint) ([]StatusChangeResponse, error) {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return nil, fmt.Errorf("student not found: %w", err)
+	}
+
+	changes, err := s.repo.GetStatusChanges(id)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]StatusChangeResponse, len(changes))
+	for i := range changes {
+		responses[i] = *s.toStatusChangeResponse(&changes[i])
+	}
+	return responses, nil
+}
+
+// ApplyDueStatusChanges applies pending status changes whose effective date has arrived.
+// A change that is no longer a valid transition, because the student's status moved on
+// since it was scheduled, is cancelled instead.
+func (s *studentService) ApplyDueStatusChanges() (*ApplyStatusChangesResponse, error) {
+	now := time.Now()
+	changes, err := s.repo.GetDueStatusChanges(now)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ApplyStatusChangesResponse{}
+	for i := range changes {
+		change := &changes[i]
+		student, err := s.repo.GetByID(change.StudentID)
+		if err != nil || !CanTransition(student.Status, change.ToStatus) {
+			log.Printf("⚠️ Cancelling status change %d: student %d can no longer move to %s", change.ID, change.StudentID, change.ToStatus)
+			change.CancelledAt = &now
+			if err := s.repo.UpdateStatusChange(change); err != nil {
+				return result, err
+			}
+			result.Skipped++
+			continue
+		}
+
+		change.FromStatus = student.Status
+		if err := s.repo.ApplyStatusChange(change, now); err != nil {
+			return result, err
+		}
+		result.Applied++
+	}
+
+	if result.Applied > 0 || result.Skipped > 0 {
+		log.Printf("✅ Applied %d student status changes (%d cancelled)", result.Applied, result.Skipped)
+	}
+	return result, nil
+}
+
 // Validation methods
 func (s *studentService) validateCreateRequest(req *CreateStudentRequest) error {
 	if strings.TrimSpace(req.FirstName) == "" {
@@ -209,6 +397,9 @@ func (s *studentService) toResponseDTO(student *Student) *StudentResponse {
 		EnrollmentDate: student.EnrollmentDate,
 		CreatedAt:      student.CreatedAt,
 		UpdatedAt:      student.UpdatedAt,
+
+		Status:              string(student.Status),
+		StatusEffectiveDate: student.StatusEffectiveDate,
 	}
 }
 
@@ -219,3 +410,18 @@ func (s *studentService) toResponseDTOList(students []Student) []StudentResponse
 	}
 	return responses
 }
+
+func (s *studentService) toStatusChangeResponse(change *StudentStatusChange) *StatusChangeResponse {
+	return &StatusChangeResponse{
+		ID:            change.ID,
+		StudentID:     change.StudentID,
+		FromStatus:    string(change.FromStatus),
+		ToStatus:      string(change.ToStatus),
+		EffectiveDate: change.EffectiveDate,
+		Reason:        change.Reason,
+		Pending:       change.Pending(),
+		AppliedAt:     change.AppliedAt,
+		CancelledAt:   change.CancelledAt,
+		CreatedAt:     change.CreatedAt,
+	}
+}
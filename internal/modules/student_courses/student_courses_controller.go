@@ -5,6 +5,8 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+
+	"school_management/internal/export"
 )
 
 // StudentCourseController handles HTTP requests for student course enrollments
@@ -66,10 +68,14 @@ func (c *StudentCourseController) GetByStudent(ctx *gin.Context) {
 		return
 	}
 
+	if export.Send(ctx, resp) {
+		return
+	}
+
 	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
 }
 
-// GetByCourse retrieves enrollments for a course
+// GetByCourse retrieves enrollments for a course, optionally filtered by ?homeroom_id
 func (c *StudentCourseController) GetByCourse(ctx *gin.Context) {
 	courseID, err := strconv.ParseUint(ctx.Param("courseId"), 10, 32)
 	if err != nil {
@@ -77,12 +83,27 @@ func (c *StudentCourseController) GetByCourse(ctx *gin.Context) {
 		return
 	}
 
-	resp, err := c.service.GetByCourse(uint(courseID))
+	var homeroomID *uint
+	if raw := ctx.Query("homeroom_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid homeroom ID"})
+			return
+		}
+		hid := uint(id)
+		homeroomID = &hid
+	}
+
+	resp, err := c.service.GetByCourse(uint(courseID), homeroomID)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	if export.Send(ctx, resp) {
+		return
+	}
+
 	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
 }
 
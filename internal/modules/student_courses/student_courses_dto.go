@@ -13,7 +13,9 @@ type EnrollStudentRequest struct {
 type StudentCourseResponse struct {
 	ID             uint      `json:"id"`
 	StudentID      uint      `json:"student_id"`
+	StudentName    string    `json:"student_name,omitempty"`
 	CourseID       uint      `json:"course_id"`
+	CourseName     string    `json:"course_name,omitempty"`
 	EnrollmentDate time.Time `json:"enrollment_date"`
 	CreatedAt      time.Time `json:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at"`
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+
+	"school_management/internal/modules/homeroom"
 )
 
 // StudentCourseRepository defines the interface for student course enrollment data access
@@ -15,6 +17,7 @@ type StudentCourseRepository interface {
 	GetAll(limit, offset int) ([]StudentCourse, error)
 	GetByStudent(studentID uint) ([]StudentCourse, error)
 	GetByCourse(courseID uint) ([]StudentCourse, error)
+	GetByCourseAndHomeroom(courseID, homeroomID uint) ([]StudentCourse, error)
 	GetByStudentAndCourse(studentID, courseID uint) (*StudentCourse, error)
 	GetEnrolledAfter(date time.Time) ([]StudentCourse, error)
 	Delete(id uint) error
@@ -59,7 +62,7 @@ func (r *studentCourseRepository) GetByIDWithRelations(id uint) (*StudentCourse,
 // GetAll retrieves all enrollments with pagination
 func (r *studentCourseRepository) GetAll(limit, offset int) ([]StudentCourse, error) {
 	var enrollments []StudentCourse
-	if err := r.db.Limit(limit).Offset(offset).Find(&enrollments).Error; err != nil {
+	if err := r.db.Preload("Student").Preload("Course").Limit(limit).Offset(offset).Find(&enrollments).Error; err != nil {
 		return nil, fmt.Errorf("failed to get enrollments: %w", err)
 	}
 	return enrollments, nil
@@ -68,7 +71,7 @@ func (r *studentCourseRepository) GetAll(limit, offset int) ([]StudentCourse, er
 // GetByStudent retrieves all enrollments for a student
 func (r *studentCourseRepository) GetByStudent(studentID uint) ([]StudentCourse, error) {
 	var enrollments []StudentCourse
-	if err := r.db.Where("student_id = ?", studentID).Find(&enrollments).Error; err != nil {
+	if err := r.db.Preload("Student").Preload("Course").Where("student_id = ?", studentID).Find(&enrollments).Error; err != nil {
 		return nil, fmt.Errorf("failed to get enrollments by student: %w", err)
 	}
 	return enrollments, nil
@@ -77,12 +80,23 @@ func (r *studentCourseRepository) GetByStudent(studentID uint) ([]StudentCourse,
 // GetByCourse retrieves all enrollments for a course
 func (r *studentCourseRepository) GetByCourse(courseID uint) ([]StudentCourse, error) {
 	var enrollments []StudentCourse
-	if err := r.db.Where("course_id = ?", courseID).Find(&enrollments).Error; err != nil {
+	if err := r.db.Preload("Student").Preload("Course").Where("course_id = ?", courseID).Find(&enrollments).Error; err != nil {
 		return nil, fmt.Errorf("failed to get enrollments by course: %w", err)
 	}
 	return enrollments, nil
 }
 
+// GetByCourseAndHomeroom retrieves a course's enrollments for students currently in a homeroom
+func (r *studentCourseRepository) GetByCourseAndHomeroom(courseID, homeroomID uint) ([]StudentCourse, error) {
+	var enrollments []StudentCourse
+	if err := r.db.Preload("Student").Preload("Course").
+		Scopes(homeroom.CurrentMembers("student_courses.student_id", homeroomID)).
+		Where("course_id = ?", courseID).Find(&enrollments).Error; err != nil {
+		return nil, fmt.Errorf("failed to get enrollments by course and homeroom: %w", err)
+	}
+	return enrollments, nil
+}
+
 // GetByStudentAndCourse retrieves a specific enrollment
 func (r *studentCourseRepository) GetByStudentAndCourse(studentID, courseID uint) (*StudentCourse, error) {
 	var enrollment StudentCourse
@@ -96,7 +110,7 @@ func (r *studentCourseRepository) GetByStudentAndCourse(studentID, courseID uint
 // GetEnrolledAfter retrieves enrollments created after a specific date
 func (r *studentCourseRepository) GetEnrolledAfter(date time.Time) ([]StudentCourse, error) {
 	var enrollments []StudentCourse
-	if err := r.db.Where("enrollment_date > ?", date).Find(&enrollments).Error; err != nil {
+	if err := r.db.Preload("Student").Preload("Course").Where("enrollment_date > ?", date).Find(&enrollments).Error; err != nil {
 		return nil, fmt.Errorf("failed to get enrollments after date: %w", err)
 	}
 	return enrollments, nil
@@ -2,26 +2,44 @@ package student_courses
 
 import (
 	"fmt"
+	"log"
+	"strings"
 	"time"
+
+	"school_management/internal/modules/student"
 )
 
 // StudentCourseService defines the business logic interface
 type StudentCourseService interface {
 	Enroll(req *EnrollStudentRequest) (*StudentCourseResponse, error)
+	CheckEnrollable(st *student.Student, courseID uint) error
 	GetByID(id uint) (*StudentCourseResponse, error)
 	GetByStudent(studentID uint) ([]StudentCourseResponse, error)
-	GetByCourse(courseID uint) ([]StudentCourseResponse, error)
+	GetByCourse(courseID uint, homeroomID *uint) ([]StudentCourseResponse, error)
 	Unenroll(id uint) error
 }
 
+// HomeworkAssigner creates pending submissions for a new enrollee's open homework
+type HomeworkAssigner interface {
+	AssignOpenHomework(studentID, courseID uint) error
+}
+
+// EligibilityChecker decides whether a student's grade level allows them into a course
+type EligibilityChecker interface {
+	CheckEligibility(studentID, courseID uint) error
+}
+
 // studentCourseService implements StudentCourseService
 type studentCourseService struct {
-	repo StudentCourseRepository
+	repo        StudentCourseRepository
+	studentRepo student.StudentRepository
+	assigner    HomeworkAssigner
+	eligibility EligibilityChecker
 }
 
 // NewStudentCourseService creates a new student course service with DI
-func NewStudentCourseService(repo StudentCourseRepository) StudentCourseService {
-	return &studentCourseService{repo: repo}
+func NewStudentCourseService(repo StudentCourseRepository, studentRepo student.StudentRepository, assigner HomeworkAssigner, eligibility EligibilityChecker) StudentCourseService {
+	return &studentCourseService{repo: repo, studentRepo: studentRepo, assigner: assigner, eligibility: eligibility}
 }
 
 // Enroll enrolls a student in a course
@@ -30,6 +48,13 @@ func (s *studentCourseService) Enroll(req *EnrollStudentRequest) (*StudentCourse
 	if err := s.validateEnrollRequest(req); err != nil {
 		return nil, err
 	}
+	st, err := s.studentRepo.GetByID(req.StudentID)
+	if err != nil {
+		return nil, fmt.Errorf("student not found: %w", err)
+	}
+	if err := s.CheckEnrollable(st, req.CourseID); err != nil {
+		return nil, err
+	}
 
 	// Check if already enrolled
 	existing, _ := s.repo.GetByStudentAndCourse(req.StudentID, req.CourseID)
@@ -55,10 +80,24 @@ func (s *studentCourseService) Enroll(req *EnrollStudentRequest) (*StudentCourse
 		return nil, fmt.Errorf("failed to enroll student: %w", err)
 	}
 
+	// Assign the course's open homework; the enrollment itself is already saved
+	if err := s.assigner.AssignOpenHomework(enrollment.StudentID, enrollment.CourseID); err != nil {
+		log.Printf("⚠️ Enrollment %d created but open homework not assigned: %v", enrollment.ID, err)
+	}
+
 	// Map Model to Response DTO
 	return s.toResponseDTO(enrollment), nil
 }
 
+// CheckEnrollable applies Enroll's rules on who may join a course: the student must be active
+// and in a grade level the course allows. The student need not be saved yet.
+func (s *studentCourseService) CheckEnrollable(st *student.Student, courseID uint) error {
+	if !st.IsActive() {
+		return fmt.Errorf("student is %s; only active students can be enrolled", st.Status)
+	}
+	return s.eligibility.CheckEligibility(st.ID, courseID)
+}
+
 // GetByID retrieves an enrollment by ID
 func (s *studentCourseService) GetByID(id uint) (*StudentCourseResponse, error) {
 	enrollment, err := s.repo.GetByID(id)
@@ -77,9 +116,15 @@ func (s *studentCourseService) GetByStudent(studentID uint) ([]StudentCourseResp
 	return s.toResponseDTOList(enrollments), nil
 }
 
-// GetByCourse retrieves enrollments for a course
-func (s *studentCourseService) GetByCourse(courseID uint) ([]StudentCourseResponse, error) {
-	enrollments, err := s.repo.GetByCourse(courseID)
+// GetByCourse retrieves enrollments for a course, optionally only for students currently in a homeroom
+func (s *studentCourseService) GetByCourse(courseID uint, homeroomID *uint) ([]StudentCourseResponse, error) {
+	var enrollments []StudentCourse
+	var err error
+	if homeroomID != nil {
+		enrollments, err = s.repo.GetByCourseAndHomeroom(courseID, *homeroomID)
+	} else {
+		enrollments, err = s.repo.GetByCourse(courseID)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get enrollments: %w", err)
 	}
@@ -118,7 +163,9 @@ func (s *studentCourseService) toResponseDTO(enrollment *StudentCourse) *Student
 	return &StudentCourseResponse{
 		ID:             enrollment.ID,
 		StudentID:      enrollment.StudentID,
+		StudentName:    strings.TrimSpace(enrollment.Student.FirstName + " " + enrollment.Student.LastName),
 		CourseID:       enrollment.CourseID,
+		CourseName:     enrollment.Course.Name,
 		EnrollmentDate: enrollment.EnrollmentDate,
 		CreatedAt:      enrollment.CreatedAt,
 		UpdatedAt:      enrollment.UpdatedAt,
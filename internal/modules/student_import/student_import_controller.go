@@ -0,0 +1,71 @@
+package student_import
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxUploadBytes caps the size of an uploaded import file
+const maxUploadBytes = 10 << 20
+
+// StudentImportController handles HTTP requests for student imports
+type StudentImportController struct {
+	service StudentImportService
+}
+
+// NewStudentImportController creates a new student import controller
+func NewStudentImportController(service StudentImportService) *StudentImportController {
+	return &StudentImportController{service: service}
+}
+
+// Import validates, and in commit mode imports, a CSV or XLSX file of students
+func (c *StudentImportController) Import(ctx *gin.Context) {
+	var req ImportStudentsRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	if header.Size > maxUploadBytes {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file is larger than 10 MB"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.Import(header.Filename, data, &req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	status := http.StatusOK
+	if resp.Mode == ModeCommit && resp.Imported > 0 {
+		status = http.StatusCreated
+	}
+	ctx.JSON(status, resp)
+}
+
+// RegisterRoutes registers all student import routes
+func (c *StudentImportController) RegisterRoutes(rg *gin.RouterGroup) {
+	students := rg.Group("/students")
+	{
+		students.POST("/import", c.Import)
+	}
+}
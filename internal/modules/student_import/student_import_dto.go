@@ -0,0 +1,43 @@
+package student_import
+
+// Import modes
+const (
+	ModeDryRun = "dry_run"
+	ModeCommit = "commit"
+)
+
+// Row statuses in an import report
+const (
+	RowValid    = "valid"
+	RowInvalid  = "invalid"
+	RowImported = "imported"
+)
+
+// ImportStudentsRequest represents the form fields sent alongside the uploaded file
+type ImportStudentsRequest struct {
+	Mapping        string `form:"mapping"` // JSON object of student field -> column header
+	Mode           string `form:"mode" binding:"omitempty,oneof=dry_run commit"`
+	CourseIDs      []uint `form:"course_ids"`
+	EnrollmentDate string `form:"enrollment_date"` // Format: YYYY-MM-DD, used for rows without one
+}
+
+// ImportReport represents the outcome of validating or importing a file
+type ImportReport struct {
+	Mode        string            `json:"mode"`
+	TotalRows   int               `json:"total_rows"`
+	ValidRows   int               `json:"valid_rows"`
+	InvalidRows int               `json:"invalid_rows"`
+	Imported    int               `json:"imported"`
+	Enrolled    int               `json:"enrolled"`
+	CourseIDs   []uint            `json:"course_ids,omitempty"`
+	Rows        []ImportRowResult `json:"rows"`
+}
+
+// ImportRowResult represents the outcome for one data row of the file
+type ImportRowResult struct {
+	Row       int      `json:"row"` // Spreadsheet row number; the header is row 1
+	Email     string   `json:"email"`
+	Status    string   `json:"status"`
+	Errors    []string `json:"errors,omitempty"`
+	StudentID uint     `json:"student_id,omitempty"`
+}
@@ -0,0 +1,79 @@
+package student_import
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"school_management/internal/modules/student"
+	"school_management/internal/modules/student_courses"
+)
+
+// StudentImportRepository defines the interface for student import data access
+type StudentImportRepository interface {
+	GetExistingEmails(emails []string) (map[string]bool, error)
+	CreateWithEnrollments(students []*student.Student, courseIDs []uint) error
+}
+
+// studentImportRepository implements StudentImportRepository
+type studentImportRepository struct {
+	db *gorm.DB
+}
+
+// NewStudentImportRepository creates a new student import repository with dependency injection
+func NewStudentImportRepository(db *gorm.DB) StudentImportRepository {
+	return &studentImportRepository{db: db}
+}
+
+// GetExistingEmails returns which of the given emails, lowercased, already belong to a student.
+// Deleted students are left out; their emails can be reused.
+func (r *studentImportRepository) GetExistingEmails(emails []string) (map[string]bool, error) {
+	existing := make(map[string]bool)
+	if len(emails) == 0 {
+		return existing, nil
+	}
+
+	lowered := make([]string, len(emails))
+	for i, e := range emails {
+		lowered[i] = strings.ToLower(e)
+	}
+
+	var found []string
+	if err := r.db.Model(&student.Student{}).
+		Where("LOWER(email) IN ?", lowered).
+		Pluck("LOWER(email)", &found).Error; err != nil {
+		return nil, fmt.Errorf("failed to check existing emails: %w", err)
+	}
+	for _, e := range found {
+		existing[e] = true
+	}
+	return existing, nil
+}
+
+// CreateWithEnrollments creates the students and enrolls each into every course in one transaction
+func (r *studentImportRepository) CreateWithEnrollments(students []*student.Student, courseIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&students).Error; err != nil {
+			return fmt.Errorf("failed to create students: %w", err)
+		}
+
+		if len(courseIDs) == 0 {
+			return nil
+		}
+		enrollments := make([]student_courses.StudentCourse, 0, len(students)*len(courseIDs))
+		for _, s := range students {
+			for _, courseID := range courseIDs {
+				enrollments = append(enrollments, student_courses.StudentCourse{
+					StudentID:      s.ID,
+					CourseID:       courseID,
+					EnrollmentDate: s.EnrollmentDate,
+				})
+			}
+		}
+		if err := tx.Omit("Student", "Course").Create(&enrollments).Error; err != nil {
+			return fmt.Errorf("failed to enroll students: %w", err)
+		}
+		return nil
+	})
+}
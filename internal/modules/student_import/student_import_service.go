@@ -0,0 +1,300 @@
+package student_import
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"path"
+	"strings"
+	"time"
+
+	"school_management/internal/modules/course"
+	"school_management/internal/modules/student"
+	"school_management/internal/modules/student_courses"
+	"school_management/internal/spreadsheet"
+)
+
+// MaxRows is the most data rows a single import file may contain
+const MaxRows = 5000
+
+// fields lists the student fields a column can be mapped to, in report order
+var fields = []string{"first_name", "last_name", "email", "phone", "date_of_birth", "enrollment_date"}
+
+// StudentImportService defines the business logic interface
+type StudentImportService interface {
+	Import(fileName string, data []byte, req *ImportStudentsRequest) (*ImportReport, error)
+}
+
+// studentImportService implements StudentImportService
+type studentImportService struct {
+	repo        StudentImportRepository
+	students    student.StudentService
+	courseRepo  course.CourseRepository
+	enrollments student_courses.StudentCourseService
+	assigner    student_courses.HomeworkAssigner
+}
+
+// NewStudentImportService creates a new student import service with DI
+func NewStudentImportService(repo StudentImportRepository, students student.StudentService, courseRepo course.CourseRepository, enrollments student_courses.StudentCourseService, assigner student_courses.HomeworkAssigner) StudentImportService {
+	return &studentImportService{repo: repo, students: students, courseRepo: courseRepo, enrollments: enrollments, assigner: assigner}
+}
+
+// Import validates every row of a CSV or XLSX file of students. In commit mode the valid
+// rows are created, and enrolled into the requested courses, in one transaction.
+func (s *studentImportService) Import(fileName string, data []byte, req *ImportStudentsRequest) (*ImportReport, error) {
+	// Validate
+	if err := s.validateImportRequest(req); err != nil {
+		return nil, err
+	}
+	mode := req.Mode
+	if mode == "" {
+		mode = ModeDryRun
+	}
+	courseIDs := uniqueIDs(req.CourseIDs)
+	for _, id := range courseIDs {
+		if _, err := s.courseRepo.GetByID(id); err != nil {
+			return nil, fmt.Errorf("course %d not found: %w", id, err)
+		}
+	}
+
+	// Read the file
+	rows, err := spreadsheet.ReadRows(fileName, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) < 2 {
+		return nil, fmt.Errorf("file has no data rows")
+	}
+	if len(rows)-1 > MaxRows {
+		return nil, fmt.Errorf("file has %d data rows; the limit is %d", len(rows)-1, MaxRows)
+	}
+	columns, err := s.resolveColumns(rows[0], req)
+	if err != nil {
+		return nil, err
+	}
+	serialDates := strings.EqualFold(path.Ext(fileName), ".xlsx")
+
+	// Validate each row and find duplicates within the file
+	report := &ImportReport{Mode: mode, CourseIDs: courseIDs}
+	var prepared []*student.Student
+	var emails []string
+	firstRow := make(map[string]int)
+	for i, row := range rows[1:] {
+		if isBlank(row) {
+			continue
+		}
+		createReq := s.toCreateRequest(row, columns, req.EnrollmentDate, serialDates)
+		result := ImportRowResult{Row: i + 2, Email: createReq.Email}
+
+		st, err := s.students.Prepare(createReq)
+		if err != nil {
+			result.Errors = append(result.Errors, err.Error())
+		} else {
+			// Imported students have no homeroom yet, so grade-level courses turn them away
+			for _, courseID := range courseIDs {
+				if err := s.enrollments.CheckEnrollable(st, courseID); err != nil {
+					result.Errors = append(result.Errors, err.Error())
+				}
+			}
+		}
+		if key := strings.ToLower(createReq.Email); key != "" {
+			if first, ok := firstRow[key]; ok {
+				result.Errors = append(result.Errors, fmt.Sprintf("duplicate email (first seen on row %d)", first))
+			} else {
+				firstRow[key] = result.Row
+				emails = append(emails, key)
+			}
+		}
+
+		report.Rows = append(report.Rows, result)
+		prepared = append(prepared, st)
+	}
+
+	// Find duplicates against existing students
+	existing, err := s.repo.GetExistingEmails(emails)
+	if err != nil {
+		return nil, err
+	}
+	for i := range report.Rows {
+		row := &report.Rows[i]
+		if existing[strings.ToLower(row.Email)] {
+			row.Errors = append(row.Errors, "a student with this email already exists")
+		}
+		if len(row.Errors) > 0 {
+			row.Status = RowInvalid
+			report.InvalidRows++
+		} else {
+			row.Status = RowValid
+			report.ValidRows++
+		}
+	}
+	report.TotalRows = len(report.Rows)
+
+	if mode == ModeDryRun || report.ValidRows == 0 {
+		return report, nil
+	}
+
+	// Create via repository
+	var valid []*student.Student
+	var indexes []int
+	for i, row := range report.Rows {
+		if row.Status == RowValid {
+			valid = append(valid, prepared[i])
+			indexes = append(indexes, i)
+		}
+	}
+	if err := s.repo.CreateWithEnrollments(valid, courseIDs); err != nil {
+		return nil, fmt.Errorf("failed to import students: %w", err)
+	}
+	for j, i := range indexes {
+		report.Rows[i].Status = RowImported
+		report.Rows[i].StudentID = valid[j].ID
+	}
+	report.Imported = len(valid)
+	report.Enrolled = len(valid) * len(courseIDs)
+
+	// Assign the courses' open homework; the students and enrollments are already saved
+	for _, st := range valid {
+		for _, courseID := range courseIDs {
+			if err := s.assigner.AssignOpenHomework(st.ID, courseID); err != nil {
+				log.Printf("⚠️ Student %d imported into course %d but open homework not assigned: %v", st.ID, courseID, err)
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// resolveColumns maps each student field to a column index, using the request's mapping
+// first and otherwise a header named like the field ("First Name" matches first_name)
+func (s *studentImportService) resolveColumns(header []string, req *ImportStudentsRequest) (map[string]int, error) {
+	byName := make(map[string]int, len(header))
+	for i, h := range header {
+		name := normalizeHeader(h)
+		if _, dup := byName[name]; !dup && name != "" {
+			byName[name] = i
+		}
+	}
+
+	mapping := make(map[string]string)
+	if req.Mapping != "" {
+		if err := json.Unmarshal([]byte(req.Mapping), &mapping); err != nil {
+			return nil, fmt.Errorf("invalid mapping (expected a JSON object of field to column): %w", err)
+		}
+	}
+
+	columns := make(map[string]int)
+	for field, col := range mapping {
+		if !isField(field) {
+			return nil, fmt.Errorf("unknown field %q in mapping (use one of %s)", field, strings.Join(fields, ", "))
+		}
+		i, ok := byName[normalizeHeader(col)]
+		if !ok {
+			return nil, fmt.Errorf("column %q for %s not found in file", col, field)
+		}
+		columns[field] = i
+	}
+	for _, field := range fields {
+		if _, mapped := columns[field]; mapped {
+			continue
+		}
+		if i, ok := byName[field]; ok {
+			columns[field] = i
+		}
+	}
+
+	for _, field := range []string{"first_name", "last_name", "email", "date_of_birth"} {
+		if _, ok := columns[field]; !ok {
+			return nil, fmt.Errorf("no column for %s; name one in mapping", field)
+		}
+	}
+	if _, ok := columns["enrollment_date"]; !ok && req.EnrollmentDate == "" {
+		return nil, fmt.Errorf("no column for enrollment_date; name one in mapping or set enrollment_date")
+	}
+	return columns, nil
+}
+
+// toCreateRequest reads a row into the same request POST /students accepts
+func (s *studentImportService) toCreateRequest(row []string, columns map[string]int, defaultEnrollment string, serialDates bool) *student.CreateStudentRequest {
+	value := func(field string) string {
+		i, ok := columns[field]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+	date := func(field string) string {
+		v := value(field)
+		if serialDates {
+			// XLSX stores date cells as day serial numbers
+			if t, ok := spreadsheet.SerialDate(v); ok {
+				return t.Format("2006-01-02")
+			}
+		}
+		return v
+	}
+
+	req := &student.CreateStudentRequest{
+		FirstName:      value("first_name"),
+		LastName:       value("last_name"),
+		Email:          value("email"),
+		Phone:          value("phone"),
+		DateOfBirth:    date("date_of_birth"),
+		EnrollmentDate: date("enrollment_date"),
+	}
+	if req.EnrollmentDate == "" {
+		req.EnrollmentDate = defaultEnrollment
+	}
+	return req
+}
+
+// Validation methods
+func (s *studentImportService) validateImportRequest(req *ImportStudentsRequest) error {
+	if req.Mode != "" && req.Mode != ModeDryRun && req.Mode != ModeCommit {
+		return fmt.Errorf("invalid mode (use dry_run or commit)")
+	}
+	if req.EnrollmentDate != "" {
+		if _, err := time.Parse("2006-01-02", req.EnrollmentDate); err != nil {
+			return fmt.Errorf("invalid enrollment date format (use YYYY-MM-DD): %w", err)
+		}
+	}
+	return nil
+}
+
+func isField(name string) bool {
+	for _, f := range fields {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeHeader lowercases a header and joins its words with underscores
+func normalizeHeader(h string) string {
+	h = strings.ToLower(strings.TrimSpace(h))
+	return strings.Join(strings.FieldsFunc(h, func(r rune) bool {
+		return r == ' ' || r == '_' || r == '-'
+	}), "_")
+}
+
+func isBlank(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if id != 0 && !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
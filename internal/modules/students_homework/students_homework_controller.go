@@ -1,10 +1,14 @@
 package students_homework
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"school_management/internal/export"
 )
 
 // StudentHomeworkController handles HTTP requests for student homework submissions
@@ -84,6 +88,10 @@ func (c *StudentHomeworkController) GetByStudent(ctx *gin.Context) {
 		return
 	}
 
+	if export.Send(ctx, resp) {
+		return
+	}
+
 	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
 }
 
@@ -101,6 +109,10 @@ func (c *StudentHomeworkController) GetByHomework(ctx *gin.Context) {
 		return
 	}
 
+	if export.Send(ctx, resp) {
+		return
+	}
+
 	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
 }
 
@@ -118,9 +130,76 @@ func (c *StudentHomeworkController) GetPendingByStudent(ctx *gin.Context) {
 		return
 	}
 
+	if export.Send(ctx, resp) {
+		return
+	}
+
 	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
 }
 
+// GetMissingByStudent retrieves missing submissions for a student
+func (c *StudentHomeworkController) GetMissingByStudent(ctx *gin.Context) {
+	studentID, err := strconv.ParseUint(ctx.Param("studentId"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid student ID"})
+		return
+	}
+
+	resp, err := c.service.GetMissingByStudent(uint(studentID))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// GetVersions retrieves the version history of a submission
+func (c *StudentHomeworkController) GetVersions(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetVersions(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// ReturnForRevision returns a submission to the student for revision
+func (c *StudentHomeworkController) ReturnForRevision(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.ReturnForRevision(uint(id))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
 // Delete deletes a submission
 func (c *StudentHomeworkController) Delete(ctx *gin.Context) {
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
@@ -137,6 +216,23 @@ func (c *StudentHomeworkController) Delete(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"message": "submission deleted successfully"})
 }
 
+// PublishHomework releases a homework's draft grades
+func (c *StudentHomeworkController) PublishHomework(ctx *gin.Context) {
+	homeworkID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid homework ID"})
+		return
+	}
+
+	resp, err := c.service.PublishHomework(uint(homeworkID))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
 // RegisterRoutes registers submission routes
 func (c *StudentHomeworkController) RegisterRoutes(rg *gin.RouterGroup) {
 	submissions := rg.Group("/submissions")
@@ -145,8 +241,16 @@ func (c *StudentHomeworkController) RegisterRoutes(rg *gin.RouterGroup) {
 		submissions.POST("/grade", c.Grade)
 		submissions.GET("/:id", c.GetByID)
 		submissions.DELETE("/:id", c.Delete)
+		submissions.GET("/:id/versions", c.GetVersions)
+		submissions.POST("/:id/return", c.ReturnForRevision)
 		submissions.GET("/student/:studentId", c.GetByStudent)
 		submissions.GET("/homework/:homeworkId", c.GetByHomework)
 		submissions.GET("/student/:studentId/pending", c.GetPendingByStudent)
+		submissions.GET("/student/:studentId/missing", c.GetMissingByStudent)
+	}
+
+	homework := rg.Group("/homework")
+	{
+		homework.POST("/:id/publish", c.PublishHomework)
 	}
 }
@@ -4,26 +4,99 @@ import "time"
 
 // SubmitHomeworkRequest represents the request body for submitting homework
 type SubmitHomeworkRequest struct {
-	StudentID      uint   `json:"student_id" binding:"required"`
-	HomeworkID     uint   `json:"homework_id" binding:"required"`
-	SubmissionDate string `json:"submission_date" binding:"required"` // Format: YYYY-MM-DD HH:MM:SS
+	StudentID      uint                `json:"student_id" binding:"required"`
+	HomeworkID     uint                `json:"homework_id" binding:"required"`
+	SubmissionDate string              `json:"submission_date" binding:"required"` // Format: YYYY-MM-DD HH:MM:SS
+	Content        string              `json:"content" binding:"omitempty"`
+	Attachments    []AttachmentRequest `json:"attachments" binding:"omitempty,dive"`
 }
 
-// GradeHomeworkRequest represents the request body for grading homework
+// AttachmentRequest represents a file attached to a submission
+type AttachmentRequest struct {
+	FileName    string `json:"file_name" binding:"required,max=255"`
+	FileURL     string `json:"file_url" binding:"required,max=500"`
+	ContentType string `json:"content_type" binding:"omitempty,max=100"`
+	SizeBytes   int64  `json:"size_bytes" binding:"omitempty,min=0"`
+}
+
+// GradeHomeworkRequest represents the request body for grading homework.
+// Homework with a rubric is graded through Criteria and the score is computed, scaled to the
+// homework's max score;
+// homework without one is graded with a bare Score.
 type GradeHomeworkRequest struct {
-	StudentID  uint    `json:"student_id" binding:"required"`
-	HomeworkID uint    `json:"homework_id" binding:"required"`
-	Score      float64 `json:"score" binding:"required,min=0"`
+	StudentID  uint                    `json:"student_id" binding:"required"`
+	HomeworkID uint                    `json:"homework_id" binding:"required"`
+	Score      *float64                `json:"score" binding:"omitempty,min=0"`
+	VersionID  *uint                   `json:"version_id" binding:"omitempty"` // Defaults to the latest version
+	Criteria   []CriterionGradeRequest `json:"criteria" binding:"omitempty,dive"`
+	Feedback   string                  `json:"feedback" binding:"omitempty,max=5000"`
+}
+
+// CriterionGradeRequest represents the level awarded on one rubric criterion
+type CriterionGradeRequest struct {
+	CriterionID uint   `json:"criterion_id" binding:"required"`
+	LevelID     uint   `json:"level_id" binding:"required"`
+	Comment     string `json:"comment" binding:"omitempty,max=2000"`
 }
 
 // StudentHomeworkResponse represents the response body for student homework data
 type StudentHomeworkResponse struct {
-	ID             uint      `json:"id"`
-	StudentID      uint      `json:"student_id"`
-	HomeworkID     uint      `json:"homework_id"`
-	SubmissionDate *string   `json:"submission_date"`
-	Score          *float64  `json:"score"`
-	Status         string    `json:"status"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
+	ID               uint       `json:"id"`
+	StudentID        uint       `json:"student_id"`
+	HomeworkID       uint       `json:"homework_id"`
+	SubmissionDate   *string    `json:"submission_date"`
+	Score            *float64   `json:"score"`
+	Status           string     `json:"status"`
+	GradedVersionID  *uint      `json:"graded_version_id"`
+	Feedback         string     `json:"feedback"`
+	GradeStatus      string     `json:"grade_status"`
+	GradePublishedAt *time.Time `json:"grade_published_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+
+	Rubric *RubricBreakdownResponse `json:"rubric,omitempty"`
+}
+
+// RubricBreakdownResponse represents a submission's per-criterion rubric results
+type RubricBreakdownResponse struct {
+	RubricID    uint                     `json:"rubric_id"`
+	TotalPoints float64                  `json:"total_points"`
+	MaxPoints   float64                  `json:"max_points"`
+	Criteria    []CriterionScoreResponse `json:"criteria"`
+}
+
+// CriterionScoreResponse represents the result on a single rubric criterion
+type CriterionScoreResponse struct {
+	CriterionID   uint    `json:"criterion_id"`
+	CriterionName string  `json:"criterion_name"`
+	LevelID       uint    `json:"level_id"`
+	LevelLabel    string  `json:"level_label"`
+	Points        float64 `json:"points"`
+	MaxPoints     float64 `json:"max_points"`
+	Comment       string  `json:"comment"`
+}
+
+// AttachmentResponse represents the response body for a submission attachment
+type AttachmentResponse struct {
+	ID          uint   `json:"id"`
+	FileName    string `json:"file_name"`
+	FileURL     string `json:"file_url"`
+	ContentType string `json:"content_type"`
+	SizeBytes   int64  `json:"size_bytes"`
+}
+
+// SubmissionVersionResponse represents the response body for a submission version
+type SubmissionVersionResponse struct {
+	ID            uint                 `json:"id"`
+	VersionNumber int                  `json:"version_number"`
+	SubmittedAt   time.Time            `json:"submitted_at"`
+	Content       string               `json:"content"`
+	Attachments   []AttachmentResponse `json:"attachments"`
+	IsGraded      bool                 `json:"is_graded"`
+}
+
+// PublishResponse represents the result of releasing a homework's grades
+type PublishResponse struct {
+	HomeworkID uint  `json:"homework_id"`
+	Published  int64 `json:"published"`
 }
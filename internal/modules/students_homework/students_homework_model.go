@@ -6,6 +6,7 @@ import (
 	"gorm.io/gorm"
 
 	"school_management/internal/modules/homework"
+	"school_management/internal/modules/rubric"
 	"school_management/internal/modules/student"
 )
 
@@ -16,22 +17,92 @@ const (
 	HomeworkPending   HomeworkStatus = "pending"
 	HomeworkSubmitted HomeworkStatus = "submitted"
 	HomeworkGraded    HomeworkStatus = "graded"
+	HomeworkReturned  HomeworkStatus = "returned" // returned to the student for revision
+	HomeworkMissing   HomeworkStatus = "missing"  // still pending after the due date
+)
+
+// GradeStatus represents whether a submission's grade has been released to the student
+type GradeStatus string
+
+const (
+	GradeDraft     GradeStatus = "draft"     // visible to teachers only
+	GradePublished GradeStatus = "published" // released to students and guardians
 )
 
 type StudentHomework struct {
 	gorm.Model
-	StudentID      uint           `gorm:"not null;uniqueIndex:idx_student_homework" json:"student_id"`
-	HomeworkID     uint           `gorm:"not null;uniqueIndex:idx_student_homework" json:"homework_id"`
-	SubmissionDate *time.Time     `gorm:"type:timestamp" json:"submission_date"`
-	Score          *float64       `json:"score"`
-	Status         HomeworkStatus `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	StudentID        uint           `gorm:"not null;uniqueIndex:idx_student_homework" json:"student_id"`
+	HomeworkID       uint           `gorm:"not null;uniqueIndex:idx_student_homework" json:"homework_id"`
+	SubmissionDate   *time.Time     `gorm:"type:timestamp" json:"submission_date"`
+	Score            *float64       `json:"score"`
+	Status           HomeworkStatus `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	GradedVersionID  *uint          `json:"graded_version_id"`
+	Feedback         string         `gorm:"type:text" json:"feedback"`
+	GradeStatus      GradeStatus    `gorm:"type:varchar(20);not null;default:'published';comment:grades that predate publishing stay visible" json:"grade_status"`
+	GradePublishedAt *time.Time     `gorm:"type:timestamp" json:"grade_published_at"`
 
 	// Belongs To relationships
 	Student  student.Student   `gorm:"foreignKey:StudentID" json:"student,omitempty"`
 	Homework homework.Homework `gorm:"foreignKey:HomeworkID" json:"homework,omitempty"`
+
+	// Has Many relationships
+	Versions        []SubmissionVersion `gorm:"foreignKey:StudentHomeworkID" json:"versions,omitempty"`
+	CriterionScores []CriterionScore    `gorm:"foreignKey:StudentHomeworkID" json:"criterion_scores,omitempty"`
 }
 
 // TableName specifies the table name for the StudentHomework model
 func (StudentHomework) TableName() string {
 	return "students_homework"
 }
+
+// SubmissionVersion is one submitted revision of a student's homework.
+// Every submit creates a new version; earlier versions are never overwritten.
+type SubmissionVersion struct {
+	gorm.Model
+	StudentHomeworkID uint      `gorm:"not null;uniqueIndex:idx_submission_version" json:"student_homework_id"`
+	VersionNumber     int       `gorm:"not null;uniqueIndex:idx_submission_version" json:"version_number"`
+	SubmittedAt       time.Time `gorm:"type:timestamp;not null" json:"submitted_at"`
+	Content           string    `gorm:"type:text" json:"content"`
+
+	// Has Many relationship
+	Attachments []SubmissionAttachment `gorm:"foreignKey:SubmissionVersionID" json:"attachments,omitempty"`
+}
+
+// TableName specifies the table name for the SubmissionVersion model
+func (SubmissionVersion) TableName() string {
+	return "submission_versions"
+}
+
+// SubmissionAttachment is a file attached to a submission version
+type SubmissionAttachment struct {
+	gorm.Model
+	SubmissionVersionID uint   `gorm:"not null;index" json:"submission_version_id"`
+	FileName            string `gorm:"not null;size:255" json:"file_name"`
+	FileURL             string `gorm:"not null;size:500" json:"file_url"`
+	ContentType         string `gorm:"size:100" json:"content_type"`
+	SizeBytes           int64  `json:"size_bytes"`
+}
+
+// TableName specifies the table name for the SubmissionAttachment model
+func (SubmissionAttachment) TableName() string {
+	return "submission_attachments"
+}
+
+// CriterionScore is the level a submission achieved on one rubric criterion
+type CriterionScore struct {
+	gorm.Model
+	StudentHomeworkID uint    `gorm:"not null;uniqueIndex:idx_submission_criterion" json:"student_homework_id"`
+	CriterionID       uint    `gorm:"not null;uniqueIndex:idx_submission_criterion" json:"criterion_id"`
+	LevelID           uint    `gorm:"not null" json:"level_id"`
+	Points            float64 `gorm:"not null" json:"points"`
+	Comment           string  `gorm:"type:text" json:"comment"`
+
+	// Belongs To relationships
+	Criterion rubric.RubricCriterion `gorm:"foreignKey:CriterionID" json:"criterion,omitempty"`
+	Level     rubric.RubricLevel     `gorm:"foreignKey:LevelID" json:"level,omitempty"`
+}
+
+// TableName specifies the table name for the CriterionScore model
+func (CriterionScore) TableName() string {
+	return "submission_criterion_scores"
+}
@@ -2,8 +2,10 @@ package students_homework
 
 import (
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // StudentHomeworkRepository defines the interface for student homework submission data access
@@ -17,8 +19,23 @@ type StudentHomeworkRepository interface {
 	GetByStudentAndHomework(studentID, homeworkID uint) (*StudentHomework, error)
 	GetByStatus(status HomeworkStatus) ([]StudentHomework, error)
 	GetPendingByStudent(studentID uint) ([]StudentHomework, error)
+	GetMissingByStudent(studentID uint) ([]StudentHomework, error)
+	CreatePending(submissions []StudentHomework) error
+	MarkOverdueAsMissing(now time.Time) (int64, error)
+	PublishByHomework(homeworkID uint, at time.Time) (int64, error)
 	Update(submission *StudentHomework) error
 	Delete(id uint) error
+
+	// Version history
+	SaveWithVersion(submission *StudentHomework, version *SubmissionVersion) error
+	GetVersions(submissionID uint) ([]SubmissionVersion, error)
+	GetVersionByID(versionID uint) (*SubmissionVersion, error)
+	GetLatestVersion(submissionID uint) (*SubmissionVersion, error)
+	CountVersions(submissionID uint) (int64, error)
+
+	// Rubric grading
+	SaveGrade(submission *StudentHomework, scores []CriterionScore) error
+	GetCriterionScores(submissionID uint) ([]CriterionScore, error)
 }
 
 // studentHomeworkRepository implements StudentHomeworkRepository
@@ -113,6 +130,54 @@ func (r *studentHomeworkRepository) GetPendingByStudent(studentID uint) ([]Stude
 	return submissions, nil
 }
 
+// GetMissingByStudent retrieves submissions a student missed
+func (r *studentHomeworkRepository) GetMissingByStudent(studentID uint) ([]StudentHomework, error) {
+	var submissions []StudentHomework
+	if err := r.db.Where("student_id = ? AND status = ?", studentID, HomeworkMissing).
+		Find(&submissions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get missing submissions: %w", err)
+	}
+	return submissions, nil
+}
+
+// CreatePending creates pending submission rows, skipping students who already have one
+func (r *studentHomeworkRepository) CreatePending(submissions []StudentHomework) error {
+	if len(submissions) == 0 {
+		return nil
+	}
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Omit(clause.Associations).
+		CreateInBatches(submissions, 200).Error; err != nil {
+		return fmt.Errorf("failed to create pending submissions: %w", err)
+	}
+	return nil
+}
+
+// MarkOverdueAsMissing moves pending submissions whose homework is past due to missing
+func (r *studentHomeworkRepository) MarkOverdueAsMissing(now time.Time) (int64, error) {
+	result := r.db.Model(&StudentHomework{}).
+		Where("status = ?", HomeworkPending).
+		Where("homework_id IN (?)", r.db.Table("homework").
+			Select("id").
+			Where("due_date < ? AND deleted_at IS NULL", now)).
+		Update("status", HomeworkMissing)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to mark missing submissions: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// PublishByHomework releases every draft grade on a homework's graded submissions
+func (r *studentHomeworkRepository) PublishByHomework(homeworkID uint, at time.Time) (int64, error) {
+	result := r.db.Model(&StudentHomework{}).
+		Where("homework_id = ? AND status = ? AND grade_status = ?", homeworkID, HomeworkGraded, GradeDraft).
+		Updates(map[string]interface{}{"grade_status": GradePublished, "grade_published_at": at})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to publish homework grades: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
 // Update updates a homework submission
 func (r *studentHomeworkRepository) Update(submission *StudentHomework) error {
 	if err := r.db.Save(submission).Error; err != nil {
@@ -128,3 +193,95 @@ func (r *studentHomeworkRepository) Delete(id uint) error {
 	}
 	return nil
 }
+
+// SaveWithVersion creates or updates a submission and appends a new version in one transaction
+func (r *studentHomeworkRepository) SaveWithVersion(submission *StudentHomework, version *SubmissionVersion) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Versions").Save(submission).Error; err != nil {
+			return fmt.Errorf("failed to save submission: %w", err)
+		}
+		version.StudentHomeworkID = submission.ID
+		if err := tx.Create(version).Error; err != nil {
+			return fmt.Errorf("failed to create submission version: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetVersions retrieves all versions of a submission, oldest first, with attachments preloaded
+func (r *studentHomeworkRepository) GetVersions(submissionID uint) ([]SubmissionVersion, error) {
+	var versions []SubmissionVersion
+	if err := r.db.Preload("Attachments").
+		Where("student_homework_id = ?", submissionID).
+		Order("version_number ASC").
+		Find(&versions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get submission versions: %w", err)
+	}
+	return versions, nil
+}
+
+// GetVersionByID retrieves a submission version by ID
+func (r *studentHomeworkRepository) GetVersionByID(versionID uint) (*SubmissionVersion, error) {
+	var version SubmissionVersion
+	if err := r.db.Preload("Attachments").First(&version, versionID).Error; err != nil {
+		return nil, fmt.Errorf("failed to get submission version: %w", err)
+	}
+	return &version, nil
+}
+
+// GetLatestVersion retrieves the most recent version of a submission
+func (r *studentHomeworkRepository) GetLatestVersion(submissionID uint) (*SubmissionVersion, error) {
+	var version SubmissionVersion
+	if err := r.db.Preload("Attachments").
+		Where("student_homework_id = ?", submissionID).
+		Order("version_number DESC").
+		First(&version).Error; err != nil {
+		return nil, fmt.Errorf("failed to get latest submission version: %w", err)
+	}
+	return &version, nil
+}
+
+// CountVersions counts the versions of a submission
+func (r *studentHomeworkRepository) CountVersions(submissionID uint) (int64, error) {
+	var count int64
+	if err := r.db.Model(&SubmissionVersion{}).
+		Where("student_homework_id = ?", submissionID).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count submission versions: %w", err)
+	}
+	return count, nil
+}
+
+// SaveGrade saves a graded submission and replaces its rubric criterion scores in one transaction
+func (r *studentHomeworkRepository) SaveGrade(submission *StudentHomework, scores []CriterionScore) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Versions", "CriterionScores").Save(submission).Error; err != nil {
+			return fmt.Errorf("failed to save submission grade: %w", err)
+		}
+		if err := tx.Unscoped().Where("student_homework_id = ?", submission.ID).
+			Delete(&CriterionScore{}).Error; err != nil {
+			return fmt.Errorf("failed to clear criterion scores: %w", err)
+		}
+		if len(scores) == 0 {
+			return nil
+		}
+		for i := range scores {
+			scores[i].StudentHomeworkID = submission.ID
+		}
+		if err := tx.Omit("Criterion", "Level").Create(&scores).Error; err != nil {
+			return fmt.Errorf("failed to save criterion scores: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetCriterionScores retrieves the rubric criterion scores of a submission
+func (r *studentHomeworkRepository) GetCriterionScores(submissionID uint) ([]CriterionScore, error) {
+	var scores []CriterionScore
+	if err := r.db.Preload("Criterion").Preload("Level").
+		Where("student_homework_id = ?", submissionID).
+		Find(&scores).Error; err != nil {
+		return nil, fmt.Errorf("failed to get criterion scores: %w", err)
+	}
+	return scores, nil
+}
@@ -2,7 +2,12 @@ package students_homework
 
 import (
 	"fmt"
+	"math"
 	"time"
+
+	"school_management/internal/modules/homework"
+	"school_management/internal/modules/rubric"
+	"school_management/internal/modules/student_courses"
 )
 
 // StudentHomeworkService defines the business logic interface
@@ -13,43 +18,82 @@ type StudentHomeworkService interface {
 	GetByStudent(studentID uint) ([]StudentHomeworkResponse, error)
 	GetByHomework(homeworkID uint) ([]StudentHomeworkResponse, error)
 	GetPendingByStudent(studentID uint) ([]StudentHomeworkResponse, error)
+	GetMissingByStudent(studentID uint) ([]StudentHomeworkResponse, error)
+	AssignHomework(homeworkID, courseID uint) error
+	AssignOpenHomework(studentID, courseID uint) error
+	MarkMissing() (int64, error)
+	GetVersions(id uint) ([]SubmissionVersionResponse, error)
+	ReturnForRevision(id uint) (*StudentHomeworkResponse, error)
+	PublishHomework(homeworkID uint) (*PublishResponse, error)
 	Delete(id uint) error
 }
 
 // studentHomeworkService implements StudentHomeworkService
 type studentHomeworkService struct {
-	repo StudentHomeworkRepository
+	repo           StudentHomeworkRepository
+	homeworkRepo   homework.HomeworkRepository
+	rubricRepo     rubric.RubricRepository
+	enrollmentRepo student_courses.StudentCourseRepository
 }
 
 // NewStudentHomeworkService creates a new student homework service with DI
-func NewStudentHomeworkService(repo StudentHomeworkRepository) StudentHomeworkService {
-	return &studentHomeworkService{repo: repo}
+func NewStudentHomeworkService(repo StudentHomeworkRepository, homeworkRepo homework.HomeworkRepository, rubricRepo rubric.RubricRepository, enrollmentRepo student_courses.StudentCourseRepository) StudentHomeworkService {
+	return &studentHomeworkService{repo: repo, homeworkRepo: homeworkRepo, rubricRepo: rubricRepo, enrollmentRepo: enrollmentRepo}
 }
 
-// Submit submits homework
+// Submit submits homework, creating a new version if the student has submitted before
 func (s *studentHomeworkService) Submit(req *SubmitHomeworkRequest) (*StudentHomeworkResponse, error) {
 	// Validate
 	if err := s.validateSubmitRequest(req); err != nil {
 		return nil, err
 	}
 
-	// Check if already submitted
-	existing, _ := s.repo.GetByStudentAndHomework(req.StudentID, req.HomeworkID)
-	if existing != nil {
-		return nil, fmt.Errorf("homework already submitted")
+	hw, err := s.homeworkRepo.GetByID(req.HomeworkID)
+	if err != nil {
+		return nil, fmt.Errorf("homework not found: %w", err)
 	}
 
-	// Map DTO to Model
 	now := time.Now()
-	submission := &StudentHomework{
-		StudentID:      req.StudentID,
-		HomeworkID:     req.HomeworkID,
-		SubmissionDate: &now,
-		Status:         HomeworkSubmitted,
+	versionNumber := 1
+
+	// Check whether a resubmission is allowed
+	submission, _ := s.repo.GetByStudentAndHomework(req.StudentID, req.HomeworkID)
+	if submission != nil {
+		count, err := s.repo.CountVersions(submission.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.checkResubmissionAllowed(submission, hw, int(count), now); err != nil {
+			return nil, err
+		}
+		versionNumber = int(count) + 1
+	} else {
+		submission = &StudentHomework{
+			StudentID:  req.StudentID,
+			HomeworkID: req.HomeworkID,
+		}
 	}
 
-	// Create via repository
-	if err := s.repo.Create(submission); err != nil {
+	submission.SubmissionDate = &now
+	submission.Status = HomeworkSubmitted
+
+	// Map DTO to version
+	version := &SubmissionVersion{
+		VersionNumber: versionNumber,
+		SubmittedAt:   now,
+		Content:       req.Content,
+	}
+	for _, a := range req.Attachments {
+		version.Attachments = append(version.Attachments, SubmissionAttachment{
+			FileName:    a.FileName,
+			FileURL:     a.FileURL,
+			ContentType: a.ContentType,
+			SizeBytes:   a.SizeBytes,
+		})
+	}
+
+	// Save submission and version via repository
+	if err := s.repo.SaveWithVersion(submission, version); err != nil {
 		return nil, fmt.Errorf("failed to submit homework: %w", err)
 	}
 
@@ -70,34 +114,90 @@ func (s *studentHomeworkService) Grade(req *GradeHomeworkRequest) (*StudentHomew
 		return nil, fmt.Errorf("submission not found: %w", err)
 	}
 
-	// Update grade
-	submission.Score = &req.Score
+	// Resolve the version being graded
+	if req.VersionID != nil {
+		version, err := s.repo.GetVersionByID(*req.VersionID)
+		if err != nil {
+			return nil, fmt.Errorf("submission version not found: %w", err)
+		}
+		if version.StudentHomeworkID != submission.ID {
+			return nil, fmt.Errorf("version %d does not belong to this submission", version.ID)
+		}
+		submission.GradedVersionID = &version.ID
+	} else if latest, err := s.repo.GetLatestVersion(submission.ID); err == nil {
+		submission.GradedVersionID = &latest.ID
+	}
+
+	hw, err := s.homeworkRepo.GetByID(req.HomeworkID)
+	if err != nil {
+		return nil, fmt.Errorf("homework not found: %w", err)
+	}
+
+	// Score per rubric criterion, or take the bare score
+	var scores []CriterionScore
+	if hw.RubricID != nil {
+		rb, err := s.rubricRepo.GetByID(*hw.RubricID)
+		if err != nil {
+			return nil, fmt.Errorf("rubric not found: %w", err)
+		}
+		var total float64
+		scores, total, err = s.scoreRubric(rb, req.Criteria)
+		if err != nil {
+			return nil, err
+		}
+		score := scaleScore(total, rb.MaxPoints(), hw.MaxScore)
+		submission.Score = &score
+	} else {
+		if len(req.Criteria) > 0 {
+			return nil, fmt.Errorf("homework has no rubric; grade it with a score")
+		}
+		if req.Score == nil {
+			return nil, fmt.Errorf("score is required")
+		}
+		submission.Score = req.Score
+	}
+
+	// Update grade; it stays a draft until the homework's grades are published
+	submission.Feedback = req.Feedback
 	submission.Status = HomeworkGraded
+	submission.GradeStatus = GradeDraft
+	submission.GradePublishedAt = nil
 
 	// Save
-	if err := s.repo.Update(submission); err != nil {
+	if err := s.repo.SaveGrade(submission, scores); err != nil {
 		return nil, fmt.Errorf("failed to grade homework: %w", err)
 	}
 
-	return s.toResponseDTO(submission), nil
+	return s.toDetailedResponseDTO(submission, hw)
 }
 
-// GetByID retrieves a submission by ID
+// GetByID retrieves a submission by ID, including its rubric breakdown; an unpublished grade is hidden
 func (s *studentHomeworkService) GetByID(id uint) (*StudentHomeworkResponse, error) {
 	submission, err := s.repo.GetByID(id)
 	if err != nil {
 		return nil, fmt.Errorf("submission not found: %w", err)
 	}
-	return s.toResponseDTO(submission), nil
+
+	hw, err := s.homeworkRepo.GetByID(submission.HomeworkID)
+	if err != nil {
+		return nil, fmt.Errorf("homework not found: %w", err)
+	}
+
+	resp, err := s.toDetailedResponseDTO(submission, hw)
+	if err != nil {
+		return nil, err
+	}
+	hideDraftGrade(resp)
+	return resp, nil
 }
 
-// GetByStudent retrieves submissions for a student
+// GetByStudent retrieves submissions for a student, hiding unpublished grades
 func (s *studentHomeworkService) GetByStudent(studentID uint) ([]StudentHomeworkResponse, error) {
 	submissions, err := s.repo.GetByStudent(studentID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get submissions: %w", err)
 	}
-	return s.toResponseDTOList(submissions), nil
+	return s.toStudentResponseDTOList(submissions), nil
 }
 
 // GetByHomework retrieves submissions for a homework
@@ -115,7 +215,121 @@ func (s *studentHomeworkService) GetPendingByStudent(studentID uint) ([]StudentH
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pending submissions: %w", err)
 	}
-	return s.toResponseDTOList(submissions), nil
+	return s.toStudentResponseDTOList(submissions), nil
+}
+
+// GetMissingByStudent retrieves submissions a student missed
+func (s *studentHomeworkService) GetMissingByStudent(studentID uint) ([]StudentHomeworkResponse, error) {
+	submissions, err := s.repo.GetMissingByStudent(studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get missing submissions: %w", err)
+	}
+	return s.toStudentResponseDTOList(submissions), nil
+}
+
+// AssignHomework creates a pending submission for every active student enrolled in the course
+func (s *studentHomeworkService) AssignHomework(homeworkID, courseID uint) error {
+	enrollments, err := s.enrollmentRepo.GetByCourse(courseID)
+	if err != nil {
+		return fmt.Errorf("failed to get course enrollments: %w", err)
+	}
+
+	var submissions []StudentHomework
+	for _, enrollment := range enrollments {
+		// Suspended, withdrawn and graduated students are not given new work
+		if !enrollment.Student.IsActive() {
+			continue
+		}
+		submissions = append(submissions, StudentHomework{
+			StudentID:  enrollment.StudentID,
+			HomeworkID: homeworkID,
+			Status:     HomeworkPending,
+		})
+	}
+
+	return s.repo.CreatePending(submissions)
+}
+
+// AssignOpenHomework creates pending submissions for a course's homework that is not yet due
+func (s *studentHomeworkService) AssignOpenHomework(studentID, courseID uint) error {
+	homeworks, err := s.homeworkRepo.GetByCourse(courseID)
+	if err != nil {
+		return fmt.Errorf("failed to get course homework: %w", err)
+	}
+
+	now := time.Now()
+	var submissions []StudentHomework
+	for _, hw := range homeworks {
+		if hw.DueDate.Before(now) {
+			continue
+		}
+		submissions = append(submissions, StudentHomework{
+			StudentID:  studentID,
+			HomeworkID: hw.ID,
+			Status:     HomeworkPending,
+		})
+	}
+
+	return s.repo.CreatePending(submissions)
+}
+
+// MarkMissing moves pending submissions past their due date to missing
+func (s *studentHomeworkService) MarkMissing() (int64, error) {
+	return s.repo.MarkOverdueAsMissing(time.Now())
+}
+
+// GetVersions retrieves the version history of a submission
+func (s *studentHomeworkService) GetVersions(id uint) ([]SubmissionVersionResponse, error) {
+	submission, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("submission not found: %w", err)
+	}
+
+	versions, err := s.repo.GetVersions(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submission versions: %w", err)
+	}
+
+	responses := make([]SubmissionVersionResponse, len(versions))
+	for i, version := range versions {
+		responses[i] = *s.toVersionResponseDTO(&version, submission.GradedVersionID)
+	}
+	return responses, nil
+}
+
+// ReturnForRevision returns a submission to the student, allowing one more submission
+// regardless of the due date or the homework's resubmission limit
+func (s *studentHomeworkService) ReturnForRevision(id uint) (*StudentHomeworkResponse, error) {
+	submission, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("submission not found: %w", err)
+	}
+
+	if submission.Status != HomeworkSubmitted && submission.Status != HomeworkGraded {
+		return nil, fmt.Errorf("only submitted or graded homework can be returned for revision")
+	}
+
+	submission.Status = HomeworkReturned
+
+	if err := s.repo.Update(submission); err != nil {
+		return nil, fmt.Errorf("failed to return submission: %w", err)
+	}
+
+	return s.toResponseDTO(submission), nil
+}
+
+// PublishHomework releases a homework's draft grades to students
+func (s *studentHomeworkService) PublishHomework(homeworkID uint) (*PublishResponse, error) {
+	if _, err := s.homeworkRepo.GetByID(homeworkID); err != nil {
+		return nil, fmt.Errorf("homework not found: %w", err)
+	}
+
+	published, err := s.repo.PublishByHomework(homeworkID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish grades: %w", err)
+	}
+
+	return &PublishResponse{HomeworkID: homeworkID, Published: published}, nil
 }
 
 // Delete deletes a submission
@@ -142,6 +356,28 @@ func (s *studentHomeworkService) validateSubmitRequest(req *SubmitHomeworkReques
 	return nil
 }
 
+// checkResubmissionAllowed decides whether a student may submit another version.
+// Returned submissions may always be resubmitted; otherwise resubmissions are limited
+// by the homework's due date and MaxResubmissions.
+func (s *studentHomeworkService) checkResubmissionAllowed(submission *StudentHomework, hw *homework.Homework, versions int, now time.Time) error {
+	if versions == 0 || submission.Status == HomeworkReturned {
+		return nil
+	}
+	if submission.Status == HomeworkGraded {
+		return fmt.Errorf("homework already graded; it must be returned for revision before resubmitting")
+	}
+	if hw.MaxResubmissions == 0 {
+		return fmt.Errorf("homework already submitted")
+	}
+	if now.After(hw.DueDate) {
+		return fmt.Errorf("resubmissions are not allowed after the due date")
+	}
+	if versions-1 >= hw.MaxResubmissions {
+		return fmt.Errorf("resubmission limit reached (%d allowed)", hw.MaxResubmissions)
+	}
+	return nil
+}
+
 func (s *studentHomeworkService) validateGradeRequest(req *GradeHomeworkRequest) error {
 	if req.StudentID == 0 {
 		return fmt.Errorf("student ID is required")
@@ -149,12 +385,70 @@ func (s *studentHomeworkService) validateGradeRequest(req *GradeHomeworkRequest)
 	if req.HomeworkID == 0 {
 		return fmt.Errorf("homework ID is required")
 	}
-	if req.Score < 0 {
+	if req.Score != nil && *req.Score < 0 {
 		return fmt.Errorf("score cannot be negative")
 	}
 	return nil
 }
 
+// scoreRubric checks that every criterion of the rubric received exactly one valid level
+// and returns the resulting criterion scores with their total
+func (s *studentHomeworkService) scoreRubric(rb *rubric.Rubric, grades []CriterionGradeRequest) ([]CriterionScore, float64, error) {
+	criteria := make(map[uint]*rubric.RubricCriterion, len(rb.Criteria))
+	for i := range rb.Criteria {
+		criteria[rb.Criteria[i].ID] = &rb.Criteria[i]
+	}
+
+	scores := make([]CriterionScore, 0, len(grades))
+	graded := make(map[uint]bool, len(grades))
+	var total float64
+
+	for _, g := range grades {
+		criterion, ok := criteria[g.CriterionID]
+		if !ok {
+			return nil, 0, fmt.Errorf("criterion %d is not part of rubric %d", g.CriterionID, rb.ID)
+		}
+		if graded[g.CriterionID] {
+			return nil, 0, fmt.Errorf("criterion %d graded more than once", g.CriterionID)
+		}
+
+		var level *rubric.RubricLevel
+		for i := range criterion.Levels {
+			if criterion.Levels[i].ID == g.LevelID {
+				level = &criterion.Levels[i]
+				break
+			}
+		}
+		if level == nil {
+			return nil, 0, fmt.Errorf("level %d is not a level of criterion %q", g.LevelID, criterion.Name)
+		}
+
+		graded[g.CriterionID] = true
+		total += level.Points
+		scores = append(scores, CriterionScore{
+			CriterionID: criterion.ID,
+			LevelID:     level.ID,
+			Points:      level.Points,
+			Comment:     g.Comment,
+		})
+	}
+
+	if len(graded) != len(criteria) {
+		return nil, 0, fmt.Errorf("all %d rubric criteria must be graded", len(criteria))
+	}
+
+	return scores, total, nil
+}
+
+// scaleScore converts rubric points to the homework's score range, rounded to two decimals,
+// so rubric-graded and score-graded homework are out of the same maximum
+func scaleScore(points, maxPoints, maxScore float64) float64 {
+	if maxPoints <= 0 {
+		return 0
+	}
+	return math.Round(points/maxPoints*maxScore*100) / 100
+}
+
 // DTO mapping methods
 func (s *studentHomeworkService) toResponseDTO(submission *StudentHomework) *StudentHomeworkResponse {
 	resp := &StudentHomeworkResponse{
@@ -164,6 +458,11 @@ func (s *studentHomeworkService) toResponseDTO(submission *StudentHomework) *Stu
 		Status:     string(submission.Status),
 		CreatedAt:  submission.CreatedAt,
 		UpdatedAt:  submission.UpdatedAt,
+
+		GradedVersionID:  submission.GradedVersionID,
+		Feedback:         submission.Feedback,
+		GradeStatus:      string(submission.GradeStatus),
+		GradePublishedAt: submission.GradePublishedAt,
 	}
 
 	if submission.SubmissionDate != nil {
@@ -178,6 +477,53 @@ func (s *studentHomeworkService) toResponseDTO(submission *StudentHomework) *Stu
 	return resp
 }
 
+// toDetailedResponseDTO maps a submission and attaches its rubric breakdown when the homework uses a rubric
+func (s *studentHomeworkService) toDetailedResponseDTO(submission *StudentHomework, hw *homework.Homework) (*StudentHomeworkResponse, error) {
+	resp := s.toResponseDTO(submission)
+	if hw.RubricID == nil {
+		return resp, nil
+	}
+
+	rb, err := s.rubricRepo.GetByID(*hw.RubricID)
+	if err != nil {
+		return nil, fmt.Errorf("rubric not found: %w", err)
+	}
+
+	scores, err := s.repo.GetCriterionScores(submission.ID)
+	if err != nil {
+		return nil, err
+	}
+	byCriterion := make(map[uint]CriterionScore, len(scores))
+	for _, score := range scores {
+		byCriterion[score.CriterionID] = score
+	}
+
+	breakdown := &RubricBreakdownResponse{
+		RubricID:  rb.ID,
+		MaxPoints: rb.MaxPoints(),
+		Criteria:  make([]CriterionScoreResponse, 0, len(rb.Criteria)),
+	}
+	for _, criterion := range rb.Criteria {
+		score, ok := byCriterion[criterion.ID]
+		if !ok {
+			continue
+		}
+		breakdown.TotalPoints += score.Points
+		breakdown.Criteria = append(breakdown.Criteria, CriterionScoreResponse{
+			CriterionID:   criterion.ID,
+			CriterionName: criterion.Name,
+			LevelID:       score.LevelID,
+			LevelLabel:    score.Level.Label,
+			Points:        score.Points,
+			MaxPoints:     criterion.MaxPoints(),
+			Comment:       score.Comment,
+		})
+	}
+	resp.Rubric = breakdown
+
+	return resp, nil
+}
+
 func (s *studentHomeworkService) toResponseDTOList(submissions []StudentHomework) []StudentHomeworkResponse {
 	responses := make([]StudentHomeworkResponse, len(submissions))
 	for i, submission := range submissions {
@@ -185,3 +531,45 @@ func (s *studentHomeworkService) toResponseDTOList(submissions []StudentHomework
 	}
 	return responses
 }
+
+// toStudentResponseDTOList maps submissions for a student-facing view; draft grades are left out
+func (s *studentHomeworkService) toStudentResponseDTOList(submissions []StudentHomework) []StudentHomeworkResponse {
+	responses := s.toResponseDTOList(submissions)
+	for i := range responses {
+		hideDraftGrade(&responses[i])
+	}
+	return responses
+}
+
+// hideDraftGrade clears the score, feedback and rubric breakdown of a grade that is not published yet
+func hideDraftGrade(resp *StudentHomeworkResponse) {
+	if resp.GradeStatus != string(GradeDraft) {
+		return
+	}
+	resp.Score = nil
+	resp.Feedback = ""
+	resp.Rubric = nil
+}
+
+func (s *studentHomeworkService) toVersionResponseDTO(version *SubmissionVersion, gradedVersionID *uint) *SubmissionVersionResponse {
+	resp := &SubmissionVersionResponse{
+		ID:            version.ID,
+		VersionNumber: version.VersionNumber,
+		SubmittedAt:   version.SubmittedAt,
+		Content:       version.Content,
+		Attachments:   make([]AttachmentResponse, len(version.Attachments)),
+		IsGraded:      gradedVersionID != nil && *gradedVersionID == version.ID,
+	}
+
+	for i, a := range version.Attachments {
+		resp.Attachments[i] = AttachmentResponse{
+			ID:          a.ID,
+			FileName:    a.FileName,
+			FileURL:     a.FileURL,
+			ContentType: a.ContentType,
+			SizeBytes:   a.SizeBytes,
+		}
+	}
+
+	return resp
+}
@@ -0,0 +1,240 @@
+package substitute
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"school_management/internal/admin"
+	"school_management/internal/export"
+	"school_management/internal/modules/department"
+)
+
+// SubstituteController handles HTTP requests for teacher leave and substitutes
+type SubstituteController struct {
+	service    SubstituteService
+	adminToken string
+}
+
+// NewSubstituteController creates a new substitute controller. A request with adminToken in the
+// X-Admin-Token header may review any leave.
+func NewSubstituteController(service SubstituteService, adminToken string) *SubstituteController {
+	return &SubstituteController{service: service, adminToken: adminToken}
+}
+
+// CreateLeave records a leave request
+func (c *SubstituteController) CreateLeave(ctx *gin.Context) {
+	var req CreateLeaveRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.CreateLeave(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// GetLeaveByID retrieves a leave request with its coverages
+func (c *SubstituteController) GetLeaveByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetLeaveByID(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetLeaves retrieves leave requests, filtered by ?teacher_id and ?status
+func (c *SubstituteController) GetLeaves(ctx *gin.Context) {
+	filter := LeaveFilter{Status: LeaveStatus(ctx.Query("status"))}
+	switch filter.Status {
+	case "", LeavePending, LeaveApproved, LeaveRejected, LeaveCancelled:
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid status"})
+		return
+	}
+	if raw := ctx.Query("teacher_id"); raw != "" {
+		teacherID, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid teacher ID"})
+			return
+		}
+		id := uint(teacherID)
+		filter.TeacherID = &id
+	}
+
+	resp, err := c.service.GetLeaves(filter)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// ReviewLeave approves or rejects a pending leave request
+func (c *SubstituteController) ReviewLeave(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	var req ReviewLeaveRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.ReviewLeave(uint(id), &req, admin.Is(ctx, c.adminToken))
+	if errors.Is(err, department.ErrNotHead) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// CancelLeave withdraws a leave request and removes its coverages
+func (c *SubstituteController) CancelLeave(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.CancelLeave(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// SuggestSubstitutes lists teachers free to cover the absent teacher's courses, or only ?course_id
+func (c *SubstituteController) SuggestSubstitutes(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+	var courseID *uint
+	if raw := ctx.Query("course_id"); raw != "" {
+		parsed, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid course ID"})
+			return
+		}
+		cid := uint(parsed)
+		courseID = &cid
+	}
+
+	resp, err := c.service.SuggestSubstitutes(uint(id), courseID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// CreateCoverage hands one of the absent teacher's courses to a substitute
+func (c *SubstituteController) CreateCoverage(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	var req CreateCoverageRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.CreateCoverage(uint(id), &req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// DeleteCoverage removes a coverage
+func (c *SubstituteController) DeleteCoverage(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	if err := c.service.DeleteCoverage(uint(id)); err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "coverage deleted successfully"})
+}
+
+// GetCoveragesBySubstitute retrieves the courses a teacher covers
+func (c *SubstituteController) GetCoveragesBySubstitute(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetCoveragesBySubstitute(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// RegisterRoutes registers leave and substitute routes
+func (c *SubstituteController) RegisterRoutes(rg *gin.RouterGroup) {
+	leave := rg.Group("/leave-requests")
+	{
+		leave.POST("", c.CreateLeave)
+		leave.GET("", c.GetLeaves)
+		leave.GET("/:id", c.GetLeaveByID)
+		leave.POST("/:id/review", c.ReviewLeave)
+		leave.POST("/:id/cancel", c.CancelLeave)
+
+		// Substitutes
+		leave.GET("/:id/substitutes", c.SuggestSubstitutes)
+		leave.POST("/:id/coverages", c.CreateCoverage)
+	}
+	rg.DELETE("/coverages/:id", c.DeleteCoverage)
+	rg.GET("/teachers/:id/coverages", c.GetCoveragesBySubstitute)
+}
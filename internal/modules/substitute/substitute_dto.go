@@ -0,0 +1,78 @@
+package substitute
+
+import "time"
+
+// CreateLeaveRequest represents the request body for requesting leave
+type CreateLeaveRequest struct {
+	TeacherID uint   `json:"teacher_id" binding:"required"`
+	Type      string `json:"type" binding:"required,oneof=sick personal professional other"`
+	StartDate string `json:"start_date" binding:"required"` // Format: YYYY-MM-DD
+	EndDate   string `json:"end_date" binding:"required"`   // Format: YYYY-MM-DD, inclusive
+	Reason    string `json:"reason" binding:"omitempty,max=1000"`
+}
+
+// ReviewLeaveRequest represents the request body for approving or rejecting leave
+type ReviewLeaveRequest struct {
+	ReviewerID uint   `json:"reviewer_id" binding:"omitempty"` // head of the teacher's department; optional for administrators
+	Decision   string `json:"decision" binding:"required,oneof=approved rejected"`
+	Note       string `json:"note" binding:"omitempty,max=1000"`
+}
+
+// CreateCoverageRequest represents the request body for handing a course to a substitute
+type CreateCoverageRequest struct {
+	CourseID     uint   `json:"course_id" binding:"required"`
+	SubstituteID uint   `json:"substitute_id" binding:"required"`
+	StartDate    string `json:"start_date" binding:"omitempty"` // YYYY-MM-DD; defaults to the leave's start
+	EndDate      string `json:"end_date" binding:"omitempty"`   // YYYY-MM-DD; defaults to the leave's end
+	Note         string `json:"note" binding:"omitempty,max=1000"`
+}
+
+// LeaveResponse represents the response body for a leave request
+type LeaveResponse struct {
+	ID           uint       `json:"id"`
+	TeacherID    uint       `json:"teacher_id"`
+	TeacherName  string     `json:"teacher_name"`
+	Type         LeaveType  `json:"type"`
+	StartDate    time.Time  `json:"start_date"`
+	EndDate      time.Time  `json:"end_date"`
+	Reason       string     `json:"reason"`
+	Status       string     `json:"status"`
+	ReviewerID   *uint      `json:"reviewer_id"`
+	ReviewerName string     `json:"reviewer_name,omitempty"`
+	ReviewedAt   *time.Time `json:"reviewed_at"`
+	DecisionNote string     `json:"decision_note"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+
+	Coverages []CoverageResponse `json:"coverages,omitempty"`
+}
+
+// CoverageResponse represents a course handed to a substitute
+type CoverageResponse struct {
+	ID             uint      `json:"id"`
+	LeaveRequestID uint      `json:"leave_request_id"`
+	CourseID       uint      `json:"course_id"`
+	CourseName     string    `json:"course_name,omitempty"`
+	SubstituteID   uint      `json:"substitute_id"`
+	SubstituteName string    `json:"substitute_name,omitempty"`
+	StartDate      time.Time `json:"start_date"`
+	EndDate        time.Time `json:"end_date"`
+	Note           string    `json:"note"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// CourseSuggestionResponse represents the teachers who could cover one of the absent teacher's courses
+type CourseSuggestionResponse struct {
+	CourseID   uint                `json:"course_id"`
+	CourseName string              `json:"course_name"`
+	Covered    bool                `json:"covered"` // already has a substitute for part of the leave
+	Candidates []CandidateResponse `json:"candidates"`
+}
+
+// CandidateResponse represents a teacher free to cover a course, least busy first
+type CandidateResponse struct {
+	TeacherID uint   `json:"teacher_id"`
+	Name      string `json:"name"`
+	Sections  int    `json:"sections"` // courses they teach themselves
+	Covering  int    `json:"covering"` // courses they already cover during the leave
+}
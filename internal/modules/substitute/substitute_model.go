@@ -0,0 +1,84 @@
+package substitute
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"school_management/internal/modules/course"
+	"school_management/internal/modules/teacher"
+)
+
+// LeaveType represents why a teacher is out
+type LeaveType string
+
+const (
+	LeaveSick         LeaveType = "sick"
+	LeavePersonal     LeaveType = "personal"
+	LeaveProfessional LeaveType = "professional" // training, conferences
+	LeaveOther        LeaveType = "other"
+)
+
+// LeaveStatus represents where a leave request is in its approval
+type LeaveStatus string
+
+const (
+	LeavePending   LeaveStatus = "pending"
+	LeaveApproved  LeaveStatus = "approved"
+	LeaveRejected  LeaveStatus = "rejected"
+	LeaveCancelled LeaveStatus = "cancelled"
+)
+
+// LeaveRequest is a teacher's request to be away for a span of days
+type LeaveRequest struct {
+	gorm.Model
+	TeacherID uint        `gorm:"not null;index" json:"teacher_id"`
+	Type      LeaveType   `gorm:"type:varchar(20);not null" json:"type"`
+	StartDate time.Time   `gorm:"type:date;not null" json:"start_date"`
+	EndDate   time.Time   `gorm:"type:date;not null" json:"end_date"` // inclusive
+	Reason    string      `gorm:"type:text" json:"reason"`
+	Status    LeaveStatus `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+
+	// Set when approved or rejected
+	ReviewerID   *uint      `json:"reviewer_id"`
+	ReviewedAt   *time.Time `json:"reviewed_at"`
+	DecisionNote string     `gorm:"type:text" json:"decision_note"`
+
+	// Belongs To relationships
+	Teacher  teacher.Teacher  `gorm:"foreignKey:TeacherID" json:"teacher,omitempty"`
+	Reviewer *teacher.Teacher `gorm:"foreignKey:ReviewerID" json:"reviewer,omitempty"`
+
+	// Has Many relationships
+	Coverages []Coverage `gorm:"foreignKey:LeaveRequestID" json:"coverages,omitempty"`
+}
+
+// TableName specifies the table name for the LeaveRequest model
+func (LeaveRequest) TableName() string {
+	return "leave_requests"
+}
+
+// Coverage hands a course to a substitute teacher for a span of days. While it lasts the
+// substitute may take the course's attendance.
+type Coverage struct {
+	gorm.Model
+	LeaveRequestID uint      `gorm:"not null;index" json:"leave_request_id"`
+	CourseID       uint      `gorm:"not null;index" json:"course_id"`
+	SubstituteID   uint      `gorm:"not null;index" json:"substitute_id"`
+	StartDate      time.Time `gorm:"type:date;not null" json:"start_date"`
+	EndDate        time.Time `gorm:"type:date;not null" json:"end_date"` // inclusive
+	Note           string    `gorm:"type:text" json:"note"`
+
+	// Belongs To relationships
+	Course     course.Course   `gorm:"foreignKey:CourseID" json:"course,omitempty"`
+	Substitute teacher.Teacher `gorm:"foreignKey:SubstituteID" json:"substitute,omitempty"`
+}
+
+// TableName specifies the table name for the Coverage model
+func (Coverage) TableName() string {
+	return "course_coverages"
+}
+
+// Covers reports whether the coverage includes a date
+func (c *Coverage) Covers(date time.Time) bool {
+	return !date.Before(c.StartDate) && !date.After(c.EndDate)
+}
@@ -0,0 +1,174 @@
+package substitute
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LeaveFilter narrows a leave request listing
+type LeaveFilter struct {
+	TeacherID *uint
+	Status    LeaveStatus
+}
+
+// SubstituteRepository defines the interface for leave and coverage data access
+type SubstituteRepository interface {
+	// Leave requests
+	CreateLeave(leave *LeaveRequest) error
+	GetLeaveByID(id uint) (*LeaveRequest, error)
+	GetLeaves(filter LeaveFilter) ([]LeaveRequest, error)
+	UpdateLeave(leave *LeaveRequest) error
+	CancelLeave(leave *LeaveRequest) error
+	GetOverlappingLeaves(teacherIDs []uint, start, end time.Time, statuses []LeaveStatus) ([]LeaveRequest, error)
+
+	// Coverages
+	CreateCoverage(coverage *Coverage) error
+	GetCoverageByID(id uint) (*Coverage, error)
+	GetCoveragesBySubstitute(substituteID uint) ([]Coverage, error)
+	GetOverlappingCoverages(courseIDs, substituteIDs []uint, start, end time.Time) ([]Coverage, error)
+	DeleteCoverage(id uint) error
+}
+
+// substituteRepository implements SubstituteRepository
+type substituteRepository struct {
+	db *gorm.DB
+}
+
+// NewSubstituteRepository creates a new substitute repository with dependency injection
+func NewSubstituteRepository(db *gorm.DB) SubstituteRepository {
+	return &substituteRepository{db: db}
+}
+
+// CreateLeave creates a leave request
+func (r *substituteRepository) CreateLeave(leave *LeaveRequest) error {
+	if err := r.db.Omit(clause.Associations).Create(leave).Error; err != nil {
+		return fmt.Errorf("failed to create leave request: %w", err)
+	}
+	return nil
+}
+
+// GetLeaveByID retrieves a leave request with its teacher, reviewer and coverages
+func (r *substituteRepository) GetLeaveByID(id uint) (*LeaveRequest, error) {
+	var leave LeaveRequest
+	if err := r.db.Preload("Teacher").Preload("Reviewer").
+		Preload("Coverages", func(db *gorm.DB) *gorm.DB { return db.Order("start_date ASC") }).
+		Preload("Coverages.Course").Preload("Coverages.Substitute").
+		First(&leave, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get leave request: %w", err)
+	}
+	return &leave, nil
+}
+
+// GetLeaves retrieves leave requests, latest start first
+func (r *substituteRepository) GetLeaves(filter LeaveFilter) ([]LeaveRequest, error) {
+	var leaves []LeaveRequest
+	query := r.db.Preload("Teacher")
+	if filter.TeacherID != nil {
+		query = query.Where("teacher_id = ?", *filter.TeacherID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if err := query.Order("start_date DESC").Find(&leaves).Error; err != nil {
+		return nil, fmt.Errorf("failed to get leave requests: %w", err)
+	}
+	return leaves, nil
+}
+
+// UpdateLeave updates a leave request's own fields
+func (r *substituteRepository) UpdateLeave(leave *LeaveRequest) error {
+	if err := r.db.Omit(clause.Associations).Save(leave).Error; err != nil {
+		return fmt.Errorf("failed to update leave request: %w", err)
+	}
+	return nil
+}
+
+// CancelLeave saves a cancelled leave request and removes its coverages in one transaction
+func (r *substituteRepository) CancelLeave(leave *LeaveRequest) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(leave).Error; err != nil {
+			return fmt.Errorf("failed to update leave request: %w", err)
+		}
+		if err := tx.Where("leave_request_id = ?", leave.ID).Delete(&Coverage{}).Error; err != nil {
+			return fmt.Errorf("failed to remove coverages: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetOverlappingLeaves retrieves the given teachers' leave requests in any of the statuses
+// that share at least one day with [start, end]
+func (r *substituteRepository) GetOverlappingLeaves(teacherIDs []uint, start, end time.Time, statuses []LeaveStatus) ([]LeaveRequest, error) {
+	var leaves []LeaveRequest
+	if len(teacherIDs) == 0 {
+		return leaves, nil
+	}
+	if err := r.db.Where("teacher_id IN ? AND status IN ?", teacherIDs, statuses).
+		Where("start_date <= ? AND end_date >= ?", end, start).
+		Find(&leaves).Error; err != nil {
+		return nil, fmt.Errorf("failed to get overlapping leave: %w", err)
+	}
+	return leaves, nil
+}
+
+// CreateCoverage creates a coverage
+func (r *substituteRepository) CreateCoverage(coverage *Coverage) error {
+	if err := r.db.Omit(clause.Associations).Create(coverage).Error; err != nil {
+		return fmt.Errorf("failed to create coverage: %w", err)
+	}
+	return nil
+}
+
+// GetCoverageByID retrieves a coverage with its course and substitute
+func (r *substituteRepository) GetCoverageByID(id uint) (*Coverage, error) {
+	var coverage Coverage
+	if err := r.db.Preload("Course").Preload("Substitute").First(&coverage, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get coverage: %w", err)
+	}
+	return &coverage, nil
+}
+
+// GetCoveragesBySubstitute retrieves the courses a teacher covers, latest first
+func (r *substituteRepository) GetCoveragesBySubstitute(substituteID uint) ([]Coverage, error) {
+	var coverages []Coverage
+	if err := r.db.Preload("Course").Preload("Substitute").
+		Where("substitute_id = ?", substituteID).
+		Order("start_date DESC").
+		Find(&coverages).Error; err != nil {
+		return nil, fmt.Errorf("failed to get coverages: %w", err)
+	}
+	return coverages, nil
+}
+
+// GetOverlappingCoverages retrieves coverages of any of the courses or by any of the substitutes
+// that share at least one day with [start, end]
+func (r *substituteRepository) GetOverlappingCoverages(courseIDs, substituteIDs []uint, start, end time.Time) ([]Coverage, error) {
+	var coverages []Coverage
+	if len(courseIDs) == 0 && len(substituteIDs) == 0 {
+		return coverages, nil
+	}
+	query := r.db.Where("start_date <= ? AND end_date >= ?", end, start)
+	switch {
+	case len(courseIDs) > 0 && len(substituteIDs) > 0:
+		query = query.Where("course_id IN ? OR substitute_id IN ?", courseIDs, substituteIDs)
+	case len(courseIDs) > 0:
+		query = query.Where("course_id IN ?", courseIDs)
+	default:
+		query = query.Where("substitute_id IN ?", substituteIDs)
+	}
+	if err := query.Find(&coverages).Error; err != nil {
+		return nil, fmt.Errorf("failed to get overlapping coverages: %w", err)
+	}
+	return coverages, nil
+}
+
+// DeleteCoverage soft deletes a coverage
+func (r *substituteRepository) DeleteCoverage(id uint) error {
+	if err := r.db.Delete(&Coverage{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete coverage: %w", err)
+	}
+	return nil
+}
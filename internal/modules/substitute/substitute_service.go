@@ -0,0 +1,611 @@
+package substitute
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"school_management/internal/modules/course"
+	"school_management/internal/modules/department"
+	"school_management/internal/modules/teacher"
+)
+
+// SubstituteService defines the business logic interface for teacher leave and substitutes
+type SubstituteService interface {
+	CreateLeave(req *CreateLeaveRequest) (*LeaveResponse, error)
+	GetLeaveByID(id uint) (*LeaveResponse, error)
+	GetLeaves(filter LeaveFilter) ([]LeaveResponse, error)
+	ReviewLeave(id uint, req *ReviewLeaveRequest, asAdmin bool) (*LeaveResponse, error)
+	CancelLeave(id uint) (*LeaveResponse, error)
+	SuggestSubstitutes(leaveID uint, courseID *uint) ([]CourseSuggestionResponse, error)
+	CreateCoverage(leaveID uint, req *CreateCoverageRequest) (*CoverageResponse, error)
+	GetCoveragesBySubstitute(teacherID uint) ([]CoverageResponse, error)
+	DeleteCoverage(id uint) error
+	CheckAttendanceTaker(teacherID, courseID uint, date time.Time) error
+}
+
+// substituteService implements SubstituteService
+type substituteService struct {
+	repo        SubstituteRepository
+	teacherRepo teacher.TeacherRepository
+	courseRepo  course.CourseRepository
+	deptService department.DepartmentService
+}
+
+// NewSubstituteService creates a new substitute service with DI
+func NewSubstituteService(repo SubstituteRepository, teacherRepo teacher.TeacherRepository, courseRepo course.CourseRepository, deptService department.DepartmentService) SubstituteService {
+	return &substituteService{repo: repo, teacherRepo: teacherRepo, courseRepo: courseRepo, deptService: deptService}
+}
+
+// commitment is a weekly period a candidate substitute already teaches between two dates
+type commitment struct {
+	period       course.ClassPeriod
+	academicYear string // of the candidate's own course; empty for a coverage
+	start, end   time.Time
+}
+
+// schedule is what a candidate substitute is already doing while the leave lasts
+type schedule struct {
+	onLeave     bool
+	sections    int
+	covering    int
+	commitments []commitment
+}
+
+// CreateLeave records a teacher's leave request
+func (s *substituteService) CreateLeave(req *CreateLeaveRequest) (*LeaveResponse, error) {
+	// Validate
+	start, end, err := parseSpan(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.teacherRepo.GetByID(req.TeacherID); err != nil {
+		return nil, fmt.Errorf("teacher not found: %w", err)
+	}
+	overlapping, err := s.repo.GetOverlappingLeaves([]uint{req.TeacherID}, start, end, []LeaveStatus{LeavePending, LeaveApproved})
+	if err != nil {
+		return nil, err
+	}
+	if len(overlapping) > 0 {
+		return nil, fmt.Errorf("teacher already has %s leave request %d for those dates", overlapping[0].Status, overlapping[0].ID)
+	}
+
+	// Map DTO to Model
+	leave := &LeaveRequest{
+		TeacherID: req.TeacherID,
+		Type:      LeaveType(req.Type),
+		StartDate: start,
+		EndDate:   end,
+		Reason:    req.Reason,
+		Status:    LeavePending,
+	}
+
+	// Create via repository
+	if err := s.repo.CreateLeave(leave); err != nil {
+		return nil, err
+	}
+	return s.GetLeaveByID(leave.ID)
+}
+
+// GetLeaveByID retrieves a leave request with its coverages
+func (s *substituteService) GetLeaveByID(id uint) (*LeaveResponse, error) {
+	leave, err := s.repo.GetLeaveByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("leave request not found: %w", err)
+	}
+	return s.toLeaveResponseDTO(leave), nil
+}
+
+// GetLeaves retrieves leave requests
+func (s *substituteService) GetLeaves(filter LeaveFilter) ([]LeaveResponse, error) {
+	leaves, err := s.repo.GetLeaves(filter)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]LeaveResponse, len(leaves))
+	for i := range leaves {
+		responses[i] = *s.toLeaveResponseDTO(&leaves[i])
+	}
+	return responses, nil
+}
+
+// ReviewLeave approves or rejects a pending leave request. The reviewer must head the teacher's
+// department, or one above it, unless an administrator reviews it, naming a reviewer or not.
+func (s *substituteService) ReviewLeave(id uint, req *ReviewLeaveRequest, asAdmin bool) (*LeaveResponse, error) {
+	// Get existing
+	leave, err := s.repo.GetLeaveByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("leave request not found: %w", err)
+	}
+
+	// Validate
+	if leave.Status != LeavePending {
+		return nil, fmt.Errorf("leave request is already %s", leave.Status)
+	}
+	if req.ReviewerID == 0 && !asAdmin {
+		return nil, fmt.Errorf("reviewer ID is required")
+	}
+	var reviewerID *uint
+	if req.ReviewerID != 0 {
+		if req.ReviewerID == leave.TeacherID {
+			return nil, fmt.Errorf("teachers cannot review their own leave")
+		}
+		if _, err := s.teacherRepo.GetByID(req.ReviewerID); err != nil {
+			return nil, fmt.Errorf("reviewer not found: %w", err)
+		}
+		if !asAdmin {
+			if err := s.deptService.CheckHead(req.ReviewerID, leave.Teacher.DepartmentID); err != nil {
+				return nil, err
+			}
+		}
+		reviewerID = &req.ReviewerID
+	}
+
+	// Update fields
+	now := time.Now()
+	leave.Status = LeaveStatus(req.Decision)
+	leave.ReviewerID = reviewerID
+	leave.ReviewedAt = &now
+	leave.DecisionNote = req.Note
+
+	// Save
+	if err := s.repo.UpdateLeave(leave); err != nil {
+		return nil, err
+	}
+	return s.GetLeaveByID(id)
+}
+
+// CancelLeave withdraws a pending or approved leave request and removes its coverages
+func (s *substituteService) CancelLeave(id uint) (*LeaveResponse, error) {
+	// Get existing
+	leave, err := s.repo.GetLeaveByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("leave request not found: %w", err)
+	}
+
+	// Validate
+	if leave.Status != LeavePending && leave.Status != LeaveApproved {
+		return nil, fmt.Errorf("leave request is already %s", leave.Status)
+	}
+
+	// Save
+	leave.Status = LeaveCancelled
+	if err := s.repo.CancelLeave(leave); err != nil {
+		return nil, err
+	}
+	return s.GetLeaveByID(id)
+}
+
+// SuggestSubstitutes lists, for each of the absent teacher's courses (or just one), the teachers
+// in the same department who are not on leave and have no timetable clash during the leave
+func (s *substituteService) SuggestSubstitutes(leaveID uint, courseID *uint) ([]CourseSuggestionResponse, error) {
+	leave, err := s.repo.GetLeaveByID(leaveID)
+	if err != nil {
+		return nil, fmt.Errorf("leave request not found: %w", err)
+	}
+	if leave.Status != LeavePending && leave.Status != LeaveApproved {
+		return nil, fmt.Errorf("leave request is %s", leave.Status)
+	}
+
+	courses, err := s.courseRepo.GetByTeacher(leave.TeacherID)
+	if err != nil {
+		return nil, err
+	}
+	if courseID != nil {
+		var only []course.Course
+		for _, c := range courses {
+			if c.ID == *courseID {
+				only = append(only, c)
+			}
+		}
+		if len(only) == 0 {
+			return nil, fmt.Errorf("course %d is not taught by the teacher on leave", *courseID)
+		}
+		courses = only
+	}
+
+	candidates, err := s.candidates(&leave.Teacher)
+	if err != nil {
+		return nil, err
+	}
+	candidateIDs := make([]uint, len(candidates))
+	for i, t := range candidates {
+		candidateIDs[i] = t.ID
+	}
+	schedules, err := s.schedules(candidateIDs, leave.StartDate, leave.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	courseIDs := make([]uint, len(courses))
+	for i, c := range courses {
+		courseIDs[i] = c.ID
+	}
+	periods, err := s.periodsByCourse(courseIDs)
+	if err != nil {
+		return nil, err
+	}
+	covered, err := s.repo.GetOverlappingCoverages(courseIDs, nil, leave.StartDate, leave.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	isCovered := make(map[uint]bool, len(covered))
+	for _, c := range covered {
+		isCovered[c.CourseID] = true
+	}
+
+	suggestions := make([]CourseSuggestionResponse, len(courses))
+	for i, c := range courses {
+		suggestion := CourseSuggestionResponse{
+			CourseID:   c.ID,
+			CourseName: c.Name,
+			Covered:    isCovered[c.ID],
+			Candidates: []CandidateResponse{},
+		}
+		for _, t := range candidates {
+			sched := schedules[t.ID]
+			if sched.onLeave || clash(sched, &c, periods[c.ID], leave.StartDate, leave.EndDate) != "" {
+				continue
+			}
+			suggestion.Candidates = append(suggestion.Candidates, CandidateResponse{
+				TeacherID: t.ID,
+				Name:      t.FirstName + " " + t.LastName,
+				Sections:  sched.sections,
+				Covering:  sched.covering,
+			})
+		}
+		sort.SliceStable(suggestion.Candidates, func(a, b int) bool {
+			ca, cb := suggestion.Candidates[a], suggestion.Candidates[b]
+			if ca.Covering != cb.Covering {
+				return ca.Covering < cb.Covering
+			}
+			return ca.Sections < cb.Sections
+		})
+		suggestions[i] = suggestion
+	}
+	return suggestions, nil
+}
+
+// CreateCoverage hands one of the absent teacher's courses to a substitute for part or all of an approved leave
+func (s *substituteService) CreateCoverage(leaveID uint, req *CreateCoverageRequest) (*CoverageResponse, error) {
+	leave, err := s.repo.GetLeaveByID(leaveID)
+	if err != nil {
+		return nil, fmt.Errorf("leave request not found: %w", err)
+	}
+
+	// Validate
+	if leave.Status != LeaveApproved {
+		return nil, fmt.Errorf("leave request is %s; only approved leave can be covered", leave.Status)
+	}
+	c, err := s.courseRepo.GetByID(req.CourseID)
+	if err != nil {
+		return nil, fmt.Errorf("course not found: %w", err)
+	}
+	if c.TeacherID != leave.TeacherID {
+		return nil, fmt.Errorf("course %d is not taught by the teacher on leave", c.ID)
+	}
+	start, end := leave.StartDate, leave.EndDate
+	if req.StartDate != "" || req.EndDate != "" {
+		startDate, endDate := req.StartDate, req.EndDate
+		if startDate == "" {
+			startDate = leave.StartDate.Format("2006-01-02")
+		}
+		if endDate == "" {
+			endDate = leave.EndDate.Format("2006-01-02")
+		}
+		if start, end, err = parseSpan(startDate, endDate); err != nil {
+			return nil, err
+		}
+		if start.Before(leave.StartDate) || end.After(leave.EndDate) {
+			return nil, fmt.Errorf("coverage must fall within the leave (%s to %s)",
+				leave.StartDate.Format("2006-01-02"), leave.EndDate.Format("2006-01-02"))
+		}
+	}
+	existing, err := s.repo.GetOverlappingCoverages([]uint{c.ID}, nil, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) > 0 {
+		return nil, fmt.Errorf("course is already covered from %s to %s",
+			existing[0].StartDate.Format("2006-01-02"), existing[0].EndDate.Format("2006-01-02"))
+	}
+	if err := s.checkSubstitute(&leave.Teacher, req.SubstituteID, c, start, end); err != nil {
+		return nil, err
+	}
+
+	// Map DTO to Model
+	coverage := &Coverage{
+		LeaveRequestID: leave.ID,
+		CourseID:       c.ID,
+		SubstituteID:   req.SubstituteID,
+		StartDate:      start,
+		EndDate:        end,
+		Note:           req.Note,
+	}
+
+	// Create via repository
+	if err := s.repo.CreateCoverage(coverage); err != nil {
+		return nil, err
+	}
+	created, err := s.repo.GetCoverageByID(coverage.ID)
+	if err != nil {
+		return nil, fmt.Errorf("coverage not found: %w", err)
+	}
+	return s.toCoverageResponseDTO(created), nil
+}
+
+// GetCoveragesBySubstitute retrieves the courses a teacher covers or has covered
+func (s *substituteService) GetCoveragesBySubstitute(teacherID uint) ([]CoverageResponse, error) {
+	coverages, err := s.repo.GetCoveragesBySubstitute(teacherID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toCoverageResponseDTOList(coverages), nil
+}
+
+// DeleteCoverage ends a coverage early by removing it
+func (s *substituteService) DeleteCoverage(id uint) error {
+	if _, err := s.repo.GetCoverageByID(id); err != nil {
+		return fmt.Errorf("coverage not found: %w", err)
+	}
+	return s.repo.DeleteCoverage(id)
+}
+
+// CheckAttendanceTaker allows the course's own teacher, or a substitute covering the course on
+// that date, to take its attendance
+func (s *substituteService) CheckAttendanceTaker(teacherID, courseID uint, date time.Time) error {
+	c, err := s.courseRepo.GetByID(courseID)
+	if err != nil {
+		return fmt.Errorf("course not found: %w", err)
+	}
+	if c.TeacherID == teacherID {
+		return nil
+	}
+
+	coverages, err := s.repo.GetOverlappingCoverages([]uint{courseID}, nil, date, date)
+	if err != nil {
+		return err
+	}
+	for _, coverage := range coverages {
+		if coverage.SubstituteID == teacherID {
+			return nil
+		}
+	}
+	return fmt.Errorf("teacher %d does not teach or cover course %d on %s", teacherID, courseID, date.Format("2006-01-02"))
+}
+
+// candidates retrieves the absent teacher's department colleagues
+func (s *substituteService) candidates(absent *teacher.Teacher) ([]teacher.Teacher, error) {
+	colleagues, err := s.teacherRepo.GetByDepartment(absent.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]teacher.Teacher, 0, len(colleagues))
+	for _, t := range colleagues {
+		if t.ID != absent.ID {
+			candidates = append(candidates, t)
+		}
+	}
+	return candidates, nil
+}
+
+// checkSubstitute explains why a teacher cannot cover a course between two dates, if they cannot
+func (s *substituteService) checkSubstitute(absent *teacher.Teacher, substituteID uint, c *course.Course, start, end time.Time) error {
+	if substituteID == absent.ID {
+		return fmt.Errorf("teachers cannot cover their own courses")
+	}
+	sub, err := s.teacherRepo.GetByID(substituteID)
+	if err != nil {
+		return fmt.Errorf("substitute not found: %w", err)
+	}
+	if sub.DepartmentID != absent.DepartmentID {
+		return fmt.Errorf("substitute must be from the same department as the teacher on leave")
+	}
+
+	schedules, err := s.schedules([]uint{substituteID}, start, end)
+	if err != nil {
+		return err
+	}
+	sched := schedules[substituteID]
+	if sched.onLeave {
+		return fmt.Errorf("substitute is on leave during the coverage")
+	}
+	periods, err := s.periodsByCourse([]uint{c.ID})
+	if err != nil {
+		return err
+	}
+	if reason := clash(sched, c, periods[c.ID], start, end); reason != "" {
+		return fmt.Errorf("substitute has a timetable clash: %s", reason)
+	}
+	return nil
+}
+
+// schedules gathers each candidate's approved leave, own courses and other coverages between two dates
+func (s *substituteService) schedules(teacherIDs []uint, start, end time.Time) (map[uint]*schedule, error) {
+	schedules := make(map[uint]*schedule, len(teacherIDs))
+	for _, id := range teacherIDs {
+		schedules[id] = &schedule{}
+	}
+	if len(teacherIDs) == 0 {
+		return schedules, nil
+	}
+
+	leaves, err := s.repo.GetOverlappingLeaves(teacherIDs, start, end, []LeaveStatus{LeaveApproved})
+	if err != nil {
+		return nil, err
+	}
+	for _, leave := range leaves {
+		schedules[leave.TeacherID].onLeave = true
+	}
+
+	// Own courses run for the whole span; their timetable only clashes within the same academic year
+	type owned struct {
+		teacherID    uint
+		academicYear string
+	}
+	owners := make(map[uint]owned)
+	for _, id := range teacherIDs {
+		courses, err := s.courseRepo.GetByTeacher(id)
+		if err != nil {
+			return nil, err
+		}
+		schedules[id].sections = len(courses)
+		for _, c := range courses {
+			owners[c.ID] = owned{teacherID: id, academicYear: c.AcademicYear}
+		}
+	}
+
+	coverages, err := s.repo.GetOverlappingCoverages(nil, teacherIDs, start, end)
+	if err != nil {
+		return nil, err
+	}
+	courseIDs := make([]uint, 0, len(owners)+len(coverages))
+	for id := range owners {
+		courseIDs = append(courseIDs, id)
+	}
+	for _, coverage := range coverages {
+		schedules[coverage.SubstituteID].covering++
+		courseIDs = append(courseIDs, coverage.CourseID)
+	}
+
+	periods, err := s.periodsByCourse(courseIDs)
+	if err != nil {
+		return nil, err
+	}
+	for courseID, owner := range owners {
+		for _, p := range periods[courseID] {
+			schedules[owner.teacherID].commitments = append(schedules[owner.teacherID].commitments,
+				commitment{period: p, academicYear: owner.academicYear, start: start, end: end})
+		}
+	}
+	for _, coverage := range coverages {
+		for _, p := range periods[coverage.CourseID] {
+			schedules[coverage.SubstituteID].commitments = append(schedules[coverage.SubstituteID].commitments,
+				commitment{period: p, start: coverage.StartDate, end: coverage.EndDate})
+		}
+	}
+	return schedules, nil
+}
+
+// periodsByCourse retrieves the weekly timetable of each course
+func (s *substituteService) periodsByCourse(courseIDs []uint) (map[uint][]course.ClassPeriod, error) {
+	periods, err := s.courseRepo.GetPeriods(courseIDs)
+	if err != nil {
+		return nil, err
+	}
+	byCourse := make(map[uint][]course.ClassPeriod)
+	for _, p := range periods {
+		byCourse[p.CourseID] = append(byCourse[p.CourseID], p)
+	}
+	return byCourse, nil
+}
+
+// clash describes the first time a schedule's commitments meet at the same time as the covered
+// course's periods between two dates, or returns "" when there is none
+func clash(sched *schedule, c *course.Course, periods []course.ClassPeriod, start, end time.Time) string {
+	for i := range periods {
+		for _, commit := range sched.commitments {
+			if commit.period.CourseID == c.ID {
+				continue
+			}
+			if commit.academicYear != "" && c.AcademicYear != "" && commit.academicYear != c.AcademicYear {
+				continue
+			}
+			from, to := later(start, commit.start), earlier(end, commit.end)
+			if from.After(to) || !occursBetween(periods[i].Weekday, from, to) || !periods[i].Overlaps(&commit.period) {
+				continue
+			}
+			return fmt.Sprintf("course %d meets %s %s-%s", commit.period.CourseID, commit.period.Weekday,
+				course.FormatClock(commit.period.StartMinute), course.FormatClock(commit.period.EndMinute))
+		}
+	}
+	return ""
+}
+
+// occursBetween reports whether a weekday falls on any date in [from, to]
+func occursBetween(day time.Weekday, from, to time.Time) bool {
+	for d := from; !d.After(to) && d.Before(from.AddDate(0, 0, 7)); d = d.AddDate(0, 0, 1) {
+		if d.Weekday() == day {
+			return true
+		}
+	}
+	return false
+}
+
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earlier(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// parseSpan parses an inclusive YYYY-MM-DD date range
+func parseSpan(startDate, endDate string) (time.Time, time.Time, error) {
+	start, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start date format (use YYYY-MM-DD): %w", err)
+	}
+	end, err := time.Parse("2006-01-02", endDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid end date format (use YYYY-MM-DD): %w", err)
+	}
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("end date cannot be before start date")
+	}
+	return start, end, nil
+}
+
+// DTO mapping methods
+func (s *substituteService) toLeaveResponseDTO(leave *LeaveRequest) *LeaveResponse {
+	resp := &LeaveResponse{
+		ID:           leave.ID,
+		TeacherID:    leave.TeacherID,
+		TeacherName:  strings.TrimSpace(leave.Teacher.FirstName + " " + leave.Teacher.LastName),
+		Type:         leave.Type,
+		StartDate:    leave.StartDate,
+		EndDate:      leave.EndDate,
+		Reason:       leave.Reason,
+		Status:       string(leave.Status),
+		ReviewerID:   leave.ReviewerID,
+		ReviewedAt:   leave.ReviewedAt,
+		DecisionNote: leave.DecisionNote,
+		CreatedAt:    leave.CreatedAt,
+		UpdatedAt:    leave.UpdatedAt,
+		Coverages:    s.toCoverageResponseDTOList(leave.Coverages),
+	}
+	if leave.Reviewer != nil {
+		resp.ReviewerName = leave.Reviewer.FirstName + " " + leave.Reviewer.LastName
+	}
+	return resp
+}
+
+func (s *substituteService) toCoverageResponseDTO(coverage *Coverage) *CoverageResponse {
+	return &CoverageResponse{
+		ID:             coverage.ID,
+		LeaveRequestID: coverage.LeaveRequestID,
+		CourseID:       coverage.CourseID,
+		CourseName:     coverage.Course.Name,
+		SubstituteID:   coverage.SubstituteID,
+		SubstituteName: strings.TrimSpace(coverage.Substitute.FirstName + " " + coverage.Substitute.LastName),
+		StartDate:      coverage.StartDate,
+		EndDate:        coverage.EndDate,
+		Note:           coverage.Note,
+		CreatedAt:      coverage.CreatedAt,
+	}
+}
+
+func (s *substituteService) toCoverageResponseDTOList(coverages []Coverage) []CoverageResponse {
+	responses := make([]CoverageResponse, len(coverages))
+	for i := range coverages {
+		responses[i] = *s.toCoverageResponseDTO(&coverages[i])
+	}
+	return responses
+}
@@ -1,10 +1,14 @@
 package teacher
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+
+	"school_management/internal/deletion"
+	"school_management/internal/export"
 )
 
 // TeacherController handles HTTP requests for teachers
@@ -57,6 +61,13 @@ func (c *TeacherController) GetAll(ctx *gin.Context) {
 	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
 	offset, _ := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
 
+	if export.Requested(ctx) {
+		export.StreamPages(ctx, offset, func(limit, offset int) (interface{}, error) {
+			return c.service.GetAll(limit, offset)
+		})
+		return
+	}
+
 	resp, err := c.service.GetAll(limit, offset)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -94,7 +105,8 @@ func (c *TeacherController) Update(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, resp)
 }
 
-// Delete deletes a teacher
+// Delete deletes a teacher; ?policy, ?reassign_to and ?dry_run decide what happens to the
+// courses and other records that still reference them
 func (c *TeacherController) Delete(ctx *gin.Context) {
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
@@ -102,12 +114,27 @@ func (c *TeacherController) Delete(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.service.Delete(uint(id)); err != nil {
+	opts, err := deletion.ParseOptions(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := c.service.Delete(uint(id), opts)
+	if errors.Is(err, deletion.ErrReferenced) || errors.Is(err, deletion.ErrConflict) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error(), "report": report})
+		return
+	}
+	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "teacher deleted successfully"})
+	if report.DryRun {
+		ctx.JSON(http.StatusOK, gin.H{"message": "dry run; nothing was deleted", "report": report})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "teacher deleted successfully", "report": report})
 }
 
 // GetByDepartment retrieves teachers by department
@@ -124,6 +151,10 @@ func (c *TeacherController) GetByDepartment(ctx *gin.Context) {
 		return
 	}
 
+	if export.Send(ctx, resp) {
+		return
+	}
+
 	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
 }
 
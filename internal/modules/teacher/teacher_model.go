@@ -10,7 +10,7 @@ type Teacher struct {
 	gorm.Model
 	FirstName    string `gorm:"not null;size:50" json:"first_name"`
 	LastName     string `gorm:"not null;size:50" json:"last_name"`
-	Email        string `gorm:"uniqueIndex;not null;size:100" json:"email"`
+	Email        string `gorm:"uniqueIndex:idx_teachers_active_email,where:deleted_at IS NULL;not null;size:100" json:"email"`
 	Phone        string `gorm:"size:20" json:"phone"`
 	DepartmentID uint   `gorm:"not null" json:"department_id"`
 
@@ -2,8 +2,11 @@ package teacher
 
 import (
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
+
+	"school_management/internal/deletion"
 )
 
 // TeacherRepository defines the interface for teacher data access
@@ -16,6 +19,8 @@ type TeacherRepository interface {
 	GetByEmail(email string) (*Teacher, error)
 	Update(teacher *Teacher) error
 	Delete(id uint) error
+	PlanDelete(id uint, opts deletion.Options) (*deletion.Plan, error)
+	DeleteWithPlan(id uint, plan *deletion.Plan) error
 }
 
 // teacherRepository implements TeacherRepository
@@ -57,7 +62,7 @@ func (r *teacherRepository) GetByIDWithDepartment(id uint) (*Teacher, error) {
 // GetAll retrieves all teachers with pagination
 func (r *teacherRepository) GetAll(limit, offset int) ([]Teacher, error) {
 	var teachers []Teacher
-	if err := r.db.Limit(limit).Offset(offset).Find(&teachers).Error; err != nil {
+	if err := r.db.Order("id").Limit(limit).Offset(offset).Find(&teachers).Error; err != nil {
 		return nil, fmt.Errorf("failed to get teachers: %w", err)
 	}
 	return teachers, nil
@@ -96,3 +101,26 @@ func (r *teacherRepository) Delete(id uint) error {
 	}
 	return nil
 }
+
+// PlanDelete works out what deleting the teacher does to the records that reference it
+func (r *teacherRepository) PlanDelete(id uint, opts deletion.Options) (*deletion.Plan, error) {
+	return deletion.Inspect(r.db, deletion.Teachers, []uint{id}, opts)
+}
+
+// DeleteWithPlan applies a delete plan and soft deletes the teacher in one transaction
+func (r *teacherRepository) DeleteWithPlan(id uint, plan *deletion.Plan) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := deletion.Lock(tx, "teachers", []uint{id}); err != nil {
+			return err
+		}
+		now := time.Now()
+		if err := plan.Apply(tx, now); err != nil {
+			return err
+		}
+		// Same timestamp as the cascaded rows, so a restore brings them back together
+		if err := tx.Model(&Teacher{}).Where("id = ?", id).Update("deleted_at", now).Error; err != nil {
+			return fmt.Errorf("failed to delete teacher: %w", err)
+		}
+		return nil
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+
+	"school_management/internal/deletion"
 )
 
 // TeacherService defines the business logic interface
@@ -14,17 +16,25 @@ type TeacherService interface {
 	GetAll(limit, offset int) ([]TeacherResponse, error)
 	GetByDepartment(deptID uint) ([]TeacherResponse, error)
 	Update(id uint, req *UpdateTeacherRequest) (*TeacherResponse, error)
-	Delete(id uint) error
+	Delete(id uint, opts deletion.Options) (*deletion.Report, error)
+}
+
+// LoadChecker rejects moving a teacher's courses to another teacher when that takes them over
+// the max-load rule. It is implemented by the workload module, which depends on teacher.
+type LoadChecker interface {
+	// CheckReassign checks that toTeacherID can take over all of fromTeacherID's courses
+	CheckReassign(fromTeacherID, toTeacherID uint) error
 }
 
 // teacherService implements TeacherService
 type teacherService struct {
-	repo TeacherRepository
+	repo  TeacherRepository
+	loads LoadChecker
 }
 
 // NewTeacherService creates a new teacher service with DI
-func NewTeacherService(repo TeacherRepository) TeacherService {
-	return &teacherService{repo: repo}
+func NewTeacherService(repo TeacherRepository, loads LoadChecker) TeacherService {
+	return &teacherService{repo: repo, loads: loads}
 }
 
 // Create creates a new teacher
@@ -135,19 +145,46 @@ func (s *teacherService) Update(id uint, req *UpdateTeacherRequest) (*TeacherRes
 	return s.toResponseDTO(teacher), nil
 }
 
-// Delete deletes a teacher
-func (s *teacherService) Delete(id uint) error {
+// Delete deletes a teacher. Courses, homerooms and other records that still reference them
+// refuse the delete, move to opts.ReassignTo, or are deleted with them, depending on opts.Policy.
+func (s *teacherService) Delete(id uint, opts deletion.Options) (*deletion.Report, error) {
 	// Check if exists
 	if _, err := s.repo.GetByID(id); err != nil {
-		return fmt.Errorf("teacher not found: %w", err)
+		return nil, fmt.Errorf("teacher not found: %w", err)
+	}
+	if opts.Policy == deletion.Reassign {
+		if opts.ReassignTo == id {
+			return nil, fmt.Errorf("cannot reassign a teacher's records to themselves")
+		}
+		if _, err := s.repo.GetByID(opts.ReassignTo); err != nil {
+			return nil, fmt.Errorf("reassign target teacher not found: %w", err)
+		}
+		if err := s.loads.CheckReassign(id, opts.ReassignTo); err != nil {
+			return nil, err
+		}
+	}
+
+	plan, err := s.repo.PlanDelete(id, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.DryRun {
+		return plan.Report(false), nil
+	}
+	if err := plan.Err(); err != nil {
+		return plan.Report(false), err
 	}
 
 	// Delete
-	if err := s.repo.Delete(id); err != nil {
-		return fmt.Errorf("failed to delete teacher: %w", err)
+	if err := s.repo.DeleteWithPlan(id, plan); err != nil {
+		if plan.Err() != nil {
+			// References added since the plan was made refuse it after all
+			return plan.Report(false), err
+		}
+		return nil, fmt.Errorf("failed to delete teacher: %w", err)
 	}
 
-	return nil
+	return plan.Report(true), nil
 }
 
 // Validation methods
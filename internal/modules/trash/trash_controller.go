@@ -0,0 +1,206 @@
+package trash
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"school_management/internal/admin"
+	"school_management/internal/export"
+	"school_management/internal/modules/department"
+)
+
+// TrashController handles HTTP requests for deleted records
+type TrashController struct {
+	service    TrashService
+	adminToken string
+}
+
+// NewTrashController creates a new trash controller. Hard deletes, purges and policy changes
+// need adminToken in the X-Admin-Token header and are disabled when it is empty.
+func NewTrashController(service TrashService, adminToken string) *TrashController {
+	return &TrashController{service: service, adminToken: adminToken}
+}
+
+// GetTrash lists deleted records, filtered by ?type, with pagination
+func (c *TrashController) GetTrash(ctx *gin.Context) {
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	offset, _ := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+	typ := ctx.Query("type")
+
+	if export.Requested(ctx) {
+		export.StreamPages(ctx, offset, func(limit, offset int) (interface{}, error) {
+			return c.service.GetTrash(typ, limit, offset)
+		})
+		return
+	}
+
+	resp, err := c.service.GetTrash(typ, limit, offset)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data":   resp,
+		"limit":  limit,
+		"offset": offset,
+		"count":  len(resp),
+	})
+}
+
+// GetPolicy retrieves the retention rule
+func (c *TrashController) GetPolicy(ctx *gin.Context) {
+	resp, err := c.service.GetPolicy()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UpdatePolicy changes the retention rule; admin only
+func (c *TrashController) UpdatePolicy(ctx *gin.Context) {
+	if !c.authorizeAdmin(ctx) {
+		return
+	}
+
+	var req UpdatePolicyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.UpdatePolicy(&req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Purge runs the retention purge now instead of waiting for the job; admin only
+func (c *TrashController) Purge(ctx *gin.Context) {
+	if !c.authorizeAdmin(ctx) {
+		return
+	}
+
+	resp, err := c.service.PurgeExpired()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error(), "data": resp})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// Restore returns a handler that restores a deleted record of the given type
+func (c *TrashController) Restore(typ string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+			return
+		}
+
+		// Checked like the matching delete's ?actor_id
+		var actorID uint64
+		if raw := ctx.Query("actor_id"); raw != "" {
+			if actorID, err = strconv.ParseUint(raw, 10, 32); err != nil {
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid actor_id"})
+				return
+			}
+		}
+
+		// The body is optional; it only carries replacement values
+		var req RestoreRequest
+		if ctx.Request.ContentLength != 0 {
+			if err := ctx.ShouldBindJSON(&req); err != nil {
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+		}
+
+		resp, err := c.service.Restore(typ, uint(id), uint(actorID), &req)
+		switch {
+		case errors.Is(err, department.ErrNotHead):
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, ErrConflict), errors.Is(err, ErrMissingParent):
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error(), "report": resp})
+			return
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		case err != nil:
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, resp)
+	}
+}
+
+// HardDelete returns a handler that permanently deletes a record of the given type and the
+// deleted records that reference it; admin only
+func (c *TrashController) HardDelete(typ string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+			return
+		}
+		if !c.authorizeAdmin(ctx) {
+			return
+		}
+
+		resp, err := c.service.HardDelete(typ, uint(id))
+		if errors.Is(err, ErrInUse) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error(), "report": resp})
+			return
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"message": typ + " permanently deleted", "report": resp})
+	}
+}
+
+// authorizeAdmin checks the X-Admin-Token header, responding 403 when it does not match
+func (c *TrashController) authorizeAdmin(ctx *gin.Context) bool {
+	if c.adminToken == "" {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "admin actions are disabled; set ADMIN_TOKEN to enable them"})
+		return false
+	}
+	if !admin.Is(ctx, c.adminToken) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "a valid X-Admin-Token header is required"})
+		return false
+	}
+	return true
+}
+
+// RegisterRoutes registers trash routes, and restore and hard delete routes for every type
+func (c *TrashController) RegisterRoutes(rg *gin.RouterGroup) {
+	trash := rg.Group("/trash")
+	{
+		trash.GET("", c.GetTrash)
+		trash.GET("/policy", c.GetPolicy)
+		trash.PUT("/policy", c.UpdatePolicy)
+		trash.POST("/purge", c.Purge)
+	}
+	for _, kind := range Kinds {
+		rg.POST("/"+kind.Resource+"/:id/restore", c.Restore(kind.Type))
+		rg.DELETE("/"+kind.Resource+"/:id/purge", c.HardDelete(kind.Type))
+	}
+}
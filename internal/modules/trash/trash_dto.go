@@ -0,0 +1,62 @@
+package trash
+
+import (
+	"time"
+
+	"school_management/internal/deletion"
+)
+
+// UpdatePolicyRequest represents the request body for changing how long deleted records are kept
+type UpdatePolicyRequest struct {
+	RetentionDays *int `json:"retention_days" binding:"required,min=0"` // 0 keeps them until purged by hand
+}
+
+// RestoreRequest represents the request body for restoring a deleted record. Values replaces
+// unique fields that an active record has taken since, e.g. {"email": "new@school.edu"}.
+type RestoreRequest struct {
+	Values map[string]string `json:"values"`
+}
+
+// PolicyResponse represents the trash retention rule
+type PolicyResponse struct {
+	RetentionDays int `json:"retention_days"`
+}
+
+// ItemResponse represents a deleted record
+type ItemResponse struct {
+	Type      string     `json:"type"`
+	ID        uint       `json:"id"`
+	Name      string     `json:"name"`
+	DeletedAt time.Time  `json:"deleted_at"`
+	PurgeAt   *time.Time `json:"purge_at"` // nil while records are kept indefinitely
+}
+
+// ConflictResponse represents an active record holding a unique value a restore needs
+type ConflictResponse struct {
+	ID     uint                   `json:"id"`
+	Fields map[string]interface{} `json:"fields"`
+}
+
+// RestoreResponse represents the outcome of a restore
+type RestoreResponse struct {
+	Type      string               `json:"type"`
+	ID        uint                 `json:"id"`
+	Restored  bool                 `json:"restored"`
+	Conflicts []ConflictResponse   `json:"conflicts,omitempty"`
+	Missing   []string             `json:"missing,omitempty"` // deleted records it points at
+	Related   []deletion.Dependent `json:"related"`           // records deleted with it and restored too
+}
+
+// HeldResponse represents a record left in the trash because active records still depend on it
+type HeldResponse struct {
+	ID         uint                 `json:"id"`
+	References []deletion.Dependent `json:"references"`
+}
+
+// PurgeResponse represents records removed for good
+type PurgeResponse struct {
+	Type    string               `json:"type,omitempty"`
+	IDs     []uint               `json:"ids"`
+	Related []deletion.Dependent `json:"related"`        // deleted records that referenced them, removed, or references cleared
+	Held    []HeldResponse       `json:"held,omitempty"` // expired records kept because active records depend on them
+}
@@ -0,0 +1,181 @@
+package trash
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"gorm.io/gorm"
+
+	"school_management/internal/deletion"
+	"school_management/internal/modules/course"
+)
+
+// DefaultRetentionDays is how long deleted records stay restorable when no policy is saved
+const DefaultRetentionDays = 30
+
+// TrashPolicy decides how long deleted records stay restorable before the purge job removes
+// them for good. Only one row is kept; 0 days keeps them until they are purged by hand.
+type TrashPolicy struct {
+	gorm.Model
+	RetentionDays int `gorm:"not null;default:30;comment:days a deleted record stays restorable, 0 to keep it" json:"retention_days"`
+}
+
+// TableName specifies the table name for the TrashPolicy model
+func (TrashPolicy) TableName() string {
+	return "trash_policies"
+}
+
+// PurgeBefore is the deletion time before which records are due for purging, or nil when
+// records are kept
+func (p *TrashPolicy) PurgeBefore(now time.Time) *time.Time {
+	if p.RetentionDays <= 0 {
+		return nil
+	}
+	cutoff := now.AddDate(0, 0, -p.RetentionDays)
+	return &cutoff
+}
+
+// Parent is a record a row points at that has to exist for the row to be restored
+type Parent struct {
+	Column string
+	Table  string
+	Label  string
+}
+
+// Kind is a type of record the trash can list, restore and purge
+type Kind struct {
+	Type       string // ?type value, e.g. student
+	Resource   string // route prefix, e.g. students
+	Table      string
+	Name       string // SQL expression naming a row in listings
+	References []deletion.Reference
+	Parents    []Parent
+	Unique     [][]string // column sets unique among rows that are not deleted
+
+	// Validators check replacement values for unique columns the way create does
+	Validators map[string]func(value string) error
+
+	// HeadedBy is the column naming the department whose head may delete the record, and so
+	// restore it; empty when anyone may
+	HeadedBy string
+}
+
+// Kinds lists every type of record the trash handles
+var Kinds = []Kind{
+	{
+		Type: "student", Resource: "students", Table: "students",
+		Name:       "first_name || ' ' || last_name",
+		References: deletion.Students,
+		Unique:     [][]string{{"email"}},
+		Validators: map[string]func(string) error{"email": validateEmail},
+	},
+	{
+		Type: "teacher", Resource: "teachers", Table: "teachers",
+		Name:       "first_name || ' ' || last_name",
+		References: deletion.Teachers,
+		Parents:    []Parent{{Column: "department_id", Table: "departments", Label: "department"}},
+		Unique:     [][]string{{"email"}},
+		Validators: map[string]func(string) error{"email": validateEmail},
+	},
+	{
+		Type: "department", Resource: "departments", Table: "departments",
+		Name:       "name",
+		References: deletion.Departments,
+		Parents:    []Parent{{Column: "parent_id", Table: "departments", Label: "parent department"}},
+	},
+	{
+		Type: "course", Resource: "courses", Table: "courses",
+		Name:       "code || ' ' || name",
+		References: deletion.Courses,
+		Parents: []Parent{
+			{Column: "department_id", Table: "departments", Label: "department"},
+			{Column: "teacher_id", Table: "teachers", Label: "teacher"},
+		},
+		Unique: [][]string{{"code", "academic_year"}},
+		Validators: map[string]func(string) error{
+			"code":          validateCourseCode,
+			"academic_year": course.ValidateAcademicYear,
+		},
+		HeadedBy: "department_id",
+	},
+	{
+		Type: "exam", Resource: "exams", Table: "exams",
+		Name:       "title",
+		References: deletion.Exams,
+		Parents:    []Parent{{Column: "course_id", Table: "courses", Label: "course"}},
+	},
+	{
+		Type: "grade", Resource: "grades", Table: "grades",
+		Name:       "'student ' || student_id || ', exam ' || exam_id",
+		References: deletion.Grades,
+		Parents: []Parent{
+			{Column: "student_id", Table: "students", Label: "student"},
+			{Column: "exam_id", Table: "exams", Label: "exam"},
+		},
+	},
+	{
+		Type: "homework", Resource: "homework", Table: "homework",
+		Name:       "title",
+		References: deletion.Homework,
+		Parents:    []Parent{{Column: "course_id", Table: "courses", Label: "course"}},
+	},
+}
+
+// KindOf finds a kind by its ?type value
+func KindOf(typ string) (*Kind, bool) {
+	for i := range Kinds {
+		if Kinds[i].Type == typ {
+			return &Kinds[i], true
+		}
+	}
+	return nil, false
+}
+
+// IsUnique reports whether the column is part of one of the kind's unique sets
+func (k *Kind) IsUnique(column string) bool {
+	for _, set := range k.Unique {
+		for _, c := range set {
+			if c == column {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// emailPattern is the email format student and teacher create accept
+var emailPattern = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+
+func validateEmail(value string) error {
+	if len(value) > 100 || !emailPattern.MatchString(value) {
+		return fmt.Errorf("invalid email format")
+	}
+	return nil
+}
+
+func validateCourseCode(value string) error {
+	if len(value) < 2 || len(value) > 20 {
+		return fmt.Errorf("course code must be 2 to 20 characters")
+	}
+	return nil
+}
+
+// Item is a deleted record
+type Item struct {
+	ID        uint
+	Name      string
+	DeletedAt time.Time
+}
+
+// Conflict is an active record holding a unique value a restore needs
+type Conflict struct {
+	ID     uint
+	Fields map[string]interface{}
+}
+
+// Held is an expired record a purge left alone because active records still depend on it
+type Held struct {
+	ID         uint
+	References []deletion.Dependent
+}
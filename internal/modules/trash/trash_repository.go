@@ -0,0 +1,272 @@
+package trash
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"school_management/internal/deletion"
+)
+
+// TrashRepository defines the interface for deleted record data access
+type TrashRepository interface {
+	GetPolicy() (*TrashPolicy, error)
+	SavePolicy(policy *TrashPolicy) error
+	GetDeleted(kind *Kind, limit, offset int) ([]Item, error)
+	GetDeletedByID(kind *Kind, id uint) (*Item, error)
+	GetExpired(kind *Kind, before time.Time) ([]uint, error)
+	Exists(kind *Kind, id uint) (bool, error)
+	GetReference(kind *Kind, id uint, column string) (uint, error)
+	GetMissingParents(kind *Kind, id uint) ([]string, error)
+	GetConflicts(kind *Kind, id uint, values map[string]string) ([]Conflict, error)
+	Restore(kind *Kind, item *Item, values map[string]string) ([]deletion.Dependent, error)
+	Purge(kind *Kind, ids []uint) ([]deletion.Dependent, []Held, error)
+}
+
+// trashRepository implements TrashRepository
+type trashRepository struct {
+	db *gorm.DB
+}
+
+// NewTrashRepository creates a new trash repository with dependency injection
+func NewTrashRepository(db *gorm.DB) TrashRepository {
+	return &trashRepository{db: db}
+}
+
+// GetPolicy retrieves the retention policy, falling back to the default when none is saved
+func (r *trashRepository) GetPolicy() (*TrashPolicy, error) {
+	var policy TrashPolicy
+	err := r.db.Order("id ASC").First(&policy).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &TrashPolicy{RetentionDays: DefaultRetentionDays}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trash policy: %w", err)
+	}
+	return &policy, nil
+}
+
+// SavePolicy creates or updates the retention policy
+func (r *trashRepository) SavePolicy(policy *TrashPolicy) error {
+	if err := r.db.Save(policy).Error; err != nil {
+		return fmt.Errorf("failed to save trash policy: %w", err)
+	}
+	return nil
+}
+
+// GetDeleted retrieves a kind's deleted records, most recently deleted first
+func (r *trashRepository) GetDeleted(kind *Kind, limit, offset int) ([]Item, error) {
+	var items []Item
+	if err := r.db.Table(kind.Table).
+		Select("id, " + kind.Name + " AS name, deleted_at").
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Scan(&items).Error; err != nil {
+		return nil, fmt.Errorf("failed to get deleted %s: %w", kind.Resource, err)
+	}
+	return items, nil
+}
+
+// GetDeletedByID retrieves one deleted record
+func (r *trashRepository) GetDeletedByID(kind *Kind, id uint) (*Item, error) {
+	var item Item
+	if err := r.db.Table(kind.Table).
+		Select("id, "+kind.Name+" AS name, deleted_at").
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Take(&item).Error; err != nil {
+		return nil, fmt.Errorf("failed to get deleted %s: %w", kind.Type, err)
+	}
+	return &item, nil
+}
+
+// GetExpired retrieves the IDs of a kind's records deleted before the given time
+func (r *trashRepository) GetExpired(kind *Kind, before time.Time) ([]uint, error) {
+	var ids []uint
+	if err := r.db.Table(kind.Table).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to get expired %s: %w", kind.Resource, err)
+	}
+	return ids, nil
+}
+
+// Exists reports whether a record exists, deleted or not
+func (r *trashRepository) Exists(kind *Kind, id uint) (bool, error) {
+	var count int64
+	if err := r.db.Table(kind.Table).Where("id = ?", id).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check %s: %w", kind.Type, err)
+	}
+	return count > 0, nil
+}
+
+// GetReference retrieves the ID a record, deleted or not, holds in a reference column
+func (r *trashRepository) GetReference(kind *Kind, id uint, column string) (uint, error) {
+	var ref uint
+	if err := r.db.Table(kind.Table).Select(column).Where("id = ?", id).Take(&ref).Error; err != nil {
+		return 0, fmt.Errorf("failed to get %s: %w", kind.Type, err)
+	}
+	return ref, nil
+}
+
+// GetMissingParents lists the records a row points at that are deleted or gone
+func (r *trashRepository) GetMissingParents(kind *Kind, id uint) ([]string, error) {
+	var missing []string
+	for _, p := range kind.Parents {
+		var count int64
+		if err := r.db.Table(kind.Table+" AS c").
+			Where("c.id = ?", id).
+			Where("c." + p.Column + " IS NULL OR EXISTS (SELECT 1 FROM " + p.Table + " AS p WHERE p.id = c." + p.Column + " AND p.deleted_at IS NULL)").
+			Count(&count).Error; err != nil {
+			return nil, fmt.Errorf("failed to check %s: %w", p.Label, err)
+		}
+		if count == 0 {
+			missing = append(missing, p.Label)
+		}
+	}
+	return missing, nil
+}
+
+// GetConflicts finds active records holding the unique values the row would have once
+// restored, its own values with the given replacements applied
+func (r *trashRepository) GetConflicts(kind *Kind, id uint, values map[string]string) ([]Conflict, error) {
+	var conflicts []Conflict
+	for _, set := range kind.Unique {
+		row := make(map[string]interface{})
+		if err := r.db.Table(kind.Table).Select(strings.Join(set, ", ")).
+			Where("id = ?", id).Take(&row).Error; err != nil {
+			return nil, fmt.Errorf("failed to get %s: %w", kind.Type, err)
+		}
+		for _, column := range set {
+			if value, ok := values[column]; ok {
+				row[column] = value
+			}
+		}
+
+		query := r.db.Table(kind.Table).Where("id <> ? AND deleted_at IS NULL", id)
+		for _, column := range set {
+			query = query.Where(column+" = ?", row[column])
+		}
+		var ids []uint
+		if err := query.Pluck("id", &ids).Error; err != nil {
+			return nil, fmt.Errorf("failed to check %s: %w", strings.Join(set, ", "), err)
+		}
+		for _, conflictID := range ids {
+			conflicts = append(conflicts, Conflict{ID: conflictID, Fields: row})
+		}
+	}
+	return conflicts, nil
+}
+
+// Restore undeletes a record with any replacement values, and the records deleted with it,
+// in one transaction
+func (r *trashRepository) Restore(kind *Kind, item *Item, values map[string]string) ([]deletion.Dependent, error) {
+	var related []deletion.Dependent
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{"deleted_at": nil, "updated_at": time.Now()}
+		for column, value := range values {
+			updates[column] = value
+		}
+		if err := tx.Table(kind.Table).Where("id = ? AND deleted_at IS NOT NULL", item.ID).
+			Updates(updates).Error; err != nil {
+			return fmt.Errorf("failed to restore %s: %w", kind.Type, err)
+		}
+
+		var err error
+		related, err = deletion.Restore(tx, kind.References, []uint{item.ID}, item.DeletedAt)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return related, nil
+}
+
+// Purge permanently deletes records, with the deleted records that reference them, in one
+// transaction. Records that active records still depend on are left alone and returned as held.
+func (r *trashRepository) Purge(kind *Kind, ids []uint) ([]deletion.Dependent, []Held, error) {
+	var related []deletion.Dependent
+	var held []Held
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var free []uint
+		for _, id := range ids {
+			subtree, _, err := withDescendants(tx, kind, []uint{id})
+			if err != nil {
+				return err
+			}
+			active, err := deletion.Active(tx, kind.References, subtree)
+			if err != nil {
+				return err
+			}
+			if len(active) > 0 {
+				held = append(held, Held{ID: id, References: active})
+			} else {
+				free = append(free, id)
+			}
+		}
+		related = []deletion.Dependent{}
+		if len(free) == 0 {
+			return nil
+		}
+
+		free, refs, err := withDescendants(tx, kind, free)
+		if err != nil {
+			return err
+		}
+		if related, err = deletion.Purge(tx, refs, free); err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM "+kind.Table+" WHERE id IN ?", free).Error; err != nil {
+			return fmt.Errorf("failed to purge %s: %w", kind.Resource, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return related, held, nil
+}
+
+// withDescendants adds the deleted rows that point at the records through a required reference
+// to their own table, such as deleted sub-departments, so they are purged with all of their own
+// references, and returns the references left to follow. Active ones are left to Active.
+func withDescendants(tx *gorm.DB, kind *Kind, ids []uint) ([]uint, []deletion.Reference, error) {
+	var refs []deletion.Reference
+	var self []deletion.Reference
+	for _, ref := range kind.References {
+		if ref.Table == kind.Table && !ref.Nullable {
+			self = append(self, ref)
+		} else {
+			refs = append(refs, ref)
+		}
+	}
+
+	seen := make(map[uint]bool)
+	for _, id := range ids {
+		seen[id] = true
+	}
+	all := ids
+	for next := ids; len(next) > 0 && len(self) > 0; {
+		var found []uint
+		for _, ref := range self {
+			var children []uint
+			if err := tx.Table(kind.Table).
+				Where(ref.Column+" IN ? AND deleted_at IS NOT NULL", next).
+				Pluck("id", &children).Error; err != nil {
+				return nil, nil, fmt.Errorf("failed to check %s: %w", ref.Label, err)
+			}
+			for _, id := range children {
+				if !seen[id] {
+					seen[id] = true
+					found = append(found, id)
+				}
+			}
+		}
+		all = append(all, found...)
+		next = found
+	}
+	return all, refs, nil
+}
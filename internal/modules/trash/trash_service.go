@@ -0,0 +1,317 @@
+package trash
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"school_management/internal/deletion"
+	"school_management/internal/modules/department"
+)
+
+// ErrConflict is returned when a restore would give a record a unique value an active one holds
+var ErrConflict = errors.New("an active record holds the same unique value")
+
+// ErrMissingParent is returned when a restore would leave a record pointing at a deleted one
+var ErrMissingParent = errors.New("a record it belongs to is deleted")
+
+// ErrInUse is returned when a hard delete finds active records that still depend on the record
+var ErrInUse = errors.New("record is still referenced by active records")
+
+// TrashService defines the business logic interface for deleted records
+type TrashService interface {
+	GetPolicy() (*PolicyResponse, error)
+	UpdatePolicy(req *UpdatePolicyRequest) (*PolicyResponse, error)
+	GetTrash(typ string, limit, offset int) ([]ItemResponse, error)
+	Restore(typ string, id, actorID uint, req *RestoreRequest) (*RestoreResponse, error)
+	HardDelete(typ string, id uint) (*PurgeResponse, error)
+	PurgeExpired() ([]PurgeResponse, error)
+}
+
+// trashService implements TrashService
+type trashService struct {
+	repo        TrashRepository
+	deptService department.DepartmentService
+}
+
+// NewTrashService creates a new trash service with DI
+func NewTrashService(repo TrashRepository, deptService department.DepartmentService) TrashService {
+	return &trashService{repo: repo, deptService: deptService}
+}
+
+// GetPolicy retrieves the retention rule
+func (s *trashService) GetPolicy() (*PolicyResponse, error) {
+	policy, err := s.repo.GetPolicy()
+	if err != nil {
+		return nil, err
+	}
+	return s.toPolicyResponseDTO(policy), nil
+}
+
+// UpdatePolicy changes how long deleted records are kept. Records already past the new
+// retention are purged on the job's next run.
+func (s *trashService) UpdatePolicy(req *UpdatePolicyRequest) (*PolicyResponse, error) {
+	// Get existing
+	policy, err := s.repo.GetPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	policy.RetentionDays = *req.RetentionDays
+
+	// Save
+	if err := s.repo.SavePolicy(policy); err != nil {
+		return nil, err
+	}
+	return s.toPolicyResponseDTO(policy), nil
+}
+
+// GetTrash lists deleted records of one type, or of every type when typ is empty, most
+// recently deleted first
+func (s *trashService) GetTrash(typ string, limit, offset int) ([]ItemResponse, error) {
+	// Validate pagination
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	kinds := Kinds
+	if typ != "" {
+		kind, err := s.kind(typ)
+		if err != nil {
+			return nil, err
+		}
+		kinds = []Kind{*kind}
+	}
+	policy, err := s.repo.GetPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	// Each type's first offset+limit rows are enough to page through the merged list
+	items := []ItemResponse{}
+	for i := range kinds {
+		found, err := s.repo.GetDeleted(&kinds[i], offset+limit, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range found {
+			items = append(items, s.toItemResponseDTO(&kinds[i], &item, policy))
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].DeletedAt.After(items[j].DeletedAt)
+	})
+
+	if offset >= len(items) {
+		return []ItemResponse{}, nil
+	}
+	items = items[offset:]
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+// Restore undeletes a record and the records that were cascade-deleted with it. It is refused
+// while a record it belongs to is still deleted, and while an active record holds one of its
+// unique values; req.Values can replace those values. An actorID other than 0 is checked the way
+// the record's delete checks it.
+func (s *trashService) Restore(typ string, id, actorID uint, req *RestoreRequest) (*RestoreResponse, error) {
+	kind, err := s.kind(typ)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate
+	if err := s.validateRestore(kind, req); err != nil {
+		return nil, err
+	}
+	item, err := s.repo.GetDeletedByID(kind, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%s %d is not in the trash: %w", kind.Type, id, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if kind.HeadedBy != "" && actorID != 0 {
+		departmentID, err := s.repo.GetReference(kind, id, kind.HeadedBy)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.deptService.CheckHead(actorID, departmentID); err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &RestoreResponse{Type: kind.Type, ID: id, Related: []deletion.Dependent{}}
+	missing, err := s.repo.GetMissingParents(kind, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(missing) > 0 {
+		resp.Missing = missing
+		return resp, fmt.Errorf("%w; restore its %s first", ErrMissingParent, strings.Join(missing, " and "))
+	}
+	conflicts, err := s.repo.GetConflicts(kind, id, req.Values)
+	if err != nil {
+		return nil, err
+	}
+	if len(conflicts) > 0 {
+		for _, c := range conflicts {
+			resp.Conflicts = append(resp.Conflicts, ConflictResponse{ID: c.ID, Fields: c.Fields})
+		}
+		return resp, fmt.Errorf("%w; pass replacement values to restore it", ErrConflict)
+	}
+
+	// Restore
+	related, err := s.repo.Restore(kind, item, req.Values)
+	if err != nil {
+		return nil, err
+	}
+	resp.Restored = true
+	resp.Related = related
+	return resp, nil
+}
+
+// HardDelete permanently deletes a record, deleted or not, together with the deleted records
+// that reference it. It is refused while active records still depend on it.
+func (s *trashService) HardDelete(typ string, id uint) (*PurgeResponse, error) {
+	kind, err := s.kind(typ)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if exists
+	exists, err := s.repo.Exists(kind, id)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s not found: %w", kind.Type, gorm.ErrRecordNotFound)
+	}
+
+	// Purge
+	related, held, err := s.repo.Purge(kind, []uint{id})
+	if err != nil {
+		return nil, err
+	}
+	if len(held) > 0 {
+		var refs []string
+		for _, d := range held[0].References {
+			refs = append(refs, fmt.Sprintf("%d %s", d.Count, d.Label))
+		}
+		resp := &PurgeResponse{Type: kind.Type, IDs: []uint{}, Related: related, Held: s.toHeldResponseDTOList(held)}
+		return resp, fmt.Errorf("%w: %s; delete or reassign them first", ErrInUse, strings.Join(refs, ", "))
+	}
+	return &PurgeResponse{Type: kind.Type, IDs: []uint{id}, Related: related}, nil
+}
+
+// PurgeExpired permanently deletes every record that has been in the trash longer than the
+// retention policy allows, along with the deleted records that reference it. Expired records
+// that active records still depend on are kept and reported as held.
+func (s *trashService) PurgeExpired() ([]PurgeResponse, error) {
+	policy, err := s.repo.GetPolicy()
+	if err != nil {
+		return nil, err
+	}
+	purged := []PurgeResponse{}
+	before := policy.PurgeBefore(time.Now())
+	if before == nil {
+		return purged, nil
+	}
+
+	for i := range Kinds {
+		ids, err := s.repo.GetExpired(&Kinds[i], *before)
+		if err != nil {
+			return purged, err
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		related, held, err := s.repo.Purge(&Kinds[i], ids)
+		if err != nil {
+			return purged, err
+		}
+		kept := make(map[uint]bool, len(held))
+		for _, h := range held {
+			kept[h.ID] = true
+		}
+		removed := []uint{}
+		for _, id := range ids {
+			if !kept[id] {
+				removed = append(removed, id)
+			}
+		}
+		purged = append(purged, PurgeResponse{Type: Kinds[i].Type, IDs: removed, Related: related, Held: s.toHeldResponseDTOList(held)})
+	}
+	return purged, nil
+}
+
+// kind looks up a record type by its ?type value
+func (s *trashService) kind(typ string) (*Kind, error) {
+	kind, ok := KindOf(typ)
+	if !ok {
+		types := make([]string, len(Kinds))
+		for i, k := range Kinds {
+			types[i] = k.Type
+		}
+		return nil, fmt.Errorf("unsupported type %q (use %s)", typ, strings.Join(types, ", "))
+	}
+	return kind, nil
+}
+
+// Validation methods
+func (s *trashService) validateRestore(kind *Kind, req *RestoreRequest) error {
+	for column, value := range req.Values {
+		if !kind.IsUnique(column) {
+			return fmt.Errorf("%s is not a unique field of a %s", column, kind.Type)
+		}
+		if strings.TrimSpace(value) == "" {
+			return fmt.Errorf("%s cannot be empty", column)
+		}
+		if validate, ok := kind.Validators[column]; ok {
+			if err := validate(value); err != nil {
+				return fmt.Errorf("%s: %w", column, err)
+			}
+		}
+	}
+	return nil
+}
+
+// DTO mapping methods
+func (s *trashService) toPolicyResponseDTO(policy *TrashPolicy) *PolicyResponse {
+	return &PolicyResponse{RetentionDays: policy.RetentionDays}
+}
+
+func (s *trashService) toItemResponseDTO(kind *Kind, item *Item, policy *TrashPolicy) ItemResponse {
+	resp := ItemResponse{
+		Type:      kind.Type,
+		ID:        item.ID,
+		Name:      item.Name,
+		DeletedAt: item.DeletedAt,
+	}
+	if policy.RetentionDays > 0 {
+		purgeAt := item.DeletedAt.AddDate(0, 0, policy.RetentionDays)
+		resp.PurgeAt = &purgeAt
+	}
+	return resp
+}
+
+func (s *trashService) toHeldResponseDTOList(held []Held) []HeldResponse {
+	if len(held) == 0 {
+		return nil
+	}
+	resp := make([]HeldResponse, len(held))
+	for i, h := range held {
+		resp[i] = HeldResponse{ID: h.ID, References: h.References}
+	}
+	return resp
+}
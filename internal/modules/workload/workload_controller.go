@@ -0,0 +1,124 @@
+package workload
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"school_management/internal/export"
+)
+
+// WorkloadController handles HTTP requests for teaching load reports
+type WorkloadController struct {
+	service WorkloadService
+}
+
+// NewWorkloadController creates a new workload controller
+func NewWorkloadController(service WorkloadService) *WorkloadController {
+	return &WorkloadController{service: service}
+}
+
+// GetByTeacher reports a teacher's load for ?academic_year, or every year when omitted
+func (c *WorkloadController) GetByTeacher(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetByTeacher(uint(id), ctx.Query("academic_year"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetByDepartment reports a department's load and its teachers' for ?academic_year
+func (c *WorkloadController) GetByDepartment(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	resp, err := c.service.GetByDepartment(uint(id), ctx.Query("academic_year"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp.Workloads) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetAll reports every teacher's load, filtered by ?academic_year and ?department_id
+func (c *WorkloadController) GetAll(ctx *gin.Context) {
+	var departmentID *uint
+	if raw := ctx.Query("department_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid department ID"})
+			return
+		}
+		deptID := uint(id)
+		departmentID = &deptID
+	}
+
+	resp, err := c.service.GetAll(departmentID, ctx.Query("academic_year"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export.Send(ctx, resp) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": resp, "count": len(resp)})
+}
+
+// GetPolicy retrieves the max-load rule
+func (c *WorkloadController) GetPolicy(ctx *gin.Context) {
+	resp, err := c.service.GetPolicy()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UpdatePolicy changes the max-load rule
+func (c *WorkloadController) UpdatePolicy(ctx *gin.Context) {
+	var req UpdatePolicyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.UpdatePolicy(&req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// RegisterRoutes registers workload routes
+func (c *WorkloadController) RegisterRoutes(rg *gin.RouterGroup) {
+	workload := rg.Group("/workload")
+	{
+		workload.GET("", c.GetAll)
+		workload.GET("/policy", c.GetPolicy)
+		workload.PUT("/policy", c.UpdatePolicy)
+	}
+	rg.GET("/teachers/:id/workload", c.GetByTeacher)
+	rg.GET("/departments/:id/workload", c.GetByDepartment)
+}
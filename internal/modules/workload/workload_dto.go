@@ -0,0 +1,41 @@
+package workload
+
+// UpdatePolicyRequest represents the request body for changing the max-load rule
+type UpdatePolicyRequest struct {
+	MaxSections *int `json:"max_sections" binding:"omitempty,min=0"` // 0 removes the limit
+	MaxCredits  *int `json:"max_credits" binding:"omitempty,min=0"`  // 0 removes the limit
+}
+
+// PolicyResponse represents the max-load rule
+type PolicyResponse struct {
+	MaxSections int `json:"max_sections"`
+	MaxCredits  int `json:"max_credits"`
+}
+
+// TeacherWorkloadResponse represents one teacher's teaching load
+type TeacherWorkloadResponse struct {
+	TeacherID           uint   `json:"teacher_id"`
+	TeacherName         string `json:"teacher_name"`
+	DepartmentID        uint   `json:"department_id"`
+	AcademicYear        string `json:"academic_year,omitempty"` // empty when counting every year
+	Sections            int    `json:"sections"`
+	CreditHours         int    `json:"credit_hours"`
+	Students            int    `json:"students"`
+	UngradedSubmissions int    `json:"ungraded_submissions"`
+	ExamsToGrade        int    `json:"exams_to_grade"`
+	Overloaded          bool   `json:"overloaded"` // over the max-load rule
+}
+
+// DepartmentWorkloadResponse represents a department's teaching load and its teachers'
+type DepartmentWorkloadResponse struct {
+	DepartmentID        uint                      `json:"department_id"`
+	AcademicYear        string                    `json:"academic_year,omitempty"`
+	Teachers            int                       `json:"teachers"`
+	Sections            int                       `json:"sections"`
+	CreditHours         int                       `json:"credit_hours"`
+	Students            int                       `json:"students"` // summed per teacher, so a student taught by two teachers counts twice
+	UngradedSubmissions int                       `json:"ungraded_submissions"`
+	ExamsToGrade        int                       `json:"exams_to_grade"`
+	Overloaded          int                       `json:"overloaded"` // teachers over the max-load rule
+	Workloads           []TeacherWorkloadResponse `json:"workloads"`
+}
@@ -0,0 +1,42 @@
+package workload
+
+import "gorm.io/gorm"
+
+// LoadPolicy caps how much teaching one teacher is assigned per academic year.
+// Only one row is kept; a cap of 0 means no limit.
+type LoadPolicy struct {
+	gorm.Model
+	MaxSections int `gorm:"not null;default:0;comment:courses per teacher per academic year, 0 for no limit" json:"max_sections"`
+	MaxCredits  int `gorm:"not null;default:0;comment:credit hours per teacher per academic year, 0 for no limit" json:"max_credits"`
+}
+
+// TableName specifies the table name for the LoadPolicy model
+func (LoadPolicy) TableName() string {
+	return "load_policies"
+}
+
+// Exceeded reports whether a teacher with the given sections and credit hours is over the caps
+func (p *LoadPolicy) Exceeded(sections, credits int) bool {
+	return p.MaxSections > 0 && sections > p.MaxSections || p.MaxCredits > 0 && credits > p.MaxCredits
+}
+
+// CourseLoad is the number of courses and credit hours a teacher is assigned
+type CourseLoad struct {
+	TeacherID   uint
+	Sections    int
+	CreditHours int
+}
+
+// YearLoad is a teacher's course count and credit hours in one academic year
+type YearLoad struct {
+	TeacherID    uint
+	AcademicYear string
+	Sections     int
+	CreditHours  int
+}
+
+// TeacherCount is a per-teacher count such as students taught or submissions waiting to be graded
+type TeacherCount struct {
+	TeacherID uint
+	Count     int
+}
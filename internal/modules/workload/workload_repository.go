@@ -0,0 +1,166 @@
+package workload
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"school_management/internal/modules/students_homework"
+	"school_management/internal/modules/teacher"
+)
+
+// LoadFilter narrows load counts to some teachers' courses in one academic year
+type LoadFilter struct {
+	TeacherIDs   []uint // all teachers when empty
+	AcademicYear string // all years when empty, unless ExactYear
+	ExactYear    bool   // match AcademicYear even when empty, i.e. only courses without a year
+}
+
+// apply restricts a query joined to courses to the filter
+func (f LoadFilter) apply(query *gorm.DB) *gorm.DB {
+	if len(f.TeacherIDs) > 0 {
+		query = query.Where("courses.teacher_id IN ?", f.TeacherIDs)
+	}
+	if f.AcademicYear != "" || f.ExactYear {
+		query = query.Where("courses.academic_year = ?", f.AcademicYear)
+	}
+	return query
+}
+
+// WorkloadRepository defines the interface for teaching load data access
+type WorkloadRepository interface {
+	GetPolicy() (*LoadPolicy, error)
+	SavePolicy(policy *LoadPolicy) error
+	GetTeachers(departmentID *uint) ([]teacher.Teacher, error)
+	GetCourseLoads(filter LoadFilter, excludeCourseID uint) ([]CourseLoad, error)
+	GetYearLoads(teacherIDs []uint) ([]YearLoad, error)
+	GetStudentCounts(filter LoadFilter) ([]TeacherCount, error)
+	GetUngradedSubmissions(filter LoadFilter) ([]TeacherCount, error)
+	GetExamsToGrade(filter LoadFilter, asOf time.Time) ([]TeacherCount, error)
+}
+
+// workloadRepository implements WorkloadRepository
+type workloadRepository struct {
+	db *gorm.DB
+}
+
+// NewWorkloadRepository creates a new workload repository with dependency injection
+func NewWorkloadRepository(db *gorm.DB) WorkloadRepository {
+	return &workloadRepository{db: db}
+}
+
+// GetPolicy retrieves the load policy, falling back to no limits when none is saved
+func (r *workloadRepository) GetPolicy() (*LoadPolicy, error) {
+	var policy LoadPolicy
+	err := r.db.Order("id ASC").First(&policy).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &LoadPolicy{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get load policy: %w", err)
+	}
+	return &policy, nil
+}
+
+// SavePolicy creates or updates the load policy
+func (r *workloadRepository) SavePolicy(policy *LoadPolicy) error {
+	if err := r.db.Save(policy).Error; err != nil {
+		return fmt.Errorf("failed to save load policy: %w", err)
+	}
+	return nil
+}
+
+// GetTeachers retrieves every teacher, or a department's teachers, by name
+func (r *workloadRepository) GetTeachers(departmentID *uint) ([]teacher.Teacher, error) {
+	var teachers []teacher.Teacher
+	query := r.db.Model(&teacher.Teacher{})
+	if departmentID != nil {
+		query = query.Where("department_id = ?", *departmentID)
+	}
+	if err := query.Order("last_name ASC, first_name ASC").Find(&teachers).Error; err != nil {
+		return nil, fmt.Errorf("failed to get teachers: %w", err)
+	}
+	return teachers, nil
+}
+
+// GetCourseLoads counts each teacher's courses and credit hours, leaving out one course
+// (0 for none) so a course being changed is not counted twice
+func (r *workloadRepository) GetCourseLoads(filter LoadFilter, excludeCourseID uint) ([]CourseLoad, error) {
+	var result []CourseLoad
+	query := r.db.Table("courses").
+		Select("courses.teacher_id, COUNT(*) AS sections, COALESCE(SUM(courses.credits), 0) AS credit_hours").
+		Where("courses.deleted_at IS NULL")
+	if excludeCourseID != 0 {
+		query = query.Where("courses.id <> ?", excludeCourseID)
+	}
+	if err := filter.apply(query).
+		Group("courses.teacher_id").
+		Scan(&result).Error; err != nil {
+		return nil, fmt.Errorf("failed to get course loads: %w", err)
+	}
+	return result, nil
+}
+
+// GetYearLoads counts each teacher's courses and credit hours per academic year
+func (r *workloadRepository) GetYearLoads(teacherIDs []uint) ([]YearLoad, error) {
+	var result []YearLoad
+	if err := r.db.Table("courses").
+		Select("courses.teacher_id, courses.academic_year, COUNT(*) AS sections, COALESCE(SUM(courses.credits), 0) AS credit_hours").
+		Where("courses.deleted_at IS NULL AND courses.teacher_id IN ?", teacherIDs).
+		Group("courses.teacher_id, courses.academic_year").
+		Scan(&result).Error; err != nil {
+		return nil, fmt.Errorf("failed to get course loads: %w", err)
+	}
+	return result, nil
+}
+
+// GetStudentCounts counts the distinct students enrolled in each teacher's courses
+func (r *workloadRepository) GetStudentCounts(filter LoadFilter) ([]TeacherCount, error) {
+	var result []TeacherCount
+	query := r.db.Table("student_courses").
+		Select("courses.teacher_id, COUNT(DISTINCT student_courses.student_id) AS count").
+		Joins("JOIN courses ON courses.id = student_courses.course_id AND courses.deleted_at IS NULL").
+		Where("student_courses.deleted_at IS NULL")
+	if err := filter.apply(query).
+		Group("courses.teacher_id").
+		Scan(&result).Error; err != nil {
+		return nil, fmt.Errorf("failed to get student counts: %w", err)
+	}
+	return result, nil
+}
+
+// GetUngradedSubmissions counts the homework submissions waiting for each teacher to grade
+func (r *workloadRepository) GetUngradedSubmissions(filter LoadFilter) ([]TeacherCount, error) {
+	var result []TeacherCount
+	query := r.db.Table("students_homework").
+		Select("courses.teacher_id, COUNT(*) AS count").
+		Joins("JOIN homework ON homework.id = students_homework.homework_id AND homework.deleted_at IS NULL").
+		Joins("JOIN courses ON courses.id = homework.course_id AND courses.deleted_at IS NULL").
+		Where("students_homework.status = ? AND students_homework.deleted_at IS NULL", students_homework.HomeworkSubmitted)
+	if err := filter.apply(query).
+		Group("courses.teacher_id").
+		Scan(&result).Error; err != nil {
+		return nil, fmt.Errorf("failed to get ungraded submissions: %w", err)
+	}
+	return result, nil
+}
+
+// GetExamsToGrade counts each teacher's exams held before asOf that have fewer grades than
+// the course has enrolled students
+func (r *workloadRepository) GetExamsToGrade(filter LoadFilter, asOf time.Time) ([]TeacherCount, error) {
+	var result []TeacherCount
+	query := r.db.Table("exams").
+		Select("courses.teacher_id, COUNT(*) AS count").
+		Joins("JOIN courses ON courses.id = exams.course_id AND courses.deleted_at IS NULL").
+		Where("exams.exam_date < ? AND exams.deleted_at IS NULL", asOf).
+		Where("(SELECT COUNT(*) FROM grades WHERE grades.exam_id = exams.id AND grades.deleted_at IS NULL) < " +
+			"(SELECT COUNT(*) FROM student_courses WHERE student_courses.course_id = exams.course_id AND student_courses.deleted_at IS NULL)")
+	if err := filter.apply(query).
+		Group("courses.teacher_id").
+		Scan(&result).Error; err != nil {
+		return nil, fmt.Errorf("failed to get exams to grade: %w", err)
+	}
+	return result, nil
+}
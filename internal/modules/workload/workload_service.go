@@ -0,0 +1,299 @@
+package workload
+
+import (
+	"fmt"
+	"time"
+
+	"school_management/internal/modules/course"
+	"school_management/internal/modules/department"
+	"school_management/internal/modules/teacher"
+)
+
+// WorkloadService defines the business logic interface for teaching load reports
+type WorkloadService interface {
+	GetPolicy() (*PolicyResponse, error)
+	UpdatePolicy(req *UpdatePolicyRequest) (*PolicyResponse, error)
+	GetByTeacher(teacherID uint, academicYear string) (*TeacherWorkloadResponse, error)
+	GetByDepartment(deptID uint, academicYear string) (*DepartmentWorkloadResponse, error)
+	GetAll(departmentID *uint, academicYear string) ([]TeacherWorkloadResponse, error)
+	CheckTeacherLoad(teacherID uint, academicYear string, credits int, excludeCourseID uint) error
+	CheckReassign(fromTeacherID, toTeacherID uint) error
+}
+
+// workloadService implements WorkloadService
+type workloadService struct {
+	repo        WorkloadRepository
+	teacherRepo teacher.TeacherRepository
+	deptRepo    department.DepartmentRepository
+}
+
+// NewWorkloadService creates a new workload service with DI
+func NewWorkloadService(repo WorkloadRepository, teacherRepo teacher.TeacherRepository, deptRepo department.DepartmentRepository) WorkloadService {
+	return &workloadService{repo: repo, teacherRepo: teacherRepo, deptRepo: deptRepo}
+}
+
+// GetPolicy retrieves the max-load rule
+func (s *workloadService) GetPolicy() (*PolicyResponse, error) {
+	policy, err := s.repo.GetPolicy()
+	if err != nil {
+		return nil, err
+	}
+	return s.toPolicyResponseDTO(policy), nil
+}
+
+// UpdatePolicy changes the max-load rule. Courses already over the new caps are left alone;
+// the rule applies the next time a teacher is assigned.
+func (s *workloadService) UpdatePolicy(req *UpdatePolicyRequest) (*PolicyResponse, error) {
+	// Get existing
+	policy, err := s.repo.GetPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	if req.MaxSections != nil {
+		policy.MaxSections = *req.MaxSections
+	}
+	if req.MaxCredits != nil {
+		policy.MaxCredits = *req.MaxCredits
+	}
+
+	// Save
+	if err := s.repo.SavePolicy(policy); err != nil {
+		return nil, err
+	}
+	return s.toPolicyResponseDTO(policy), nil
+}
+
+// GetByTeacher reports one teacher's load for an academic year, or every year when empty
+func (s *workloadService) GetByTeacher(teacherID uint, academicYear string) (*TeacherWorkloadResponse, error) {
+	// Validate
+	if err := s.validateAcademicYear(academicYear); err != nil {
+		return nil, err
+	}
+	t, err := s.teacherRepo.GetByID(teacherID)
+	if err != nil {
+		return nil, fmt.Errorf("teacher not found: %w", err)
+	}
+
+	workloads, err := s.report([]teacher.Teacher{*t}, academicYear)
+	if err != nil {
+		return nil, err
+	}
+	return &workloads[0], nil
+}
+
+// GetByDepartment reports a department's load with a breakdown by teacher
+func (s *workloadService) GetByDepartment(deptID uint, academicYear string) (*DepartmentWorkloadResponse, error) {
+	// Validate
+	if err := s.validateAcademicYear(academicYear); err != nil {
+		return nil, err
+	}
+	if _, err := s.deptRepo.GetByID(deptID); err != nil {
+		return nil, fmt.Errorf("department not found: %w", err)
+	}
+
+	teachers, err := s.repo.GetTeachers(&deptID)
+	if err != nil {
+		return nil, err
+	}
+	workloads, err := s.report(teachers, academicYear)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &DepartmentWorkloadResponse{
+		DepartmentID: deptID,
+		AcademicYear: academicYear,
+		Teachers:     len(workloads),
+		Workloads:    workloads,
+	}
+	for _, w := range workloads {
+		resp.Sections += w.Sections
+		resp.CreditHours += w.CreditHours
+		resp.Students += w.Students
+		resp.UngradedSubmissions += w.UngradedSubmissions
+		resp.ExamsToGrade += w.ExamsToGrade
+		if w.Overloaded {
+			resp.Overloaded++
+		}
+	}
+	return resp, nil
+}
+
+// GetAll reports every teacher's load, optionally only a department's
+func (s *workloadService) GetAll(departmentID *uint, academicYear string) ([]TeacherWorkloadResponse, error) {
+	// Validate
+	if err := s.validateAcademicYear(academicYear); err != nil {
+		return nil, err
+	}
+
+	teachers, err := s.repo.GetTeachers(departmentID)
+	if err != nil {
+		return nil, err
+	}
+	return s.report(teachers, academicYear)
+}
+
+// CheckTeacherLoad rejects giving a teacher one more course of the given credits in an academic
+// year when that takes them over the max-load rule. excludeCourseID is the course being changed.
+func (s *workloadService) CheckTeacherLoad(teacherID uint, academicYear string, credits int, excludeCourseID uint) error {
+	policy, err := s.repo.GetPolicy()
+	if err != nil {
+		return err
+	}
+	if policy.MaxSections == 0 && policy.MaxCredits == 0 {
+		return nil
+	}
+
+	loads, err := s.repo.GetCourseLoads(LoadFilter{TeacherIDs: []uint{teacherID}, AcademicYear: academicYear, ExactYear: true}, excludeCourseID)
+	if err != nil {
+		return err
+	}
+	var current CourseLoad
+	if len(loads) > 0 {
+		current = loads[0]
+	}
+
+	year := academicYear
+	if year == "" {
+		year = "courses without an academic year"
+	}
+	if policy.MaxSections > 0 && current.Sections+1 > policy.MaxSections {
+		return fmt.Errorf("teacher %d already teaches %d of %d allowed sections for %s", teacherID, current.Sections, policy.MaxSections, year)
+	}
+	if policy.MaxCredits > 0 && current.CreditHours+credits > policy.MaxCredits {
+		return fmt.Errorf("teacher %d would teach %d credit hours for %s, above the limit of %d", teacherID, current.CreditHours+credits, year, policy.MaxCredits)
+	}
+	return nil
+}
+
+// CheckReassign rejects moving all of one teacher's courses to another when that takes the
+// other over the max-load rule in any academic year
+func (s *workloadService) CheckReassign(fromTeacherID, toTeacherID uint) error {
+	policy, err := s.repo.GetPolicy()
+	if err != nil {
+		return err
+	}
+	if policy.MaxSections == 0 && policy.MaxCredits == 0 {
+		return nil
+	}
+
+	loads, err := s.repo.GetYearLoads([]uint{fromTeacherID, toTeacherID})
+	if err != nil {
+		return err
+	}
+	current := make(map[string]YearLoad)
+	for _, l := range loads {
+		if l.TeacherID == toTeacherID {
+			current[l.AcademicYear] = l
+		}
+	}
+	for _, moved := range loads {
+		if moved.TeacherID != fromTeacherID {
+			continue
+		}
+		year := moved.AcademicYear
+		if year == "" {
+			year = "courses without an academic year"
+		}
+		sections := current[moved.AcademicYear].Sections + moved.Sections
+		credits := current[moved.AcademicYear].CreditHours + moved.CreditHours
+		if policy.MaxSections > 0 && sections > policy.MaxSections {
+			return fmt.Errorf("teacher %d would teach %d sections for %s, above the limit of %d", toTeacherID, sections, year, policy.MaxSections)
+		}
+		if policy.MaxCredits > 0 && credits > policy.MaxCredits {
+			return fmt.Errorf("teacher %d would teach %d credit hours for %s, above the limit of %d", toTeacherID, credits, year, policy.MaxCredits)
+		}
+	}
+	return nil
+}
+
+// report gathers the load counts for the given teachers, in the order given
+func (s *workloadService) report(teachers []teacher.Teacher, academicYear string) ([]TeacherWorkloadResponse, error) {
+	workloads := make([]TeacherWorkloadResponse, len(teachers))
+	if len(teachers) == 0 {
+		return workloads, nil
+	}
+
+	policy, err := s.repo.GetPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[uint]*TeacherWorkloadResponse, len(teachers))
+	filter := LoadFilter{AcademicYear: academicYear}
+	for i, t := range teachers {
+		workloads[i] = TeacherWorkloadResponse{
+			TeacherID:    t.ID,
+			TeacherName:  t.FirstName + " " + t.LastName,
+			DepartmentID: t.DepartmentID,
+			AcademicYear: academicYear,
+		}
+		index[t.ID] = &workloads[i]
+		filter.TeacherIDs = append(filter.TeacherIDs, t.ID)
+	}
+
+	loads, err := s.repo.GetCourseLoads(filter, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, load := range loads {
+		if w, ok := index[load.TeacherID]; ok {
+			w.Sections = load.Sections
+			w.CreditHours = load.CreditHours
+		}
+	}
+
+	students, err := s.repo.GetStudentCounts(filter)
+	if err != nil {
+		return nil, err
+	}
+	submissions, err := s.repo.GetUngradedSubmissions(filter)
+	if err != nil {
+		return nil, err
+	}
+	exams, err := s.repo.GetExamsToGrade(filter, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range students {
+		if w, ok := index[c.TeacherID]; ok {
+			w.Students = c.Count
+		}
+	}
+	for _, c := range submissions {
+		if w, ok := index[c.TeacherID]; ok {
+			w.UngradedSubmissions = c.Count
+		}
+	}
+	for _, c := range exams {
+		if w, ok := index[c.TeacherID]; ok {
+			w.ExamsToGrade = c.Count
+		}
+	}
+
+	// The rule is per academic year, so it only says something about a single year's load
+	if academicYear != "" {
+		for i := range workloads {
+			workloads[i].Overloaded = policy.Exceeded(workloads[i].Sections, workloads[i].CreditHours)
+		}
+	}
+	return workloads, nil
+}
+
+// Validation methods
+func (s *workloadService) validateAcademicYear(academicYear string) error {
+	if academicYear == "" {
+		return nil
+	}
+	return course.ValidateAcademicYear(academicYear)
+}
+
+// DTO mapping methods
+func (s *workloadService) toPolicyResponseDTO(policy *LoadPolicy) *PolicyResponse {
+	return &PolicyResponse{
+		MaxSections: policy.MaxSections,
+		MaxCredits:  policy.MaxCredits,
+	}
+}
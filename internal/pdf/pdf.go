@@ -0,0 +1,234 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A4 page geometry in points
+const (
+	pageWidth  = 595.28
+	pageHeight = 841.89
+	margin     = 50.0
+)
+
+// Font resource names registered on every page
+const (
+	fontRegular = "F1" // Helvetica
+	fontBold    = "F2" // Helvetica-Bold
+)
+
+// Document builds a simple flowing A4 document with the standard Helvetica fonts.
+// Content is laid out top to bottom and breaks onto a new page when it runs out of room.
+// Text is encoded as WinAnsi; characters outside Latin-1 are replaced with '?'.
+type Document struct {
+	pages []*bytes.Buffer
+	y     float64
+}
+
+// New creates an empty document with one blank page
+func New() *Document {
+	d := &Document{}
+	d.newPage()
+	return d
+}
+
+// Heading writes a large bold line
+func (d *Document) Heading(text string) {
+	d.Space(4)
+	d.line(text, fontBold, 16)
+	d.Space(4)
+}
+
+// Subheading writes a bold section title
+func (d *Document) Subheading(text string) {
+	d.Space(6)
+	d.line(text, fontBold, 12)
+	d.Space(2)
+}
+
+// Paragraph writes text wrapped to the page width
+func (d *Document) Paragraph(text string) {
+	for _, l := range wrap(text, 10, contentWidth()) {
+		d.line(l, fontRegular, 10)
+	}
+}
+
+// Rule draws a horizontal line across the content area
+func (d *Document) Rule() {
+	d.ensure(8)
+	d.y -= 4
+	fmt.Fprintf(d.page(), "0.5 w %.2f %.2f m %.2f %.2f l S\n", margin, d.y, pageWidth-margin, d.y)
+	d.y -= 4
+}
+
+// Space moves the cursor down by the given number of points
+func (d *Document) Space(points float64) {
+	d.y -= points
+	if d.y < margin {
+		d.newPage()
+	}
+}
+
+// Table draws a grid with a bold header row and equal-width columns.
+// Cells that do not fit their column are truncated.
+func (d *Document) Table(header []string, rows [][]string) {
+	cols := len(header)
+	for _, r := range rows {
+		if len(r) > cols {
+			cols = len(r)
+		}
+	}
+	if cols == 0 {
+		return
+	}
+	colWidth := contentWidth() / float64(cols)
+
+	d.tableRow(header, cols, colWidth, fontBold)
+	for _, r := range rows {
+		d.tableRow(r, cols, colWidth, fontRegular)
+	}
+	d.Space(4)
+}
+
+// Bytes serialises the document as a PDF file
+func (d *Document) Bytes() []byte {
+	var out bytes.Buffer
+	offsets := []int{}
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1-4: catalog, page tree and the two fonts. Pages start at object 5,
+	// each followed by its content stream.
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, content := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] "+
+			"/Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, fontRegular, fontBold, 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// tableRow draws one bordered table row
+func (d *Document) tableRow(cells []string, cols int, colWidth float64, font string) {
+	const size, height = 9.0, 16.0
+	d.ensure(height)
+	top := d.y
+	d.y -= height
+
+	buf := d.page()
+	fmt.Fprintf(buf, "0.5 w %.2f %.2f %.2f %.2f re S\n", margin, d.y, contentWidth(), height)
+	for i := 0; i < cols; i++ {
+		x := margin + float64(i)*colWidth
+		if i > 0 {
+			fmt.Fprintf(buf, "%.2f %.2f m %.2f %.2f l S\n", x, d.y, x, top)
+		}
+		if i < len(cells) {
+			text := truncate(strings.TrimSpace(cells[i]), size, colWidth-6)
+			fmt.Fprintf(buf, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x+3, d.y+5, encode(text))
+		}
+	}
+}
+
+// line writes a single line of text at the cursor
+func (d *Document) line(text, font string, size float64) {
+	height := size * 1.4
+	d.ensure(height)
+	d.y -= height
+	fmt.Fprintf(d.page(), "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, margin, d.y+size*0.3, encode(text))
+}
+
+// ensure starts a new page when less than height points are left
+func (d *Document) ensure(height float64) {
+	if d.y-height < margin {
+		d.newPage()
+	}
+}
+
+func (d *Document) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pageHeight - margin
+}
+
+func (d *Document) page() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+func contentWidth() float64 {
+	return pageWidth - 2*margin
+}
+
+// textWidth estimates the rendered width of text using Helvetica's average glyph width
+func textWidth(text string, size float64) float64 {
+	return float64(len([]rune(text))) * size * 0.52
+}
+
+// wrap breaks text into lines that fit the given width, splitting on spaces
+func wrap(text string, size, width float64) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return []string{""}
+	}
+	var lines []string
+	current := words[0]
+	for _, w := range words[1:] {
+		if textWidth(current+" "+w, size) > width {
+			lines = append(lines, current)
+			current = w
+			continue
+		}
+		current += " " + w
+	}
+	return append(lines, current)
+}
+
+// truncate shortens text with an ellipsis so it fits the given width
+func truncate(text string, size, width float64) string {
+	if textWidth(text, size) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && textWidth(string(runes)+"...", size) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+// encode escapes text for a PDF string literal in WinAnsi encoding
+func encode(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 32 && r < 127:
+			b.WriteRune(r)
+		case r >= 160 && r <= 255:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
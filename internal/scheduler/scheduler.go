@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// Every runs job once at startup and then at every interval in a background goroutine.
+// Errors are logged and do not stop the schedule.
+func Every(name string, interval time.Duration, job func() error) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			run(name, job)
+			<-ticker.C
+		}
+	}()
+}
+
+// Daily runs job every day at the given local time of day, written as HH:MM (24-hour), in a
+// background goroutine. Unlike Every it does not run at startup. Errors are logged and do not
+// stop the schedule.
+func Daily(name, at string, job func() error) error {
+	clock, err := time.Parse("15:04", at)
+	if err != nil {
+		return fmt.Errorf("invalid time of day %q for job %s (use HH:MM): %w", at, name, err)
+	}
+
+	go func() {
+		for {
+			time.Sleep(time.Until(nextAt(time.Now(), clock.Hour(), clock.Minute())))
+			run(name, job)
+		}
+	}()
+	return nil
+}
+
+// nextAt returns the first time strictly after now that falls on hour:minute local time
+func nextAt(now time.Time, hour, minute int) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+	if !next.After(now) {
+		next = time.Date(now.Year(), now.Month(), now.Day()+1, hour, minute, 0, 0, now.Location())
+	}
+	return next
+}
+
+// run executes a job, logging failures and recovering from panics
+func run(name string, job func() error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("❌ Job %s panicked: %v", name, r)
+		}
+	}()
+
+	if err := job(); err != nil {
+		log.Printf("❌ Job %s failed: %v", name, err)
+	}
+}
@@ -1,17 +1,40 @@
 package server
 
 import (
+	"log"
+	"time"
+
+	"school_management/internal/config"
 	"school_management/internal/database"
+	"school_management/internal/modules/admission"
 	"school_management/internal/modules/attendance"
 	"school_management/internal/modules/course"
 	"school_management/internal/modules/department"
 	"school_management/internal/modules/exam"
+	"school_management/internal/modules/exam_seating"
+	"school_management/internal/modules/exam_statistics"
 	"school_management/internal/modules/grade"
+	"school_management/internal/modules/grade_curve"
+	"school_management/internal/modules/guardian"
+	"school_management/internal/modules/homeroom"
 	"school_management/internal/modules/homework"
+	"school_management/internal/modules/online_exam"
+	"school_management/internal/modules/regrade"
+	"school_management/internal/modules/report_card"
+	"school_management/internal/modules/risk"
+	"school_management/internal/modules/rollover"
+	"school_management/internal/modules/rubric"
+	"school_management/internal/modules/similarity"
+	"school_management/internal/modules/standing"
 	"school_management/internal/modules/student"
 	"school_management/internal/modules/student_courses"
+	"school_management/internal/modules/student_import"
 	"school_management/internal/modules/students_homework"
+	"school_management/internal/modules/substitute"
 	"school_management/internal/modules/teacher"
+	"school_management/internal/modules/trash"
+	"school_management/internal/modules/workload"
+	"school_management/internal/scheduler"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -21,7 +44,7 @@ import (
 )
 
 // SetupRouter creates and configures the Gin router
-func SetupRouter() *gin.Engine {
+func SetupRouter(cfg *config.Config) *gin.Engine {
 	router := gin.Default()
 
 	// Swagger documentation endpoint
@@ -46,21 +69,56 @@ func SetupRouter() *gin.Engine {
 	gradeRepo := grade.NewGradeRepository(database.DB)
 	enrollmentRepo := student_courses.NewStudentCourseRepository(database.DB)
 	submissionRepo := students_homework.NewStudentHomeworkRepository(database.DB)
+	rubricRepo := rubric.NewRubricRepository(database.DB)
+	similarityRepo := similarity.NewSimilarityRepository(database.DB)
+	onlineExamRepo := online_exam.NewOnlineExamRepository(database.DB)
+	seatingRepo := exam_seating.NewExamSeatingRepository(database.DB)
+	regradeRepo := regrade.NewRegradeRepository(database.DB)
+	curveRepo := grade_curve.NewGradeCurveRepository(database.DB)
+	standingRepo := standing.NewStandingRepository(database.DB)
+	riskRepo := risk.NewRiskRepository(database.DB)
+	reportCardRepo := report_card.NewReportCardRepository(database.DB)
+	importRepo := student_import.NewStudentImportRepository(database.DB)
+	guardianRepo := guardian.NewGuardianRepository(database.DB)
+	homeroomRepo := homeroom.NewHomeroomRepository(database.DB)
+	rolloverRepo := rollover.NewRolloverRepository(database.DB)
+	admissionRepo := admission.NewAdmissionRepository(database.DB)
+	workloadRepo := workload.NewWorkloadRepository(database.DB)
+	substituteRepo := substitute.NewSubstituteRepository(database.DB)
+	trashRepo := trash.NewTrashRepository(database.DB)
 
 	// Initialize services
 	deptService := department.NewDepartmentService(deptRepo)
-	teacherService := teacher.NewTeacherService(teacherRepo)
-	studentService := student.NewStudentService(studentRepo)
-	courseService := course.NewCourseService(courseRepo)
-	attendanceService := attendance.NewAttendanceService(attendanceRepo)
-	homeworkService := homework.NewHomeworkService(homeworkRepo)
+	workloadService := workload.NewWorkloadService(workloadRepo, teacherRepo, deptRepo)
+	teacherService := teacher.NewTeacherService(teacherRepo, workloadService)
+	standingService := standing.NewStandingService(standingRepo)
+	studentService := student.NewStudentService(studentRepo, standingService)
+	courseService := course.NewCourseService(courseRepo, workloadService, deptService)
+	substituteService := substitute.NewSubstituteService(substituteRepo, teacherRepo, courseRepo, deptService)
+	attendanceService := attendance.NewAttendanceService(attendanceRepo, studentRepo, substituteService)
+	submissionService := students_homework.NewStudentHomeworkService(submissionRepo, homeworkRepo, rubricRepo, enrollmentRepo)
+	homeworkService := homework.NewHomeworkService(homeworkRepo, submissionService, rubricRepo)
 	examService := exam.NewExamService(examRepo)
-	gradeService := grade.NewGradeService(gradeRepo)
-	enrollmentService := student_courses.NewStudentCourseService(enrollmentRepo)
-	submissionService := students_homework.NewStudentHomeworkService(submissionRepo)
+	gradeService := grade.NewGradeService(gradeRepo, examRepo)
+	homeroomService := homeroom.NewHomeroomService(homeroomRepo, studentRepo, teacherRepo, courseRepo)
+	enrollmentService := student_courses.NewStudentCourseService(enrollmentRepo, studentRepo, submissionService, homeroomService)
+	rubricService := rubric.NewRubricService(rubricRepo)
+	similarityService := similarity.NewSimilarityService(similarityRepo, homeworkRepo, submissionRepo)
+	onlineExamService := online_exam.NewOnlineExamService(onlineExamRepo, examRepo, gradeRepo, enrollmentRepo)
+	seatingService := exam_seating.NewExamSeatingService(seatingRepo, examRepo, enrollmentRepo)
+	regradeService := regrade.NewRegradeService(regradeRepo, gradeRepo, examRepo, submissionRepo, homeworkRepo, courseRepo, deptService)
+	examStatsService := exam_statistics.NewExamStatisticsService(examRepo, gradeRepo, onlineExamRepo)
+	curveService := grade_curve.NewGradeCurveService(curveRepo, examRepo, gradeRepo)
+	riskService := risk.NewRiskService(riskRepo)
+	reportCardService := report_card.NewReportCardService(reportCardRepo, studentRepo, courseRepo)
+	importService := student_import.NewStudentImportService(importRepo, studentService, courseRepo, enrollmentService, submissionService)
+	guardianService := guardian.NewGuardianService(guardianRepo, studentRepo)
+	rolloverService := rollover.NewRolloverService(rolloverRepo, homeroomRepo, courseRepo, studentRepo, standingRepo)
+	admissionService := admission.NewAdmissionService(admissionRepo, studentService, studentRepo, homeroomRepo, courseRepo, teacherRepo, submissionService)
+	trashService := trash.NewTrashService(trashRepo, deptService)
 
 	// Initialize controllers
-	deptController := department.NewDepartmentController(deptService)
+	deptController := department.NewDepartmentController(deptService, cfg.AdminToken)
 	teacherController := teacher.NewTeacherController(teacherService)
 	studentController := student.NewStudentController(studentService)
 	courseController := course.NewCourseController(courseService)
@@ -70,6 +128,60 @@ func SetupRouter() *gin.Engine {
 	gradeController := grade.NewGradeController(gradeService)
 	enrollmentController := student_courses.NewStudentCourseController(enrollmentService)
 	submissionController := students_homework.NewStudentHomeworkController(submissionService)
+	rubricController := rubric.NewRubricController(rubricService)
+	similarityController := similarity.NewSimilarityController(similarityService)
+	onlineExamController := online_exam.NewOnlineExamController(onlineExamService)
+	seatingController := exam_seating.NewExamSeatingController(seatingService)
+	regradeController := regrade.NewRegradeController(regradeService)
+	examStatsController := exam_statistics.NewExamStatisticsController(examStatsService)
+	curveController := grade_curve.NewGradeCurveController(curveService)
+	standingController := standing.NewStandingController(standingService)
+	riskController := risk.NewRiskController(riskService)
+	reportCardController := report_card.NewReportCardController(reportCardService)
+	importController := student_import.NewStudentImportController(importService)
+	guardianController := guardian.NewGuardianController(guardianService)
+	homeroomController := homeroom.NewHomeroomController(homeroomService)
+	rolloverController := rollover.NewRolloverController(rolloverService)
+	admissionController := admission.NewAdmissionController(admissionService)
+	workloadController := workload.NewWorkloadController(workloadService)
+	substituteController := substitute.NewSubstituteController(substituteService, cfg.AdminToken)
+	trashController := trash.NewTrashController(trashService, cfg.AdminToken)
+
+	// Background jobs
+	scheduler.Every("mark-missing-homework", 15*time.Minute, func() error {
+		_, err := submissionService.MarkMissing()
+		return err
+	})
+	scheduler.Every("finalize-expired-exam-attempts", time.Minute, func() error {
+		_, err := onlineExamService.FinalizeExpired()
+		return err
+	})
+	scheduler.Every("recompute-academic-standing", 5*time.Minute, func() error {
+		_, err := standingService.Recompute()
+		return err
+	})
+	scheduler.Every("apply-student-status-changes", time.Hour, func() error {
+		if _, err := studentService.ApplyDueStatusChanges(); err != nil {
+			return err
+		}
+		_, err := admissionService.EnrollAdmitted()
+		return err
+	})
+	if err := scheduler.Daily("recompute-risk-scores", cfg.NightlyAt, func() error {
+		_, err := riskService.Recompute()
+		return err
+	}); err != nil {
+		log.Fatalf("Failed to schedule jobs: %v", err)
+	}
+	scheduler.Every("purge-expired-trash", 24*time.Hour, func() error {
+		purged, err := trashService.PurgeExpired()
+		for _, p := range purged {
+			for _, h := range p.Held {
+				log.Printf("⚠️ Trash purge kept %s %d: active records still depend on it", p.Type, h.ID)
+			}
+		}
+		return err
+	})
 
 	// Register routes
 	deptController.RegisterRoutes(v1)
@@ -82,6 +194,24 @@ func SetupRouter() *gin.Engine {
 	gradeController.RegisterRoutes(v1)
 	enrollmentController.RegisterRoutes(v1)
 	submissionController.RegisterRoutes(v1)
+	rubricController.RegisterRoutes(v1)
+	similarityController.RegisterRoutes(v1)
+	onlineExamController.RegisterRoutes(v1)
+	seatingController.RegisterRoutes(v1)
+	regradeController.RegisterRoutes(v1)
+	examStatsController.RegisterRoutes(v1)
+	curveController.RegisterRoutes(v1)
+	standingController.RegisterRoutes(v1)
+	riskController.RegisterRoutes(v1)
+	reportCardController.RegisterRoutes(v1)
+	importController.RegisterRoutes(v1)
+	guardianController.RegisterRoutes(v1)
+	homeroomController.RegisterRoutes(v1)
+	rolloverController.RegisterRoutes(v1)
+	admissionController.RegisterRoutes(v1)
+	workloadController.RegisterRoutes(v1)
+	substituteController.RegisterRoutes(v1)
+	trashController.RegisterRoutes(v1)
 
 	return router
 }
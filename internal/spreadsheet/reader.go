@@ -0,0 +1,231 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sheet size limits of the XLSX format; a reference past them is rejected rather than
+// allocated for
+const (
+	maxColumns = 16384   // column XFD
+	maxRows    = 1048576 // row 1048576
+)
+
+// ReadRows reads every row of a CSV file or of the first sheet of an XLSX workbook.
+// The format is chosen from the file name's extension.
+func ReadRows(fileName string, data []byte) ([][]string, error) {
+	switch strings.ToLower(path.Ext(fileName)) {
+	case ".csv":
+		return readCSV(data)
+	case ".xlsx":
+		return readXLSX(data)
+	}
+	return nil, fmt.Errorf("unsupported file type %q (use .csv or .xlsx)", path.Ext(fileName))
+}
+
+// SerialDate converts an Excel date serial number, as stored in XLSX cells, to a date
+func SerialDate(value string) (time.Time, bool) {
+	serial, err := strconv.ParseFloat(value, 64)
+	if err != nil || serial <= 0 {
+		return time.Time{}, false
+	}
+	// Excel's day zero is 1899-12-30 once its fictitious 1900-02-29 is accounted for
+	epoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	return epoch.AddDate(0, 0, int(serial)), true
+}
+
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")) // UTF-8 byte order mark written by Excel
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+	return rows, nil
+}
+
+// XML shapes of the workbook parts that are read
+type (
+	xlsxRels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	xlsxWorkbook struct {
+		Sheets []struct {
+			RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	xlsxText struct {
+		T    string `xml:"t"`
+		Runs []struct {
+			T string `xml:"t"`
+		} `xml:"r"`
+	}
+	xlsxSharedStrings struct {
+		Items []xlsxText `xml:"si"`
+	}
+	xlsxSheet struct {
+		Rows []struct {
+			Ref   string `xml:"r,attr"`
+			Cells []struct {
+				Ref    string   `xml:"r,attr"`
+				Type   string   `xml:"t,attr"`
+				Value  string   `xml:"v"`
+				Inline xlsxText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+)
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var b strings.Builder
+	for _, r := range t.Runs {
+		b.WriteString(r.T)
+	}
+	return b.String()
+}
+
+func readXLSX(data []byte) ([][]string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open XLSX: %w", err)
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	var shared xlsxSharedStrings
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := decodePart(f, &shared); err != nil {
+			return nil, err
+		}
+	}
+
+	sheetFile, ok := files[firstSheetPath(files)]
+	if !ok {
+		return nil, fmt.Errorf("failed to read XLSX: workbook has no sheets")
+	}
+	var sheet xlsxSheet
+	if err := decodePart(sheetFile, &sheet); err != nil {
+		return nil, err
+	}
+
+	// Rows and cells may leave out their reference, meaning they follow the previous one; empty
+	// rows and cells are often left out altogether, so gaps are filled to keep positions right
+	rows := make([][]string, 0, len(sheet.Rows))
+	for _, r := range sheet.Rows {
+		rowNum := len(rows) + 1
+		if r.Ref != "" {
+			n, err := strconv.Atoi(r.Ref)
+			if err != nil || n < rowNum || n > maxRows {
+				return nil, fmt.Errorf("failed to read XLSX: bad row reference %q", r.Ref)
+			}
+			rowNum = n
+		}
+		for len(rows) < rowNum-1 {
+			rows = append(rows, nil)
+		}
+
+		var row []string
+		for _, c := range r.Cells {
+			col := len(row)
+			if c.Ref != "" {
+				var err error
+				if col, err = columnIndex(c.Ref); err != nil {
+					return nil, err
+				}
+			}
+			if col >= maxColumns {
+				return nil, fmt.Errorf("failed to read XLSX: too many columns")
+			}
+			for len(row) <= col {
+				row = append(row, "")
+			}
+			switch c.Type {
+			case "s":
+				idx, err := strconv.Atoi(c.Value)
+				if err != nil || idx < 0 || idx >= len(shared.Items) {
+					return nil, fmt.Errorf("failed to read XLSX: bad shared string reference %q", c.Value)
+				}
+				row[col] = shared.Items[idx].String()
+			case "inlineStr":
+				row[col] = c.Inline.String()
+			default:
+				row[col] = c.Value
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// firstSheetPath resolves the first sheet's part name through the workbook relationships
+func firstSheetPath(files map[string]*zip.File) string {
+	const fallback = "xl/worksheets/sheet1.xml"
+
+	var wb xlsxWorkbook
+	var rels xlsxRels
+	wbFile, ok1 := files["xl/workbook.xml"]
+	relsFile, ok2 := files["xl/_rels/workbook.xml.rels"]
+	if !ok1 || !ok2 || decodePart(wbFile, &wb) != nil || decodePart(relsFile, &rels) != nil || len(wb.Sheets) == 0 {
+		return fallback
+	}
+	for _, rel := range rels.Relationships {
+		if rel.ID != wb.Sheets[0].RelID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/")
+		}
+		return path.Join("xl", rel.Target)
+	}
+	return fallback
+}
+
+func decodePart(f *zip.File, v interface{}) error {
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("failed to read XLSX part %s: %w", f.Name, err)
+	}
+	defer rc.Close()
+	if err := xml.NewDecoder(io.LimitReader(rc, 64<<20)).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse XLSX part %s: %w", f.Name, err)
+	}
+	return nil
+}
+
+// columnIndex converts a cell reference such as "AB12" to its zero-based column index
+func columnIndex(ref string) (int, error) {
+	letters := strings.TrimRightFunc(ref, func(r rune) bool { return r >= '0' && r <= '9' })
+	if letters == "" || len(letters) == len(ref) || len(letters) > 3 {
+		return 0, fmt.Errorf("failed to read XLSX: bad cell reference %q", ref)
+	}
+	col := 0
+	for _, r := range letters {
+		if r < 'A' || r > 'Z' {
+			return 0, fmt.Errorf("failed to read XLSX: bad cell reference %q", ref)
+		}
+		col = col*26 + int(r-'A') + 1
+	}
+	if col > maxColumns {
+		return 0, fmt.Errorf("failed to read XLSX: cell reference %q is past the last column", ref)
+	}
+	return col - 1, nil
+}